@@ -0,0 +1,318 @@
+// Package backtest 基于历史K线和归集成交的现货回测
+//
+// Loader 分页下载并缓存历史数据，Backtest 把数据按时间顺序回放为与 Websocket 推送相同的事件，
+// 策略沿用实盘的 binance.Handler[market.WsKlineEvent]、binance.Handler[market.WsAggTradeEvent] 回调，
+// 在回调中用 Buy、Sell、Limit 下单，回放结束后返回权益曲线、成交记录和统计指标:
+//
+//	klines, err := backtest.NewLoader(client).SetCacheDir("testdata").Klines(ctx, "BTCUSDT", enums.KlineIntervalType1h, start, end)
+//	bt := backtest.New(decimal.NewFromInt(10000)).AddKlines("BTCUSDT", enums.KlineIntervalType1h, klines)
+//	bt.OnKline = func(event market.WsKlineEvent) { ... bt.Buy("BTCUSDT", qty) ... } // 只能在回调中下单
+//	result, err := bt.Run(ctx)
+//
+// 撮合规则:
+//   - 回放完全由数据驱动，同一份数据每次回放结果相同
+//   - 市价单在下一个事件成交: 有归集成交时按下一笔成交价，只有K线时按下一根K线开盘价，并按 SlippageModel 调整成交价
+//   - 限价单在下一个事件的价格(成交价或开盘价)可以成交时作为 taker 按该价格成交，否则挂单，
+//     之后成交价或K线最高、最低价触及挂单价时作为 maker 按挂单价成交
+//   - 有归集成交的交易对只用成交撮合，K线只用于回调
+//   - 按现货规则结算，不能卖出超过持仓的数量，资金或持仓不足时订单过期
+package backtest
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/binance/spot/market"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+	"github.com/sleep-go/coin-go/pkg/kline"
+)
+
+var (
+	// ErrInvalidOrder 订单参数错误
+	ErrInvalidOrder = errors.New("backtest: invalid order")
+	// ErrNoSuchOrder 订单不存在或已结束
+	ErrNoSuchOrder = errors.New("backtest: no such order")
+	// ErrNotRunning 在 Run 的回调之外下单或撤单
+	ErrNotRunning = errors.New("backtest: not running")
+)
+
+// FeeModel 按成交价和数量计算手续费，手续费以计价资产扣除
+type FeeModel func(maker bool, price, qty decimal.Decimal) decimal.Decimal
+
+// RateFee 按成交额的固定费率收取手续费
+func RateFee(maker, taker decimal.Decimal) FeeModel {
+	return func(isMaker bool, price, qty decimal.Decimal) decimal.Decimal {
+		if isMaker {
+			return price.Mul(qty).Mul(maker)
+		}
+		return price.Mul(qty).Mul(taker)
+	}
+}
+
+// SlippageModel 按参考价返回 taker 的实际成交价
+type SlippageModel func(side enums.SideType, price, qty decimal.Decimal) decimal.Decimal
+
+// NoSlippage 按参考价成交
+func NoSlippage(side enums.SideType, price, qty decimal.Decimal) decimal.Decimal {
+	return price
+}
+
+// FixedSlippage 按固定比例滑点成交，买入价格上浮、卖出价格下浮，如 0.0005 为 5 个基点
+func FixedSlippage(rate decimal.Decimal) SlippageModel {
+	return func(side enums.SideType, price, qty decimal.Decimal) decimal.Decimal {
+		if side == enums.SideTypeBuy {
+			return price.Add(price.Mul(rate))
+		}
+		return price.Sub(price.Mul(rate))
+	}
+}
+
+// Order 回测订单
+type Order struct {
+	Id       int64
+	Symbol   string
+	Side     enums.SideType
+	Type     enums.OrderType // MARKET 或 LIMIT
+	Price    decimal.Decimal // 限价单价格
+	Quantity decimal.Decimal
+	Status   enums.OrderStatusType
+	Time     int64 // 下单时的回放时间
+
+	resting bool // 限价单已挂单，之后只作为 maker 成交
+}
+
+// Trade 一笔成交
+type Trade struct {
+	Time     int64
+	OrderId  int64
+	Symbol   string
+	Side     enums.SideType
+	Price    decimal.Decimal
+	Quantity decimal.Decimal
+	Fee      decimal.Decimal // 计价资产
+	Maker    bool
+	Realized decimal.Decimal // 卖出时按持仓均价计算的已实现盈亏，不含手续费
+}
+
+// Position 持仓
+type Position struct {
+	Symbol     string
+	Amount     decimal.Decimal
+	EntryPrice decimal.Decimal // 持仓均价，不含手续费
+}
+
+// Backtest 回测引擎，不是并发安全的
+// 下单和撤单只能在 Run 的回调中调用，否则返回 ErrNotRunning；查询方法在 Run 结束后返回回放结束时的状态
+type Backtest struct {
+	OnKline    binance.Handler[market.WsKlineEvent]
+	OnAggTrade binance.Handler[market.WsAggTradeEvent]
+
+	initial  decimal.Decimal
+	fee      FeeModel
+	slippage SlippageModel
+	events   []event
+	// tradeSymbols 有归集成交数据的交易对
+	tradeSymbols map[string]bool
+
+	cash        decimal.Decimal
+	now         int64
+	last        map[string]decimal.Decimal
+	positions   map[string]*Position
+	open        []*Order
+	trades      []Trade
+	equity      []EquityPoint
+	nextOrderId int64
+	running     bool
+}
+
+// New 初始资金为计价资产 cash，默认手续费 0.1%，无滑点
+func New(cash decimal.Decimal) *Backtest {
+	rate := decimal.New(1, 3)
+	return &Backtest{
+		initial:      cash,
+		fee:          RateFee(rate, rate),
+		slippage:     NoSlippage,
+		tradeSymbols: make(map[string]bool),
+	}
+}
+
+func (b *Backtest) SetFee(fee FeeModel) *Backtest {
+	b.fee = fee
+	return b
+}
+
+func (b *Backtest) SetSlippage(slippage SlippageModel) *Backtest {
+	b.slippage = slippage
+	return b
+}
+
+// AddKlines 添加回放的K线，每根K线按收盘时间回放一次完结的 kline 事件
+func (b *Backtest) AddKlines(symbol string, interval enums.KlineIntervalType, klines []kline.Kline) *Backtest {
+	for _, k := range klines {
+		b.events = append(b.events, event{time: k.CloseTime, kline: &market.WsKlineEvent{
+			Event:  "kline",
+			Time:   k.CloseTime,
+			Symbol: symbol,
+			Kline: market.WsKline{
+				StartTime:            k.OpenTime,
+				EndTime:              k.CloseTime,
+				Symbol:               symbol,
+				Interval:             string(interval),
+				Open:                 k.Open.String(),
+				Close:                k.Close.String(),
+				High:                 k.High.String(),
+				Low:                  k.Low.String(),
+				Volume:               k.Volume.String(),
+				TradeNum:             k.TradeCount,
+				IsFinal:              true,
+				QuoteVolume:          k.QuoteVolume.String(),
+				ActiveBuyVolume:      k.TakerBuyVolume.String(),
+				ActiveBuyQuoteVolume: k.TakerBuyQuoteVolume.String(),
+			},
+		}})
+	}
+	return b
+}
+
+// AddAggTrades 添加回放的归集成交
+func (b *Backtest) AddAggTrades(trades []market.WsAggTradeEvent) *Backtest {
+	for i := range trades {
+		b.events = append(b.events, event{time: trades[i].TradeTime, trade: &trades[i]})
+		b.tradeSymbols[trades[i].Symbol] = true
+	}
+	return b
+}
+
+// Run 按时间顺序回放全部事件，ctx 结束时返回 ctx.Err()
+// 每次调用都从初始资金重新开始
+func (b *Backtest) Run(ctx context.Context) (*Result, error) {
+	b.reset()
+	b.running = true
+	defer func() { b.running = false }()
+	events := b.sorted()
+	hasKlines := slices.ContainsFunc(events, func(e event) bool { return e.kline != nil })
+	for _, e := range events {
+		err := ctx.Err()
+		if err != nil {
+			return nil, err
+		}
+		if e.trade != nil {
+			b.onTrade(e.trade)
+			if !hasKlines {
+				b.record()
+			}
+		} else {
+			b.onKline(e.kline)
+		}
+	}
+	return b.result(), nil
+}
+
+// Now 当前回放时间(毫秒)
+func (b *Backtest) Now() int64 {
+	return b.now
+}
+
+// Cash 可用的计价资产
+func (b *Backtest) Cash() decimal.Decimal {
+	return b.cash
+}
+
+// Price 交易对的最新价
+func (b *Backtest) Price(symbol string) decimal.Decimal {
+	return b.last[symbol]
+}
+
+// Position 交易对的持仓
+func (b *Backtest) Position(symbol string) Position {
+	if p, ok := b.positions[symbol]; ok {
+		return *p
+	}
+	return Position{Symbol: symbol}
+}
+
+// Equity 按最新价计算的权益
+func (b *Backtest) Equity() decimal.Decimal {
+	equity := b.cash
+	for symbol, p := range b.positions {
+		equity = equity.Add(p.Amount.Mul(b.last[symbol]))
+	}
+	return equity
+}
+
+// OpenOrders 未成交的订单
+func (b *Backtest) OpenOrders() []Order {
+	orders := make([]Order, len(b.open))
+	for i, o := range b.open {
+		orders[i] = *o
+	}
+	return orders
+}
+
+// Buy 市价买入
+func (b *Backtest) Buy(symbol string, qty decimal.Decimal) (Order, error) {
+	return b.place(symbol, enums.SideTypeBuy, enums.OrderTypeMarket, decimal.Zero, qty)
+}
+
+// Sell 市价卖出
+func (b *Backtest) Sell(symbol string, qty decimal.Decimal) (Order, error) {
+	return b.place(symbol, enums.SideTypeSell, enums.OrderTypeMarket, decimal.Zero, qty)
+}
+
+// Limit 限价单，GTC
+func (b *Backtest) Limit(symbol string, side enums.SideType, price, qty decimal.Decimal) (Order, error) {
+	if !price.IsPositive() {
+		return Order{}, ErrInvalidOrder
+	}
+	return b.place(symbol, side, enums.OrderTypeLimit, price, qty)
+}
+
+// Cancel 撤销未成交的订单
+func (b *Backtest) Cancel(orderId int64) error {
+	if !b.running {
+		return ErrNotRunning
+	}
+	i := slices.IndexFunc(b.open, func(o *Order) bool { return o.Id == orderId })
+	if i < 0 {
+		return ErrNoSuchOrder
+	}
+	b.open[i].Status = enums.OrderStatusTypeCanceled
+	b.open = slices.Delete(b.open, i, i+1)
+	return nil
+}
+
+func (b *Backtest) place(symbol string, side enums.SideType, typ enums.OrderType, price, qty decimal.Decimal) (Order, error) {
+	if !b.running {
+		return Order{}, ErrNotRunning
+	}
+	if !qty.IsPositive() || side != enums.SideTypeBuy && side != enums.SideTypeSell {
+		return Order{}, ErrInvalidOrder
+	}
+	b.nextOrderId++
+	o := &Order{
+		Id:       b.nextOrderId,
+		Symbol:   symbol,
+		Side:     side,
+		Type:     typ,
+		Price:    price,
+		Quantity: qty,
+		Status:   enums.OrderStatusTypeNew,
+		Time:     b.now,
+	}
+	b.open = append(b.open, o)
+	return *o, nil
+}
+
+func (b *Backtest) reset() {
+	b.cash = b.initial
+	b.now = 0
+	b.last = make(map[string]decimal.Decimal)
+	b.positions = make(map[string]*Position)
+	b.open = nil
+	b.trades = nil
+	b.equity = nil
+	b.nextOrderId = 0
+}
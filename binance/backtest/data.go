@@ -0,0 +1,130 @@
+package backtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/binance/spot/market"
+	"github.com/sleep-go/coin-go/pkg/kline"
+)
+
+// pageLimit 每次请求的最大条数
+const pageLimit = enums.Limit1000
+
+// Loader 分页下载历史K线和归集成交，设置缓存目录后相同参数只下载一次
+type Loader struct {
+	client *binance.Client
+	dir    string
+}
+
+func NewLoader(client *binance.Client) *Loader {
+	return &Loader{client: client}
+}
+
+// SetCacheDir 缓存目录，为空时不缓存
+func (l *Loader) SetCacheDir(dir string) *Loader {
+	l.dir = dir
+	return l
+}
+
+// Klines 下载 [start, end] 内开盘的K线，时间为毫秒时间戳
+// end 未过去或最后一根K线未完结时不写入缓存
+func (l *Loader) Klines(ctx context.Context, symbol string, interval enums.KlineIntervalType, start, end int64) ([]kline.Kline, error) {
+	var klines []kline.Kline
+	name := fmt.Sprintf("%s_kline_%s_%d_%d.json", symbol, interval, start, end)
+	err := l.cached(name, &klines, func() (bool, error) {
+		rows, err := market.NewKlines(l.client, symbol, pageLimit).
+			SetInterval(interval).
+			SetStartTime(start).
+			SetEndTime(end).
+			Iterate().
+			Collect(ctx)
+		if err != nil {
+			return false, err
+		}
+		klines, err = kline.FromArrays(rows)
+		if err != nil {
+			return false, err
+		}
+		now := l.now()
+		for i := range klines {
+			klines[i].SetFinal(now)
+		}
+		return end < now && (len(klines) == 0 || klines[len(klines)-1].IsFinal), nil
+	})
+	return klines, err
+}
+
+// AggTrades 下载 [start, end] 内的归集成交
+// end 未过去时成交还不完整，不写入缓存
+func (l *Loader) AggTrades(ctx context.Context, symbol string, start, end int64) ([]market.WsAggTradeEvent, error) {
+	var trades []market.WsAggTradeEvent
+	name := fmt.Sprintf("%s_aggTrade_%d_%d.json", symbol, start, end)
+	err := l.cached(name, &trades, func() (bool, error) {
+		complete := end < l.now()
+		p := market.NewAggTrades(l.client, symbol, pageLimit).SetStartTime(start).SetEndTime(end).Iterate()
+		for p.Next(ctx) {
+			row := p.Value()
+			event := market.WsAggTradeEvent{Event: "aggTrade", Time: row.TradeTime, Symbol: symbol}
+			event.AggTradeID = row.AggTradeID
+			event.Price = row.Price
+			event.Quantity = row.Quantity
+			event.FirstBreakdownTradeID = row.FirstBreakdownTradeID
+			event.LastBreakdownTradeID = row.LastBreakdownTradeID
+			event.TradeTime = row.TradeTime
+			event.IsBuyerMaker = row.IsBuyerMaker
+			event.Placeholder = row.Placeholder
+			trades = append(trades, event)
+		}
+		return complete, p.Err()
+	})
+	return trades, err
+}
+
+// now 按时钟偏差校正后的当前时间(毫秒)
+func (l *Loader) now() int64 {
+	return time.Now().UnixMilli() + atomic.LoadInt64(&l.client.TimeOffset)
+}
+
+// cached 缓存文件存在时读取到 v，否则调用 download，返回的数据完整时写入缓存
+func (l *Loader) cached(name string, v any, download func() (complete bool, err error)) error {
+	if l.dir == "" {
+		_, err := download()
+		return err
+	}
+	path := filepath.Join(l.dir, name)
+	data, err := os.ReadFile(path)
+	if err == nil {
+		return json.Unmarshal(data, v)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	complete, err := download()
+	if err != nil || !complete {
+		return err
+	}
+	data, err = json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(l.dir, 0o755)
+	if err != nil {
+		return err
+	}
+	// 先写临时文件再重命名，中断时不会留下不完整的缓存
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, data, 0o644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,112 @@
+package backtest
+
+import (
+	"math"
+
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// yearMillis 一年的毫秒数，加密货币全年交易
+const yearMillis = 365 * 24 * 60 * 60 * 1000
+
+// EquityPoint 权益曲线上的一个点，有K线时每根K线收盘记录一次，否则每笔成交记录一次
+type EquityPoint struct {
+	Time   int64
+	Equity decimal.Decimal
+}
+
+// Result 回测结果
+type Result struct {
+	Equity      []EquityPoint
+	Trades      []Trade
+	Initial     decimal.Decimal // 初始资金
+	Final       decimal.Decimal // 按最后价格计算的期末权益
+	PnL         decimal.Decimal // 期末权益 - 初始资金
+	Return      decimal.Decimal // PnL / 初始资金
+	Fees        decimal.Decimal // 手续费合计
+	MaxDrawdown decimal.Decimal // 权益曲线从高点回落的最大比例
+	// Sharpe 按权益曲线逐点收益率计算的年化夏普比率，无风险利率为 0，点数不足或收益率没有波动时为 0
+	Sharpe float64
+	// WinRate 卖出成交中已实现盈亏扣除该笔手续费后为正的比例
+	WinRate decimal.Decimal
+}
+
+func (b *Backtest) result() *Result {
+	r := &Result{
+		Equity:      b.equity,
+		Trades:      b.trades,
+		Initial:     b.initial,
+		Final:       b.Equity(),
+		Fees:        decimal.Zero,
+		MaxDrawdown: maxDrawdown(b.equity),
+		Sharpe:      sharpe(b.equity),
+		WinRate:     decimal.Zero,
+	}
+	r.PnL = r.Final.Sub(r.Initial)
+	r.Return = decimal.Zero
+	if r.Initial.IsPositive() {
+		r.Return = r.PnL.Div(r.Initial)
+	}
+	var wins, closes int64
+	for _, t := range b.trades {
+		r.Fees = r.Fees.Add(t.Fee)
+		if t.Side != enums.SideTypeSell {
+			continue
+		}
+		closes++
+		if t.Realized.Sub(t.Fee).IsPositive() {
+			wins++
+		}
+	}
+	if closes > 0 {
+		r.WinRate = decimal.NewFromInt(wins).Div(decimal.NewFromInt(closes))
+	}
+	return r
+}
+
+func maxDrawdown(equity []EquityPoint) decimal.Decimal {
+	drawdown := decimal.Zero
+	if len(equity) == 0 {
+		return drawdown
+	}
+	peak := equity[0].Equity
+	for _, p := range equity {
+		peak = decimal.Max(peak, p.Equity)
+		if peak.IsPositive() {
+			drawdown = decimal.Max(drawdown, peak.Sub(p.Equity).Div(peak))
+		}
+	}
+	return drawdown
+}
+
+// sharpe 逐点收益率的均值 / 标准差 * sqrt(每年的点数)，每年的点数按平均间隔估算
+func sharpe(equity []EquityPoint) float64 {
+	if len(equity) < 3 {
+		return 0
+	}
+	returns := make([]float64, 0, len(equity)-1)
+	for i := 1; i < len(equity); i++ {
+		prev := equity[i-1].Equity.Float64()
+		if prev == 0 {
+			return 0
+		}
+		returns = append(returns, equity[i].Equity.Float64()/prev-1)
+	}
+	var mean float64
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+	var variance float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	std := math.Sqrt(variance / float64(len(returns)-1))
+	span := equity[len(equity)-1].Time - equity[0].Time
+	if std == 0 || span <= 0 {
+		return 0
+	}
+	periods := float64(yearMillis) / (float64(span) / float64(len(returns)))
+	return mean / std * math.Sqrt(periods)
+}
@@ -0,0 +1,149 @@
+package backtest
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/binance/spot/market"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// event 回放的一个事件，kline 和 trade 只有一个不为空
+type event struct {
+	time  int64
+	kline *market.WsKlineEvent
+	trade *market.WsAggTradeEvent
+}
+
+// sorted 按时间排序，同一时间成交在K线之前，同类事件保持添加顺序
+func (b *Backtest) sorted() []event {
+	events := slices.Clone(b.events)
+	slices.SortStableFunc(events, func(x, y event) int {
+		if c := cmp.Compare(x.time, y.time); c != 0 {
+			return c
+		}
+		if x.trade != nil && y.kline != nil {
+			return -1
+		}
+		if x.kline != nil && y.trade != nil {
+			return 1
+		}
+		return 0
+	})
+	return events
+}
+
+func (b *Backtest) onTrade(e *market.WsAggTradeEvent) {
+	b.now = e.TradeTime
+	price := e.PriceDecimal()
+	b.match(e.Symbol, price, price, price)
+	b.last[e.Symbol] = price
+	if b.OnAggTrade != nil {
+		b.OnAggTrade(*e)
+	}
+}
+
+func (b *Backtest) onKline(e *market.WsKlineEvent) {
+	b.now = e.Kline.EndTime
+	k := e.Kline
+	if !b.tradeSymbols[e.Symbol] {
+		b.match(e.Symbol, k.OpenDecimal(), k.HighDecimal(), k.LowDecimal())
+	}
+	b.last[e.Symbol] = k.CloseDecimal()
+	b.record()
+	if b.OnKline != nil {
+		b.OnKline(*e)
+	}
+}
+
+// match 用一个价格区间撮合订单，open 为区间的第一个价格，成交事件的三个价格相同
+func (b *Backtest) match(symbol string, open, high, low decimal.Decimal) {
+	for _, o := range slices.Clone(b.open) {
+		if o.Symbol != symbol {
+			continue
+		}
+		switch {
+		case o.Type == enums.OrderTypeMarket:
+			b.fill(o, b.slippage(o.Side, open, o.Quantity), false)
+		case !o.resting && o.crosses(open):
+			// 下单时已可以成交，作为 taker 成交，滑点后的价格不超过限价
+			price := b.slippage(o.Side, open, o.Quantity)
+			if o.Side == enums.SideTypeBuy {
+				price = decimal.Min(price, o.Price)
+			} else {
+				price = decimal.Max(price, o.Price)
+			}
+			b.fill(o, price, false)
+		case o.Side == enums.SideTypeBuy && low.LessThanOrEqual(o.Price),
+			o.Side == enums.SideTypeSell && high.GreaterThanOrEqual(o.Price):
+			b.fill(o, o.Price, true)
+		default:
+			o.resting = true
+		}
+	}
+}
+
+// crosses 限价单按 price 是否可以立即成交
+func (o *Order) crosses(price decimal.Decimal) bool {
+	if o.Side == enums.SideTypeBuy {
+		return price.LessThanOrEqual(o.Price)
+	}
+	return price.GreaterThanOrEqual(o.Price)
+}
+
+// fill 全部成交并结算，资金或持仓不足时过期
+func (b *Backtest) fill(o *Order, price decimal.Decimal, maker bool) {
+	b.open = slices.DeleteFunc(b.open, func(other *Order) bool { return other == o })
+	fee := b.fee(maker, price, o.Quantity)
+	p := b.positions[o.Symbol]
+	if p == nil {
+		p = &Position{Symbol: o.Symbol}
+		b.positions[o.Symbol] = p
+	}
+	t := Trade{
+		Time:     b.now,
+		OrderId:  o.Id,
+		Symbol:   o.Symbol,
+		Side:     o.Side,
+		Price:    price,
+		Quantity: o.Quantity,
+		Fee:      fee,
+		Maker:    maker,
+	}
+	amount := price.Mul(o.Quantity)
+	if o.Side == enums.SideTypeBuy {
+		cost := amount.Add(fee)
+		if b.cash.LessThan(cost) {
+			o.Status = enums.OrderStatusTypeExpiredCanceled
+			return
+		}
+		b.cash = b.cash.Sub(cost)
+		total := p.Amount.Add(o.Quantity)
+		p.EntryPrice = p.Amount.Mul(p.EntryPrice).Add(amount).Div(total)
+		p.Amount = total
+	} else {
+		if p.Amount.LessThan(o.Quantity) {
+			o.Status = enums.OrderStatusTypeExpiredCanceled
+			return
+		}
+		b.cash = b.cash.Add(amount).Sub(fee)
+		t.Realized = price.Sub(p.EntryPrice).Mul(o.Quantity)
+		p.Amount = p.Amount.Sub(o.Quantity)
+		if p.Amount.IsZero() {
+			p.EntryPrice = decimal.Zero
+		}
+	}
+	o.Status = enums.OrderStatusTypeFilled
+	b.trades = append(b.trades, t)
+}
+
+// record 记录权益曲线，同一时间只保留最后一个点
+func (b *Backtest) record() {
+	point := EquityPoint{Time: b.now, Equity: b.Equity()}
+	if n := len(b.equity); n > 0 && b.equity[n-1].Time == point.Time {
+		b.equity[n-1] = point
+		return
+	}
+	b.equity = append(b.equity, point)
+}
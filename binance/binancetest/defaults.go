@@ -0,0 +1,285 @@
+package binancetest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"maps"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+)
+
+// registerDefaults 默认接口，可以通过 Handle、HandleWsApi 或 fixture 覆盖
+func (s *Server) registerDefaults() {
+	empty := func(r *Request) (any, error) { return struct{}{}, nil }
+	serverTime := func(r *Request) (any, error) { return map[string]int64{"serverTime": s.Now()}, nil }
+	depth := func(r *Request) (any, error) {
+		now := s.Now()
+		return map[string]any{"lastUpdateId": 1, "E": now, "T": now, "bids": [][]string{}, "asks": [][]string{}}, nil
+	}
+	for _, path := range []string{consts.ApiPing, consts.FApiPing, consts.DApiPing} {
+		s.rest[http.MethodGet+" "+path] = empty
+	}
+	for _, path := range []string{consts.ApiTime, consts.FApiTime, consts.DApiTime} {
+		s.rest[http.MethodGet+" "+path] = serverTime
+	}
+	for _, path := range []string{consts.ApiMarketDepth, consts.FApiMarketDepth, consts.DApiMarketDepth} {
+		s.rest[http.MethodGet+" "+path] = depth
+	}
+	for _, path := range []string{consts.ApiOrder, consts.FApiOrder, consts.DApiOrder, consts.SApiMarginOrder} {
+		s.rest[http.MethodPost+" "+path] = s.placeOrder
+		s.rest[http.MethodGet+" "+path] = s.queryOrder
+		s.rest[http.MethodDelete+" "+path] = s.cancelOrder
+	}
+	for _, path := range []string{consts.FApiOrder, consts.DApiOrder} {
+		s.rest[http.MethodPut+" "+path] = s.modifyOrder
+	}
+	for _, path := range []string{consts.ApiTradingOrderTest, consts.FApiTradingOrderTest} {
+		s.rest[http.MethodPost+" "+path] = empty
+	}
+	for _, path := range []string{consts.ApiStreamUserDataStream, consts.FApiStreamListenKey, consts.DApiStreamListenKey, consts.SApiUserDataStream, consts.SApiUserDataStreamIsolated} {
+		s.rest[http.MethodPost+" "+path] = s.startUserDataStream
+		s.rest[http.MethodPut+" "+path] = s.pingUserDataStream
+		s.rest[http.MethodDelete+" "+path] = s.stopUserDataStream
+	}
+
+	s.wsApi["ping"] = empty
+	s.wsApi["time"] = serverTime
+	s.wsApi["depth"] = depth
+	s.wsApi["order.place"] = s.placeOrder
+	s.wsApi["order.test"] = empty
+	s.wsApi["order.status"] = s.queryOrder
+	s.wsApi["order.cancel"] = s.cancelOrder
+	s.wsApi["order.modify"] = s.modifyOrder
+	s.wsApi["userDataStream.start"] = s.startUserDataStream
+	s.wsApi["userDataStream.ping"] = s.pingUserDataStream
+	s.wsApi["userDataStream.stop"] = s.stopUserDataStream
+	s.wsApi["userDataStream.subscribe"] = s.subscribeUserData
+	s.wsApi["userDataStream.unsubscribe"] = s.unsubscribeUserData
+	s.wsApi["session.logon"] = s.sessionLogon
+	s.wsApi["session.status"] = s.sessionStatus
+	s.wsApi["session.logout"] = s.sessionLogout
+}
+
+// orderStore 默认下单接口保存的订单，订单不会成交，状态保持 NEW 直到撤销
+type orderStore struct {
+	mu     sync.Mutex
+	nextId int64
+	orders []map[string]any
+}
+
+func newOrderStore() *orderStore {
+	return &orderStore{nextId: 1}
+}
+
+func (o *orderStore) find(symbol string, orderId int64, clientOrderId string) map[string]any {
+	for _, order := range o.orders {
+		if order["symbol"] != symbol {
+			continue
+		}
+		if orderId != 0 && order["orderId"] == orderId || orderId == 0 && clientOrderId != "" && order["clientOrderId"] == clientOrderId {
+			return order
+		}
+	}
+	return nil
+}
+
+// Orders 默认下单接口收到的订单
+func (s *Server) Orders() []map[string]any {
+	s.orders.mu.Lock()
+	defer s.orders.mu.Unlock()
+	orders := make([]map[string]any, len(s.orders.orders))
+	for i, order := range s.orders.orders {
+		orders[i] = maps.Clone(order)
+	}
+	return orders
+}
+
+func (s *Server) placeOrder(r *Request) (any, error) {
+	for _, key := range []string{"symbol", "side", "type"} {
+		if r.Params.Get(key) == "" {
+			return nil, Error(binance.ErrMandatoryParamEmpty.Code, "Mandatory parameter '"+key+"' was not sent, was empty/null, or malformed.")
+		}
+	}
+	o := s.orders
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	symbol := r.Params.Get("symbol")
+	clientOrderId := r.Params.Get("newClientOrderId")
+	if clientOrderId != "" {
+		if order := o.find(symbol, 0, clientOrderId); order != nil && order["status"] == "NEW" {
+			return nil, Error(binance.ErrNewOrderRejected.Code, "Duplicate order sent.")
+		}
+	} else {
+		clientOrderId = "binancetest-" + strconv.FormatInt(o.nextId, 10)
+	}
+	now := s.Now()
+	order := map[string]any{
+		"symbol":                  symbol,
+		"orderId":                 o.nextId,
+		"orderListId":             -1,
+		"clientOrderId":           clientOrderId,
+		"transactTime":            now,
+		"time":                    now,
+		"updateTime":              now,
+		"workingTime":             now,
+		"price":                   value(r, "price", "0"),
+		"stopPrice":               value(r, "stopPrice", "0"),
+		"origQty":                 value(r, "quantity", "0"),
+		"executedQty":             "0",
+		"cummulativeQuoteQty":     "0",
+		"cumQty":                  "0",
+		"cumQuote":                "0",
+		"avgPrice":                "0",
+		"status":                  "NEW",
+		"timeInForce":             value(r, "timeInForce", "GTC"),
+		"type":                    r.Params.Get("type"),
+		"origType":                r.Params.Get("type"),
+		"side":                    r.Params.Get("side"),
+		"positionSide":            value(r, "positionSide", "BOTH"),
+		"reduceOnly":              r.Params.Get("reduceOnly") == "true",
+		"selfTradePreventionMode": value(r, "selfTradePreventionMode", "NONE"),
+		"fills":                   []any{},
+	}
+	o.nextId++
+	o.orders = append(o.orders, order)
+	return maps.Clone(order), nil
+}
+
+func (s *Server) queryOrder(r *Request) (any, error) {
+	orderId, err := param(r, "orderId", 0)
+	if err != nil {
+		return nil, err
+	}
+	s.orders.mu.Lock()
+	defer s.orders.mu.Unlock()
+	order := s.orders.find(r.Params.Get("symbol"), orderId, r.Params.Get("origClientOrderId"))
+	if order == nil {
+		return nil, Error(binance.ErrNoSuchOrder.Code, "Order does not exist.")
+	}
+	return maps.Clone(order), nil
+}
+
+func (s *Server) cancelOrder(r *Request) (any, error) {
+	orderId, err := param(r, "orderId", 0)
+	if err != nil {
+		return nil, err
+	}
+	s.orders.mu.Lock()
+	defer s.orders.mu.Unlock()
+	order := s.orders.find(r.Params.Get("symbol"), orderId, r.Params.Get("origClientOrderId"))
+	if order == nil || order["status"] != "NEW" {
+		return nil, Error(binance.ErrCancelRejected.Code, "Unknown order sent.")
+	}
+	order["status"] = "CANCELED"
+	order["updateTime"] = s.Now()
+	return maps.Clone(order), nil
+}
+
+// modifyOrder 合约修改订单，只修改未成交订单的价格和数量
+func (s *Server) modifyOrder(r *Request) (any, error) {
+	orderId, err := param(r, "orderId", 0)
+	if err != nil {
+		return nil, err
+	}
+	s.orders.mu.Lock()
+	defer s.orders.mu.Unlock()
+	order := s.orders.find(r.Params.Get("symbol"), orderId, r.Params.Get("origClientOrderId"))
+	if order == nil {
+		return nil, Error(binance.ErrNoSuchOrder.Code, "Order does not exist.")
+	}
+	if order["status"] != "NEW" {
+		return nil, Error(binance.ErrCancelRejected.Code, "Unknown order sent.")
+	}
+	order["price"] = value(r, "price", order["price"].(string))
+	order["origQty"] = value(r, "quantity", order["origQty"].(string))
+	order["updateTime"] = s.Now()
+	return maps.Clone(order), nil
+}
+
+// startUserDataStream 合约已有 listenKey 时返回原来的 listenKey
+func (s *Server) startUserDataStream(r *Request) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if listenKey := s.currentListenKey(); listenKey != "" && isFutures(r) {
+		return map[string]string{"listenKey": listenKey}, nil
+	}
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	listenKey := hex.EncodeToString(b)
+	s.listenKeys[listenKey] = true
+	return map[string]string{"listenKey": listenKey}, nil
+}
+
+// ListenKeys 当前有效的 listenKey，用户数据推送通过 Push(listenKey, event) 发送
+func (s *Server) ListenKeys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.listenKeys))
+	for k := range s.listenKeys {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (s *Server) pingUserDataStream(r *Request) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.validListenKey(r) {
+		return nil, Error(binance.ErrInvalidListenKey.Code, "This listenKey does not exist.")
+	}
+	if isFutures(r) {
+		return map[string]string{"listenKey": s.currentListenKey()}, nil
+	}
+	return struct{}{}, nil
+}
+
+func (s *Server) stopUserDataStream(r *Request) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.validListenKey(r) {
+		return nil, Error(binance.ErrInvalidListenKey.Code, "This listenKey does not exist.")
+	}
+	if listenKey := r.Params.Get("listenKey"); listenKey != "" {
+		delete(s.listenKeys, listenKey)
+	} else {
+		clear(s.listenKeys)
+	}
+	return struct{}{}, nil
+}
+
+// validListenKey 合约的延长和关闭接口不带 listenKey 参数，作用于当前的 listenKey
+func (s *Server) validListenKey(r *Request) bool {
+	listenKey := r.Params.Get("listenKey")
+	if listenKey == "" && isFutures(r) {
+		return len(s.listenKeys) > 0
+	}
+	return s.listenKeys[listenKey]
+}
+
+// currentListenKey 合约接口使用的 listenKey，有多个时取排序后的第一个
+func (s *Server) currentListenKey() string {
+	var first string
+	for k := range s.listenKeys {
+		if first == "" || k < first {
+			first = k
+		}
+	}
+	return first
+}
+
+// isFutures U本位(/fapi)和币本位(/dapi)合约接口
+func isFutures(r *Request) bool {
+	return strings.HasPrefix(r.Path, "/fapi/") || strings.HasPrefix(r.Path, "/dapi/")
+}
+
+func value(r *Request, key, def string) string {
+	if v := r.Params.Get(key); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,85 @@
+package binancetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Fixtures 录制的响应
+//
+//	{
+//	  "rest":    {"GET /api/v3/depth": {"lastUpdateId": 1, "bids": [], "asks": []}},
+//	  "wsApi":   {"depth": {"lastUpdateId": 1, "bids": [], "asks": []}},
+//	  "streams": {"btcusdt@depth": [{"e": "depthUpdate", "U": 1, "u": 2}]}
+//	}
+type Fixtures struct {
+	Rest    map[string]json.RawMessage   `json:"rest"`    // key 为 "GET /api/v3/depth"
+	WsApi   map[string]json.RawMessage   `json:"wsApi"`   // key 为 WS API 方法名，值为 result
+	Streams map[string][]json.RawMessage `json:"streams"` // key 为 stream 名称，订阅后按顺序推送
+}
+
+// LoadFixtures 从 JSON 文件加载录制的响应，覆盖同名接口
+func (s *Server) LoadFixtures(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var f Fixtures
+	err = json.Unmarshal(data, &f)
+	if err != nil {
+		return fmt.Errorf("binancetest: %s: %w", path, err)
+	}
+	return s.SetFixtures(&f)
+}
+
+// SetFixtures 使用录制的响应，覆盖同名接口
+func (s *Server) SetFixtures(f *Fixtures) error {
+	for key, body := range f.Rest {
+		method, path, ok := strings.Cut(key, " ")
+		if !ok {
+			return fmt.Errorf("binancetest: invalid rest fixture key %q, want \"METHOD /path\"", key)
+		}
+		s.Fixture(method, path, body)
+	}
+	for method, result := range f.WsApi {
+		s.WsApiFixture(method, result)
+	}
+	for stream, events := range f.Streams {
+		s.mu.Lock()
+		s.streams[stream] = append(s.streams[stream], events...)
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// Fixture REST 接口固定返回 body
+func (s *Server) Fixture(method, path string, body any) {
+	s.Handle(method, path, func(r *Request) (any, error) {
+		return body, nil
+	})
+}
+
+// WsApiFixture WS API 方法固定返回 result
+func (s *Server) WsApiFixture(method string, result any) {
+	s.HandleWsApi(method, func(r *Request) (any, error) {
+		return result, nil
+	})
+}
+
+// StreamFixture 追加 stream 的推送，之后订阅该 stream 的连接会先收到这些推送
+func (s *Server) StreamFixture(stream string, events ...any) error {
+	raw := make([]json.RawMessage, len(events))
+	for i, event := range events {
+		data, err := marshal(event)
+		if err != nil {
+			return err
+		}
+		raw[i] = data
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.streams[stream] = append(s.streams[stream], raw...)
+	return nil
+}
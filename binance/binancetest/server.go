@@ -0,0 +1,266 @@
+// Package binancetest 进程内的模拟交易所，用于离线测试现货和合约客户端
+//
+// Server 基于 httptest.Server，在同一个地址上提供 REST 接口、WS API(/ws-api/v3、/ws-fapi/v1)
+// 和行情推送(/ws/<streams>、/stream?streams=)。响应来自录制的 fixture 或者通过 Handle 注册的处理函数，
+// 注册了 API Key 后会按 HMAC、RSA、Ed25519 校验签名请求。
+//
+//	s := binancetest.NewServer()
+//	defer s.Close()
+//	client := binance.NewClient("key", "secret", s.URL)
+package binancetest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sleep-go/coin-go/binance"
+)
+
+// Request 服务端收到的请求
+type Request struct {
+	Method string      // HTTP 方法，WS API 为空
+	Path   string      // REST 路径，WS API 为方法名，如 order.place
+	Params url.Values  // query、表单和 WS API params 合并后的参数
+	APIKey string      // REST 为 X-MBX-APIKEY，WS API 为 params.apiKey
+	Signed bool        // 带有 signature 或 timestamp 参数
+	Header http.Header // REST 请求头，WS API 为空
+
+	conn       *wsConn // 收到 WS API 请求的连接
+	terminated bool    // 响应之后推送 eventStreamTerminated
+}
+
+// Handler 处理请求，返回值按 JSON 编码作为响应，[]byte 和 json.RawMessage 原样返回
+// 返回 *binance.APIError 时响应币安格式的错误，其他错误响应 500 和 -1000
+type Handler func(r *Request) (any, error)
+
+// Server 模拟交易所
+type Server struct {
+	*httptest.Server
+	TimeOffset int64 // 服务器时间与本地时间的差值(毫秒)，用于模拟时钟偏差
+
+	mu         sync.Mutex
+	rest       map[string]Handler
+	wsApi      map[string]Handler
+	streams    map[string][]json.RawMessage
+	keys       map[string]*apiKey
+	requests   []*Request
+	conns      map[*wsConn]struct{}
+	orders     *orderStore
+	listenKeys map[string]bool
+	upgrader   websocket.Upgrader
+}
+
+// NewServer 启动模拟交易所，默认提供 ping、time、深度、下单、查单、撤单、合约改单和 listenKey 接口
+func NewServer() *Server {
+	s := &Server{
+		rest:       make(map[string]Handler),
+		wsApi:      make(map[string]Handler),
+		streams:    make(map[string][]json.RawMessage),
+		keys:       make(map[string]*apiKey),
+		conns:      make(map[*wsConn]struct{}),
+		orders:     newOrderStore(),
+		listenKeys: make(map[string]bool),
+	}
+	s.registerDefaults()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// WsURL 行情推送的 base URL，用于 binance.NewWsClient
+func (s *Server) WsURL() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+// WsApiURL 现货 WS API 地址
+func (s *Server) WsApiURL() string {
+	return s.WsURL() + "/ws-api/v3"
+}
+
+// WsFApiURL 合约 WS API 地址
+func (s *Server) WsFApiURL() string {
+	return s.WsURL() + "/ws-fapi/v1"
+}
+
+// Close 断开所有 websocket 连接并关闭服务
+func (s *Server) Close() {
+	s.mu.Lock()
+	conns := make([]*wsConn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	s.mu.Unlock()
+	for _, c := range conns {
+		_ = c.conn.Close()
+	}
+	s.Server.Close()
+}
+
+// Handle 注册 REST 接口，覆盖同一路径已有的处理函数
+func (s *Server) Handle(method, path string, h Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rest[method+" "+path] = h
+}
+
+// HandleWsApi 注册 WS API 方法，覆盖已有的处理函数
+func (s *Server) HandleWsApi(method string, h Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.wsApi[method] = h
+}
+
+// Requests 已收到的 REST 和 WS API 请求，按到达顺序
+func (s *Server) Requests() []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Request(nil), s.requests...)
+}
+
+// Now 服务器当前时间(毫秒)，包含 TimeOffset
+func (s *Server) Now() int64 {
+	return time.Now().UnixMilli() + s.TimeOffset
+}
+
+// Error 构造币安格式的错误，状态码按币安的约定: -1002、-2014、-2015 为 401，-1003 为 429，其余为 400
+func Error(code int, msg string) *binance.APIError {
+	status := http.StatusBadRequest
+	switch code {
+	case binance.ErrUnauthorized.Code, binance.ErrBadAPIKeyFmt.Code, binance.ErrRejectedAPIKey.Code:
+		status = http.StatusUnauthorized
+	case binance.ErrTooManyRequests.Code:
+		status = http.StatusTooManyRequests
+	}
+	return &binance.APIError{Code: code, Msg: msg, StatusCode: status}
+}
+
+func (s *Server) record(r *Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	switch {
+	case strings.HasPrefix(req.URL.Path, "/ws-api/"), strings.HasPrefix(req.URL.Path, "/ws-fapi/"):
+		s.serveWsApi(w, req)
+		return
+	case req.URL.Path == "/ws", strings.HasPrefix(req.URL.Path, "/ws/"), req.URL.Path == "/stream":
+		s.serveStream(w, req)
+		return
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		writeError(w, Error(binance.ErrUnknown.Code, err.Error()))
+		return
+	}
+	query := req.URL.Query()
+	form, _ := url.ParseQuery(string(body))
+	r := &Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Params: url.Values{},
+		APIKey: req.Header.Get("X-MBX-APIKEY"),
+		Header: req.Header,
+	}
+	for _, values := range []url.Values{query, form} {
+		for k, v := range values {
+			r.Params[k] = append(r.Params[k], v...)
+		}
+	}
+	r.Signed = r.Params.Has("signature") || r.Params.Has("timestamp")
+	s.record(r)
+	// 签名内容为原始 query 去掉 signature 后加上原始请求体，不重新编码
+	err = s.authenticate(r, unsignedQuery(req.URL.RawQuery)+string(body))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	s.mu.Lock()
+	h, ok := s.rest[req.Method+" "+req.URL.Path]
+	s.mu.Unlock()
+	if !ok {
+		e := Error(binance.ErrUnknown.Code, "unknown endpoint "+req.Method+" "+req.URL.Path)
+		e.StatusCode = http.StatusNotFound
+		writeError(w, e)
+		return
+	}
+	res, err := h(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	data, err := marshal(res)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	_, _ = w.Write(data)
+}
+
+// unsignedQuery 去掉原始 query 中的 signature，其余参数保持原样
+func unsignedQuery(raw string) string {
+	params := strings.Split(raw, "&")
+	kept := params[:0]
+	for _, p := range params {
+		if !strings.HasPrefix(p, "signature=") {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, "&")
+}
+
+// apiError 处理函数返回的错误转换为 *binance.APIError
+func apiError(err error) *binance.APIError {
+	var e *binance.APIError
+	if !errors.As(err, &e) {
+		return &binance.APIError{Code: binance.ErrUnknown.Code, Msg: err.Error(), StatusCode: http.StatusInternalServerError}
+	}
+	if e.StatusCode == 0 {
+		e = &binance.APIError{Code: e.Code, Msg: e.Msg, StatusCode: http.StatusBadRequest, Header: e.Header}
+	}
+	return e
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	e := apiError(err)
+	for k, v := range e.Header {
+		w.Header()[k] = v
+	}
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.WriteHeader(e.StatusCode)
+	data, _ := json.Marshal(e)
+	_, _ = w.Write(data)
+}
+
+func marshal(v any) ([]byte, error) {
+	switch v := v.(type) {
+	case json.RawMessage:
+		return v, nil
+	case []byte:
+		return v, nil
+	}
+	return json.Marshal(v)
+}
+
+// param 读取整数参数，不存在时返回 def
+func param(r *Request, key string, def int64) (int64, error) {
+	v := r.Params.Get(key)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, Error(binance.ErrMandatoryParamEmpty.Code, "Illegal characters found in parameter '"+key+"'; legal range is '^[0-9]{1,20}$'.")
+	}
+	return n, nil
+}
@@ -0,0 +1,99 @@
+package binancetest
+
+import (
+	"encoding/json"
+
+	"github.com/sleep-go/coin-go/binance"
+)
+
+// session WS API 连接的登录状态和用户数据订阅
+type session struct {
+	apiKey     string // session.logon 使用的 API Key，未登录时为空
+	connected  int64
+	authorized int64
+	userData   bool // 已通过 userDataStream.subscribe 订阅用户数据
+}
+
+// sessionLogon 签名已经由 authenticate 校验，登录后同一连接上的请求不需要 apiKey 和 signature
+func (s *Server) sessionLogon(r *Request) (any, error) {
+	if !r.Signed {
+		return nil, Error(binance.ErrMandatoryParamEmpty.Code, "Mandatory parameter 'signature' was not sent, was empty/null, or malformed.")
+	}
+	s.mu.Lock()
+	r.conn.apiKey = r.APIKey
+	r.conn.authorized = s.Now()
+	s.mu.Unlock()
+	return s.sessionStatus(r)
+}
+
+func (s *Server) sessionStatus(r *Request) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var apiKey, authorized any
+	if r.conn.apiKey != "" {
+		apiKey, authorized = r.conn.apiKey, r.conn.authorized
+	}
+	return map[string]any{
+		"apiKey":           apiKey,
+		"authorizedSince":  authorized,
+		"connectedSince":   r.conn.connected,
+		"returnRateLimits": false,
+		"serverTime":       s.Now(),
+		"userDataStream":   r.conn.userData,
+	}, nil
+}
+
+// sessionLogout 登出后已订阅的用户数据推送停止
+func (s *Server) sessionLogout(r *Request) (any, error) {
+	s.mu.Lock()
+	r.terminated = r.conn.userData
+	r.conn.session = session{connected: r.conn.connected}
+	s.mu.Unlock()
+	return s.sessionStatus(r)
+}
+
+func (s *Server) subscribeUserData(r *Request) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.conn.apiKey == "" {
+		return nil, Error(binance.ErrUnauthorized.Code, "You are not authorized to execute this request.")
+	}
+	r.conn.userData = true
+	return map[string]int{"subscriptionId": 0}, nil
+}
+
+func (s *Server) unsubscribeUserData(r *Request) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r.terminated = r.conn.userData
+	r.conn.userData = false
+	return struct{}{}, nil
+}
+
+// PushUserData 向所有通过 userDataStream.subscribe 订阅了用户数据的 WS API 连接推送 event，返回推送的连接数
+func (s *Server) PushUserData(event any) (int, error) {
+	data, err := marshal(event)
+	if err != nil {
+		return 0, err
+	}
+	var conns []*wsConn
+	s.mu.Lock()
+	for c := range s.conns {
+		if c.userData {
+			conns = append(conns, c)
+		}
+	}
+	s.mu.Unlock()
+	n := 0
+	for _, c := range conns {
+		if c.writeMessage(userDataFrame(data)) == nil {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func userDataFrame(event json.RawMessage) []byte {
+	b, _ := json.Marshal(map[string]any{"subscriptionId": 0, "event": event})
+	return b
+}
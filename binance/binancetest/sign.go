@@ -0,0 +1,128 @@
+package binancetest
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+
+	"github.com/sleep-go/coin-go/binance"
+)
+
+type apiKey struct {
+	secret string
+	public crypto.PublicKey
+}
+
+// AddKey 注册 HMAC API Key
+// 注册了任意 Key 后签名请求必须使用已注册的 Key 并且签名正确，否则返回 -2015 或 -1022
+func (s *Server) AddKey(key, secret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[key] = &apiKey{secret: secret}
+}
+
+// AddPublicKey 注册 RSA(*rsa.PublicKey) 或 Ed25519(ed25519.PublicKey) API Key
+func (s *Server) AddPublicKey(key string, public crypto.PublicKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[key] = &apiKey{public: public}
+}
+
+func (k *apiKey) verify(payload, signature string) bool {
+	if k.secret != "" {
+		h := hmac.New(sha256.New, []byte(k.secret))
+		h.Write([]byte(payload))
+		sig, err := hex.DecodeString(signature)
+		return err == nil && hmac.Equal(sig, h.Sum(nil))
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	switch public := k.public.(type) {
+	case *rsa.PublicKey:
+		hash := sha256.Sum256([]byte(payload))
+		return rsa.VerifyPKCS1v15(public, crypto.SHA256, hash[:], sig) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(public, []byte(payload), sig)
+	}
+	return false
+}
+
+// authenticate 校验签名请求的 API Key、签名和时间戳，没有注册 Key 时只校验时间戳
+func (s *Server) authenticate(r *Request, payload string) error {
+	if !r.Signed {
+		return nil
+	}
+	s.mu.Lock()
+	n := len(s.keys)
+	k := s.keys[r.APIKey]
+	s.mu.Unlock()
+	if n > 0 {
+		if k == nil {
+			return Error(binance.ErrRejectedAPIKey.Code, "Invalid API-key, IP, or permissions for action.")
+		}
+		if !k.verify(payload, r.Params.Get("signature")) {
+			return Error(binance.ErrInvalidSignature.Code, "Signature for this request is not valid.")
+		}
+	}
+	// timestamp < serverTime + 1000 且 serverTime - timestamp <= recvWindow
+	timestamp, err := param(r, "timestamp", 0)
+	if err != nil {
+		return err
+	}
+	recvWindow, err := param(r, "recvWindow", 5000)
+	if err != nil {
+		return err
+	}
+	now := s.Now()
+	if timestamp >= now+1000 || now-timestamp > recvWindow {
+		return Error(binance.ErrTimestampOutsideRecvWindow.Code, "Timestamp for this request is outside of the recvWindow.")
+	}
+	return nil
+}
+
+// GenerateRSAKey 生成 2048 位 RSA 私钥写入 dir，返回私钥路径和公钥，私钥路径用于 binance.NewRsaClient
+func GenerateRSAKey(dir string) (string, *rsa.PublicKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return "", nil, err
+	}
+	path, err := writeKey(filepath.Join(dir, "rsa-prv-key.pem"), key)
+	if err != nil {
+		return "", nil, err
+	}
+	return path, &key.PublicKey, nil
+}
+
+// GenerateED25519Key 生成 Ed25519 私钥写入 dir，返回私钥路径和公钥，私钥路径用于 binance.NewED25519Client
+func GenerateED25519Key(dir string) (string, ed25519.PublicKey, error) {
+	public, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", nil, err
+	}
+	path, err := writeKey(filepath.Join(dir, "ed25519-prv-key.pem"), key)
+	if err != nil {
+		return "", nil, err
+	}
+	return path, public, nil
+}
+
+// writeKey 私钥按 PKCS#8 PEM 格式写入文件
+func writeKey(path string, key any) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	return path, os.WriteFile(path, data, 0600)
+}
@@ -0,0 +1,362 @@
+package binancetest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sleep-go/coin-go/binance"
+)
+
+type wsConn struct {
+	conn     *websocket.Conn
+	mu       sync.Mutex
+	combined bool
+	api      bool // WS API 连接
+	streams  map[string]bool
+	session
+}
+
+func (c *wsConn) writeJSON(v any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn.WriteJSON(v)
+}
+
+func (c *wsConn) writeMessage(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+func (s *Server) accept(w http.ResponseWriter, req *http.Request, combined bool) *wsConn {
+	conn, err := s.upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		return nil
+	}
+	c := &wsConn{conn: conn, combined: combined, streams: make(map[string]bool)}
+	c.connected = s.Now()
+	s.mu.Lock()
+	s.conns[c] = struct{}{}
+	s.mu.Unlock()
+	return c
+}
+
+func (s *Server) release(c *wsConn) {
+	s.mu.Lock()
+	delete(s.conns, c)
+	s.mu.Unlock()
+	_ = c.conn.Close()
+}
+
+// ****************************** Websocket Api *******************************
+
+type wsApiReqMsg struct {
+	Id     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params map[string]any  `json:"params"`
+}
+type wsApiRespMsg struct {
+	Id         json.RawMessage      `json:"id"`
+	Status     int                  `json:"status"`
+	Result     json.RawMessage      `json:"result,omitempty"`
+	Error      *binance.APIError    `json:"error,omitempty"`
+	RateLimits []binance.RateLimits `json:"rateLimits,omitempty"`
+}
+
+func (s *Server) serveWsApi(w http.ResponseWriter, req *http.Request) {
+	c := s.accept(w, req, false)
+	if c == nil {
+		return
+	}
+	defer s.release(c)
+	s.mu.Lock()
+	c.api = true
+	s.mu.Unlock()
+	for {
+		_, message, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var msg wsApiReqMsg
+		d := json.NewDecoder(bytes.NewReader(message))
+		d.UseNumber()
+		if err = d.Decode(&msg); err != nil {
+			e := Error(binance.ErrInvalidMessage.Code, "Malformed request: "+err.Error())
+			_ = c.writeJSON(&wsApiRespMsg{Id: json.RawMessage("null"), Status: e.StatusCode, Error: e})
+			continue
+		}
+		resp, r := s.handleWsApi(c, &msg)
+		_ = c.writeJSON(resp)
+		if r.terminated {
+			data, _ := marshal(map[string]any{"e": "eventStreamTerminated", "E": s.Now()})
+			_ = c.writeMessage(userDataFrame(data))
+		}
+	}
+}
+
+func (s *Server) handleWsApi(c *wsConn, msg *wsApiReqMsg) (*wsApiRespMsg, *Request) {
+	r := &Request{Path: msg.Method, Params: url.Values{}, conn: c}
+	for k, v := range msg.Params {
+		switch v := v.(type) {
+		case string:
+			r.Params.Set(k, v)
+		case json.Number:
+			r.Params.Set(k, v.String())
+		default:
+			data, _ := json.Marshal(v)
+			r.Params.Set(k, string(data))
+		}
+	}
+	r.APIKey = r.Params.Get("apiKey")
+	r.Signed = r.Params.Has("signature")
+	s.mu.Lock()
+	if r.APIKey == "" && !r.Signed {
+		// session.logon 登录后的请求使用会话的 API Key
+		r.APIKey = c.apiKey
+	}
+	s.mu.Unlock()
+	s.record(r)
+	// 签名内容为去掉 signature 后按参数名排序的全部参数
+	payload := url.Values{}
+	for k, v := range r.Params {
+		if k != "signature" {
+			payload[k] = v
+		}
+	}
+	resp := &wsApiRespMsg{Id: msg.Id, Status: http.StatusOK}
+	fail := func(err error) (*wsApiRespMsg, *Request) {
+		resp.Error = apiError(err)
+		resp.Status = resp.Error.StatusCode
+		return resp, r
+	}
+	err := s.authenticate(r, payload.Encode())
+	if err != nil {
+		return fail(err)
+	}
+	s.mu.Lock()
+	h, ok := s.wsApi[msg.Method]
+	s.mu.Unlock()
+	if !ok {
+		return fail(Error(binance.ErrUnsupportedOp.Code, "Unknown method '"+msg.Method+"'."))
+	}
+	res, err := h(r)
+	if err != nil {
+		return fail(err)
+	}
+	resp.Result, err = marshal(res)
+	if err != nil {
+		return fail(err)
+	}
+	return resp, r
+}
+
+// ****************************** Websocket Stream *******************************
+
+type wsStreamReqMsg struct {
+	Id     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params []any           `json:"params"`
+}
+
+// serveStream 行情推送，/ws/<a>/<b> 推送原始 event，/stream?streams=a/b 推送 {"stream","data"}
+func (s *Server) serveStream(w http.ResponseWriter, req *http.Request) {
+	var streams []string
+	combined := req.URL.Path == "/stream"
+	if combined {
+		streams = strings.Split(queryStreams(req.URL.RawQuery), "/")
+	} else {
+		streams = strings.Split(strings.TrimPrefix(req.URL.Path, "/ws"), "/")
+	}
+	c := s.accept(w, req, combined)
+	if c == nil {
+		return
+	}
+	defer s.release(c)
+	s.subscribe(c, streams)
+	for {
+		_, message, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var msg wsStreamReqMsg
+		if err = json.Unmarshal(message, &msg); err != nil {
+			_ = c.writeJSON(map[string]any{"id": nil, "error": map[string]any{"code": 3, "msg": "Invalid JSON: " + err.Error()}})
+			continue
+		}
+		var result any
+		switch msg.Method {
+		case "SUBSCRIBE":
+			s.subscribe(c, toStrings(msg.Params))
+		case "UNSUBSCRIBE":
+			s.mu.Lock()
+			for _, name := range toStrings(msg.Params) {
+				delete(c.streams, name)
+			}
+			s.mu.Unlock()
+		case "LIST_SUBSCRIPTIONS":
+			s.mu.Lock()
+			list := make([]string, 0, len(c.streams))
+			for name := range c.streams {
+				list = append(list, name)
+			}
+			s.mu.Unlock()
+			sort.Strings(list)
+			result = list
+		case "SET_PROPERTY":
+			if len(msg.Params) == 2 && msg.Params[0] == "combined" {
+				if v, ok := msg.Params[1].(bool); ok {
+					s.mu.Lock()
+					c.combined = v
+					s.mu.Unlock()
+				}
+			}
+		case "GET_PROPERTY":
+			s.mu.Lock()
+			result = c.combined
+			s.mu.Unlock()
+		default:
+			_ = c.writeJSON(map[string]any{"id": msg.Id, "error": map[string]any{"code": 2, "msg": "Invalid request: unknown method"}})
+			continue
+		}
+		_ = c.writeJSON(map[string]any{"id": msg.Id, "result": result})
+	}
+}
+
+// subscribe 订阅并回放 fixture 中录制的推送
+func (s *Server) subscribe(c *wsConn, streams []string) {
+	var replay [][]byte
+	s.mu.Lock()
+	for _, name := range streams {
+		if name == "" || c.streams[name] {
+			continue
+		}
+		c.streams[name] = true
+		for _, event := range s.streams[name] {
+			replay = append(replay, frame(name, event, c.combined))
+		}
+	}
+	s.mu.Unlock()
+	for _, data := range replay {
+		_ = c.writeMessage(data)
+	}
+}
+
+// Push 向所有订阅了 stream 的连接推送 event，返回推送的连接数
+func (s *Server) Push(stream string, event any) (int, error) {
+	data, err := marshal(event)
+	if err != nil {
+		return 0, err
+	}
+	type target struct {
+		c    *wsConn
+		data []byte
+	}
+	var targets []target
+	s.mu.Lock()
+	for c := range s.conns {
+		if c.streams[stream] {
+			targets = append(targets, target{c: c, data: frame(stream, data, c.combined)})
+		}
+	}
+	s.mu.Unlock()
+	n := 0
+	for _, t := range targets {
+		if t.c.writeMessage(t.data) == nil {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// WaitSubscribed 等待至少一个连接订阅了 stream，用于在 Push 之前确认客户端已经连接
+func (s *Server) WaitSubscribed(ctx context.Context, stream string) error {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		s.mu.Lock()
+		for c := range s.conns {
+			if c.streams[stream] {
+				s.mu.Unlock()
+				return nil
+			}
+		}
+		s.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Disconnect 断开所有订阅了 stream 的连接，模拟网络中断，返回断开的连接数
+func (s *Server) Disconnect(stream string) int {
+	var conns []*wsConn
+	s.mu.Lock()
+	for c := range s.conns {
+		if c.streams[stream] {
+			conns = append(conns, c)
+		}
+	}
+	s.mu.Unlock()
+	for _, c := range conns {
+		_ = c.conn.Close()
+	}
+	return len(conns)
+}
+
+// DisconnectWsApi 断开所有 WS API 连接，模拟网络中断，返回断开的连接数
+func (s *Server) DisconnectWsApi() int {
+	var conns []*wsConn
+	s.mu.Lock()
+	for c := range s.conns {
+		if c.api {
+			conns = append(conns, c)
+		}
+	}
+	s.mu.Unlock()
+	for _, c := range conns {
+		_ = c.conn.Close()
+	}
+	return len(conns)
+}
+
+func frame(stream string, data json.RawMessage, combined bool) []byte {
+	if !combined {
+		return data
+	}
+	b, _ := json.Marshal(map[string]any{"stream": stream, "data": data})
+	return b
+}
+
+func toStrings(params []any) []string {
+	streams := make([]string, 0, len(params))
+	for _, p := range params {
+		if s, ok := p.(string); ok {
+			streams = append(streams, s)
+		}
+	}
+	return streams
+}
+
+// queryStreams 从原始 query 中取出 streams，+ 不按表单编码解码为空格，保留K线时区后缀 @+08:00
+func queryStreams(raw string) string {
+	for _, part := range strings.Split(raw, "&") {
+		if v, ok := strings.CutPrefix(part, "streams="); ok {
+			streams, err := url.PathUnescape(v)
+			if err != nil {
+				return v
+			}
+			return streams
+		}
+	}
+	return ""
+}
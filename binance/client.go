@@ -9,13 +9,17 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/tidwall/gjson"
 )
 
 var LogLevel = os.Stderr
@@ -27,7 +31,8 @@ type Client struct {
 	BaseURL        string
 	HTTPClient     *http.Client
 	Logger         *log.Logger
-	TimeOffset     int64
+	TimeOffset     int64 // 服务器时间与本地时间的差值(毫秒)，由 TimeSync 维护
+	RecvWindow     int64 // 签名请求的 recvWindow(毫秒)，0 时使用服务端默认值 5000
 	Debug          bool
 	PrivateKey     crypto.Signer
 	conn           *websocket.Conn
@@ -35,7 +40,16 @@ type Client struct {
 	IsCombined     bool
 	IsFast         bool // 更新速度更快： 100ms
 	Timezone       string
-	ReqResponseMap map[string]chan []byte
+	Supervisor     *Supervisor  // 非空时 websocket 行情推送断线自动重连
+	RateLimiter    *RateLimiter // 非空时按接口权重和下单次数限流
+	TimeSync       *TimeSync    // 非空时签名请求遇到 -1021 重新同步时间并重试一次
+	Retry          *RetryPolicy // 非空时按策略重试，并在多个 base URL 之间切换
+	mu             sync.Mutex
+	onDial         []func(conn *websocket.Conn) error // 新连接建立后、开始读取之前依次调用
+	wsLimiter      *msgLimiter                        // 非空时 ping/pong 控制帧也按每秒 5 个消息限流
+	WsApiTimeout   time.Duration                      // WS API 请求等待响应的超时时间，0 时使用 DefaultWsApiTimeout
+	wsApiTransport *wsApiTransport                    // WS API 连接，第一次请求时创建
+	wsApiEvent     messageHandler                     // WS API 连接上收到的用户数据推送
 }
 
 // NewClient 创建客户端函数来初始化客户端
@@ -117,9 +131,10 @@ func (c *Client) request(ctx context.Context, r *Request) (*http.Request, error)
 	queryString := r.query.Encode()
 	//获取body
 	bodyString := r.form.Encode()
+	var signature string
 	if r.needSign {
-		r.SetOptionalParam("recvWindow", c.TimeOffset)
-		r.SetParam("timestamp", time.Now().UnixMilli())
+		r.SetOptionalParam("recvWindow", c.recvWindow(ctx, r))
+		r.SetParam("timestamp", c.timestamp())
 		//获取 query url
 		queryString = r.query.Encode()
 		//获取body
@@ -129,17 +144,25 @@ func (c *Client) request(ctx context.Context, r *Request) (*http.Request, error)
 		//设置签名参数
 		raw := fmt.Sprintf("%s%s", queryString, bodyString)
 		if c.SecretKey != "" {
-			r.SetParam("signature", signPayload(raw, c.SecretKey))
+			signature = signPayload(raw, c.SecretKey)
 		} else if c.PrivateKey != nil {
-			r.SetParam("signature", signPayload(raw, c.PrivateKey))
+			signature = signPayload(raw, c.PrivateKey)
 		} else {
 			c.Println("signature is empty")
 		}
 	}
 	//获取请求地址完整路径
-	r.fullURL = fmt.Sprintf("%s%s", c.BaseURL, r.Path)
+	baseURL := c.BaseURL
+	if r.host != "" {
+		baseURL = r.host
+	}
+	r.fullURL = fmt.Sprintf("%s%s", baseURL, r.Path)
 	if queryString != "" {
-		r.fullURL = fmt.Sprintf("%s?%s", r.fullURL, r.query.Encode())
+		r.fullURL = fmt.Sprintf("%s?%s", r.fullURL, queryString)
+	}
+	// 签名放在 query 末尾，其余参数保持签名时的顺序
+	if signature != "" {
+		r.fullURL = fmt.Sprintf("%s&signature=%s", r.fullURL, url.QueryEscape(signature))
 	}
 	req, err := http.NewRequest(r.Method, r.fullURL, r.body)
 	if err != nil {
@@ -153,12 +176,61 @@ func (c *Client) request(ctx context.Context, r *Request) (*http.Request, error)
 	return req, nil
 }
 func (c *Client) Do(ctx context.Context, r *Request) (*http.Response, error) {
+	if c.Retry != nil {
+		return c.Retry.do(ctx, c, r)
+	}
+	return c.send(ctx, r)
+}
+
+// send 发送一次请求，-1021 时重新同步时间后重试一次
+func (c *Client) send(ctx context.Context, r *Request) (*http.Response, error) {
+	sent := time.Now()
+	resp, err := c.do(ctx, r)
+	if err != nil || !r.needSign || c.TimeSync == nil || !isTimestampError(resp) {
+		return resp, err
+	}
+	// -1021 本地时钟与服务器偏差过大，重新同步后重试一次
+	c.Debugf("timestamp outside of recvWindow, resync and retry")
+	err = c.TimeSync.resync(ctx, sent)
+	if err != nil {
+		c.Debugf("time sync err:%v", err)
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+	return c.do(ctx, r)
+}
+func (c *Client) do(ctx context.Context, r *Request) (*http.Response, error) {
+	if c.RateLimiter != nil {
+		err := c.RateLimiter.Wait(ctx, r)
+		if err != nil {
+			c.Debugf("rate limit err:%v", err)
+			return nil, err
+		}
+	}
 	request, err := c.request(ctx, r)
 	if err != nil {
 		c.Debugf("request err:%v", err)
 		return nil, err
 	}
-	return c.HTTPClient.Do(request)
+	resp, err := c.HTTPClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if c.RateLimiter != nil {
+		c.RateLimiter.Update(resp)
+	}
+	return resp, nil
+}
+
+// isTimestampError 响应是否为 -1021，读取后恢复 resp.Body
+func isTimestampError(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusOK {
+		return false
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return err == nil && gjson.GetBytes(body, "code").Int() == int64(ErrTimestampOutsideRecvWindow.Code)
 }
 func (c *Client) Debugf(format string, v ...interface{}) {
 	if c.Debug {
@@ -35,3 +35,49 @@ const (
 const (
 	FApiAccountOrderAmendment = "/fapi/v1/orderAmendment"
 )
+
+const (
+	// FApiAccount 账户信息V2 (USER_DATA)
+	FApiAccount = "/fapi/v2/account"
+	// FApiBalance 账户余额V2 (USER_DATA)
+	FApiBalance = "/fapi/v2/balance"
+	// FApiPositionRisk 用户持仓风险V2 (USER_DATA)
+	FApiPositionRisk = "/fapi/v2/positionRisk"
+	// FApiLeverage 调整开仓杠杆 (TRADE)
+	FApiLeverage = "/fapi/v1/leverage"
+	// FApiMarginType 变换逐全仓模式 (TRADE)
+	FApiMarginType = "/fapi/v1/marginType"
+	// FApiPositionMargin 调整逐仓保证金 (TRADE)
+	FApiPositionMargin = "/fapi/v1/positionMargin"
+	// FApiPositionSideDual 更改/查询持仓模式 (TRADE/USER_DATA)
+	FApiPositionSideDual = "/fapi/v1/positionSide/dual"
+	// FApiMultiAssetsMargin 更改/查询联合保证金模式 (TRADE/USER_DATA)
+	FApiMultiAssetsMargin = "/fapi/v1/multiAssetsMargin"
+	// FApiLeverageBracket 杠杆分层标准 (USER_DATA)
+	FApiLeverageBracket = "/fapi/v1/leverageBracket"
+	// FApiCommissionRate 用户手续费率 (USER_DATA)
+	FApiCommissionRate = "/fapi/v1/commissionRate"
+	// FApiAdlQuantile 持仓ADL队列估算 (USER_DATA)
+	FApiAdlQuantile = "/fapi/v1/adlQuantile"
+)
+
+const (
+	// FApiIncome 获取账户损益资金流水 (USER_DATA)
+	FApiIncome = "/fapi/v1/income"
+	// FApiUserTrades 账户成交历史 (USER_DATA)
+	FApiUserTrades = "/fapi/v1/userTrades"
+	// FApiForceOrders 用户强平单历史 (USER_DATA)
+	FApiForceOrders = "/fapi/v1/forceOrders"
+	// FApiIncomeAsyn 获取合约资金流水下载Id (USER_DATA)
+	FApiIncomeAsyn = "/fapi/v1/income/asyn"
+	// FApiIncomeAsynId 通过下载Id获取合约资金流水下载链接 (USER_DATA)
+	FApiIncomeAsynId = "/fapi/v1/income/asyn/id"
+	// FApiOrderAsyn 获取合约订单历史下载Id (USER_DATA)
+	FApiOrderAsyn = "/fapi/v1/order/asyn"
+	// FApiOrderAsynId 通过下载Id获取合约订单历史下载链接 (USER_DATA)
+	FApiOrderAsynId = "/fapi/v1/order/asyn/id"
+	// FApiTradeAsyn 获取合约交易历史下载Id (USER_DATA)
+	FApiTradeAsyn = "/fapi/v1/trade/asyn"
+	// FApiTradeAsynId 通过下载Id获取合约交易历史下载链接 (USER_DATA)
+	FApiTradeAsynId = "/fapi/v1/trade/asyn/id"
+)
@@ -31,6 +31,8 @@ const (
 	REST_FAPI = "https://fapi.binance.com"
 	// REST_FAPI_TEST 期货测试 rest api
 	REST_FAPI_TEST = "https://testnet.binancefuture.com"
+	// WS_FSTREAM 期货 Websocket stream 行情推送
+	WS_FSTREAM = "wss://fstream.binance.com"
 	// WS_FUTURE_TEST 期货 Websocket stream 行情推送
 	WS_FSTREAM_TEST = "wss://fstream.binancefuture.com"
 
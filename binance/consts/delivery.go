@@ -0,0 +1,90 @@
+package consts
+
+// 币本位合约，交易对形如 BTCUSD_PERP、BTCUSD_250627，数量单位为张
+const (
+	// REST_DAPI 币本位合约 rest api
+	REST_DAPI = "https://dapi.binance.com"
+	// REST_DAPI_TEST 币本位合约测试 rest api
+	REST_DAPI_TEST = "https://testnet.binancefuture.com"
+	// WS_DSTREAM 币本位合约 Websocket stream 行情推送
+	WS_DSTREAM = "wss://dstream.binance.com"
+	// WS_DSTREAM_TEST 币本位合约测试 Websocket stream 行情推送
+	WS_DSTREAM_TEST = "wss://dstream.binancefuture.com"
+)
+
+const (
+	DApiExchangeInfo = "/dapi/v1/exchangeInfo" //交易规范信息
+	DApiPing         = "/dapi/v1/ping"         //测试服务器连通性 PING
+	DApiTime         = "/dapi/v1/time"         //获取服务器时间
+)
+
+const (
+	// DApiMarketDepth 深度信息
+	DApiMarketDepth = "/dapi/v1/depth"
+	// DApiMarketTrades 近期成交
+	DApiMarketTrades = "/dapi/v1/trades"
+	// DApiMarketKLines K线数据
+	DApiMarketKLines = "/dapi/v1/klines"
+	// DApiMarketContinuousKlines 连续合约K线数据，按 pair 和 contractType
+	DApiMarketContinuousKlines = "/dapi/v1/continuousKlines"
+	// DApiMarketIndexPriceKlines 价格指数K线数据，按 pair
+	DApiMarketIndexPriceKlines = "/dapi/v1/indexPriceKlines"
+	// DApiMarketMarkPriceKlines 标记价格K线数据
+	DApiMarketMarkPriceKlines = "/dapi/v1/markPriceKlines"
+	// DApiMarketPremiumIndex 最新标记价格和资金费率
+	DApiMarketPremiumIndex = "/dapi/v1/premiumIndex"
+	// DApiMarketFundingRate 查询永续合约资金费率历史
+	DApiMarketFundingRate = "/dapi/v1/fundingRate"
+	// DApiMarketTicker24Hr 24hr价格变动情况
+	DApiMarketTicker24Hr = "/dapi/v1/ticker/24hr"
+	// DApiMarketTickerPrice 最新价格
+	DApiMarketTickerPrice = "/dapi/v1/ticker/price"
+	// DApiMarketTickerBookTicker 当前最优挂单
+	DApiMarketTickerBookTicker = "/dapi/v1/ticker/bookTicker"
+	// DApiMarketOpenInterest 获取未平仓合约数
+	DApiMarketOpenInterest = "/dapi/v1/openInterest"
+
+	// DApiDataOpenInterestHist 合约持仓量历史，按 pair 和 contractType
+	DApiDataOpenInterestHist = "/futures/data/openInterestHist"
+	// DApiDataBasis 基差
+	DApiDataBasis = "/futures/data/basis"
+)
+
+const (
+	// DApiOrder 下单/修改/撤销/查询订单
+	DApiOrder = "/dapi/v1/order"
+	// DApiBatchOrders 批量下单/修改/撤销订单
+	DApiBatchOrders = "/dapi/v1/batchOrders"
+	// DApiAllOpenOrders 撤销全部订单 (TRADE)
+	DApiAllOpenOrders = "/dapi/v1/allOpenOrders"
+	// DApiOpenOrders 查看当前全部挂单 (USER_DATA)
+	DApiOpenOrders = "/dapi/v1/openOrders"
+	// DApiAllOrders 查询所有订单 (USER_DATA)
+	DApiAllOrders = "/dapi/v1/allOrders"
+)
+
+const (
+	// DApiAccount 账户信息 (USER_DATA)
+	DApiAccount = "/dapi/v1/account"
+	// DApiBalance 账户余额 (USER_DATA)
+	DApiBalance = "/dapi/v1/balance"
+	// DApiPositionRisk 用户持仓风险 (USER_DATA)
+	DApiPositionRisk = "/dapi/v1/positionRisk"
+	// DApiLeverage 调整开仓杠杆 (TRADE)
+	DApiLeverage = "/dapi/v1/leverage"
+	// DApiMarginType 变换逐全仓模式 (TRADE)
+	DApiMarginType = "/dapi/v1/marginType"
+	// DApiPositionMargin 调整逐仓保证金 (TRADE)
+	DApiPositionMargin = "/dapi/v1/positionMargin"
+	// DApiPositionSideDual 更改/查询持仓模式 (TRADE/USER_DATA)
+	DApiPositionSideDual = "/dapi/v1/positionSide/dual"
+	// DApiUserTrades 账户成交历史 (USER_DATA)
+	DApiUserTrades = "/dapi/v1/userTrades"
+	// DApiIncome 获取账户损益资金流水 (USER_DATA)
+	DApiIncome = "/dapi/v1/income"
+	// DApiCommissionRate 用户手续费率 (USER_DATA)
+	DApiCommissionRate = "/dapi/v1/commissionRate"
+
+	// DApiStreamListenKey 币本位合约用户数据流 listenKey
+	DApiStreamListenKey = "/dapi/v1/listenKey"
+)
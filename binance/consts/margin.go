@@ -0,0 +1,39 @@
+package consts
+
+// 杠杆账户接口与现货使用同一个域名 REST_API，权重按 IP 和 UID 单独计算，不占用 /api 的权重
+const (
+	// SApiMarginBorrowRepay 杠杆账户借贷/还款 (MARGIN)，GET 为查询借贷/还款记录
+	SApiMarginBorrowRepay = "/sapi/v1/margin/borrow-repay"
+	// SApiMarginAccount 查询全仓杠杆账户详情 (USER_DATA)
+	SApiMarginAccount = "/sapi/v1/margin/account"
+	// SApiMarginIsolatedAccount 查询逐仓杠杆账户信息 (USER_DATA)
+	SApiMarginIsolatedAccount = "/sapi/v1/margin/isolated/account"
+	// SApiMarginMaxBorrowable 查询账户最大可借贷额度 (USER_DATA)
+	SApiMarginMaxBorrowable = "/sapi/v1/margin/maxBorrowable"
+	// SApiMarginMaxTransferable 查询最大可转出额 (USER_DATA)
+	SApiMarginMaxTransferable = "/sapi/v1/margin/maxTransferable"
+	// SApiMarginInterestHistory 获取利息历史 (USER_DATA)
+	SApiMarginInterestHistory = "/sapi/v1/margin/interestHistory"
+)
+
+const (
+	// SApiMarginOrder 杠杆账户下单/撤单/查询订单 (TRADE)
+	SApiMarginOrder = "/sapi/v1/margin/order"
+	// SApiMarginOpenOrders 查询/撤销杠杆账户挂单 (USER_DATA/TRADE)
+	SApiMarginOpenOrders = "/sapi/v1/margin/openOrders"
+	// SApiMarginAllOrders 查询杠杆账户的所有订单 (USER_DATA)
+	SApiMarginAllOrders = "/sapi/v1/margin/allOrders"
+	// SApiMarginMyTrades 查询杠杆账户成交历史 (USER_DATA)
+	SApiMarginMyTrades = "/sapi/v1/margin/myTrades"
+	// SApiMarginOrderOco 杠杆账户 OCO 下单 (TRADE)
+	SApiMarginOrderOco = "/sapi/v1/margin/order/oco"
+	// SApiMarginOrderList 撤销/查询杠杆账户 OCO 订单 (TRADE/USER_DATA)
+	SApiMarginOrderList = "/sapi/v1/margin/orderList"
+)
+
+const (
+	// SApiUserDataStream 全仓杠杆账户 listenKey (USER_STREAM)
+	SApiUserDataStream = "/sapi/v1/userDataStream"
+	// SApiUserDataStreamIsolated 逐仓杠杆账户 listenKey，需要 symbol 参数 (USER_STREAM)
+	SApiUserDataStreamIsolated = "/sapi/v1/userDataStream/isolated"
+)
@@ -2,4 +2,6 @@ package consts
 
 const (
 	ApiStreamUserDataStream = "/api/v3/userDataStream"
+	// FApiStreamListenKey U本位合约用户数据流 listenKey
+	FApiStreamListenKey = "/fapi/v1/listenKey"
 )
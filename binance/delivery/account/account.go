@@ -0,0 +1,79 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// Account 账户信息
+type Account interface {
+	Call(ctx context.Context) (body *accountResponse, err error)
+}
+
+type accountRequest struct {
+	*binance.Client
+}
+
+// accountResponse 币本位合约每个保证金资产单独计算，没有U本位的汇总字段
+type accountResponse struct {
+	FeeTier     int   `json:"feeTier"`     // 手续费等级
+	CanTrade    bool  `json:"canTrade"`    // 是否可以交易
+	CanDeposit  bool  `json:"canDeposit"`  // 是否可以入金
+	CanWithdraw bool  `json:"canWithdraw"` // 是否可以出金
+	UpdateTime  int64 `json:"updateTime"`
+	Assets      []struct {
+		Asset                  string          `json:"asset"`                  // 资产
+		WalletBalance          decimal.Decimal `json:"walletBalance"`          // 账户余额
+		UnrealizedProfit       decimal.Decimal `json:"unrealizedProfit"`       // 全部持仓未实现盈亏
+		MarginBalance          decimal.Decimal `json:"marginBalance"`          // 保证金余额
+		MaintMargin            decimal.Decimal `json:"maintMargin"`            // 维持保证金
+		InitialMargin          decimal.Decimal `json:"initialMargin"`          // 当前所需起始保证金(按最新标标记价格)
+		PositionInitialMargin  decimal.Decimal `json:"positionInitialMargin"`  // 当前所需持仓起始保证金(按最新标标记价格)
+		OpenOrderInitialMargin decimal.Decimal `json:"openOrderInitialMargin"` // 当前所需挂单起始保证金(按最新标标记价格)
+		MaxWithdrawAmount      decimal.Decimal `json:"maxWithdrawAmount"`      // 最大可提款金额
+		CrossWalletBalance     decimal.Decimal `json:"crossWalletBalance"`     // 可用于全仓的账户余额
+		CrossUnPnl             decimal.Decimal `json:"crossUnPnl"`             // 所有全仓持仓的未实现盈亏
+		AvailableBalance       decimal.Decimal `json:"availableBalance"`       // 可用下单余额
+		UpdateTime             int64           `json:"updateTime"`             // 更新时间
+	} `json:"assets"`
+	Positions []struct {
+		Symbol                 string                 `json:"symbol"`                 // 交易对
+		PositionAmt            decimal.Decimal        `json:"positionAmt"`            // 持仓数量(张)
+		InitialMargin          decimal.Decimal        `json:"initialMargin"`          // 当前所需起始保证金(按最新标标记价格)
+		MaintMargin            decimal.Decimal        `json:"maintMargin"`            // 持仓维持保证金
+		UnrealizedProfit       decimal.Decimal        `json:"unrealizedProfit"`       // 持仓未实现盈亏
+		PositionInitialMargin  decimal.Decimal        `json:"positionInitialMargin"`  // 当前所需持仓起始保证金(按最新标标记价格)
+		OpenOrderInitialMargin decimal.Decimal        `json:"openOrderInitialMargin"` // 当前所需挂单起始保证金(按最新标标记价格)
+		Leverage               decimal.Decimal        `json:"leverage"`               // 杠杆倍率
+		Isolated               bool                   `json:"isolated"`               // 是否是逐仓模式
+		PositionSide           enums.PositionSideType `json:"positionSide"`           // 持仓方向
+		EntryPrice             decimal.Decimal        `json:"entryPrice"`             // 平均持仓成本
+		BreakEvenPrice         decimal.Decimal        `json:"breakEvenPrice"`         // 盈亏平衡价
+		MaxQty                 decimal.Decimal        `json:"maxQty"`                 // 当前杠杆下最大可开仓数(张)
+		UpdateTime             int64                  `json:"updateTime"`             // 更新时间
+	} `json:"positions"`
+}
+
+func NewAccount(client *binance.Client) Account {
+	return &accountRequest{Client: client}
+}
+
+// Call 账户信息 (USER_DATA)
+func (a *accountRequest) Call(ctx context.Context) (body *accountResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiAccount,
+	}
+	req.SetNeedSign(true)
+	resp, err := a.Do(ctx, req)
+	if err != nil {
+		a.Debugf("accountRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*accountResponse](resp)
+}
@@ -0,0 +1,48 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// Balance 账户余额
+type Balance interface {
+	Call(ctx context.Context) (body []*balanceResponse, err error)
+}
+
+type balanceRequest struct {
+	*binance.Client
+}
+type balanceResponse struct {
+	AccountAlias       string          `json:"accountAlias"`       // 账户唯一识别码
+	Asset              string          `json:"asset"`              // 资产
+	Balance            decimal.Decimal `json:"balance"`            // 账户余额
+	WithdrawAvailable  decimal.Decimal `json:"withdrawAvailable"`  // 最大可提款金额
+	CrossWalletBalance decimal.Decimal `json:"crossWalletBalance"` // 全仓账户余额
+	CrossUnPnl         decimal.Decimal `json:"crossUnPnl"`         // 全仓持仓未实现盈亏
+	AvailableBalance   decimal.Decimal `json:"availableBalance"`   // 可用下单余额
+	UpdateTime         int64           `json:"updateTime"`
+}
+
+func NewBalance(client *binance.Client) Balance {
+	return &balanceRequest{Client: client}
+}
+
+// Call 账户余额 (USER_DATA)
+func (b *balanceRequest) Call(ctx context.Context) (body []*balanceResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiBalance,
+	}
+	req.SetNeedSign(true)
+	resp, err := b.Do(ctx, req)
+	if err != nil {
+		b.Debugf("balanceRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*balanceResponse](resp)
+}
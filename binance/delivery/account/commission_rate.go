@@ -0,0 +1,51 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// CommissionRate 用户手续费率
+type CommissionRate interface {
+	SetSymbol(symbol string) *commissionRateRequest
+	Call(ctx context.Context) (body *commissionRateResponse, err error)
+}
+
+type commissionRateRequest struct {
+	*binance.Client
+	symbol string
+}
+type commissionRateResponse struct {
+	Symbol              string          `json:"symbol"`
+	MakerCommissionRate decimal.Decimal `json:"makerCommissionRate"` // 0.02%
+	TakerCommissionRate decimal.Decimal `json:"takerCommissionRate"` // 0.04%
+}
+
+func NewCommissionRate(client *binance.Client, symbol string) CommissionRate {
+	return &commissionRateRequest{Client: client, symbol: symbol}
+}
+
+func (c *commissionRateRequest) SetSymbol(symbol string) *commissionRateRequest {
+	c.symbol = symbol
+	return c
+}
+
+// Call 用户手续费率 (USER_DATA)
+func (c *commissionRateRequest) Call(ctx context.Context) (body *commissionRateResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiCommissionRate,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", c.symbol)
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		c.Debugf("commissionRateRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*commissionRateResponse](resp)
+}
@@ -0,0 +1,95 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// Income 获取账户损益资金流水
+type Income interface {
+	SetSymbol(symbol string) *incomeRequest
+	SetIncomeType(incomeType enums.IncomeType) *incomeRequest
+	SetStartTime(startTime int64) *incomeRequest
+	SetEndTime(endTime int64) *incomeRequest
+	SetPage(page int) *incomeRequest
+	SetLimit(limit enums.LimitType) *incomeRequest
+	Call(ctx context.Context) (body []*incomeResponse, err error)
+}
+
+// 如果startTime 和 endTime 均未发送, 只会返回最近7天的数据。
+// 如果incomeType没有发送，返回所有类型账户损益资金流水。
+// startTime 和 endTime 的最大间隔为一年。
+type incomeRequest struct {
+	*binance.Client
+	symbol     *string
+	incomeType *enums.IncomeType //收益类型
+	startTime  *int64            //起始时间
+	endTime    *int64            //结束时间
+	page       *int
+	limit      enums.LimitType //返回的结果集数量 默认值:100 最大值:1000
+}
+type incomeResponse struct {
+	Symbol     string           `json:"symbol"`     // 交易对，仅针对涉及交易对的资金流
+	IncomeType enums.IncomeType `json:"incomeType"` // 资金流类型
+	Income     decimal.Decimal  `json:"income"`     // 资金流数量，正数代表流入，负数代表流出
+	Asset      string           `json:"asset"`      // 资产内容，币本位合约为保证金币种
+	Info       string           `json:"info"`       // 备注信息，取决于流水类型
+	Time       int64            `json:"time"`       // 时间
+	TranId     int64            `json:"tranId"`     // 划转ID
+	TradeId    string           `json:"tradeId"`    // 引起流水产生的原始交易ID
+}
+
+func NewIncome(client *binance.Client) Income {
+	return &incomeRequest{Client: client}
+}
+
+func (i *incomeRequest) SetSymbol(symbol string) *incomeRequest {
+	i.symbol = &symbol
+	return i
+}
+func (i *incomeRequest) SetIncomeType(incomeType enums.IncomeType) *incomeRequest {
+	i.incomeType = &incomeType
+	return i
+}
+func (i *incomeRequest) SetStartTime(startTime int64) *incomeRequest {
+	i.startTime = &startTime
+	return i
+}
+func (i *incomeRequest) SetEndTime(endTime int64) *incomeRequest {
+	i.endTime = &endTime
+	return i
+}
+func (i *incomeRequest) SetPage(page int) *incomeRequest {
+	i.page = &page
+	return i
+}
+func (i *incomeRequest) SetLimit(limit enums.LimitType) *incomeRequest {
+	i.limit = limit
+	return i
+}
+
+// Call 获取账户损益资金流水 (USER_DATA)
+func (i *incomeRequest) Call(ctx context.Context) (body []*incomeResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiIncome,
+	}
+	req.SetNeedSign(true)
+	req.SetOptionalParam("symbol", i.symbol)
+	req.SetOptionalParam("incomeType", i.incomeType)
+	req.SetOptionalParam("startTime", i.startTime)
+	req.SetOptionalParam("endTime", i.endTime)
+	req.SetOptionalParam("page", i.page)
+	req.SetOptionalParam("limit", i.limit)
+	resp, err := i.Do(ctx, req)
+	if err != nil {
+		i.Debugf("incomeRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*incomeResponse](resp)
+}
@@ -0,0 +1,58 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+)
+
+// PositionMode 持仓模式，币本位合约没有联合保证金模式
+type PositionMode interface {
+	CallDualSidePosition(ctx context.Context) (body *dualSidePositionResponse, err error)
+	CallChangeDualSidePosition(ctx context.Context, dualSidePosition bool) (body *codeResponse, err error)
+}
+
+type positionModeRequest struct {
+	*binance.Client
+}
+type dualSidePositionResponse struct {
+	DualSidePosition bool `json:"dualSidePosition"` // "true": 双向持仓模式；"false": 单向持仓模式
+}
+
+func NewPositionMode(client *binance.Client) PositionMode {
+	return &positionModeRequest{Client: client}
+}
+
+// CallDualSidePosition 查询持仓模式 (USER_DATA)
+func (p *positionModeRequest) CallDualSidePosition(ctx context.Context) (body *dualSidePositionResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiPositionSideDual,
+	}
+	req.SetNeedSign(true)
+	resp, err := p.Do(ctx, req)
+	if err != nil {
+		p.Debugf("CallDualSidePosition response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*dualSidePositionResponse](resp)
+}
+
+// CallChangeDualSidePosition 更改持仓模式 (TRADE)
+// 变换用户在所有币本位合约上的持仓模式，有持仓或挂单时不能更改。
+func (p *positionModeRequest) CallChangeDualSidePosition(ctx context.Context, dualSidePosition bool) (body *codeResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodPost,
+		Path:   consts.DApiPositionSideDual,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("dualSidePosition", dualSidePosition)
+	resp, err := p.Do(ctx, req)
+	if err != nil {
+		p.Debugf("CallChangeDualSidePosition response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*codeResponse](resp)
+}
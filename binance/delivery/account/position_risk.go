@@ -0,0 +1,76 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// PositionRisk 用户持仓风险
+type PositionRisk interface {
+	SetMarginAsset(marginAsset string) *positionRiskRequest
+	SetPair(pair string) *positionRiskRequest
+	Call(ctx context.Context) (body []*positionRiskResponse, err error)
+}
+
+type positionRiskRequest struct {
+	*binance.Client
+	marginAsset *string
+	pair        *string
+}
+type positionRiskResponse struct {
+	Symbol           string                 `json:"symbol"`           // 交易对
+	PositionAmt      decimal.Decimal        `json:"positionAmt"`      // 头寸数量(张)，符号代表多空方向, 正数为多，负数为空
+	EntryPrice       decimal.Decimal        `json:"entryPrice"`       // 开仓均价
+	BreakEvenPrice   decimal.Decimal        `json:"breakEvenPrice"`   // 盈亏平衡价
+	MarkPrice        decimal.Decimal        `json:"markPrice"`        // 当前标记价格
+	UnRealizedProfit decimal.Decimal        `json:"unRealizedProfit"` // 持仓未实现盈亏，以保证金资产计价
+	LiquidationPrice decimal.Decimal        `json:"liquidationPrice"` // 参考强平价格
+	Leverage         decimal.Decimal        `json:"leverage"`         // 当前杠杆倍数
+	MaxQty           decimal.Decimal        `json:"maxQty"`           // 当前杠杆倍数允许的数量上限(张)
+	MarginType       string                 `json:"marginType"`       // 逐仓模式或全仓模式: isolated, cross
+	IsolatedMargin   decimal.Decimal        `json:"isolatedMargin"`   // 逐仓保证金
+	IsAutoAddMargin  string                 `json:"isAutoAddMargin"`  // 是否自动追加保证金: "true", "false"
+	PositionSide     enums.PositionSideType `json:"positionSide"`     // 持仓方向
+	NotionalValue    decimal.Decimal        `json:"notionalValue"`    // 名义价值，以保证金资产计价
+	IsolatedWallet   decimal.Decimal        `json:"isolatedWallet"`   // 逐仓钱包余额
+	UpdateTime       int64                  `json:"updateTime"`       // 更新时间
+}
+
+func NewPositionRisk(client *binance.Client) PositionRisk {
+	return &positionRiskRequest{Client: client}
+}
+
+// SetMarginAsset 保证金资产，如 BTC
+func (p *positionRiskRequest) SetMarginAsset(marginAsset string) *positionRiskRequest {
+	p.marginAsset = &marginAsset
+	return p
+}
+
+// SetPair 标的交易对，如 BTCUSD，返回该标的下的永续和交割合约持仓
+func (p *positionRiskRequest) SetPair(pair string) *positionRiskRequest {
+	p.pair = &pair
+	return p
+}
+
+// Call 用户持仓风险 (USER_DATA)
+// marginAsset 和 pair 不能同时传，都不传时返回所有持仓
+func (p *positionRiskRequest) Call(ctx context.Context) (body []*positionRiskResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiPositionRisk,
+	}
+	req.SetNeedSign(true)
+	req.SetOptionalParam("marginAsset", p.marginAsset)
+	req.SetOptionalParam("pair", p.pair)
+	resp, err := p.Do(ctx, req)
+	if err != nil {
+		p.Debugf("positionRiskRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*positionRiskResponse](resp)
+}
@@ -0,0 +1,25 @@
+package account
+
+import (
+	"context"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/futures/account"
+)
+
+// ****************************** Websocket Stream *******************************
+
+// UserDataHandlers 币本位合约用户数据流的事件格式与U本位合约一致，
+// ACCOUNT_UPDATE 中的余额以保证金币种计价，ORDER_TRADE_UPDATE 中的数量单位为张
+type UserDataHandlers = account.UserDataHandlers
+
+// NewWsUserData 币本位合约用户数据流
+// listenKey 由 stream.NewUserDataStream 或 stream.NewKeepAlive 生成，c 为 consts.WS_DSTREAM 客户端
+func NewWsUserData(ctx context.Context, c *binance.Client, listenKey string, handlers UserDataHandlers, exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return account.NewWsUserData(ctx, c, listenKey, handlers, exception)
+}
+
+// NewStreamUserData 币本位合约用户数据流，组合流格式
+func NewStreamUserData(ctx context.Context, c *binance.Client, listenKey string, handlers UserDataHandlers, exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return account.NewStreamUserData(ctx, c, listenKey, handlers, exception)
+}
@@ -0,0 +1,115 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// UserTrades 账户成交历史
+type UserTrades interface {
+	SetSymbol(symbol string) *userTradesRequest
+	SetPair(pair string) *userTradesRequest
+	SetOrderId(orderId int64) *userTradesRequest
+	SetStartTime(startTime int64) *userTradesRequest
+	SetEndTime(endTime int64) *userTradesRequest
+	SetFromId(fromId int64) *userTradesRequest
+	SetLimit(limit enums.LimitType) *userTradesRequest
+	Call(ctx context.Context) (body []*userTradesResponse, err error)
+}
+
+// symbol 和 pair 必须且只能传一个
+// 如果startTime 和 endTime 均未发送, 只会返回最近7天的数据。
+// startTime 和 endTime 的最大间隔为7天。
+// 不支持同时传入 fromId 与 startTime/endTime，也不支持 pair 与 fromId 一起使用。
+type userTradesRequest struct {
+	*binance.Client
+	symbol    *string
+	pair      *string
+	orderId   *int64 //必须要和参数symbol一起使用
+	startTime *int64
+	endTime   *int64
+	fromId    *int64          //返回该fromId及之后的成交，缺省返回最近的成交
+	limit     enums.LimitType //返回的结果集数量 默认值:50 最大值:1000
+}
+type userTradesResponse struct {
+	Symbol          string                 `json:"symbol"`          // 交易对
+	Pair            string                 `json:"pair"`            // 标的交易对
+	Id              int64                  `json:"id"`              // 交易ID
+	OrderId         int64                  `json:"orderId"`         // 订单编号
+	Side            enums.SideType         `json:"side"`            // 买卖方向
+	PositionSide    enums.PositionSideType `json:"positionSide"`    // 持仓方向
+	Price           decimal.Decimal        `json:"price"`           // 成交价
+	Qty             decimal.Decimal        `json:"qty"`             // 成交量(张)
+	BaseQty         decimal.Decimal        `json:"baseQty"`         // 成交额(标的数量)
+	RealizedPnl     decimal.Decimal        `json:"realizedPnl"`     // 实现盈亏
+	MarginAsset     string                 `json:"marginAsset"`     // 保证金币种
+	Commission      decimal.Decimal        `json:"commission"`      // 手续费
+	CommissionAsset string                 `json:"commissionAsset"` // 手续费计价单位
+	Buyer           bool                   `json:"buyer"`           // 是否是买方
+	Maker           bool                   `json:"maker"`           // 是否是挂单方
+	Time            int64                  `json:"time"`            // 时间
+}
+
+func NewUserTrades(client *binance.Client) UserTrades {
+	return &userTradesRequest{Client: client}
+}
+
+func (u *userTradesRequest) SetSymbol(symbol string) *userTradesRequest {
+	u.symbol = &symbol
+	return u
+}
+func (u *userTradesRequest) SetPair(pair string) *userTradesRequest {
+	u.pair = &pair
+	return u
+}
+func (u *userTradesRequest) SetOrderId(orderId int64) *userTradesRequest {
+	u.orderId = &orderId
+	return u
+}
+func (u *userTradesRequest) SetStartTime(startTime int64) *userTradesRequest {
+	u.startTime = &startTime
+	return u
+}
+func (u *userTradesRequest) SetEndTime(endTime int64) *userTradesRequest {
+	u.endTime = &endTime
+	return u
+}
+func (u *userTradesRequest) SetFromId(fromId int64) *userTradesRequest {
+	u.fromId = &fromId
+	return u
+}
+func (u *userTradesRequest) SetLimit(limit enums.LimitType) *userTradesRequest {
+	u.limit = limit
+	return u
+}
+
+// Call 账户成交历史 (USER_DATA)
+// 按 symbol 查询权重20，按 pair 查询权重40
+func (u *userTradesRequest) Call(ctx context.Context) (body []*userTradesResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiUserTrades,
+	}
+	req.SetNeedSign(true)
+	req.SetOptionalParam("symbol", u.symbol)
+	req.SetOptionalParam("pair", u.pair)
+	req.SetOptionalParam("orderId", u.orderId)
+	req.SetOptionalParam("startTime", u.startTime)
+	req.SetOptionalParam("endTime", u.endTime)
+	req.SetOptionalParam("fromId", u.fromId)
+	req.SetOptionalParam("limit", u.limit)
+	if u.symbol == nil {
+		req.SetWeight(40)
+	}
+	resp, err := u.Do(ctx, req)
+	if err != nil {
+		u.Debugf("userTradesRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*userTradesResponse](resp)
+}
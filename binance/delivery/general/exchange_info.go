@@ -0,0 +1,145 @@
+package general
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/binance/rules"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+type ExchangeInfo interface {
+	Call(ctx context.Context) (body *exchangeInfoResponse, err error)
+}
+type exchangeInfoRequest struct {
+	*binance.Client
+}
+
+func NewExchangeInfo(client *binance.Client) ExchangeInfo {
+	return &exchangeInfoRequest{Client: client}
+}
+
+type exchangeInfoResponse struct {
+	ExchangeFilters []interface{}        `json:"exchangeFilters"`
+	RateLimits      []binance.RateLimits `json:"rateLimits"`
+	ServerTime      int64                `json:"serverTime"`
+	Symbols         []struct {
+		Symbol                string                   `json:"symbol"`
+		Pair                  string                   `json:"pair"`         // 标的交易对，如 BTCUSD
+		ContractType          enums.ContractType       `json:"contractType"` // PERPETUAL, CURRENT_QUARTER, NEXT_QUARTER
+		DeliveryDate          int64                    `json:"deliveryDate"`
+		OnboardDate           int64                    `json:"onboardDate"`
+		ContractStatus        enums.ContractStatusType `json:"contractStatus"`
+		ContractSize          int64                    `json:"contractSize"` // 合约面值(美元)，数量以张为单位
+		MarginAsset           string                   `json:"marginAsset"`  // 保证金资产，即标的币种
+		MaintMarginPercent    decimal.Decimal          `json:"maintMarginPercent"`
+		RequiredMarginPercent decimal.Decimal          `json:"requiredMarginPercent"`
+		BaseAsset             string                   `json:"baseAsset"`
+		QuoteAsset            string                   `json:"quoteAsset"`
+		PricePrecision        int                      `json:"pricePrecision"`
+		QuantityPrecision     int                      `json:"quantityPrecision"`
+		BaseAssetPrecision    int                      `json:"baseAssetPrecision"`
+		QuotePrecision        int                      `json:"quotePrecision"`
+		EqualQtyPrecision     int                      `json:"equalQtyPrecision"`
+		TriggerProtect        decimal.Decimal          `json:"triggerProtect"`
+		LiquidationFee        decimal.Decimal          `json:"liquidationFee"`
+		MarketTakeBound       decimal.Decimal          `json:"marketTakeBound"`
+		UnderlyingType        string                   `json:"underlyingType"`
+		UnderlyingSubType     []string                 `json:"underlyingSubType"`
+		Filters               []struct {
+			FilterType     string          `json:"filterType"`
+			MaxPrice       decimal.Decimal `json:"maxPrice,omitempty"`
+			MinPrice       decimal.Decimal `json:"minPrice,omitempty"`
+			TickSize       decimal.Decimal `json:"tickSize,omitempty"`
+			MaxQty         decimal.Decimal `json:"maxQty,omitempty"`
+			MinQty         decimal.Decimal `json:"minQty,omitempty"`
+			StepSize       decimal.Decimal `json:"stepSize,omitempty"`
+			Limit          int             `json:"limit,omitempty"`
+			MultiplierUp   decimal.Decimal `json:"multiplierUp,omitempty"`
+			MultiplierDown decimal.Decimal `json:"multiplierDown,omitempty"`
+		} `json:"filters"`
+		OrderTypes  []enums.OrderType       `json:"orderTypes"`
+		TimeInForce []enums.TimeInForceType `json:"timeInForce"`
+	} `json:"symbols"`
+	Timezone string `json:"timezone"`
+}
+
+// Call 交易规范信息
+func (ex *exchangeInfoRequest) Call(ctx context.Context) (body *exchangeInfoResponse, err error) {
+	r := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiExchangeInfo,
+	}
+	resp, err := ex.Do(ctx, r)
+	if err != nil {
+		ex.Debugf("exchangeInfoRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*exchangeInfoResponse](resp)
+}
+
+// ContractSizes 各交易对的合约面值，用于张数和币数量之间的换算
+func (e *exchangeInfoResponse) ContractSizes() map[string]int64 {
+	sizes := make(map[string]int64, len(e.Symbols))
+	for _, symbol := range e.Symbols {
+		sizes[symbol.Symbol] = symbol.ContractSize
+	}
+	return sizes
+}
+
+// Rules 把交易对的过滤器转换为下单规则，数量规则的单位是张
+func (e *exchangeInfoResponse) Rules() []*rules.Symbol {
+	list := make([]*rules.Symbol, 0, len(e.Symbols))
+	for _, symbol := range e.Symbols {
+		s := &rules.Symbol{Symbol: symbol.Symbol}
+		for _, f := range symbol.Filters {
+			switch f.FilterType {
+			case rules.FilterPrice:
+				s.Price = &rules.PriceFilter{MinPrice: f.MinPrice, MaxPrice: f.MaxPrice, TickSize: f.TickSize}
+			case rules.FilterPercentPrice:
+				s.PercentPrice = &rules.PercentPriceFilter{
+					Type:              f.FilterType,
+					BidMultiplierUp:   f.MultiplierUp,
+					BidMultiplierDown: f.MultiplierDown,
+					AskMultiplierUp:   f.MultiplierUp,
+					AskMultiplierDown: f.MultiplierDown,
+				}
+			case rules.FilterLotSize:
+				s.LotSize = &rules.LotSizeFilter{MinQty: f.MinQty, MaxQty: f.MaxQty, StepSize: f.StepSize}
+			case rules.FilterMarketLotSize:
+				s.MarketLotSize = &rules.LotSizeFilter{MinQty: f.MinQty, MaxQty: f.MaxQty, StepSize: f.StepSize}
+			case rules.FilterMaxNumOrders:
+				s.MaxNumOrders = f.Limit
+			}
+		}
+		list = append(list, s)
+	}
+	return list
+}
+
+// NewRules 由 exchangeInfo 驱动的交易对规则缓存，用于下单前检查和舍入
+func NewRules(client *binance.Client) *rules.Registry {
+	return rules.NewRegistry(func(ctx context.Context) ([]*rules.Symbol, error) {
+		res, err := NewExchangeInfo(client).Call(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return res.Rules(), nil
+	})
+}
+
+// NewRateLimiter 按 exchangeInfo 返回的 rateLimits 创建币本位合约限流器，并设置为 client 的限流器
+// 获取 exchangeInfo 失败时返回使用默认限制的限流器和错误
+func NewRateLimiter(ctx context.Context, client *binance.Client) (*binance.RateLimiter, error) {
+	limiter := binance.NewDeliveryRateLimiter()
+	client.RateLimiter = limiter
+	res, err := NewExchangeInfo(client).Call(ctx)
+	if err != nil {
+		return limiter, err
+	}
+	limiter.SetLimits(res.RateLimits...)
+	return limiter, nil
+}
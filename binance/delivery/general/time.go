@@ -0,0 +1,49 @@
+package general
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+)
+
+type Time interface {
+	Call(ctx context.Context) (body *timeResponse, err error)
+}
+
+type timeRequest struct {
+	*binance.Client
+}
+
+func NewTime(client *binance.Client) Time {
+	return &timeRequest{Client: client}
+}
+
+func (t *timeRequest) Call(ctx context.Context) (body *timeResponse, err error) {
+	r := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiTime,
+	}
+	res, err := t.Client.Do(ctx, r)
+	if err != nil {
+		t.Debugf("timeRequest response err: %v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*timeResponse](res)
+}
+
+type timeResponse struct {
+	ServerTime int64 `json:"serverTime"`
+}
+
+// NewTimeSync 通过 NewTime 同步服务器时间，并设置为 client 的 TimeSync
+func NewTimeSync(client *binance.Client) *binance.TimeSync {
+	return binance.NewTimeSync(client, func(ctx context.Context) (int64, error) {
+		res, err := NewTime(client).Call(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return res.ServerTime, nil
+	})
+}
@@ -0,0 +1,34 @@
+package market
+
+import (
+	"context"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/futures/market"
+)
+
+// ****************************** Websocket 行情推送 *******************************
+
+// WsAggTradeEvent 与U本位合约归集交易推送格式相同，q 为成交张数
+type (
+	WsAggTradeEvent     = market.WsAggTradeEvent
+	StreamAggTradeEvent = market.StreamAggTradeEvent
+)
+
+// NewWsAggTrade 归集交易
+// 同一价格、同一方向、同一时间(100ms计算)的trade会被聚合为一条
+// Stream 名称: <symbol>@aggTrade
+// 更新速度: 100ms
+func NewWsAggTrade(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[WsAggTradeEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return market.NewWsAggTrade(ctx, c, symbols, handler, exception)
+}
+
+// NewStreamAggTrade 组合 Stream 的归集交易
+func NewStreamAggTrade(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[StreamAggTradeEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return market.NewStreamAggTrade(ctx, c, symbols, handler, exception)
+}
+
+// SubscribeAggTrade 在组合 Stream 连接上订阅归集交易
+func SubscribeAggTrade(ctx context.Context, s *binance.WsSubscriptions, symbols []string, handler binance.Handler[WsAggTradeEvent]) error {
+	return market.SubscribeAggTrade(ctx, s, symbols, handler)
+}
@@ -0,0 +1,84 @@
+package data
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+type Basis interface {
+	Call(ctx context.Context) (body []*basisResponse, err error)
+	SetLimit(limit enums.LimitType) *basisRequest
+	SetStartTime(startTime int64) *basisRequest
+	SetEndTime(endTime int64) *basisRequest
+}
+
+type basisRequest struct {
+	*binance.Client
+	pair         string
+	contractType enums.ContractType      // CURRENT_QUARTER, NEXT_QUARTER, PERPETUAL
+	period       enums.KlineIntervalType // 5m,15m,30m,1h,2h,4h,6h,12h,1d
+	limit        enums.LimitType         // 默认 30，最大 500
+	startTime    *int64
+	endTime      *int64
+}
+
+type basisResponse struct {
+	Pair                string             `json:"pair"`
+	ContractType        enums.ContractType `json:"contractType"`
+	IndexPrice          decimal.Decimal    `json:"indexPrice"`          // 指数价格
+	FuturesPrice        decimal.Decimal    `json:"futuresPrice"`        // 合约价格
+	Basis               decimal.Decimal    `json:"basis"`               // 基差 = 合约价格 - 指数价格
+	BasisRate           decimal.Decimal    `json:"basisRate"`           // 基差率 = 基差 / 指数价格
+	AnnualizedBasisRate decimal.Decimal    `json:"annualizedBasisRate"` // 年化基差率，永续合约为空
+	Timestamp           int64              `json:"timestamp"`
+}
+
+// NewBasis 基差
+// 只有最近30天的数据，不传 startTime 和 endTime 时返回最近的 limit 条
+func NewBasis(client *binance.Client, pair string, contractType enums.ContractType, period enums.KlineIntervalType) Basis {
+	return &basisRequest{
+		Client:       client,
+		pair:         pair,
+		contractType: contractType,
+		period:       period,
+	}
+}
+
+func (b *basisRequest) SetLimit(limit enums.LimitType) *basisRequest {
+	b.limit = limit
+	return b
+}
+
+func (b *basisRequest) SetStartTime(startTime int64) *basisRequest {
+	b.startTime = &startTime
+	return b
+}
+
+func (b *basisRequest) SetEndTime(endTime int64) *basisRequest {
+	b.endTime = &endTime
+	return b
+}
+
+func (b *basisRequest) Call(ctx context.Context) (body []*basisResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiDataBasis,
+	}
+	req.SetParam("pair", b.pair)
+	req.SetParam("contractType", b.contractType)
+	req.SetParam("period", b.period)
+	req.SetOptionalParam("limit", b.limit)
+	req.SetOptionalParam("startTime", b.startTime)
+	req.SetOptionalParam("endTime", b.endTime)
+	resp, err := b.Do(ctx, req)
+	if err != nil {
+		b.Debugf("basisRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*basisResponse](resp)
+}
@@ -0,0 +1,81 @@
+package data
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+type OpenInterestHist interface {
+	Call(ctx context.Context) (body []*openInterestHistResponse, err error)
+	SetLimit(limit enums.LimitType) *openInterestHistRequest
+	SetStartTime(startTime int64) *openInterestHistRequest
+	SetEndTime(endTime int64) *openInterestHistRequest
+}
+
+// 币本位合约按 pair 和 contractType 查询，而不是 symbol
+type openInterestHistRequest struct {
+	*binance.Client
+	pair         string
+	contractType enums.ContractType // ALL, CURRENT_QUARTER, NEXT_QUARTER, PERPETUAL
+	period       enums.KlineIntervalType
+	limit        enums.LimitType
+	startTime    *int64
+	endTime      *int64
+}
+
+type openInterestHistResponse struct {
+	Pair                 string             `json:"pair"`
+	ContractType         enums.ContractType `json:"contractType"`
+	SumOpenInterest      decimal.Decimal    `json:"sumOpenInterest"`      // 持仓总数量(张)
+	SumOpenInterestValue decimal.Decimal    `json:"sumOpenInterestValue"` // 持仓总价值(标的币种)
+	Timestamp            int64              `json:"timestamp"`
+}
+
+// NewOpenInterestHist 合约持仓量历史
+func NewOpenInterestHist(client *binance.Client, pair string, contractType enums.ContractType, period enums.KlineIntervalType) OpenInterestHist {
+	return &openInterestHistRequest{
+		Client:       client,
+		pair:         pair,
+		contractType: contractType,
+		period:       period,
+	}
+}
+
+func (t *openInterestHistRequest) SetLimit(limit enums.LimitType) *openInterestHistRequest {
+	t.limit = limit
+	return t
+}
+
+func (t *openInterestHistRequest) SetStartTime(startTime int64) *openInterestHistRequest {
+	t.startTime = &startTime
+	return t
+}
+
+func (t *openInterestHistRequest) SetEndTime(endTime int64) *openInterestHistRequest {
+	t.endTime = &endTime
+	return t
+}
+
+func (t *openInterestHistRequest) Call(ctx context.Context) (body []*openInterestHistResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiDataOpenInterestHist,
+	}
+	req.SetParam("pair", t.pair)
+	req.SetParam("contractType", t.contractType)
+	req.SetParam("period", t.period)
+	req.SetOptionalParam("limit", t.limit)
+	req.SetOptionalParam("startTime", t.startTime)
+	req.SetOptionalParam("endTime", t.endTime)
+	resp, err := t.Do(ctx, req)
+	if err != nil {
+		t.Debugf("openInterestHistRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*openInterestHistResponse](resp)
+}
@@ -0,0 +1,138 @@
+// Package market 币本位合约行情
+//
+// 推送使用 binance.NewDeliveryWsClient 创建的 client，默认连接 consts.WS_DSTREAM。
+// 标记价格、强平订单等格式与U本位合约相同的推送直接调用 futures/market 中的构造函数即可。
+package market
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/orderbook"
+)
+
+type Depth interface {
+	Call(ctx context.Context) (body *depthResponse, err error)
+}
+
+type depthRequest struct {
+	*binance.Client
+	symbol string
+	limit  enums.LimitType
+}
+
+type depthResponse struct {
+	LastUpdateId int64             `json:"lastUpdateId"`
+	Symbol       string            `json:"symbol"`
+	Pair         string            `json:"pair"`
+	E            int64             `json:"E"`    // 消息时间
+	T            int64             `json:"T"`    // 撮合引擎时间
+	Bids         []orderbook.Level `json:"bids"` // 买单，数量单位为张
+	Asks         []orderbook.Level `json:"asks"` // 卖单，数量单位为张
+}
+
+// NewDepth 深度信息
+func NewDepth(c *binance.Client, symbol string, limit enums.LimitType) Depth {
+	return &depthRequest{Client: c, symbol: symbol, limit: limit}
+}
+
+// Call 深度信息
+// 默认 500; 可选值:[5, 10, 20, 50, 100, 500, 1000]
+func (d *depthRequest) Call(ctx context.Context) (body *depthResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiMarketDepth,
+	}
+	req.SetParam("symbol", d.symbol)
+	req.SetOptionalParam("limit", d.limit)
+	req.SetWeight(depthWeight(d.limit))
+	resp, err := d.Do(ctx, req)
+	if err != nil {
+		d.Debugf("depthRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*depthResponse](resp)
+}
+
+// depthWeight 权重随 limit 变化: 5、10、20、50 为 2，100 为 5，500 为 10，1000 为 20
+func depthWeight(limit enums.LimitType) int {
+	switch {
+	case limit == 0:
+		return 10
+	case limit <= 50:
+		return 2
+	case limit <= 100:
+		return 5
+	case limit <= 500:
+		return 10
+	default:
+		return 20
+	}
+}
+
+// ****************************** Websocket 行情推送 *******************************
+
+type StreamDepthEvent struct {
+	Stream string        `json:"stream"`
+	Data   *WsDepthEvent `json:"data"`
+}
+
+// WsDepthEvent 增量深度，数量单位为张
+type WsDepthEvent struct {
+	Event            string     `json:"e"`
+	Time             int64      `json:"E"`
+	TransactionTime  int64      `json:"T"` // 撮合时间
+	Symbol           string     `json:"s"`
+	Pair             string     `json:"ps"` // 标的交易对
+	FirstUpdateID    int        `json:"U"`
+	LastUpdateID     int        `json:"u"`
+	PrevLastUpdateID int        `json:"pu"` // 上一个 event 的 u
+	Bids             [][]string `json:"b"`
+	Asks             [][]string `json:"a"`
+}
+
+// BidLevels 解析买单档位
+func (e WsDepthEvent) BidLevels() ([]orderbook.Level, error) {
+	return orderbook.ParseLevels(e.Bids)
+}
+
+// AskLevels 解析卖单档位
+func (e WsDepthEvent) AskLevels() ([]orderbook.Level, error) {
+	return orderbook.ParseLevels(e.Asks)
+}
+
+// NewWsDepth 增量深度信息
+// Stream 名称: <symbol>@depth 或 <symbol>@depth@100ms(c.IsFast)
+// 更新速度: 250ms 或 100ms
+func NewWsDepth(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[*WsDepthEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsDepth(ctx, c, symbols, handler, exception)
+}
+
+// NewStreamDepth 组合 Stream 的增量深度信息
+func NewStreamDepth(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[*StreamDepthEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsDepth(ctx, c, symbols, handler, exception)
+}
+
+func wsDepth[T *WsDepthEvent | *StreamDepthEvent](ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	endpoint := c.BaseURL
+	for _, s := range binance.SymbolStreams(symbols, depthSuffix(c)) {
+		endpoint += s + "/"
+	}
+	endpoint = endpoint[:len(endpoint)-1]
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeDepth 在组合 Stream 连接上订阅增量深度信息
+func SubscribeDepth(ctx context.Context, s *binance.WsSubscriptions, symbols []string, handler binance.Handler[*WsDepthEvent]) error {
+	return binance.Subscribe(ctx, s, binance.SymbolStreams(symbols, depthSuffix(s.Client())), handler)
+}
+
+func depthSuffix(c *binance.Client) string {
+	if c.IsFast {
+		return "depth@100ms"
+	}
+	return "depth"
+}
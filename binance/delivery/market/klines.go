@@ -0,0 +1,155 @@
+package market
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/binance/futures/market"
+)
+
+// KlinesResponse 与U本位合约K线的数组格式相同，成交量为张数，成交额为标的数量
+type KlinesResponse = market.KlinesResponse
+
+type Klines interface {
+	// Call 按交易对，如 BTCUSD_PERP
+	Call(ctx context.Context) (body []*KlinesResponse, err error)
+	// CallContinuousKlines 连续合约K线数据，按 pair 和 contractType
+	CallContinuousKlines(ctx context.Context) (body []*KlinesResponse, err error)
+	// CallIndexPriceKlines 价格指数K线数据，按 pair
+	CallIndexPriceKlines(ctx context.Context) (body []*KlinesResponse, err error)
+	// CallMarkPriceKlines 标记价格K线数据，按交易对
+	CallMarkPriceKlines(ctx context.Context) (body []*KlinesResponse, err error)
+	SetSymbol(symbol string) *klinesRequest
+	SetPair(pair string) *klinesRequest
+	SetContractType(contractType enums.ContractType) *klinesRequest
+	SetLimit(limit enums.LimitType) *klinesRequest
+	SetStartTime(startTime int64) *klinesRequest
+	SetEndTime(endTime int64) *klinesRequest
+}
+
+// klinesRequest 币本位合约K线
+// 交易对(symbol)和标的(pair)不同: BTCUSD_PERP、BTCUSD_250627 的 pair 都是 BTCUSD，连续合约和指数K线按 pair 查询
+// startTime 与 endTime 之间最多只可以相差200天
+type klinesRequest struct {
+	*binance.Client
+	symbol       string
+	pair         string
+	contractType enums.ContractType
+	interval     enums.KlineIntervalType
+	limit        enums.LimitType
+	startTime    *int64
+	endTime      *int64
+}
+
+func NewKlines(client *binance.Client, symbol string, interval enums.KlineIntervalType, limit enums.LimitType) Klines {
+	return &klinesRequest{Client: client, symbol: symbol, interval: interval, limit: limit}
+}
+
+func (k *klinesRequest) SetSymbol(symbol string) *klinesRequest {
+	k.symbol = symbol
+	return k
+}
+func (k *klinesRequest) SetPair(pair string) *klinesRequest {
+	k.pair = pair
+	return k
+}
+func (k *klinesRequest) SetContractType(contractType enums.ContractType) *klinesRequest {
+	k.contractType = contractType
+	return k
+}
+func (k *klinesRequest) SetLimit(limit enums.LimitType) *klinesRequest {
+	k.limit = limit
+	return k
+}
+func (k *klinesRequest) SetStartTime(startTime int64) *klinesRequest {
+	k.startTime = &startTime
+	return k
+}
+func (k *klinesRequest) SetEndTime(endTime int64) *klinesRequest {
+	k.endTime = &endTime
+	return k
+}
+
+func (k *klinesRequest) Call(ctx context.Context) (body []*KlinesResponse, err error) {
+	req := &binance.Request{Method: http.MethodGet, Path: consts.DApiMarketKLines}
+	req.SetParam("symbol", k.symbol)
+	return k.call(ctx, req)
+}
+
+func (k *klinesRequest) CallContinuousKlines(ctx context.Context) (body []*KlinesResponse, err error) {
+	req := &binance.Request{Method: http.MethodGet, Path: consts.DApiMarketContinuousKlines}
+	req.SetParam("pair", k.pair)
+	req.SetParam("contractType", k.contractType)
+	return k.call(ctx, req)
+}
+
+func (k *klinesRequest) CallIndexPriceKlines(ctx context.Context) (body []*KlinesResponse, err error) {
+	req := &binance.Request{Method: http.MethodGet, Path: consts.DApiMarketIndexPriceKlines}
+	req.SetParam("pair", k.pair)
+	return k.call(ctx, req)
+}
+
+func (k *klinesRequest) CallMarkPriceKlines(ctx context.Context) (body []*KlinesResponse, err error) {
+	req := &binance.Request{Method: http.MethodGet, Path: consts.DApiMarketMarkPriceKlines}
+	req.SetParam("symbol", k.symbol)
+	return k.call(ctx, req)
+}
+
+func (k *klinesRequest) call(ctx context.Context, req *binance.Request) (body []*KlinesResponse, err error) {
+	req.SetParam("interval", k.interval)
+	req.SetOptionalParam("limit", k.limit)
+	req.SetOptionalParam("startTime", k.startTime)
+	req.SetOptionalParam("endTime", k.endTime)
+	req.SetWeight(klinesWeight(k.limit))
+	resp, err := k.Do(ctx, req)
+	if err != nil {
+		k.Debugf("klinesRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*KlinesResponse](resp)
+}
+
+// klinesWeight 权重随 limit 变化: [1,100) 为 1，[100,500) 为 2，[500,1000] 为 5，>1000 为 10，默认 500
+func klinesWeight(limit enums.LimitType) int {
+	switch {
+	case limit == 0:
+		return 5
+	case limit < 100:
+		return 1
+	case limit < 500:
+		return 2
+	case limit <= 1000:
+		return 5
+	default:
+		return 10
+	}
+}
+
+// ****************************** Websocket 行情推送 *******************************
+
+// WsKlineEvent 与U本位合约K线推送格式相同，v 为成交张数，q 为成交额(标的数量)
+type (
+	WsKlineEvent     = market.WsKlineEvent
+	StreamKlineEvent = market.StreamKlineEvent
+	WsKline          = market.WsKline
+)
+
+// NewWsKline K线
+// Stream 名称: <symbol>@kline_<interval>
+// 更新速度: 250ms
+func NewWsKline(ctx context.Context, c *binance.Client, symbolsInterval map[string]enums.KlineIntervalType, handler binance.Handler[WsKlineEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return market.NewWsKline(ctx, c, symbolsInterval, handler, exception)
+}
+
+// NewStreamKline 组合 Stream 的K线
+func NewStreamKline(ctx context.Context, c *binance.Client, symbolsInterval map[string]enums.KlineIntervalType, handler binance.Handler[StreamKlineEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return market.NewStreamKline(ctx, c, symbolsInterval, handler, exception)
+}
+
+// SubscribeKline 在组合 Stream 连接上订阅K线
+func SubscribeKline(ctx context.Context, s *binance.WsSubscriptions, symbolsInterval map[string]enums.KlineIntervalType, handler binance.Handler[WsKlineEvent]) error {
+	return market.SubscribeKline(ctx, s, symbolsInterval, handler)
+}
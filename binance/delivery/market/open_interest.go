@@ -0,0 +1,47 @@
+package market
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+type OpenInterest interface {
+	Call(ctx context.Context) (body *openInterestResponse, err error)
+}
+
+type openInterestRequest struct {
+	*binance.Client
+	symbol string
+}
+
+type openInterestResponse struct {
+	Symbol       string             `json:"symbol"`
+	Pair         string             `json:"pair"`
+	OpenInterest decimal.Decimal    `json:"openInterest"` // 未平仓合约数(张)
+	ContractType enums.ContractType `json:"contractType"`
+	Time         int64              `json:"time"`
+}
+
+// NewOpenInterest 获取未平仓合约数
+func NewOpenInterest(client *binance.Client, symbol string) OpenInterest {
+	return &openInterestRequest{Client: client, symbol: symbol}
+}
+
+func (o *openInterestRequest) Call(ctx context.Context) (body *openInterestResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiMarketOpenInterest,
+	}
+	req.SetParam("symbol", o.symbol)
+	resp, err := o.Do(ctx, req)
+	if err != nil {
+		o.Debugf("openInterestRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*openInterestResponse](resp)
+}
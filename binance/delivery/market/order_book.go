@@ -0,0 +1,131 @@
+package market
+
+import (
+	"context"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/binance/futures/market"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+	"github.com/sleep-go/coin-go/pkg/orderbook"
+)
+
+// OrderBook 本地维护的币本位合约 orderbook 副本，数量单位为张
+//
+// 连续性规则与U本位合约相同，见 futures/market.SequenceDepth
+type OrderBook struct {
+	symbol   string
+	m        *orderbook.Maintainer
+	onChange binance.Handler[*OrderBook]
+	onResync binance.Handler[error]
+}
+
+// NewOrderBook 创建本地 orderbook
+// c 用于获取 REST 深度快照，limit 为快照档数，默认 1000
+func NewOrderBook(c *binance.Client, symbol string, limit enums.LimitType) *OrderBook {
+	if limit == 0 {
+		limit = enums.Limit1000
+	}
+	o := &OrderBook{symbol: symbol}
+	o.m = orderbook.NewMaintainer(symbol, func(ctx context.Context) (*orderbook.Snapshot, error) {
+		snapshot, err := NewDepth(c, symbol, limit).Call(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &orderbook.Snapshot{LastUpdateId: int(snapshot.LastUpdateId), Bids: levelStrings(snapshot.Bids), Asks: levelStrings(snapshot.Asks)}, nil
+	}, market.SequenceDepth).SetDebugf(c.Debugf).SetOnChange(func() {
+		if o.onChange != nil {
+			o.onChange(o)
+		}
+	}).SetOnResync(func(err error) {
+		if o.onResync != nil {
+			o.onResync(err)
+		}
+	})
+	return o
+}
+
+// levelStrings 快照已解析为 orderbook.Level，转换回 [价格, 数量]
+func levelStrings(levels []orderbook.Level) [][]string {
+	out := make([][]string, 0, len(levels))
+	for _, l := range levels {
+		out = append(out, []string{l.Price.String(), l.Quantity.String()})
+	}
+	return out
+}
+
+// SetOnChange 每次 orderbook 更新后回调，回调串行执行
+func (o *OrderBook) SetOnChange(onChange binance.Handler[*OrderBook]) *OrderBook {
+	o.onChange = onChange
+	return o
+}
+
+// SetOnResync 检测到丢包、缓存溢出或获取快照失败需要重新初始化时回调
+// 回调时不持有锁，可以读取 orderbook
+func (o *OrderBook) SetOnResync(onResync binance.Handler[error]) *OrderBook {
+	o.onResync = onResync
+	return o
+}
+
+// Run 订阅增量深度并开始维护 orderbook
+// wsClient 为 binance.NewDeliveryWsClient 创建的客户端，组合 Stream 和普通 Stream 均可
+func (o *OrderBook) Run(ctx context.Context, wsClient *binance.Client, exception binance.ErrorHandler) (*binance.WsStream, error) {
+	o.m.SetContext(ctx)
+	symbols := []string{o.symbol}
+	if wsClient.IsCombined {
+		return NewStreamDepth(ctx, wsClient, symbols, func(event *StreamDepthEvent) {
+			o.Update(event.Data)
+		}, exception)
+	}
+	return NewWsDepth(ctx, wsClient, symbols, o.Update, exception)
+}
+
+// Update 处理一个增量深度 event
+// 也可以配合 SubscribeDepth 使用，自行把 event 交给 Update，不能在回调中调用
+func (o *OrderBook) Update(event *WsDepthEvent) {
+	if event == nil {
+		return
+	}
+	o.m.Update(&orderbook.Diff{
+		FirstUpdateId:    event.FirstUpdateID,
+		LastUpdateId:     event.LastUpdateID,
+		PrevLastUpdateId: event.PrevLastUpdateID,
+		Bids:             event.Bids,
+		Asks:             event.Asks,
+	})
+}
+
+// Ready 是否已完成初始化
+func (o *OrderBook) Ready() bool {
+	return o.m.Ready()
+}
+
+// LastUpdateId 最后一次更新的ID
+func (o *OrderBook) LastUpdateId() int {
+	return o.m.LastUpdateId()
+}
+
+// BestBid 买一
+func (o *OrderBook) BestBid() (orderbook.Level, bool) {
+	return o.m.BestBid()
+}
+
+// BestAsk 卖一
+func (o *OrderBook) BestAsk() (orderbook.Level, bool) {
+	return o.m.BestAsk()
+}
+
+// Bids 前 n 档买单，n <= 0 返回全部
+func (o *OrderBook) Bids(n int) []orderbook.Level {
+	return o.m.Bids(n)
+}
+
+// Asks 前 n 档卖单，n <= 0 返回全部
+func (o *OrderBook) Asks(n int) []orderbook.Level {
+	return o.m.Asks(n)
+}
+
+// CumulativeVolume 从最优价到 price (含) 的累计挂单量
+func (o *OrderBook) CumulativeVolume(side orderbook.Side, price decimal.Decimal) decimal.Decimal {
+	return o.m.CumulativeVolume(side, price)
+}
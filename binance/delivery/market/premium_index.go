@@ -0,0 +1,166 @@
+package market
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+type PremiumIndex interface {
+	SetSymbol(symbol string) *premiumIndexRequest
+	SetPair(pair string) *premiumIndexRequest
+	Call(ctx context.Context) (body []*premiumIndexResponse, err error)
+}
+
+type premiumIndexRequest struct {
+	*binance.Client
+	symbol string
+	pair   string
+}
+
+type premiumIndexResponse struct {
+	Symbol               string          `json:"symbol"`
+	Pair                 string          `json:"pair"`
+	MarkPrice            decimal.Decimal `json:"markPrice"`            // 标记价格
+	IndexPrice           decimal.Decimal `json:"indexPrice"`           // 指数价格
+	EstimatedSettlePrice decimal.Decimal `json:"estimatedSettlePrice"` // 预估结算价,仅在交割开始前最后一小时有意义
+	LastFundingRate      decimal.Decimal `json:"lastFundingRate"`      // 最近更新的资金费率,只对永续合约有效，其他合约返回""
+	InterestRate         decimal.Decimal `json:"interestRate"`         // 标的资产基础利率,只对永续合约有效，其他合约返回""
+	NextFundingTime      int64           `json:"nextFundingTime"`      // 下次资金费时间，只对永续合约有效，其他合约返回0
+	Time                 int64           `json:"time"`                 // 更新时间
+}
+
+// NewPremiumIndex 最新标记价格和资金费率
+// symbol 和 pair 都不传时返回所有交易对；按 pair 查询时返回该标的下的永续和交割合约
+func NewPremiumIndex(client *binance.Client) PremiumIndex {
+	return &premiumIndexRequest{Client: client}
+}
+
+func (p *premiumIndexRequest) SetSymbol(symbol string) *premiumIndexRequest {
+	p.symbol = symbol
+	return p
+}
+func (p *premiumIndexRequest) SetPair(pair string) *premiumIndexRequest {
+	p.pair = pair
+	return p
+}
+
+// Call 与U本位合约不同，即使指定 symbol 也返回数组
+func (p *premiumIndexRequest) Call(ctx context.Context) (body []*premiumIndexResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiMarketPremiumIndex,
+	}
+	req.SetOptionalParam("symbol", p.symbol)
+	req.SetOptionalParam("pair", p.pair)
+	resp, err := p.Do(ctx, req)
+	if err != nil {
+		p.Debugf("premiumIndexRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*premiumIndexResponse](resp)
+}
+
+type FundingRate interface {
+	SetStartTime(startTime int64) *fundingRateRequest
+	SetEndTime(endTime int64) *fundingRateRequest
+	Call(ctx context.Context) (body []*fundingRateResponse, err error)
+}
+
+type fundingRateRequest struct {
+	*binance.Client
+	symbol    string
+	startTime *int64
+	endTime   *int64
+	limit     enums.LimitType
+}
+
+type fundingRateResponse struct {
+	Symbol      string          `json:"symbol"`
+	FundingTime int64           `json:"fundingTime"` // 资金费时间
+	FundingRate decimal.Decimal `json:"fundingRate"` // 资金费率
+}
+
+// NewFundingRate 查询永续合约资金费率历史，交割合约没有资金费
+func NewFundingRate(client *binance.Client, symbol string, limit enums.LimitType) FundingRate {
+	return &fundingRateRequest{Client: client, symbol: symbol, limit: limit}
+}
+
+func (f *fundingRateRequest) SetStartTime(startTime int64) *fundingRateRequest {
+	f.startTime = &startTime
+	return f
+}
+func (f *fundingRateRequest) SetEndTime(endTime int64) *fundingRateRequest {
+	f.endTime = &endTime
+	return f
+}
+
+// Call 不传 startTime 和 endTime 时返回最近的 limit 条数据
+func (f *fundingRateRequest) Call(ctx context.Context) (body []*fundingRateResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiMarketFundingRate,
+	}
+	req.SetParam("symbol", f.symbol)
+	req.SetOptionalParam("startTime", f.startTime)
+	req.SetOptionalParam("endTime", f.endTime)
+	req.SetOptionalParam("limit", f.limit)
+	resp, err := f.Do(ctx, req)
+	if err != nil {
+		f.Debugf("fundingRateRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*fundingRateResponse](resp)
+}
+
+// ****************************** Websocket 行情推送 *******************************
+
+type StreamIndexPriceEvent struct {
+	Stream string            `json:"stream"`
+	Data   WsIndexPriceEvent `json:"data"`
+}
+
+// WsIndexPriceEvent 指数价格
+type WsIndexPriceEvent struct {
+	Event      string          `json:"e"` // 事件类型 indexPriceUpdate
+	Time       int64           `json:"E"` // 事件时间
+	Pair       string          `json:"i"` // 标的交易对
+	IndexPrice decimal.Decimal `json:"p"` // 指数价格
+}
+
+// NewWsIndexPrice 指数价格
+// Stream 名称: <pair>@indexPrice 或 <pair>@indexPrice@1s
+// 更新速度: 3000ms 或 1000ms(c.IsFast)
+func NewWsIndexPrice(ctx context.Context, c *binance.Client, pairs []string, handler binance.Handler[WsIndexPriceEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return indexPrice(ctx, c, pairs, handler, exception)
+}
+
+// NewStreamIndexPrice 组合 Stream 的指数价格
+func NewStreamIndexPrice(ctx context.Context, c *binance.Client, pairs []string, handler binance.Handler[StreamIndexPriceEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return indexPrice(ctx, c, pairs, handler, exception)
+}
+
+func indexPrice[T WsIndexPriceEvent | StreamIndexPriceEvent](ctx context.Context, c *binance.Client, pairs []string, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	endpoint := c.BaseURL
+	for _, s := range binance.SymbolStreams(pairs, indexPriceSuffix(c)) {
+		endpoint += s + "/"
+	}
+	endpoint = endpoint[:len(endpoint)-1]
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeIndexPrice 在组合 Stream 连接上订阅指数价格
+func SubscribeIndexPrice(ctx context.Context, s *binance.WsSubscriptions, pairs []string, handler binance.Handler[WsIndexPriceEvent]) error {
+	return binance.Subscribe(ctx, s, binance.SymbolStreams(pairs, indexPriceSuffix(s.Client())), handler)
+}
+
+func indexPriceSuffix(c *binance.Client) string {
+	if c.IsFast {
+		return "indexPrice@1s"
+	}
+	return "indexPrice"
+}
@@ -0,0 +1,78 @@
+// Package ticker 币本位合约行情统计
+// 与U本位合约不同，指定 symbol 或 pair 时也返回数组；按 pair 查询返回该标的下的永续和交割合约
+package ticker
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+type Hr24 interface {
+	SetSymbol(symbol string) *hr24Request
+	SetPair(pair string) *hr24Request
+	Call(ctx context.Context) (body []*hr24Response, err error)
+}
+
+type hr24Request struct {
+	*binance.Client
+	symbol string
+	pair   string
+}
+
+type hr24Response struct {
+	Symbol             string          `json:"symbol"`             // 交易对
+	Pair               string          `json:"pair"`               // 标的交易对
+	PriceChange        decimal.Decimal `json:"priceChange"`        // 24小时价格变动
+	PriceChangePercent decimal.Decimal `json:"priceChangePercent"` // 24小时价格变动百分比
+	WeightedAvgPrice   decimal.Decimal `json:"weightedAvgPrice"`   // 加权平均价
+	LastPrice          decimal.Decimal `json:"lastPrice"`          // 最近一次成交价
+	LastQty            decimal.Decimal `json:"lastQty"`            // 最近一次成交量(张)
+	OpenPrice          decimal.Decimal `json:"openPrice"`          // 24小时内第一次成交的价格
+	HighPrice          decimal.Decimal `json:"highPrice"`          // 24小时最高价
+	LowPrice           decimal.Decimal `json:"lowPrice"`           // 24小时最低价
+	Volume             decimal.Decimal `json:"volume"`             // 24小时成交量(张)
+	BaseVolume         decimal.Decimal `json:"baseVolume"`         // 24小时成交额(标的数量)
+	OpenTime           int64           `json:"openTime"`           // 24小时内，第一笔交易的发生时间
+	CloseTime          int64           `json:"closeTime"`          // 24小时内，最后一笔交易的发生时间
+	FirstId            int64           `json:"firstId"`            // 首笔成交id
+	LastId             int64           `json:"lastId"`             // 末笔成交id
+	Count              int             `json:"count"`              // 成交笔数
+}
+
+// NewHr24 24hr价格变动情况
+func NewHr24(client *binance.Client) Hr24 {
+	return &hr24Request{Client: client}
+}
+
+func (hr *hr24Request) SetSymbol(symbol string) *hr24Request {
+	hr.symbol = symbol
+	return hr
+}
+func (hr *hr24Request) SetPair(pair string) *hr24Request {
+	hr.pair = pair
+	return hr
+}
+
+// Call 24hr价格变动情况
+// symbol 和 pair 都不传时返回全部交易对，权重40
+func (hr *hr24Request) Call(ctx context.Context) (body []*hr24Response, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiMarketTicker24Hr,
+	}
+	req.SetOptionalParam("symbol", hr.symbol)
+	req.SetOptionalParam("pair", hr.pair)
+	if hr.symbol == "" && hr.pair == "" {
+		req.SetWeight(40)
+	}
+	res, err := hr.Do(ctx, req)
+	if err != nil {
+		hr.Debugf("hr24Request response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*hr24Response](res)
+}
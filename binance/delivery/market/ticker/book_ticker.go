@@ -0,0 +1,113 @@
+package ticker
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+type BookTicker interface {
+	SetSymbol(symbol string) *bookTickerRequest
+	SetPair(pair string) *bookTickerRequest
+	Call(ctx context.Context) (body []*bookTickerResponse, err error)
+}
+
+type bookTickerRequest struct {
+	*binance.Client
+	symbol string
+	pair   string
+}
+
+type bookTickerResponse struct {
+	Symbol   string          `json:"symbol"`
+	Pair     string          `json:"pair"`
+	BidPrice decimal.Decimal `json:"bidPrice"`
+	BidQty   decimal.Decimal `json:"bidQty"` // 张
+	AskPrice decimal.Decimal `json:"askPrice"`
+	AskQty   decimal.Decimal `json:"askQty"` // 张
+	Time     int64           `json:"time"`
+}
+
+// NewBookTicker 当前最优挂单
+// 返回当前最优的挂单(最高买单，最低卖单)
+func NewBookTicker(client *binance.Client) BookTicker {
+	return &bookTickerRequest{Client: client}
+}
+
+func (b *bookTickerRequest) SetSymbol(symbol string) *bookTickerRequest {
+	b.symbol = symbol
+	return b
+}
+func (b *bookTickerRequest) SetPair(pair string) *bookTickerRequest {
+	b.pair = pair
+	return b
+}
+
+// Call 当前最优挂单，symbol 和 pair 都不传时返回全部交易对，权重5
+func (b *bookTickerRequest) Call(ctx context.Context) (body []*bookTickerResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiMarketTickerBookTicker,
+	}
+	req.SetOptionalParam("symbol", b.symbol)
+	req.SetOptionalParam("pair", b.pair)
+	if b.symbol == "" && b.pair == "" {
+		req.SetWeight(5)
+	}
+	res, err := b.Do(ctx, req)
+	if err != nil {
+		b.Debugf("bookTickerRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*bookTickerResponse](res)
+}
+
+// ****************************** Websocket 行情推送 *******************************
+
+type StreamBookTickerEvent struct {
+	Stream string            `json:"stream"`
+	Data   WsBookTickerEvent `json:"data"`
+}
+
+// WsBookTickerEvent 最优挂单，数量单位为张
+type WsBookTickerEvent struct {
+	Event           string          `json:"e"`
+	UpdateID        int64           `json:"u"`
+	Symbol          string          `json:"s"`
+	Pair            string          `json:"ps"` // 标的交易对
+	BestBidPrice    decimal.Decimal `json:"b"`
+	BestBidQty      decimal.Decimal `json:"B"`
+	BestAskPrice    decimal.Decimal `json:"a"`
+	BestAskQty      decimal.Decimal `json:"A"`
+	TransactionTime int64           `json:"T"` // 撮合时间
+	Time            int64           `json:"E"` // 事件时间
+}
+
+// NewWsBookTicker 按Symbol的最优挂单信息
+// Stream 名称: <symbol>@bookTicker
+// 更新速度: 实时
+func NewWsBookTicker(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[WsBookTickerEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return bookTicker(ctx, c, symbols, handler, exception)
+}
+
+// NewStreamBookTicker 组合 Stream 的最优挂单信息
+func NewStreamBookTicker(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[StreamBookTickerEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return bookTicker(ctx, c, symbols, handler, exception)
+}
+
+func bookTicker[T WsBookTickerEvent | StreamBookTickerEvent](ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	endpoint := c.BaseURL
+	for _, s := range binance.SymbolStreams(symbols, "bookTicker") {
+		endpoint += s + "/"
+	}
+	endpoint = endpoint[:len(endpoint)-1]
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeBookTicker 在组合 Stream 连接上订阅最优挂单信息
+func SubscribeBookTicker(ctx context.Context, s *binance.WsSubscriptions, symbols []string, handler binance.Handler[WsBookTickerEvent]) error {
+	return binance.Subscribe(ctx, s, binance.SymbolStreams(symbols, "bookTicker"), handler)
+}
@@ -0,0 +1,62 @@
+package ticker
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+type Price interface {
+	SetSymbol(symbol string) *priceRequest
+	SetPair(pair string) *priceRequest
+	Call(ctx context.Context) (body []*priceResponse, err error)
+}
+
+type priceRequest struct {
+	*binance.Client
+	symbol string
+	pair   string
+}
+
+type priceResponse struct {
+	Symbol string          `json:"symbol"`
+	Ps     string          `json:"ps"` // 标的交易对
+	Price  decimal.Decimal `json:"price"`
+	Time   int64           `json:"time"`
+}
+
+// NewPrice 最新价格接口
+func NewPrice(client *binance.Client) Price {
+	return &priceRequest{Client: client}
+}
+
+func (t *priceRequest) SetSymbol(symbol string) *priceRequest {
+	t.symbol = symbol
+	return t
+}
+func (t *priceRequest) SetPair(pair string) *priceRequest {
+	t.pair = pair
+	return t
+}
+
+// Call 最新价格，symbol 和 pair 都不传时返回全部交易对，权重2
+func (t *priceRequest) Call(ctx context.Context) (body []*priceResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiMarketTickerPrice,
+	}
+	req.SetOptionalParam("symbol", t.symbol)
+	req.SetOptionalParam("pair", t.pair)
+	if t.symbol == "" && t.pair == "" {
+		req.SetWeight(2)
+	}
+	resp, err := t.Do(ctx, req)
+	if err != nil {
+		t.Debugf("priceRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*priceResponse](resp)
+}
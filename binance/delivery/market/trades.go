@@ -0,0 +1,51 @@
+package market
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+type Trades interface {
+	Call(ctx context.Context) (body []*tradesResponse, err error)
+}
+
+type tradesRequest struct {
+	*binance.Client
+	symbol string
+	limit  enums.LimitType
+}
+
+type tradesResponse struct {
+	Id           int64           `json:"id"`
+	Price        decimal.Decimal `json:"price"`
+	Qty          decimal.Decimal `json:"qty"`     // 成交张数
+	BaseQty      decimal.Decimal `json:"baseQty"` // 成交额(标的数量)
+	Time         int64           `json:"time"`
+	IsBuyerMaker bool            `json:"isBuyerMaker"` // 买方是否为挂单方
+}
+
+// NewTrades 近期成交
+func NewTrades(client *binance.Client, symbol string, limit enums.LimitType) Trades {
+	return &tradesRequest{Client: client, symbol: symbol, limit: limit}
+}
+
+// Call 近期成交，仅返回订单簿成交
+func (t *tradesRequest) Call(ctx context.Context) (body []*tradesResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiMarketTrades,
+	}
+	req.SetParam("symbol", t.symbol)
+	req.SetOptionalParam("limit", t.limit)
+	resp, err := t.Do(ctx, req)
+	if err != nil {
+		t.Debugf("tradesRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*tradesResponse](resp)
+}
@@ -0,0 +1,18 @@
+package stream
+
+import (
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/stream"
+)
+
+// NewUserDataStream 币本位合约用户数据流 listenKey 管理 (USER_STREAM)
+// 接口与U本位合约一致，只是路径为 /dapi/v1/listenKey
+func NewUserDataStream(client *binance.Client) stream.UserDataStream {
+	return stream.NewUserDataStreamPath(client, consts.DApiStreamListenKey)
+}
+
+// NewKeepAlive 定时延长币本位合约的 listenKey 有效期
+func NewKeepAlive(client *binance.Client) *stream.KeepAlive {
+	return stream.NewKeepAliveStream(NewUserDataStream(client))
+}
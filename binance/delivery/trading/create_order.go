@@ -0,0 +1,245 @@
+package trading
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/binance/rules"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+type CreateOrder interface {
+	SetSymbol(symbol string) *CreateOrderRequest
+	SetSide(side enums.SideType) *CreateOrderRequest
+	SetPositionSide(positionSide enums.PositionSideType) *CreateOrderRequest
+	SetType(orderType enums.OrderType) *CreateOrderRequest
+	SetTimeInForce(timeInForce enums.TimeInForceType) *CreateOrderRequest
+	SetQuantity(contracts int64) *CreateOrderRequest
+	SetPrice(price string) *CreateOrderRequest
+	SetReduceOnly(reduceOnly bool) *CreateOrderRequest
+	SetNewClientOrderId(newClientOrderId string) *CreateOrderRequest
+	SetStopPrice(stopPrice string) *CreateOrderRequest
+	SetClosePosition(closePosition bool) *CreateOrderRequest
+	SetActivationPrice(activationPrice string) *CreateOrderRequest
+	SetCallbackRate(callbackRate string) *CreateOrderRequest
+	SetWorkingType(workingType enums.WorkingType) *CreateOrderRequest
+	SetPriceProtect(priceProtect string) *CreateOrderRequest
+	SetNewOrderRespType(newOrderRespType enums.NewOrderRespType) *CreateOrderRequest
+	SetPriceMatch(priceMatch enums.PriceMatchType) *CreateOrderRequest
+	SetSelfTradePreventionMode(selfTradePreventionMode enums.StpModeType) *CreateOrderRequest
+	SetRules(registry *rules.Registry, round bool) *CreateOrderRequest
+	Validate(ctx context.Context) error
+	Call(ctx context.Context) (body *orderResponse, err error)
+	CallBatch(ctx context.Context, data []*CreateOrderRequest) (body []*orderResponse, err error)
+}
+
+// CreateOrderRequest 币本位合约下单
+// 与U本位合约的区别: quantity 是整数张数，每张面值见 exchangeInfo 的 contractSize，不支持 goodTillDate
+type CreateOrderRequest struct {
+	*binance.Client
+	Symbol                  string                 `json:"symbol,omitempty"`
+	Side                    enums.SideType         `json:"side,omitempty"`                    //订单方向
+	PositionSide            enums.PositionSideType `json:"positionSide,omitempty"`            //持仓方向，单向持仓模式下非必填，默认且仅可填BOTH;在双向持仓模式下必填,且仅可选择 LONG 或 SHORT
+	Type                    enums.OrderType        `json:"type,omitempty"`                    //订单类型
+	TimeInForce             enums.TimeInForceType  `json:"timeInForce,omitempty"`             //有效方法
+	Quantity                *int64                 `json:"quantity,string,omitempty"`         //下单张数,使用closePosition不支持此参数。
+	ReduceOnly              *bool                  `json:"reduceOnly,string,omitempty"`       //true, false; 非双开模式下默认false；双开模式下不接受此参数； 使用closePosition不支持此参数。
+	Price                   *string                `json:"price,omitempty"`                   //委托价格
+	NewClientOrderId        *string                `json:"newClientOrderId,omitempty"`        //用户自定义的订单号，不可以重复出现在挂单中
+	StopPrice               *string                `json:"stopPrice,omitempty"`               //触发价, 仅 STOP, STOP_MARKET, TAKE_PROFIT, TAKE_PROFIT_MARKET 需要此参数
+	ClosePosition           *bool                  `json:"closePosition,string,omitempty"`    //触发后全部平仓，仅支持STOP_MARKET和TAKE_PROFIT_MARKET
+	ActivationPrice         *string                `json:"activationPrice,omitempty"`         //追踪止损激活价格，仅TRAILING_STOP_MARKET 需要此参数
+	CallbackRate            *string                `json:"callbackRate,omitempty"`            //追踪止损回调比例，可取值范围[0.1, 10],其中 1代表1%
+	WorkingType             enums.WorkingType      `json:"workingType,omitempty"`             //stopPrice 触发类型: MARK_PRICE, CONTRACT_PRICE. 默认 CONTRACT_PRICE
+	PriceProtect            *string                `json:"priceProtect,omitempty"`            //条件单触发保护："TRUE","FALSE", 默认"FALSE"
+	NewOrderRespType        enums.NewOrderRespType `json:"newOrderRespType,omitempty"`        //"ACK", "RESULT", 默认 "ACK"
+	PriceMatch              enums.PriceMatchType   `json:"priceMatch,omitempty"`              //不能与price同时传
+	SelfTradePreventionMode enums.StpModeType      `json:"selfTradePreventionMode,omitempty"` //订单自成交保护模式
+	validator               *rules.Validator
+}
+
+// orderResponse 币本位合约订单，下单、改单、撤单和查单返回相同的字段
+type orderResponse struct {
+	Code                    int                    `json:"code,omitempty"`
+	Msg                     string                 `json:"msg,omitempty"`
+	ClientOrderId           string                 `json:"clientOrderId"`
+	CumQty                  decimal.Decimal        `json:"cumQty"`
+	CumBase                 decimal.Decimal        `json:"cumBase"`     // 成交额(标的数量)
+	ExecutedQty             decimal.Decimal        `json:"executedQty"` // 成交张数
+	OrderId                 int64                  `json:"orderId"`
+	AvgPrice                decimal.Decimal        `json:"avgPrice"`
+	OrigQty                 decimal.Decimal        `json:"origQty"` // 原始委托张数
+	Price                   decimal.Decimal        `json:"price"`
+	ReduceOnly              bool                   `json:"reduceOnly"`
+	Side                    enums.SideType         `json:"side"`
+	PositionSide            enums.PositionSideType `json:"positionSide"`
+	Status                  enums.StatusType       `json:"status"`
+	StopPrice               decimal.Decimal        `json:"stopPrice"`
+	ClosePosition           bool                   `json:"closePosition"`
+	Symbol                  string                 `json:"symbol"`
+	Pair                    string                 `json:"pair"` // 标的交易对
+	Time                    int64                  `json:"time"` // 订单时间，查单时返回
+	TimeInForce             enums.TimeInForceType  `json:"timeInForce"`
+	Type                    enums.OrderType        `json:"type"`
+	OrigType                enums.OrderType        `json:"origType"`
+	ActivatePrice           decimal.Decimal        `json:"activatePrice"`
+	PriceRate               decimal.Decimal        `json:"priceRate"`
+	UpdateTime              int64                  `json:"updateTime"`
+	WorkingType             enums.WorkingType      `json:"workingType"`
+	PriceProtect            bool                   `json:"priceProtect"`
+	PriceMatch              enums.PriceMatchType   `json:"priceMatch"`
+	SelfTradePreventionMode enums.StpModeType      `json:"selfTradePreventionMode"`
+}
+
+func NewOrder(client *binance.Client, symbol string) CreateOrder {
+	return &CreateOrderRequest{Client: client, Symbol: symbol}
+}
+
+func (c *CreateOrderRequest) SetSymbol(symbol string) *CreateOrderRequest {
+	c.Symbol = symbol
+	return c
+}
+func (c *CreateOrderRequest) SetSide(side enums.SideType) *CreateOrderRequest {
+	c.Side = side
+	return c
+}
+func (c *CreateOrderRequest) SetPositionSide(positionSide enums.PositionSideType) *CreateOrderRequest {
+	c.PositionSide = positionSide
+	return c
+}
+func (c *CreateOrderRequest) SetType(_type enums.OrderType) *CreateOrderRequest {
+	c.Type = _type
+	return c
+}
+func (c *CreateOrderRequest) SetTimeInForce(timeInForce enums.TimeInForceType) *CreateOrderRequest {
+	c.TimeInForce = timeInForce
+	return c
+}
+
+// SetQuantity 下单张数，可以通过 Contracts 由标的数量换算
+func (c *CreateOrderRequest) SetQuantity(contracts int64) *CreateOrderRequest {
+	c.Quantity = &contracts
+	return c
+}
+func (c *CreateOrderRequest) SetPrice(price string) *CreateOrderRequest {
+	c.Price = &price
+	return c
+}
+func (c *CreateOrderRequest) SetReduceOnly(reduceOnly bool) *CreateOrderRequest {
+	c.ReduceOnly = &reduceOnly
+	return c
+}
+func (c *CreateOrderRequest) SetNewClientOrderId(newClientOrderId string) *CreateOrderRequest {
+	c.NewClientOrderId = &newClientOrderId
+	return c
+}
+func (c *CreateOrderRequest) SetStopPrice(stopPrice string) *CreateOrderRequest {
+	c.StopPrice = &stopPrice
+	return c
+}
+func (c *CreateOrderRequest) SetClosePosition(closePosition bool) *CreateOrderRequest {
+	c.ClosePosition = &closePosition
+	return c
+}
+func (c *CreateOrderRequest) SetActivationPrice(activationPrice string) *CreateOrderRequest {
+	c.ActivationPrice = &activationPrice
+	return c
+}
+func (c *CreateOrderRequest) SetCallbackRate(callbackRate string) *CreateOrderRequest {
+	c.CallbackRate = &callbackRate
+	return c
+}
+func (c *CreateOrderRequest) SetWorkingType(workingType enums.WorkingType) *CreateOrderRequest {
+	c.WorkingType = workingType
+	return c
+}
+func (c *CreateOrderRequest) SetPriceProtect(priceProtect string) *CreateOrderRequest {
+	c.PriceProtect = &priceProtect
+	return c
+}
+func (c *CreateOrderRequest) SetNewOrderRespType(newOrderRespType enums.NewOrderRespType) *CreateOrderRequest {
+	c.NewOrderRespType = newOrderRespType
+	return c
+}
+func (c *CreateOrderRequest) SetPriceMatch(priceMatch enums.PriceMatchType) *CreateOrderRequest {
+	c.PriceMatch = priceMatch
+	return c
+}
+func (c *CreateOrderRequest) SetSelfTradePreventionMode(selfTradePreventionMode enums.StpModeType) *CreateOrderRequest {
+	c.SelfTradePreventionMode = selfTradePreventionMode
+	return c
+}
+
+// Call 下单 (TRADE)
+func (c *CreateOrderRequest) Call(ctx context.Context) (body *orderResponse, err error) {
+	err = c.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := &binance.Request{
+		Method: http.MethodPost,
+		Path:   consts.DApiOrder,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", c.Symbol)
+	req.SetParam("side", c.Side)
+	req.SetOptionalParam("positionSide", c.PositionSide)
+	req.SetParam("type", c.Type)
+	req.SetOptionalParam("timeInForce", c.TimeInForce)
+	req.SetOptionalParam("quantity", c.Quantity)
+	req.SetOptionalParam("reduceOnly", c.ReduceOnly)
+	req.SetOptionalParam("price", c.Price)
+	req.SetOptionalParam("newClientOrderId", c.NewClientOrderId)
+	req.SetOptionalParam("stopPrice", c.StopPrice)
+	req.SetOptionalParam("closePosition", c.ClosePosition)
+	req.SetOptionalParam("activationPrice", c.ActivationPrice)
+	req.SetOptionalParam("callbackRate", c.CallbackRate)
+	req.SetOptionalParam("workingType", c.WorkingType)
+	req.SetOptionalParam("priceProtect", c.PriceProtect)
+	req.SetOptionalParam("newOrderRespType", c.NewOrderRespType)
+	req.SetOptionalParam("priceMatch", c.PriceMatch)
+	req.SetOptionalParam("selfTradePreventionMode", c.SelfTradePreventionMode)
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		c.Debugf("createOrderRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*orderResponse](resp)
+}
+
+// CallBatch 批量下单 (TRADE)，最多 5 个订单
+// 批量下单采取并发处理，不保证订单撮合顺序；返回内容顺序与订单列表顺序一致，失败的订单只有 code 和 msg
+func (c *CreateOrderRequest) CallBatch(ctx context.Context, data []*CreateOrderRequest) (body []*orderResponse, err error) {
+	for _, d := range data {
+		// 未单独设置规则的订单使用 c 的规则
+		v := d.validator
+		if v == nil {
+			v = c.validator
+		}
+		err = d.validate(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	req := &binance.Request{
+		Method: http.MethodPost,
+		Path:   consts.DApiBatchOrders,
+	}
+	req.SetNeedSign(true)
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	req.SetParam("batchOrders", string(bytes))
+	req.SetOrderCount(len(data))
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		c.Debugf("CallBatch response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*orderResponse](resp)
+}
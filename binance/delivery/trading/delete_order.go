@@ -0,0 +1,101 @@
+package trading
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/pkg/errors"
+)
+
+type DeleteOrder interface {
+	SetSymbol(symbol string) *deleteOrderRequest
+	SetOrderId(orderId int64) *deleteOrderRequest
+	SetOrigClientOrderId(origClientOrderId string) *deleteOrderRequest
+	Call(ctx context.Context) (body *orderResponse, err error)
+	CallBatch(ctx context.Context, orderIdList []int64) (body []*orderResponse, err error)
+	CallAllOpenOrders(ctx context.Context) (body *errors.Status, err error)
+}
+
+// deleteOrderRequest 撤销订单 (TRADE)
+// orderId 与 origClientOrderId 必须至少发送一个.
+type deleteOrderRequest struct {
+	*binance.Client
+	symbol            string
+	orderId           *int64
+	origClientOrderId *string
+}
+
+func NewDeleteOrder(client *binance.Client, symbol string) DeleteOrder {
+	return &deleteOrderRequest{Client: client, symbol: symbol}
+}
+
+func (d *deleteOrderRequest) SetSymbol(symbol string) *deleteOrderRequest {
+	d.symbol = symbol
+	return d
+}
+func (d *deleteOrderRequest) SetOrderId(orderId int64) *deleteOrderRequest {
+	d.orderId = &orderId
+	return d
+}
+func (d *deleteOrderRequest) SetOrigClientOrderId(origClientOrderId string) *deleteOrderRequest {
+	d.origClientOrderId = &origClientOrderId
+	return d
+}
+
+// Call 撤销订单 (TRADE)
+func (d *deleteOrderRequest) Call(ctx context.Context) (body *orderResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodDelete,
+		Path:   consts.DApiOrder,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", d.symbol)
+	req.SetOptionalParam("orderId", d.orderId)
+	req.SetOptionalParam("origClientOrderId", d.origClientOrderId)
+	resp, err := d.Do(ctx, req)
+	if err != nil {
+		d.Debugf("deleteOrderRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*orderResponse](resp)
+}
+
+// CallBatch 批量撤销订单 (TRADE)，最多 10 个订单
+func (d *deleteOrderRequest) CallBatch(ctx context.Context, orderIdList []int64) (body []*orderResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodDelete,
+		Path:   consts.DApiBatchOrders,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", d.symbol)
+	bytes, err := json.Marshal(orderIdList)
+	if err != nil {
+		return nil, err
+	}
+	req.SetParam("orderIdList", string(bytes))
+	resp, err := d.Do(ctx, req)
+	if err != nil {
+		d.Debugf("CallBatch response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*orderResponse](resp)
+}
+
+// CallAllOpenOrders 撤销交易对的全部挂单 (TRADE)
+func (d *deleteOrderRequest) CallAllOpenOrders(ctx context.Context) (body *errors.Status, err error) {
+	req := &binance.Request{
+		Method: http.MethodDelete,
+		Path:   consts.DApiAllOpenOrders,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", d.symbol)
+	resp, err := d.Do(ctx, req)
+	if err != nil {
+		d.Debugf("CallAllOpenOrders response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*errors.Status](resp)
+}
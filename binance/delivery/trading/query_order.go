@@ -0,0 +1,134 @@
+package trading
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+)
+
+type QueryOrder interface {
+	SetSymbol(symbol string) *queryOrderRequest
+	SetPair(pair string) *queryOrderRequest
+	SetOrderId(orderId int64) *queryOrderRequest
+	SetOrigClientOrderId(origClientOrderId string) *queryOrderRequest
+	SetStartTime(startTime int64) *queryOrderRequest
+	SetEndTime(endTime int64) *queryOrderRequest
+	SetLimit(limit enums.LimitType) *queryOrderRequest
+	Call(ctx context.Context) (body *orderResponse, err error)
+	CallOpenOrders(ctx context.Context) (body []*orderResponse, err error)
+	CallAllOrders(ctx context.Context) (body []*orderResponse, err error)
+}
+
+// queryOrderRequest 查询订单
+// 查询单个订单需要 symbol 和 orderId 或 origClientOrderId 中的一个；
+// 查询挂单和所有订单时 symbol 和 pair 二选一，按 pair 查询时返回该标的下所有合约(永续和交割)的订单
+type queryOrderRequest struct {
+	*binance.Client
+	symbol            string
+	pair              string
+	orderId           *int64
+	origClientOrderId *string
+	startTime         *int64
+	endTime           *int64
+	limit             enums.LimitType
+}
+
+func NewQueryOrder(client *binance.Client, symbol string) QueryOrder {
+	return &queryOrderRequest{Client: client, symbol: symbol}
+}
+
+func (o *queryOrderRequest) SetSymbol(symbol string) *queryOrderRequest {
+	o.symbol = symbol
+	return o
+}
+func (o *queryOrderRequest) SetPair(pair string) *queryOrderRequest {
+	o.pair = pair
+	return o
+}
+func (o *queryOrderRequest) SetOrderId(orderId int64) *queryOrderRequest {
+	o.orderId = &orderId
+	return o
+}
+func (o *queryOrderRequest) SetOrigClientOrderId(origClientOrderId string) *queryOrderRequest {
+	o.origClientOrderId = &origClientOrderId
+	return o
+}
+func (o *queryOrderRequest) SetStartTime(startTime int64) *queryOrderRequest {
+	o.startTime = &startTime
+	return o
+}
+func (o *queryOrderRequest) SetEndTime(endTime int64) *queryOrderRequest {
+	o.endTime = &endTime
+	return o
+}
+func (o *queryOrderRequest) SetLimit(limit enums.LimitType) *queryOrderRequest {
+	o.limit = limit
+	return o
+}
+
+// Call 查询订单 (USER_DATA)
+func (o *queryOrderRequest) Call(ctx context.Context) (body *orderResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiOrder,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", o.symbol)
+	req.SetOptionalParam("orderId", o.orderId)
+	req.SetOptionalParam("origClientOrderId", o.origClientOrderId)
+	resp, err := o.Do(ctx, req)
+	if err != nil {
+		o.Debugf("queryOrderRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*orderResponse](resp)
+}
+
+// CallOpenOrders 查看当前全部挂单 (USER_DATA)
+// symbol 和 pair 都不传时返回所有交易对的挂单，权重 40
+func (o *queryOrderRequest) CallOpenOrders(ctx context.Context) (body []*orderResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiOpenOrders,
+	}
+	req.SetNeedSign(true)
+	req.SetOptionalParam("symbol", o.symbol)
+	req.SetOptionalParam("pair", o.pair)
+	if o.symbol == "" && o.pair == "" {
+		req.SetWeight(40)
+	}
+	resp, err := o.Do(ctx, req)
+	if err != nil {
+		o.Debugf("CallOpenOrders response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*orderResponse](resp)
+}
+
+// CallAllOrders 查询所有订单(包括历史订单) (USER_DATA)
+// symbol 和 pair 必须传一个，按 pair 查询时权重 40；查询时间范围最大不得超过7天
+func (o *queryOrderRequest) CallAllOrders(ctx context.Context) (body []*orderResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.DApiAllOrders,
+	}
+	req.SetNeedSign(true)
+	req.SetOptionalParam("symbol", o.symbol)
+	req.SetOptionalParam("pair", o.pair)
+	req.SetOptionalParam("orderId", o.orderId)
+	req.SetOptionalParam("startTime", o.startTime)
+	req.SetOptionalParam("endTime", o.endTime)
+	req.SetOptionalParam("limit", o.limit)
+	if o.symbol == "" {
+		req.SetWeight(40)
+	}
+	resp, err := o.Do(ctx, req)
+	if err != nil {
+		o.Debugf("CallAllOrders response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*orderResponse](resp)
+}
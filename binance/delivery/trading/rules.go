@@ -0,0 +1,56 @@
+package trading
+
+import (
+	"context"
+
+	"github.com/sleep-go/coin-go/binance/rules"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// SetRules 下单前按 exchangeInfo 的过滤器检查订单，round 为 true 时先把价格舍入到 tickSize
+// 张数是整数，只检查不舍入
+func (c *CreateOrderRequest) SetRules(registry *rules.Registry, round bool) *CreateOrderRequest {
+	c.validator = &rules.Validator{Registry: registry, Round: round}
+	return c
+}
+
+// Validate 按交易对规则检查订单，未通过时返回 *rules.ValidationError
+func (c *CreateOrderRequest) Validate(ctx context.Context) error {
+	return c.validate(ctx, c.validator)
+}
+
+func (c *CreateOrderRequest) validate(ctx context.Context, v *rules.Validator) error {
+	if v == nil || v.Registry == nil {
+		return nil
+	}
+	o := &rules.Order{Side: string(c.Side), Type: string(c.Type)}
+	var err error
+	o.Price, err = rules.ParseDecimal("price", c.Price)
+	if err != nil {
+		return err
+	}
+	o.StopPrice, err = rules.ParseDecimal("stopPrice", c.StopPrice)
+	if err != nil {
+		return err
+	}
+	if c.Quantity != nil {
+		o.Quantity = decimal.NewFromInt(*c.Quantity)
+	}
+	err = v.Check(ctx, c.Symbol, o)
+	if v.Round {
+		rules.FormatDecimal(o.Price, &c.Price)
+		rules.FormatDecimal(o.StopPrice, &c.StopPrice)
+	}
+	return err
+}
+
+// Contracts 币本位合约数量以张为单位，每张面值 contractSize 美元
+// 把标的币种数量 amount 按价格 price 换算为张数，向下取整
+func Contracts(amount, price decimal.Decimal, contractSize int64) int64 {
+	return amount.Mul(price).DivRound(decimal.NewFromInt(contractSize), 0, decimal.RoundDown).IntPart()
+}
+
+// BaseQty 张数按价格 price 换算为标的币种数量
+func BaseQty(contracts int64, price decimal.Decimal, contractSize int64) decimal.Decimal {
+	return decimal.NewFromInt(contracts).Mul(decimal.NewFromInt(contractSize)).Div(price)
+}
@@ -0,0 +1,113 @@
+package trading
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+)
+
+type UpdateOrder interface {
+	SetOrderId(orderId int64) *UpdateOrderRequest
+	SetOrigClientOrderId(origClientOrderId string) *UpdateOrderRequest
+	SetSymbol(symbol string) *UpdateOrderRequest
+	SetSide(side enums.SideType) *UpdateOrderRequest
+	SetQuantity(contracts int64) *UpdateOrderRequest
+	SetPrice(price string) *UpdateOrderRequest
+	SetPriceMatch(priceMatch enums.PriceMatchType) *UpdateOrderRequest
+	Call(ctx context.Context) (body *orderResponse, err error)
+	CallBatch(ctx context.Context, data []*UpdateOrderRequest) (body []*orderResponse, err error)
+}
+
+// UpdateOrderRequest 修改订单，目前只支持 LIMIT 订单
+// orderId 与 origClientOrderId 必须至少发送一个，同时发送则以 order id为准
+// 与U本位合约不同，quantity 与 price 可以只发送一个
+type UpdateOrderRequest struct {
+	*binance.Client
+	OrderId           *int64               `json:"orderId,string,omitempty"`    //系统订单号
+	OrigClientOrderId *string              `json:"origClientOrderId,omitempty"` //用户自定义的订单号
+	Symbol            string               `json:"symbol,omitempty"`            //交易对
+	Side              enums.SideType       `json:"side,omitempty"`              //订单方向
+	Quantity          *int64               `json:"quantity,string,omitempty"`   //下单张数
+	Price             *string              `json:"price,omitempty"`             //委托价格
+	PriceMatch        enums.PriceMatchType `json:"priceMatch,omitempty"`        //不能与price同时传
+}
+
+func NewUpdateOrder(client *binance.Client, symbol string) UpdateOrder {
+	return &UpdateOrderRequest{Client: client, Symbol: symbol}
+}
+
+func (c *UpdateOrderRequest) SetOrderId(orderId int64) *UpdateOrderRequest {
+	c.OrderId = &orderId
+	return c
+}
+func (c *UpdateOrderRequest) SetOrigClientOrderId(origClientOrderId string) *UpdateOrderRequest {
+	c.OrigClientOrderId = &origClientOrderId
+	return c
+}
+func (c *UpdateOrderRequest) SetSymbol(symbol string) *UpdateOrderRequest {
+	c.Symbol = symbol
+	return c
+}
+func (c *UpdateOrderRequest) SetSide(side enums.SideType) *UpdateOrderRequest {
+	c.Side = side
+	return c
+}
+func (c *UpdateOrderRequest) SetQuantity(contracts int64) *UpdateOrderRequest {
+	c.Quantity = &contracts
+	return c
+}
+func (c *UpdateOrderRequest) SetPrice(price string) *UpdateOrderRequest {
+	c.Price = &price
+	return c
+}
+func (c *UpdateOrderRequest) SetPriceMatch(priceMatch enums.PriceMatchType) *UpdateOrderRequest {
+	c.PriceMatch = priceMatch
+	return c
+}
+
+// Call 修改订单 (TRADE)
+func (c *UpdateOrderRequest) Call(ctx context.Context) (body *orderResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodPut,
+		Path:   consts.DApiOrder,
+	}
+	req.SetNeedSign(true)
+	req.SetOptionalParam("orderId", c.OrderId)
+	req.SetOptionalParam("origClientOrderId", c.OrigClientOrderId)
+	req.SetParam("symbol", c.Symbol)
+	req.SetParam("side", c.Side)
+	req.SetOptionalParam("quantity", c.Quantity)
+	req.SetOptionalParam("price", c.Price)
+	req.SetOptionalParam("priceMatch", c.PriceMatch)
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		c.Debugf("UpdateOrderRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*orderResponse](resp)
+}
+
+// CallBatch 批量修改订单 (TRADE)，最多 5 个订单
+func (c *UpdateOrderRequest) CallBatch(ctx context.Context, data []*UpdateOrderRequest) (body []*orderResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodPut,
+		Path:   consts.DApiBatchOrders,
+	}
+	req.SetNeedSign(true)
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	req.SetParam("batchOrders", string(bytes))
+	req.SetOrderCount(len(data))
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		c.Debugf("CallBatch response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*orderResponse](resp)
+}
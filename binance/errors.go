@@ -0,0 +1,241 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ErrorCode 币安文档中的错误码，作为哨兵错误使用: errors.Is(err, binance.ErrUnknownOrder)
+type ErrorCode struct {
+	Code  int
+	Name  string
+	match func(e *APIError) bool // 为空时按 Code 匹配
+}
+
+func (c *ErrorCode) Error() string {
+	return fmt.Sprintf("binance: %s (%d)", c.Name, c.Code)
+}
+
+func (c *ErrorCode) matches(e *APIError) bool {
+	if c.match != nil {
+		return c.match(e)
+	}
+	return e.Code == c.Code
+}
+
+var errorCodes = map[int]*ErrorCode{}
+
+func newErrorCode(code int, name string) *ErrorCode {
+	c := &ErrorCode{Code: code, Name: name}
+	errorCodes[code] = c
+	return c
+}
+
+// 10xx 常规服务器或网络问题
+var (
+	ErrUnknown             = newErrorCode(-1000, "UNKNOWN")
+	ErrDisconnected        = newErrorCode(-1001, "DISCONNECTED")
+	ErrUnauthorized        = newErrorCode(-1002, "UNAUTHORIZED")
+	ErrTooManyRequests     = newErrorCode(-1003, "TOO_MANY_REQUESTS")
+	ErrUnexpectedResponse  = newErrorCode(-1006, "UNEXPECTED_RESP")
+	ErrTimeout             = newErrorCode(-1007, "TIMEOUT")
+	ErrServerBusy          = newErrorCode(-1008, "SERVER_BUSY")
+	ErrInvalidMessage      = newErrorCode(-1013, "INVALID_MESSAGE")
+	ErrUnknownOrderComp    = newErrorCode(-1014, "UNKNOWN_ORDER_COMPOSITION")
+	ErrTooManyOrders       = newErrorCode(-1015, "TOO_MANY_ORDERS")
+	ErrServiceShuttingDown = newErrorCode(-1016, "SERVICE_SHUTTING_DOWN")
+	ErrUnsupportedOp       = newErrorCode(-1020, "UNSUPPORTED_OPERATION")
+	// ErrTimestampOutsideRecvWindow 请求的时间戳在 recvWindow 之外，设置了 Client.TimeSync 时会自动重试
+	ErrTimestampOutsideRecvWindow = newErrorCode(-1021, "INVALID_TIMESTAMP")
+	ErrInvalidSignature           = newErrorCode(-1022, "INVALID_SIGNATURE")
+)
+
+// 11xx 请求内容问题
+var (
+	ErrIllegalChars           = newErrorCode(-1100, "ILLEGAL_CHARS")
+	ErrTooManyParameters      = newErrorCode(-1101, "TOO_MANY_PARAMETERS")
+	ErrMandatoryParamEmpty    = newErrorCode(-1102, "MANDATORY_PARAM_EMPTY_OR_MALFORMED")
+	ErrUnknownParam           = newErrorCode(-1103, "UNKNOWN_PARAM")
+	ErrUnreadParameters       = newErrorCode(-1104, "UNREAD_PARAMETERS")
+	ErrParamEmpty             = newErrorCode(-1105, "PARAM_EMPTY")
+	ErrParamNotRequired       = newErrorCode(-1106, "PARAM_NOT_REQUIRED")
+	ErrBadPrecision           = newErrorCode(-1111, "BAD_PRECISION")
+	ErrNoDepth                = newErrorCode(-1112, "NO_DEPTH")
+	ErrTifNotRequired         = newErrorCode(-1114, "TIF_NOT_REQUIRED")
+	ErrInvalidTif             = newErrorCode(-1115, "INVALID_TIF")
+	ErrInvalidOrderType       = newErrorCode(-1116, "INVALID_ORDER_TYPE")
+	ErrInvalidSide            = newErrorCode(-1117, "INVALID_SIDE")
+	ErrEmptyNewClOrdId        = newErrorCode(-1118, "EMPTY_NEW_CL_ORD_ID")
+	ErrEmptyOrgClOrdId        = newErrorCode(-1119, "EMPTY_ORG_CL_ORD_ID")
+	ErrBadInterval            = newErrorCode(-1120, "BAD_INTERVAL")
+	ErrBadSymbol              = newErrorCode(-1121, "BAD_SYMBOL")
+	ErrInvalidListenKey       = newErrorCode(-1125, "INVALID_LISTEN_KEY")
+	ErrMoreThanXXHours        = newErrorCode(-1127, "MORE_THAN_XX_HOURS")
+	ErrOptionalParamsBadCombo = newErrorCode(-1128, "OPTIONAL_PARAMS_BAD_COMBO")
+	ErrInvalidParameter       = newErrorCode(-1130, "INVALID_PARAMETER")
+	ErrBadRecvWindow          = newErrorCode(-1131, "BAD_RECV_WINDOW")
+)
+
+// 20xx 下单、撤单问题，-2021、-2022 在现货和合约中含义不同，没有单独定义
+var (
+	ErrNewOrderRejected     = newErrorCode(-2010, "NEW_ORDER_REJECTED")
+	ErrCancelRejected       = newErrorCode(-2011, "CANCEL_REJECTED")
+	ErrNoSuchOrder          = newErrorCode(-2013, "NO_SUCH_ORDER")
+	ErrBadAPIKeyFmt         = newErrorCode(-2014, "BAD_API_KEY_FMT")
+	ErrRejectedAPIKey       = newErrorCode(-2015, "REJECTED_MBX_KEY")
+	ErrNoTradingWindow      = newErrorCode(-2016, "NO_TRADING_WINDOW")
+	ErrBalanceNotSufficient = newErrorCode(-2018, "BALANCE_NOT_SUFFICIENT")
+	ErrMarginNotSufficient  = newErrorCode(-2019, "MARGIN_NOT_SUFFICIENT")
+	ErrUnableToFill         = newErrorCode(-2020, "UNABLE_TO_FILL")
+	ErrMaxOpenOrderExceeded = newErrorCode(-2025, "MAX_OPEN_ORDER_EXCEEDED")
+	ErrOrderArchived        = newErrorCode(-2026, "ORDER_ARCHIVED")
+)
+
+// 40xx 合约账户设置问题
+var (
+	// ErrNoNeedToChangeMarginType 保证金模式已经是目标模式
+	ErrNoNeedToChangeMarginType = newErrorCode(-4046, "NO_NEED_TO_CHANGE_MARGIN_TYPE")
+	// ErrNoNeedToChangePositionSide 持仓模式已经是目标模式
+	ErrNoNeedToChangePositionSide = newErrorCode(-4059, "NO_NEED_TO_CHANGE_POSITION_SIDE")
+)
+
+// 按错误码和错误信息组合匹配的哨兵错误
+var (
+	// ErrInsufficientBalance 余额或保证金不足: 现货 -2010 的余额不足，合约 -2018、-2019
+	ErrInsufficientBalance = &ErrorCode{Code: -2010, Name: "INSUFFICIENT_BALANCE", match: func(e *APIError) bool {
+		switch e.Code {
+		case ErrBalanceNotSufficient.Code, ErrMarginNotSufficient.Code:
+			return true
+		case ErrNewOrderRejected.Code:
+			return strings.Contains(strings.ToLower(e.Msg), "insufficient balance")
+		}
+		return false
+	}}
+	// ErrUnknownOrder 订单不存在: -2013，以及 -2011 撤单时的 "Unknown order sent."
+	ErrUnknownOrder = &ErrorCode{Code: -2013, Name: "UNKNOWN_ORDER", match: func(e *APIError) bool {
+		switch e.Code {
+		case ErrNoSuchOrder.Code:
+			return true
+		case ErrCancelRejected.Code:
+			return strings.Contains(e.Msg, "Unknown order")
+		}
+		return false
+	}}
+)
+
+// APIError 币安接口返回的错误
+type APIError struct {
+	Code       int         `json:"code"` // 币安错误码，如 -2010
+	Msg        string      `json:"msg"`
+	StatusCode int         `json:"-"` // HTTP 状态码，WS API 为响应中的 status
+	Header     http.Header `json:"-"` // REST 响应头，WS API 为空
+}
+
+// NewAPIError 从响应内容解析错误，body 不是币安的错误格式时只保留状态码和原始内容
+func NewAPIError(statusCode int, header http.Header, body []byte) *APIError {
+	e := &APIError{StatusCode: statusCode, Header: header}
+	if json.Unmarshal(body, e) != nil || (e.Code == 0 && e.Msg == "") {
+		e.Code = 0
+		e.Msg = strings.TrimSpace(string(body))
+		if e.Msg == "" {
+			e.Msg = http.StatusText(statusCode)
+		}
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	if c, ok := errorCodes[e.Code]; ok {
+		return fmt.Sprintf("binance: status=%d code=%d %s msg=%s", e.StatusCode, e.Code, c.Name, e.Msg)
+	}
+	return fmt.Sprintf("binance: status=%d code=%d msg=%s", e.StatusCode, e.Code, e.Msg)
+}
+
+// Is 支持 errors.Is 匹配 ErrorCode 哨兵错误和 ErrRateLimited
+func (e *APIError) Is(target error) bool {
+	switch t := target.(type) {
+	case *ErrorCode:
+		return t.matches(e)
+	case *APIError:
+		return t.Code == e.Code
+	}
+	if target == ErrRateLimited {
+		return e.Code == ErrTooManyRequests.Code || e.StatusCode == http.StatusTooManyRequests || e.StatusCode == http.StatusTeapot
+	}
+	return false
+}
+
+// ExecutionUnknown 请求可能已经被执行，例如下单后返回 5xx 或超时
+// 这类错误不能当作失败处理，应先查询订单状态再决定是否重试
+func (e *APIError) ExecutionUnknown() bool {
+	switch e.Code {
+	case ErrUnknown.Code, ErrUnexpectedResponse.Code, ErrTimeout.Code:
+		return true
+	case ErrDisconnected.Code, ErrServerBusy.Code:
+		return false
+	}
+	if e.StatusCode == http.StatusServiceUnavailable {
+		// 503 Service Unavailable 表示请求没有被处理，其他 503 信息表示执行状态未知
+		return !strings.Contains(e.Msg, "Service Unavailable")
+	}
+	return e.StatusCode >= 500
+}
+
+// Retryable 请求确定没有被执行，可以原样重试
+// 429、-1003、-1015 需要等待频率限制窗口重置后再重试，418 表示 IP 已被封禁
+func (e *APIError) Retryable() bool {
+	switch e.Code {
+	case ErrDisconnected.Code, ErrServerBusy.Code, ErrTooManyRequests.Code, ErrTooManyOrders.Code,
+		ErrServiceShuttingDown.Code, ErrTimestampOutsideRecvWindow.Code:
+		return true
+	}
+	switch e.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusServiceUnavailable:
+		return !e.ExecutionUnknown()
+	}
+	return false
+}
+
+// IsRetryable 请求确定没有被执行并且可以重试
+// 包括可以重试的 *APIError、本地限流的 *RateLimitError，以及连接没有建立的网络错误
+func IsRetryable(err error) bool {
+	var e *APIError
+	if errors.As(err, &e) {
+		return e.Retryable()
+	}
+	var rl *RateLimitError
+	if errors.As(err, &rl) {
+		return rl.StatusCode != http.StatusTeapot
+	}
+	var op *net.OpError
+	if errors.As(err, &op) && op.Op == "dial" {
+		return true
+	}
+	return false
+}
+
+// IsExecutionUnknown 请求可能已经到达服务端并被执行
+// 包括执行状态未知的 *APIError，以及超时、取消或连接断开的网络错误
+func IsExecutionUnknown(err error) bool {
+	if err == nil {
+		return false
+	}
+	var e *APIError
+	if errors.As(err, &e) {
+		return e.ExecutionUnknown()
+	}
+	if IsRetryable(err) {
+		return false
+	}
+	var ne net.Error
+	return errors.As(err, &ne) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
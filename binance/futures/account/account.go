@@ -0,0 +1,119 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// Account 账户信息V2
+type Account interface {
+	Call(ctx context.Context) (body *accountResponse, err error)
+}
+
+type accountRequest struct {
+	*binance.Client
+}
+type accountResponse struct {
+	FeeTier                     int             `json:"feeTier"`     // 手续费等级
+	CanTrade                    bool            `json:"canTrade"`    // 是否可以交易
+	CanDeposit                  bool            `json:"canDeposit"`  // 是否可以入金
+	CanWithdraw                 bool            `json:"canWithdraw"` // 是否可以出金
+	FeeBurn                     bool            `json:"feeBurn"`     // 是否开启 BNB 抵扣手续费
+	MultiAssetsMargin           bool            `json:"multiAssetsMargin"`
+	TradeGroupId                int             `json:"tradeGroupId"`
+	UpdateTime                  int64           `json:"updateTime"`
+	TotalInitialMargin          decimal.Decimal `json:"totalInitialMargin"`          // 当前所需起始保证金总额(存在逐仓请忽略), 仅计算usdt资产
+	TotalMaintMargin            decimal.Decimal `json:"totalMaintMargin"`            // 维持保证金总额, 仅计算usdt资产
+	TotalWalletBalance          decimal.Decimal `json:"totalWalletBalance"`          // 账户总余额, 仅计算usdt资产
+	TotalUnrealizedProfit       decimal.Decimal `json:"totalUnrealizedProfit"`       // 持仓未实现盈亏总额, 仅计算usdt资产
+	TotalMarginBalance          decimal.Decimal `json:"totalMarginBalance"`          // 保证金总余额, 仅计算usdt资产
+	TotalPositionInitialMargin  decimal.Decimal `json:"totalPositionInitialMargin"`  // 持仓所需起始保证金(基于最新标记价格), 仅计算usdt资产
+	TotalOpenOrderInitialMargin decimal.Decimal `json:"totalOpenOrderInitialMargin"` // 当前挂单所需起始保证金(基于最新标记价格), 仅计算usdt资产
+	TotalCrossWalletBalance     decimal.Decimal `json:"totalCrossWalletBalance"`     // 全仓账户余额, 仅计算usdt资产
+	TotalCrossUnPnl             decimal.Decimal `json:"totalCrossUnPnl"`             // 全仓持仓未实现盈亏总额, 仅计算usdt资产
+	AvailableBalance            decimal.Decimal `json:"availableBalance"`            // 可用余额, 仅计算usdt资产
+	MaxWithdrawAmount           decimal.Decimal `json:"maxWithdrawAmount"`           // 最大可转出余额, 仅计算usdt资产
+	Assets                      []struct {
+		Asset                  string          `json:"asset"`                  // 资产
+		WalletBalance          decimal.Decimal `json:"walletBalance"`          // 余额
+		UnrealizedProfit       decimal.Decimal `json:"unrealizedProfit"`       // 未实现盈亏
+		MarginBalance          decimal.Decimal `json:"marginBalance"`          // 保证金余额
+		MaintMargin            decimal.Decimal `json:"maintMargin"`            // 维持保证金
+		InitialMargin          decimal.Decimal `json:"initialMargin"`          // 当前所需起始保证金
+		PositionInitialMargin  decimal.Decimal `json:"positionInitialMargin"`  // 持仓所需起始保证金(基于最新标记价格)
+		OpenOrderInitialMargin decimal.Decimal `json:"openOrderInitialMargin"` // 当前挂单所需起始保证金(基于最新标记价格)
+		CrossWalletBalance     decimal.Decimal `json:"crossWalletBalance"`     // 全仓账户余额
+		CrossUnPnl             decimal.Decimal `json:"crossUnPnl"`             // 全仓持仓未实现盈亏
+		AvailableBalance       decimal.Decimal `json:"availableBalance"`       // 可用余额
+		MaxWithdrawAmount      decimal.Decimal `json:"maxWithdrawAmount"`      // 最大可转出余额
+		MarginAvailable        bool            `json:"marginAvailable"`        // 是否可用作联合保证金
+		UpdateTime             int64           `json:"updateTime"`             // 更新时间
+	} `json:"assets"`
+	Positions []struct {
+		Symbol                 string                 `json:"symbol"`                 // 交易对
+		InitialMargin          decimal.Decimal        `json:"initialMargin"`          // 当前所需起始保证金(基于最新标记价格)
+		MaintMargin            decimal.Decimal        `json:"maintMargin"`            // 维持保证金
+		UnrealizedProfit       decimal.Decimal        `json:"unrealizedProfit"`       // 持仓未实现盈亏
+		PositionInitialMargin  decimal.Decimal        `json:"positionInitialMargin"`  // 持仓所需起始保证金(基于最新标记价格)
+		OpenOrderInitialMargin decimal.Decimal        `json:"openOrderInitialMargin"` // 当前挂单所需起始保证金(基于最新标记价格)
+		Leverage               decimal.Decimal        `json:"leverage"`               // 杠杆倍率
+		Isolated               bool                   `json:"isolated"`               // 是否是逐仓模式
+		EntryPrice             decimal.Decimal        `json:"entryPrice"`             // 持仓成本价
+		BreakEvenPrice         decimal.Decimal        `json:"breakEvenPrice"`         // 盈亏平衡价
+		MaxNotional            decimal.Decimal        `json:"maxNotional"`            // 当前杠杆下用户可用的最大名义价值
+		BidNotional            decimal.Decimal        `json:"bidNotional"`            // 买单净值，忽略
+		AskNotional            decimal.Decimal        `json:"askNotional"`            // 卖单净值，忽略
+		PositionSide           enums.PositionSideType `json:"positionSide"`           // 持仓方向
+		PositionAmt            decimal.Decimal        `json:"positionAmt"`            // 持仓数量
+		UpdateTime             int64                  `json:"updateTime"`             // 更新时间
+	} `json:"positions"` // 头寸，将返回所有市场symbol
+}
+
+func NewAccount(client *binance.Client) Account {
+	return &accountRequest{Client: client}
+}
+
+// Call 账户信息V2 (USER_DATA)
+// 现有账户信息。 用户在单资产模式和多资产模式下会看到不同结果，响应部分的注释解释了两种模式下的不同。
+func (a *accountRequest) Call(ctx context.Context) (body *accountResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.FApiAccount,
+	}
+	req.SetNeedSign(true)
+	resp, err := a.Do(ctx, req)
+	if err != nil {
+		a.Debugf("accountRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*accountResponse](resp)
+}
+
+// ****************************** Websocket Api *******************************
+
+type WsApiAccount interface {
+	binance.WsApi[*WsApiAccountResponse]
+	Account
+}
+type WsApiAccountResponse struct {
+	binance.WsApiResponse
+	Result *accountResponse `json:"result"`
+}
+
+// NewWsApiAccount 账户信息
+func NewWsApiAccount(c *binance.Client) WsApiAccount {
+	return &accountRequest{Client: c}
+}
+
+// Send 账户信息 (USER_DATA)
+// 返回字段与 REST 账户信息V2 一致
+func (a *accountRequest) Send(ctx context.Context) (*WsApiAccountResponse, error) {
+	req := &binance.Request{Path: "v2/account.status"}
+	req.SetNeedSign(true)
+	return binance.WsApiHandler[*WsApiAccountResponse](ctx, a.Client, req)
+}
@@ -0,0 +1,53 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+)
+
+// AdlQuantile 持仓ADL队列估算
+type AdlQuantile interface {
+	SetSymbol(symbol string) *adlQuantileRequest
+	Call(ctx context.Context) (body []*adlQuantileResponse, err error)
+}
+
+type adlQuantileRequest struct {
+	*binance.Client
+	symbol *string
+}
+type adlQuantileResponse struct {
+	Symbol string `json:"symbol"`
+	// 队列分数 0 到 4，分数越高越可能被自动减仓
+	// 单向持仓模式下只有 BOTH；双向持仓模式下为 LONG、SHORT，以及同时持有多空仓位时的 HEDGE(仅作标识)
+	AdlQuantile map[enums.PositionSideType]int `json:"adlQuantile"`
+}
+
+func NewAdlQuantile(client *binance.Client) AdlQuantile {
+	return &adlQuantileRequest{Client: client}
+}
+
+func (a *adlQuantileRequest) SetSymbol(symbol string) *adlQuantileRequest {
+	a.symbol = &symbol
+	return a
+}
+
+// Call 持仓ADL队列估算 (USER_DATA)
+// 每30秒更新数据
+func (a *adlQuantileRequest) Call(ctx context.Context) (body []*adlQuantileResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.FApiAdlQuantile,
+	}
+	req.SetNeedSign(true)
+	req.SetOptionalParam("symbol", a.symbol)
+	resp, err := a.Do(ctx, req)
+	if err != nil {
+		a.Debugf("adlQuantileRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*adlQuantileResponse](resp)
+}
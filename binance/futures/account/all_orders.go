@@ -7,7 +7,7 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/futures/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type AllOrders interface {
@@ -63,6 +63,31 @@ type allOrdersResponse struct {
 	GoodTillDate            int                    `json:"goodTillDate"`            //订单TIF为GTD时的自动取消时间
 }
 
+func (a allOrdersResponse) AvgPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.AvgPrice)
+}
+func (a allOrdersResponse) CumQuoteDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.CumQuote)
+}
+func (a allOrdersResponse) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.ExecutedQty)
+}
+func (a allOrdersResponse) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.OrigQty)
+}
+func (a allOrdersResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.Price)
+}
+func (a allOrdersResponse) StopPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.StopPrice)
+}
+func (a allOrdersResponse) ActivatePriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.ActivatePrice)
+}
+func (a allOrdersResponse) PriceRateDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.PriceRate)
+}
+
 // NewAllOrders 账户订单历史 (USER_DATA)
 func NewAllOrders(client *binance.Client, symbol string, limit enums.LimitType) AllOrders {
 	return &allOrdersRequest{Client: client, symbol: symbol, limit: limit}
@@ -108,7 +133,7 @@ func (o *allOrdersRequest) Call(ctx context.Context) (body []*allOrdersResponse,
 		o.Debugf("allOrdersRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*allOrdersResponse](resp)
+	return binance.ParseHttpResponse[[]*allOrdersResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
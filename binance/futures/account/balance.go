@@ -0,0 +1,73 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// Balance 账户余额V2
+type Balance interface {
+	Call(ctx context.Context) (body []*balanceResponse, err error)
+}
+
+type balanceRequest struct {
+	*binance.Client
+}
+type balanceResponse struct {
+	AccountAlias       string          `json:"accountAlias"`       // 账户唯一识别码
+	Asset              string          `json:"asset"`              // 资产
+	Balance            decimal.Decimal `json:"balance"`            // 总余额
+	CrossWalletBalance decimal.Decimal `json:"crossWalletBalance"` // 全仓余额
+	CrossUnPnl         decimal.Decimal `json:"crossUnPnl"`         // 全仓持仓未实现盈亏
+	AvailableBalance   decimal.Decimal `json:"availableBalance"`   // 下单可用余额
+	MaxWithdrawAmount  decimal.Decimal `json:"maxWithdrawAmount"`  // 最大可转出余额
+	MarginAvailable    bool            `json:"marginAvailable"`    // 是否可用作联合保证金
+	UpdateTime         int64           `json:"updateTime"`
+}
+
+func NewBalance(client *binance.Client) Balance {
+	return &balanceRequest{Client: client}
+}
+
+// Call 账户余额V2 (USER_DATA)
+func (b *balanceRequest) Call(ctx context.Context) (body []*balanceResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.FApiBalance,
+	}
+	req.SetNeedSign(true)
+	resp, err := b.Do(ctx, req)
+	if err != nil {
+		b.Debugf("balanceRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*balanceResponse](resp)
+}
+
+// ****************************** Websocket Api *******************************
+
+type WsApiBalance interface {
+	binance.WsApi[*WsApiBalanceResponse]
+	Balance
+}
+type WsApiBalanceResponse struct {
+	binance.WsApiResponse
+	Result []*balanceResponse `json:"result"`
+}
+
+// NewWsApiBalance 账户余额
+func NewWsApiBalance(c *binance.Client) WsApiBalance {
+	return &balanceRequest{Client: c}
+}
+
+// Send 账户余额 (USER_DATA)
+// 返回字段与 REST 账户余额V2 一致
+func (b *balanceRequest) Send(ctx context.Context) (*WsApiBalanceResponse, error) {
+	req := &binance.Request{Path: "v2/account.balance"}
+	req.SetNeedSign(true)
+	return binance.WsApiHandler[*WsApiBalanceResponse](ctx, b.Client, req)
+}
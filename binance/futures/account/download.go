@@ -0,0 +1,105 @@
+package account
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+)
+
+// Download 异步下载合约资金流水、订单历史、交易历史
+// 先用 CallCreate 获取下载Id，生成完成后用 CallLink 获取下载链接
+type Download interface {
+	CallCreate(ctx context.Context, startTime, endTime int64) (body *downloadIdResponse, err error)
+	CallLink(ctx context.Context, downloadId string) (body *downloadLinkResponse, err error)
+	Wait(ctx context.Context, downloadId string, interval time.Duration) (body *downloadLinkResponse, err error)
+}
+
+type downloadRequest struct {
+	*binance.Client
+	name   string // 用于日志
+	create string // 获取下载Id的路径
+	link   string // 获取下载链接的路径
+}
+type downloadIdResponse struct {
+	AvgCostTimestampOfLast30d int64  `json:"avgCostTimestampOfLast30d"` // 过去30天平均数据下载时间(毫秒)
+	DownloadId                string `json:"downloadId"`                // 下载Id
+}
+type downloadLinkResponse struct {
+	DownloadId          string                   `json:"downloadId"`          // 下载Id
+	Status              enums.DownloadStatusType `json:"status"`              // completed 或 processing
+	Url                 string                   `json:"url"`                 // 下载链接，processing 时为空
+	Notified            bool                     `json:"notified"`            // 忽略
+	ExpirationTimestamp int64                    `json:"expirationTimestamp"` // 链接有效期，-1 表示还没有生成
+	IsExpired           *bool                    `json:"isExpired"`           // 链接是否已过期，processing 时为空
+}
+
+// NewIncomeDownload 合约资金流水异步下载，请求权重1000，每月最多5次
+func NewIncomeDownload(client *binance.Client) Download {
+	return &downloadRequest{Client: client, name: "income", create: consts.FApiIncomeAsyn, link: consts.FApiIncomeAsynId}
+}
+
+// NewOrderDownload 合约订单历史异步下载，请求权重1000，每月最多10次
+func NewOrderDownload(client *binance.Client) Download {
+	return &downloadRequest{Client: client, name: "order", create: consts.FApiOrderAsyn, link: consts.FApiOrderAsynId}
+}
+
+// NewTradeDownload 合约交易历史异步下载，请求权重1000，每月最多5次
+func NewTradeDownload(client *binance.Client) Download {
+	return &downloadRequest{Client: client, name: "trade", create: consts.FApiTradeAsyn, link: consts.FApiTradeAsynId}
+}
+
+// CallCreate 获取下载Id (USER_DATA)
+// startTime 和 endTime 的最大间隔为1年
+func (d *downloadRequest) CallCreate(ctx context.Context, startTime, endTime int64) (body *downloadIdResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   d.create,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("startTime", startTime)
+	req.SetParam("endTime", endTime)
+	resp, err := d.Do(ctx, req)
+	if err != nil {
+		d.Debugf("%s download CallCreate response err:%v", d.name, err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*downloadIdResponse](resp)
+}
+
+// CallLink 通过下载Id获取下载链接 (USER_DATA)
+// 下载链接有效期为7天
+func (d *downloadRequest) CallLink(ctx context.Context, downloadId string) (body *downloadLinkResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   d.link,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("downloadId", downloadId)
+	resp, err := d.Do(ctx, req)
+	if err != nil {
+		d.Debugf("%s download CallLink response err:%v", d.name, err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*downloadLinkResponse](resp)
+}
+
+// Wait 每隔 interval 查询一次，直到下载链接生成或 ctx 结束
+func (d *downloadRequest) Wait(ctx context.Context, downloadId string, interval time.Duration) (body *downloadLinkResponse, err error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		body, err = d.CallLink(ctx, downloadId)
+		if err != nil || body.Status == enums.DownloadStatusTypeCompleted {
+			return body, err
+		}
+		select {
+		case <-ctx.Done():
+			return body, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package account
+
+import (
+	"cmp"
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+)
+
+// ForceOrders 用户强平单历史
+type ForceOrders interface {
+	Iterate() *binance.Pager[*allOrdersResponse]
+	SetSymbol(symbol string) *forceOrdersRequest
+	SetAutoCloseType(autoCloseType enums.AutoCloseType) *forceOrdersRequest
+	SetStartTime(startTime int64) *forceOrdersRequest
+	SetEndTime(endTime int64) *forceOrdersRequest
+	SetLimit(limit enums.LimitType) *forceOrdersRequest
+	Call(ctx context.Context) (body []*allOrdersResponse, err error)
+}
+
+// 如果没有传 autoCloseType，强平单和ADL减仓单都会被返回。
+// 如果没有传 startTime，只会返回 endTime 之前7天的数据。
+// 仅可查询最近90天的数据。
+type forceOrdersRequest struct {
+	*binance.Client
+	symbol        *string
+	autoCloseType *enums.AutoCloseType //LIQUIDATION: 强平单, ADL: ADL减仓单
+	startTime     *int64
+	endTime       *int64
+	limit         enums.LimitType //返回的结果集数量 默认值:50 最大值:100
+}
+
+func NewForceOrders(client *binance.Client) ForceOrders {
+	return &forceOrdersRequest{Client: client}
+}
+
+func (f *forceOrdersRequest) SetSymbol(symbol string) *forceOrdersRequest {
+	f.symbol = &symbol
+	return f
+}
+func (f *forceOrdersRequest) SetAutoCloseType(autoCloseType enums.AutoCloseType) *forceOrdersRequest {
+	f.autoCloseType = &autoCloseType
+	return f
+}
+func (f *forceOrdersRequest) SetStartTime(startTime int64) *forceOrdersRequest {
+	f.startTime = &startTime
+	return f
+}
+func (f *forceOrdersRequest) SetEndTime(endTime int64) *forceOrdersRequest {
+	f.endTime = &endTime
+	return f
+}
+func (f *forceOrdersRequest) SetLimit(limit enums.LimitType) *forceOrdersRequest {
+	f.limit = limit
+	return f
+}
+
+// Call 用户强平单历史 (USER_DATA)，返回的订单格式与 allOrders 相同
+func (f *forceOrdersRequest) Call(ctx context.Context) (body []*allOrdersResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.FApiForceOrders,
+	}
+	req.SetNeedSign(true)
+	req.SetOptionalParam("symbol", f.symbol)
+	req.SetOptionalParam("autoCloseType", f.autoCloseType)
+	req.SetOptionalParam("startTime", f.startTime)
+	req.SetOptionalParam("endTime", f.endTime)
+	req.SetOptionalParam("limit", f.limit)
+	resp, err := f.Do(ctx, req)
+	if err != nil {
+		f.Debugf("forceOrdersRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*allOrdersResponse](resp)
+}
+
+// Iterate 按 7 天拆分 [startTime, endTime] 按时间遍历强平单，没有设置 startTime 时从 endTime 之前 90 天开始
+func (f *forceOrdersRequest) Iterate() *binance.Pager[*allOrdersResponse] {
+	limit := cmp.Or(f.limit, enums.Limit100)
+	start, end := binance.TimeRange(f.startTime, f.endTime)
+	if f.startTime == nil {
+		start = max(end-90*day, 0)
+	}
+	return binance.NewPager(binance.TimeCursor(start, end, week, int(limit), func(ctx context.Context, start, end int64) ([]*allOrdersResponse, error) {
+		r := *f
+		r.limit, r.startTime, r.endTime = limit, &start, &end
+		return r.Call(ctx)
+	}, func(order *allOrdersResponse) int64 {
+		return order.Time
+	}, func(order *allOrdersResponse) int {
+		return order.OrderId
+	}))
+}
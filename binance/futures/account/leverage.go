@@ -0,0 +1,59 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// Leverage 调整开仓杠杆
+type Leverage interface {
+	SetSymbol(symbol string) *leverageRequest
+	SetLeverage(leverage int) *leverageRequest
+	Call(ctx context.Context) (body *leverageResponse, err error)
+}
+
+type leverageRequest struct {
+	*binance.Client
+	symbol   string
+	leverage int //目标杠杆倍数：1 到 125 整数
+}
+type leverageResponse struct {
+	Leverage         int             `json:"leverage"`         // 杠杆倍数
+	MaxNotionalValue decimal.Decimal `json:"maxNotionalValue"` // 当前杠杆倍数下允许的最大名义价值
+	Symbol           string          `json:"symbol"`           // 交易对
+}
+
+func NewLeverage(client *binance.Client, symbol string, leverage int) Leverage {
+	return &leverageRequest{Client: client, symbol: symbol, leverage: leverage}
+}
+
+func (l *leverageRequest) SetSymbol(symbol string) *leverageRequest {
+	l.symbol = symbol
+	return l
+}
+func (l *leverageRequest) SetLeverage(leverage int) *leverageRequest {
+	l.leverage = leverage
+	return l
+}
+
+// Call 调整开仓杠杆 (TRADE)
+// 调整用户在指定symbol合约的开仓杠杆。
+func (l *leverageRequest) Call(ctx context.Context) (body *leverageResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodPost,
+		Path:   consts.FApiLeverage,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", l.symbol)
+	req.SetParam("leverage", l.leverage)
+	resp, err := l.Do(ctx, req)
+	if err != nil {
+		l.Debugf("leverageRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*leverageResponse](resp)
+}
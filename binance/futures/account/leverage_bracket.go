@@ -0,0 +1,61 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// LeverageBracket 杠杆分层标准
+type LeverageBracket interface {
+	SetSymbol(symbol string) *leverageBracketRequest
+	Call(ctx context.Context) (body []*leverageBracketResponse, err error)
+}
+
+type leverageBracketRequest struct {
+	*binance.Client
+	symbol *string
+}
+type leverageBracketResponse struct {
+	Symbol       string          `json:"symbol"`       // 交易对
+	NotionalCoef decimal.Decimal `json:"notionalCoef"` // 用户bracket相对默认bracket的倍数，仅在和交易对默认不一样时显示
+	Brackets     []struct {
+		Bracket          int             `json:"bracket"`          // 层级
+		InitialLeverage  int             `json:"initialLeverage"`  // 该层允许的最高初始杠杆倍数
+		NotionalCap      decimal.Decimal `json:"notionalCap"`      // 该层对应的名义价值上限
+		NotionalFloor    decimal.Decimal `json:"notionalFloor"`    // 该层对应的名义价值下限
+		MaintMarginRatio decimal.Decimal `json:"maintMarginRatio"` // 该层对应的维持保证金率
+		Cum              decimal.Decimal `json:"cum"`              // 速算数
+	} `json:"brackets"`
+}
+
+func NewLeverageBracket(client *binance.Client) LeverageBracket {
+	return &leverageBracketRequest{Client: client}
+}
+
+func (l *leverageBracketRequest) SetSymbol(symbol string) *leverageBracketRequest {
+	l.symbol = &symbol
+	return l
+}
+
+// Call 杠杆分层标准 (USER_DATA)
+// 查询用户在指定symbol或全部symbol上的杠杆分层标准。
+// 指定 symbol 时接口返回单个对象，这里统一返回数组
+func (l *leverageBracketRequest) Call(ctx context.Context) (body []*leverageBracketResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.FApiLeverageBracket,
+	}
+	req.SetNeedSign(true)
+	req.SetOptionalParam("symbol", l.symbol)
+	resp, err := l.Do(ctx, req)
+	if err != nil {
+		l.Debugf("leverageBracketRequest response err:%v", err)
+		return nil, err
+	}
+	body, err = binance.ParseHttpResponse[binance.ObjectOrArray[*leverageBracketResponse]](resp)
+	return body, err
+}
@@ -0,0 +1,61 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+)
+
+// MarginType 变换逐全仓模式
+type MarginType interface {
+	SetSymbol(symbol string) *marginTypeRequest
+	SetMarginType(marginType enums.MarginType) *marginTypeRequest
+	Call(ctx context.Context) (body *codeResponse, err error)
+}
+
+type marginTypeRequest struct {
+	*binance.Client
+	symbol     string
+	marginType enums.MarginType //保证金模式 ISOLATED(逐仓), CROSSED(全仓)
+}
+
+// codeResponse 只返回执行结果的接口: {"code": 200, "msg": "success"}
+type codeResponse struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+func NewMarginType(client *binance.Client, symbol string, marginType enums.MarginType) MarginType {
+	return &marginTypeRequest{Client: client, symbol: symbol, marginType: marginType}
+}
+
+func (m *marginTypeRequest) SetSymbol(symbol string) *marginTypeRequest {
+	m.symbol = symbol
+	return m
+}
+func (m *marginTypeRequest) SetMarginType(marginType enums.MarginType) *marginTypeRequest {
+	m.marginType = marginType
+	return m
+}
+
+// Call 变换逐全仓模式 (TRADE)
+// 变换用户在指定symbol合约上的保证金模式：逐仓或全仓。
+// 已经是目标模式时返回 -4046，可以用 errors.Is(err, binance.ErrNoNeedToChangeMarginType) 判断。
+func (m *marginTypeRequest) Call(ctx context.Context) (body *codeResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodPost,
+		Path:   consts.FApiMarginType,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", m.symbol)
+	req.SetParam("marginType", m.marginType)
+	resp, err := m.Do(ctx, req)
+	if err != nil {
+		m.Debugf("marginTypeRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*codeResponse](resp)
+}
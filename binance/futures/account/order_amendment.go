@@ -1,23 +1,25 @@
 package account
 
 import (
+	"cmp"
 	"context"
 	"net/http"
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/futures/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 // OrderAmendment 查询订单修改历史
 type OrderAmendment interface {
+	Iterate() *binance.Pager[*orderAmendmentResponse]
 	SetOrderId(orderId int64) *orderAmendmentRequest
 	SetOrigClientOrderId(origClientOrderId string) *orderAmendmentRequest
 	SetSymbol(symbol string) *orderAmendmentRequest
 	SetLimit(limit enums.LimitType) *orderAmendmentRequest
 	SetStartTime(startTime int64) *orderAmendmentRequest
 	SetEndTime(endTime int64) *orderAmendmentRequest
+	Call(ctx context.Context) (body []*orderAmendmentResponse, err error)
 }
 
 type orderAmendmentRequest struct {
@@ -97,7 +99,22 @@ func (o *orderAmendmentRequest) Call(ctx context.Context) (body []*orderAmendmen
 		o.Debugf("orderAmendmentRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*orderAmendmentResponse](resp)
+	return binance.ParseHttpResponse[[]*orderAmendmentResponse](resp)
+}
+
+// Iterate 按修改时间遍历 [startTime, endTime] 内的订单修改历史
+func (o *orderAmendmentRequest) Iterate() *binance.Pager[*orderAmendmentResponse] {
+	start, end := binance.TimeRange(o.startTime, o.endTime)
+	limit := cmp.Or(o.limit, enums.Limit50)
+	return binance.NewPager(binance.TimeCursor(start, end, 0, int(limit), func(ctx context.Context, start, end int64) ([]*orderAmendmentResponse, error) {
+		r := *o
+		r.limit, r.startTime, r.endTime = limit, &start, &end
+		return r.Call(ctx)
+	}, func(a *orderAmendmentResponse) int64 {
+		return a.Time
+	}, func(a *orderAmendmentResponse) int64 {
+		return int64(a.AmendmentId)
+	}))
 }
 
 // ****************************** Websocket Api *******************************
@@ -0,0 +1,196 @@
+package account
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// PnLDay 一个交易对一天内按结算资产汇总的收益，支出为负
+type PnLDay struct {
+	Date        string          // 日期 2006-01-02，按 PnLReport 的时区划分
+	Symbol      string          // 交易对
+	Asset       string          // 结算资产，BNB 抵扣的手续费单独一条
+	RealizedPnl decimal.Decimal // 已实现盈亏
+	Commission  decimal.Decimal // 手续费
+	FundingFee  decimal.Decimal // 资金费用
+}
+
+// Net 已实现盈亏、手续费和资金费用之和
+func (d *PnLDay) Net() decimal.Decimal {
+	return decimal.Sum(d.RealizedPnl, d.Commission, d.FundingFee)
+}
+
+// FundingFeeSummary 一个交易对按结算资产汇总的资金费用
+type FundingFeeSummary struct {
+	Symbol   string
+	Asset    string
+	Received decimal.Decimal // 收到的资金费用
+	Paid     decimal.Decimal // 支付的资金费用，为负数
+	Count    int             // 结算次数
+}
+
+// Net 资金费用净收入
+func (f *FundingFeeSummary) Net() decimal.Decimal {
+	return f.Received.Add(f.Paid)
+}
+
+// PnLReport 根据资金流水和成交历史按交易对、按天汇总已实现盈亏、手续费和资金费用
+//
+// 资金流水中的 REALIZED_PNL、COMMISSION 和成交历史中的 realizedPnl、commission 按成交ID去重，
+// 可以同时加入 NewIncome 和 NewUserTrades 的结果；重复加入同一条流水或成交不会重复计算。
+type PnLReport struct {
+	loc     *time.Location
+	days    map[pnlDayKey]*PnLDay
+	funding map[fundingKey]*FundingFeeSummary
+	seen    map[pnlSeenKey]struct{}
+}
+
+type pnlDayKey struct {
+	date, symbol, asset string
+}
+type fundingKey struct {
+	symbol, asset string
+}
+
+// pnlSeenKey 已经计算过的收益，id 为成交ID，没有成交ID的流水为 tranId
+type pnlSeenKey struct {
+	incomeType enums.IncomeType
+	symbol     string
+	id         string
+}
+
+// NewPnLReport 按 loc 时区划分日期，loc 为空时使用 UTC
+func NewPnLReport(loc *time.Location) *PnLReport {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &PnLReport{
+		loc:     loc,
+		days:    make(map[pnlDayKey]*PnLDay),
+		funding: make(map[fundingKey]*FundingFeeSummary),
+		seen:    make(map[pnlSeenKey]struct{}),
+	}
+}
+
+// AddIncome 加入资金流水，只统计 REALIZED_PNL、COMMISSION 和 FUNDING_FEE
+func (r *PnLReport) AddIncome(incomes ...*incomeResponse) {
+	for _, income := range incomes {
+		id := income.TradeId
+		if id == "" {
+			id = "tran:" + strconv.FormatInt(income.TranId, 10)
+		}
+		switch income.IncomeType {
+		case enums.IncomeTypeRealizedPnl:
+			if r.mark(income.IncomeType, income.Symbol, id) {
+				d := r.day(income.Time, income.Symbol, income.Asset)
+				d.RealizedPnl = d.RealizedPnl.Add(income.Income)
+			}
+		case enums.IncomeTypeCommission:
+			if r.mark(income.IncomeType, income.Symbol, id) {
+				d := r.day(income.Time, income.Symbol, income.Asset)
+				d.Commission = d.Commission.Add(income.Income)
+			}
+		case enums.IncomeTypeFundingFee:
+			if r.mark(income.IncomeType, income.Symbol, id) {
+				d := r.day(income.Time, income.Symbol, income.Asset)
+				d.FundingFee = d.FundingFee.Add(income.Income)
+				f := r.fundingFee(income.Symbol, income.Asset)
+				if income.Income.IsNegative() {
+					f.Paid = f.Paid.Add(income.Income)
+				} else {
+					f.Received = f.Received.Add(income.Income)
+				}
+				f.Count++
+			}
+		}
+	}
+}
+
+// AddTrades 加入成交历史，统计每笔成交的已实现盈亏和手续费
+// 成交历史中没有资金费用，需要资金费用时同时加入 FUNDING_FEE 流水
+func (r *PnLReport) AddTrades(trades ...*userTradesResponse) {
+	for _, trade := range trades {
+		id := strconv.FormatInt(trade.Id, 10)
+		if r.mark(enums.IncomeTypeRealizedPnl, trade.Symbol, id) && !trade.RealizedPnl.IsZero() {
+			d := r.day(trade.Time, trade.Symbol, marginAsset(trade))
+			d.RealizedPnl = d.RealizedPnl.Add(trade.RealizedPnl)
+		}
+		if r.mark(enums.IncomeTypeCommission, trade.Symbol, id) && !trade.Commission.IsZero() {
+			d := r.day(trade.Time, trade.Symbol, trade.CommissionAsset)
+			d.Commission = d.Commission.Sub(trade.Commission)
+		}
+	}
+}
+
+// Days 按日期、交易对、资产排序的每日收益
+func (r *PnLReport) Days() []*PnLDay {
+	days := make([]*PnLDay, 0, len(r.days))
+	for _, d := range r.days {
+		days = append(days, d)
+	}
+	slices.SortFunc(days, func(a, b *PnLDay) int {
+		return cmp.Or(cmp.Compare(a.Date, b.Date), cmp.Compare(a.Symbol, b.Symbol), cmp.Compare(a.Asset, b.Asset))
+	})
+	return days
+}
+
+// FundingFees 按交易对、资产排序的资金费用汇总
+func (r *PnLReport) FundingFees() []*FundingFeeSummary {
+	fees := make([]*FundingFeeSummary, 0, len(r.funding))
+	for _, f := range r.funding {
+		fees = append(fees, f)
+	}
+	slices.SortFunc(fees, func(a, b *FundingFeeSummary) int {
+		return cmp.Or(cmp.Compare(a.Symbol, b.Symbol), cmp.Compare(a.Asset, b.Asset))
+	})
+	return fees
+}
+
+// mark 记录已经计算过的收益，第一次出现时返回 true
+func (r *PnLReport) mark(incomeType enums.IncomeType, symbol, id string) bool {
+	key := pnlSeenKey{incomeType: incomeType, symbol: symbol, id: id}
+	if _, ok := r.seen[key]; ok {
+		return false
+	}
+	r.seen[key] = struct{}{}
+	return true
+}
+
+func (r *PnLReport) day(t int64, symbol, asset string) *PnLDay {
+	date := time.UnixMilli(t).In(r.loc).Format(time.DateOnly)
+	key := pnlDayKey{date: date, symbol: symbol, asset: asset}
+	d, ok := r.days[key]
+	if !ok {
+		d = &PnLDay{Date: date, Symbol: symbol, Asset: asset}
+		r.days[key] = d
+	}
+	return d
+}
+
+func (r *PnLReport) fundingFee(symbol, asset string) *FundingFeeSummary {
+	key := fundingKey{symbol: symbol, asset: asset}
+	f, ok := r.funding[key]
+	if !ok {
+		f = &FundingFeeSummary{Symbol: symbol, Asset: asset}
+		r.funding[key] = f
+	}
+	return f
+}
+
+// marginAsset 成交的结算资产，成交历史中没有返回，按交易对的计价资产推断
+func marginAsset(trade *userTradesResponse) string {
+	// 交割合约为 BTCUSDT_250627
+	pair, _, _ := strings.Cut(trade.Symbol, "_")
+	for _, quote := range []string{"USDT", "USDC", "FDUSD", "BFUSD", "BTC"} {
+		if len(pair) > len(quote) && strings.HasSuffix(pair, quote) {
+			return quote
+		}
+	}
+	return trade.CommissionAsset
+}
@@ -0,0 +1,75 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// PositionMargin 调整逐仓保证金
+type PositionMargin interface {
+	SetSymbol(symbol string) *positionMarginRequest
+	SetPositionSide(positionSide enums.PositionSideType) *positionMarginRequest
+	SetAmount(amount string) *positionMarginRequest
+	SetType(_type enums.PositionMarginType) *positionMarginRequest
+	Call(ctx context.Context) (body *positionMarginResponse, err error)
+}
+
+type positionMarginRequest struct {
+	*binance.Client
+	symbol       string
+	positionSide *enums.PositionSideType  //持仓方向，单向持仓模式下非必填，默认且仅可填BOTH;在双向持仓模式下必填,且仅可选择 LONG 或 SHORT
+	amount       string                   //保证金资金
+	_type        enums.PositionMarginType //调整方向 1: 增加逐仓保证金，2: 减少逐仓保证金
+}
+type positionMarginResponse struct {
+	Amount decimal.Decimal          `json:"amount"`
+	Code   int                      `json:"code"`
+	Msg    string                   `json:"msg"`
+	Type   enums.PositionMarginType `json:"type"`
+}
+
+func NewPositionMargin(client *binance.Client, symbol string, amount string, _type enums.PositionMarginType) PositionMargin {
+	return &positionMarginRequest{Client: client, symbol: symbol, amount: amount, _type: _type}
+}
+
+func (p *positionMarginRequest) SetSymbol(symbol string) *positionMarginRequest {
+	p.symbol = symbol
+	return p
+}
+func (p *positionMarginRequest) SetPositionSide(positionSide enums.PositionSideType) *positionMarginRequest {
+	p.positionSide = &positionSide
+	return p
+}
+func (p *positionMarginRequest) SetAmount(amount string) *positionMarginRequest {
+	p.amount = amount
+	return p
+}
+func (p *positionMarginRequest) SetType(_type enums.PositionMarginType) *positionMarginRequest {
+	p._type = _type
+	return p
+}
+
+// Call 调整逐仓保证金 (TRADE)
+// 针对逐仓模式下的仓位，调整其逐仓保证金资金。只能在逐仓模式下使用。
+func (p *positionMarginRequest) Call(ctx context.Context) (body *positionMarginResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodPost,
+		Path:   consts.FApiPositionMargin,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", p.symbol)
+	req.SetOptionalParam("positionSide", p.positionSide)
+	req.SetParam("amount", p.amount)
+	req.SetParam("type", p._type)
+	resp, err := p.Do(ctx, req)
+	if err != nil {
+		p.Debugf("positionMarginRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*positionMarginResponse](resp)
+}
@@ -0,0 +1,98 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+)
+
+// PositionMode 持仓模式和联合保证金模式
+type PositionMode interface {
+	CallDualSidePosition(ctx context.Context) (body *dualSidePositionResponse, err error)
+	CallChangeDualSidePosition(ctx context.Context, dualSidePosition bool) (body *codeResponse, err error)
+	CallMultiAssetsMargin(ctx context.Context) (body *multiAssetsMarginResponse, err error)
+	CallChangeMultiAssetsMargin(ctx context.Context, multiAssetsMargin bool) (body *codeResponse, err error)
+}
+
+type positionModeRequest struct {
+	*binance.Client
+}
+type dualSidePositionResponse struct {
+	DualSidePosition bool `json:"dualSidePosition"` // "true": 双向持仓模式；"false": 单向持仓模式
+}
+type multiAssetsMarginResponse struct {
+	MultiAssetsMargin bool `json:"multiAssetsMargin"` // "true": 联合保证金模式开启；"false": 联合保证金模式关闭
+}
+
+func NewPositionMode(client *binance.Client) PositionMode {
+	return &positionModeRequest{Client: client}
+}
+
+// CallDualSidePosition 查询持仓模式 (USER_DATA)
+// 查询用户目前在所有symbol合约上的持仓模式：双向持仓或单向持仓。
+func (p *positionModeRequest) CallDualSidePosition(ctx context.Context) (body *dualSidePositionResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.FApiPositionSideDual,
+	}
+	req.SetNeedSign(true)
+	resp, err := p.Do(ctx, req)
+	if err != nil {
+		p.Debugf("CallDualSidePosition response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*dualSidePositionResponse](resp)
+}
+
+// CallChangeDualSidePosition 更改持仓模式 (TRADE)
+// 变换用户在所有symbol合约上的持仓模式：双向持仓或单向持仓。有持仓或挂单时不能更改。
+// 已经是目标模式时返回 -4059，可以用 errors.Is(err, binance.ErrNoNeedToChangePositionSide) 判断。
+func (p *positionModeRequest) CallChangeDualSidePosition(ctx context.Context, dualSidePosition bool) (body *codeResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodPost,
+		Path:   consts.FApiPositionSideDual,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("dualSidePosition", dualSidePosition)
+	resp, err := p.Do(ctx, req)
+	if err != nil {
+		p.Debugf("CallChangeDualSidePosition response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*codeResponse](resp)
+}
+
+// CallMultiAssetsMargin 查询联合保证金模式 (USER_DATA)
+// 查询用户目前在所有symbol合约上的联合保证金模式。
+func (p *positionModeRequest) CallMultiAssetsMargin(ctx context.Context) (body *multiAssetsMarginResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.FApiMultiAssetsMargin,
+	}
+	req.SetNeedSign(true)
+	resp, err := p.Do(ctx, req)
+	if err != nil {
+		p.Debugf("CallMultiAssetsMargin response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*multiAssetsMarginResponse](resp)
+}
+
+// CallChangeMultiAssetsMargin 更改联合保证金模式 (TRADE)
+// 变换用户在所有symbol合约上的联合保证金模式：开启或关闭联合保证金模式。
+func (p *positionModeRequest) CallChangeMultiAssetsMargin(ctx context.Context, multiAssetsMargin bool) (body *codeResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodPost,
+		Path:   consts.FApiMultiAssetsMargin,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("multiAssetsMargin", multiAssetsMargin)
+	resp, err := p.Do(ctx, req)
+	if err != nil {
+		p.Debugf("CallChangeMultiAssetsMargin response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*codeResponse](resp)
+}
@@ -0,0 +1,92 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// PositionRisk 用户持仓风险V2
+type PositionRisk interface {
+	SetSymbol(symbol string) *positionRiskRequest
+	Call(ctx context.Context) (body []*positionRiskResponse, err error)
+}
+
+type positionRiskRequest struct {
+	*binance.Client
+	symbol *string
+}
+type positionRiskResponse struct {
+	Symbol           string                 `json:"symbol"`           // 交易对
+	PositionAmt      decimal.Decimal        `json:"positionAmt"`      // 头寸数量，符号代表多空方向, 正数为多，负数为空
+	EntryPrice       decimal.Decimal        `json:"entryPrice"`       // 开仓均价
+	BreakEvenPrice   decimal.Decimal        `json:"breakEvenPrice"`   // 盈亏平衡价
+	MarkPrice        decimal.Decimal        `json:"markPrice"`        // 当前标记价格
+	UnRealizedProfit decimal.Decimal        `json:"unRealizedProfit"` // 持仓未实现盈亏
+	LiquidationPrice decimal.Decimal        `json:"liquidationPrice"` // 参考强平价格
+	Leverage         decimal.Decimal        `json:"leverage"`         // 当前杠杆倍数
+	MaxNotionalValue decimal.Decimal        `json:"maxNotionalValue"` // 当前杠杆倍数允许的名义价值上限
+	MarginType       string                 `json:"marginType"`       // 逐仓模式或全仓模式: isolated, cross
+	IsolatedMargin   decimal.Decimal        `json:"isolatedMargin"`   // 逐仓保证金
+	IsAutoAddMargin  string                 `json:"isAutoAddMargin"`  // 是否自动追加保证金: "true", "false"
+	PositionSide     enums.PositionSideType `json:"positionSide"`     // 持仓方向
+	Notional         decimal.Decimal        `json:"notional"`         // 名义价值
+	IsolatedWallet   decimal.Decimal        `json:"isolatedWallet"`   // 逐仓钱包余额
+	UpdateTime       int64                  `json:"updateTime"`       // 更新时间
+}
+
+func NewPositionRisk(client *binance.Client) PositionRisk {
+	return &positionRiskRequest{Client: client}
+}
+
+func (p *positionRiskRequest) SetSymbol(symbol string) *positionRiskRequest {
+	p.symbol = &symbol
+	return p
+}
+
+// Call 用户持仓风险V2 (USER_DATA)
+// 请与账户推送信息ACCOUNT_UPDATE配合使用，以满足您的及时性和准确性需求。
+// 不传 symbol 时返回所有交易对；单向持仓模式下只返回 BOTH，双向持仓模式下返回 LONG 和 SHORT。
+func (p *positionRiskRequest) Call(ctx context.Context) (body []*positionRiskResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.FApiPositionRisk,
+	}
+	req.SetNeedSign(true)
+	req.SetOptionalParam("symbol", p.symbol)
+	resp, err := p.Do(ctx, req)
+	if err != nil {
+		p.Debugf("positionRiskRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*positionRiskResponse](resp)
+}
+
+// ****************************** Websocket Api *******************************
+
+type WsApiPositionRisk interface {
+	binance.WsApi[*WsApiPositionRiskResponse]
+	PositionRisk
+}
+type WsApiPositionRiskResponse struct {
+	binance.WsApiResponse
+	Result []*positionRiskResponse `json:"result"`
+}
+
+// NewWsApiPositionRisk 持仓信息
+func NewWsApiPositionRisk(c *binance.Client) WsApiPositionRisk {
+	return &positionRiskRequest{Client: c}
+}
+
+// Send 持仓信息 (USER_DATA)
+// 返回字段与 REST 用户持仓风险V2 一致
+func (p *positionRiskRequest) Send(ctx context.Context) (*WsApiPositionRiskResponse, error) {
+	req := &binance.Request{Path: "v2/account.position"}
+	req.SetNeedSign(true)
+	req.SetOptionalParam("symbol", p.symbol)
+	return binance.WsApiHandler[*WsApiPositionRiskResponse](ctx, p.Client, req)
+}
@@ -0,0 +1,260 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+	"github.com/tidwall/gjson"
+)
+
+// ****************************** Websocket Stream *******************************
+
+// WsAccountUpdateEvent Balance和Position更新推送
+// 账户信息或持仓发生变化时推送，只推送有变动的资产和持仓；
+// 资金费用产生时只推送相关资产余额，不推送持仓(全仓持仓的情况下)
+type WsAccountUpdateEvent struct {
+	Event           enums.UserDataEventType `json:"e"` // 事件类型
+	Time            int64                   `json:"E"` // 事件时间
+	TransactionTime int64                   `json:"T"` // 撮合时间
+	Account         struct {
+		Reason   string `json:"m"` // 事件推出原因 DEPOSIT/WITHDRAW/ORDER/FUNDING_FEE/...
+		Balances []struct {
+			Asset              string          `json:"a"`  // 资产名称
+			WalletBalance      decimal.Decimal `json:"wb"` // 钱包余额
+			CrossWalletBalance decimal.Decimal `json:"cw"` // 除去逐仓仓位保证金的钱包余额
+			BalanceChange      decimal.Decimal `json:"bc"` // 除去盈亏与交易手续费以外的钱包余额改变量
+		} `json:"B"`
+		Positions []struct {
+			Symbol              string                 `json:"s"`   // 交易对
+			PositionAmount      decimal.Decimal        `json:"pa"`  // 仓位
+			EntryPrice          decimal.Decimal        `json:"ep"`  // 入仓价格
+			BreakEvenPrice      decimal.Decimal        `json:"bep"` // 盈亏平衡价
+			AccumulatedRealized decimal.Decimal        `json:"cr"`  // (费前)累计实现损益
+			UnrealizedProfit    decimal.Decimal        `json:"up"`  // 持仓未实现盈亏
+			MarginType          string                 `json:"mt"`  // 保证金模式 isolated/cross
+			IsolatedWallet      decimal.Decimal        `json:"iw"`  // 若为逐仓，仓位保证金
+			PositionSide        enums.PositionSideType `json:"ps"`  // 持仓方向
+		} `json:"P"`
+	} `json:"a"`
+}
+
+// WsOrderTradeUpdateEvent 订单/交易更新推送
+// 当有新订单创建、订单有新成交或者新的状态变化时推送
+type WsOrderTradeUpdateEvent struct {
+	Event           enums.UserDataEventType `json:"e"` // 事件类型
+	Time            int64                   `json:"E"` // 事件时间
+	TransactionTime int64                   `json:"T"` // 撮合时间
+	Order           struct {
+		Symbol                  string                 `json:"s"`   // 交易对
+		ClientOrderId           string                 `json:"c"`   // 客户端自定订单ID
+		Side                    enums.SideType         `json:"S"`   // 订单方向
+		Type                    enums.OrderType        `json:"o"`   // 订单类型
+		TimeInForce             enums.TimeInForceType  `json:"f"`   // 有效方式
+		OrigQty                 decimal.Decimal        `json:"q"`   // 订单原始数量
+		Price                   decimal.Decimal        `json:"p"`   // 订单原始价格
+		AvgPrice                decimal.Decimal        `json:"ap"`  // 订单平均价格
+		StopPrice               decimal.Decimal        `json:"sp"`  // 条件订单触发价格，对追踪止损单无效
+		ExecutionType           string                 `json:"x"`   // 本次事件的具体执行类型 NEW/CANCELED/CALCULATED/EXPIRED/TRADE/AMENDMENT
+		Status                  enums.StatusType       `json:"X"`   // 订单的当前状态
+		OrderId                 int64                  `json:"i"`   // 订单ID
+		LastFilledQty           decimal.Decimal        `json:"l"`   // 订单末次成交量
+		FilledQty               decimal.Decimal        `json:"z"`   // 订单累计已成交量
+		LastFilledPrice         decimal.Decimal        `json:"L"`   // 订单末次成交价格
+		CommissionAsset         string                 `json:"N"`   // 手续费资产类型
+		Commission              decimal.Decimal        `json:"n"`   // 手续费数量
+		TradeTime               int64                  `json:"T"`   // 成交时间
+		TradeId                 int64                  `json:"t"`   // 成交ID
+		BidsNotional            decimal.Decimal        `json:"b"`   // 买单净值
+		AsksNotional            decimal.Decimal        `json:"a"`   // 卖单净值
+		IsMaker                 bool                   `json:"m"`   // 该成交是作为挂单成交吗？
+		ReduceOnly              bool                   `json:"R"`   // 是否是只减仓单
+		WorkingType             enums.WorkingType      `json:"wt"`  // 触发价类型
+		OrigType                enums.OrderType        `json:"ot"`  // 原始订单类型
+		PositionSide            enums.PositionSideType `json:"ps"`  // 持仓方向
+		ClosePosition           bool                   `json:"cp"`  // 是否为触发平仓单; 仅在条件订单情况下会推送此字段
+		ActivatePrice           decimal.Decimal        `json:"AP"`  // 追踪止损激活价格, 仅在追踪止损单时会推送此字段
+		PriceRate               decimal.Decimal        `json:"cr"`  // 追踪止损回调比例, 仅在追踪止损单时会推送此字段
+		PriceProtect            bool                   `json:"pP"`  // 是否开启条件单触发保护
+		RealizedProfit          decimal.Decimal        `json:"rp"`  // 该交易实现盈亏
+		SelfTradePreventionMode enums.StpModeType      `json:"V"`   // 自成交防止模式
+		PriceMatch              enums.PriceMatchType   `json:"pm"`  // 价格匹配模式
+		GoodTillDate            int64                  `json:"gtd"` // TIF为GTD的订单自动取消时间
+	} `json:"o"`
+}
+
+// WsTradeLiteEvent 精简交易推送
+// 仅在有成交时推送，比 ORDER_TRADE_UPDATE 延迟更低
+type WsTradeLiteEvent struct {
+	Event           enums.UserDataEventType `json:"e"` // 事件类型
+	Time            int64                   `json:"E"` // 事件时间
+	TransactionTime int64                   `json:"T"` // 交易时间
+	Symbol          string                  `json:"s"` // 交易对
+	OrigQty         decimal.Decimal         `json:"q"` // 订单原始数量
+	Price           decimal.Decimal         `json:"p"` // 订单原始价格
+	IsMaker         bool                    `json:"m"` // 该成交是作为挂单成交吗？
+	ClientOrderId   string                  `json:"c"` // 客户端自定订单ID
+	Side            enums.SideType          `json:"S"` // 订单方向
+	LastFilledPrice decimal.Decimal         `json:"L"` // 订单末次成交价格
+	LastFilledQty   decimal.Decimal         `json:"l"` // 订单末次成交量
+	TradeId         int64                   `json:"t"` // 成交ID
+	OrderId         int64                   `json:"i"` // 订单ID
+}
+
+// WsMarginCallEvent 追加保证金通知
+// 用户持仓风险过高时推送，仅作为风险指导信息，不建议用于投资策略
+type WsMarginCallEvent struct {
+	Event              enums.UserDataEventType `json:"e"`  // 事件类型
+	Time               int64                   `json:"E"`  // 事件时间
+	CrossWalletBalance decimal.Decimal         `json:"cw"` // 除去逐仓仓位保证金的钱包余额, 仅在全仓 margin call 情况下推送此字段
+	Positions          []struct {
+		Symbol            string                 `json:"s"`  // 交易对
+		PositionSide      enums.PositionSideType `json:"ps"` // 持仓方向
+		PositionAmount    decimal.Decimal        `json:"pa"` // 仓位
+		MarginType        string                 `json:"mt"` // 保证金模式 CROSSED/ISOLATED
+		IsolatedWallet    decimal.Decimal        `json:"iw"` // 若为逐仓，仓位保证金
+		MarkPrice         decimal.Decimal        `json:"mp"` // 标记价格
+		UnrealizedProfit  decimal.Decimal        `json:"up"` // 未实现盈亏
+		MaintenanceMargin decimal.Decimal        `json:"mm"` // 持仓需要的维持保证金
+	} `json:"p"`
+}
+
+// WsAccountConfigUpdateEvent 杠杆倍数等账户配置更新推送
+// 交易对杠杆倍数变化时推送 ac，联合保证金状态变化时推送 ai
+type WsAccountConfigUpdateEvent struct {
+	Event           enums.UserDataEventType `json:"e"` // 事件类型
+	Time            int64                   `json:"E"` // 事件时间
+	TransactionTime int64                   `json:"T"` // 撮合时间
+	AccountConfig   *struct {
+		Symbol   string `json:"s"` // 交易对
+		Leverage int    `json:"l"` // 杠杆倍数
+	} `json:"ac"`
+	AccountInfo *struct {
+		MultiAssetsMode bool `json:"j"` // 联合保证金状态
+	} `json:"ai"`
+}
+
+// WsStrategyUpdateEvent 策略交易更新推送
+type WsStrategyUpdateEvent struct {
+	Event           enums.UserDataEventType `json:"e"` // 事件类型
+	Time            int64                   `json:"E"` // 事件时间
+	TransactionTime int64                   `json:"T"` // 撮合时间
+	StrategyUpdate  struct {
+		StrategyId     int64  `json:"si"` // 策略 ID
+		StrategyType   string `json:"st"` // 策略类型
+		StrategyStatus string `json:"ss"` // 策略状态 NEW/WORKING/CANCELLED/EXPIRED
+		Symbol         string `json:"s"`  // 交易对
+		UpdateTime     int64  `json:"ut"` // 更新时间
+		OpCode         int    `json:"c"`  // 操作代码
+	} `json:"su"`
+}
+
+// WsGridUpdateEvent 网格更新推送
+// 子订单成交时推送
+type WsGridUpdateEvent struct {
+	Event           enums.UserDataEventType `json:"e"` // 事件类型
+	Time            int64                   `json:"E"` // 事件时间
+	TransactionTime int64                   `json:"T"` // 撮合时间
+	GridUpdate      struct {
+		StrategyId     int64           `json:"si"` // 策略 ID
+		StrategyType   string          `json:"st"` // 策略类型
+		StrategyStatus string          `json:"ss"` // 策略状态
+		Symbol         string          `json:"s"`  // 交易对
+		RealizedPnl    decimal.Decimal `json:"r"`  // 已实现 PNL
+		UnmatchedAvg   decimal.Decimal `json:"up"` // 未配对均价
+		UnmatchedQty   decimal.Decimal `json:"uq"` // 未配对数量
+		UnmatchedFee   decimal.Decimal `json:"uf"` // 未配对手续费
+		MatchedPnl     decimal.Decimal `json:"mp"` // 已配对 PNL
+		UpdateTime     int64           `json:"ut"` // 更新时间
+	} `json:"gu"`
+}
+
+// WsConditionalOrderTriggerRejectEvent 条件订单触发后被拒绝推送
+type WsConditionalOrderTriggerRejectEvent struct {
+	Event           enums.UserDataEventType `json:"e"` // 事件类型
+	Time            int64                   `json:"E"` // 事件时间
+	TransactionTime int64                   `json:"T"` // 撮合时间
+	OrderReject     struct {
+		Symbol       string `json:"s"` // 交易对
+		OrderId      int64  `json:"i"` // 订单ID
+		RejectReason string `json:"r"` // 拒绝原因
+	} `json:"or"`
+}
+
+// WsListenKeyExpiredEvent listenKey 过期推送
+// 收到后需要重新生成 listenKey 并订阅
+type WsListenKeyExpiredEvent struct {
+	Event     enums.UserDataEventType `json:"e"`         // 事件类型
+	Time      json.Number             `json:"E"`         // 事件时间，该推送中可能为字符串
+	ListenKey string                  `json:"listenKey"` // 过期的 listenKey
+}
+
+// UserDataHandlers 用户数据推送回调，为空的回调对应的事件会被忽略
+type UserDataHandlers struct {
+	AccountUpdate                 binance.Handler[*WsAccountUpdateEvent]
+	OrderTradeUpdate              binance.Handler[*WsOrderTradeUpdateEvent]
+	TradeLite                     binance.Handler[*WsTradeLiteEvent]
+	MarginCall                    binance.Handler[*WsMarginCallEvent]
+	AccountConfigUpdate           binance.Handler[*WsAccountConfigUpdateEvent]
+	StrategyUpdate                binance.Handler[*WsStrategyUpdateEvent]
+	GridUpdate                    binance.Handler[*WsGridUpdateEvent]
+	ConditionalOrderTriggerReject binance.Handler[*WsConditionalOrderTriggerRejectEvent]
+	ListenKeyExpired              binance.Handler[*WsListenKeyExpiredEvent]
+}
+
+// NewWsUserData U本位合约用户数据流
+// listenKey 由 stream.NewUserDataStream 或 stream.NewKeepAlive 生成，c 为合约 websocket 客户端
+func NewWsUserData(ctx context.Context, c *binance.Client, listenKey string, handlers UserDataHandlers, exception binance.ErrorHandler) (*binance.WsStream, error) {
+	h := func(mt int, msg []byte) {
+		handlers.dispatch(mt, msg, exception)
+	}
+	return c.Serve(ctx, c.BaseURL+listenKey, h, exception)
+}
+
+// NewStreamUserData U本位合约用户数据流，组合流格式
+func NewStreamUserData(ctx context.Context, c *binance.Client, listenKey string, handlers UserDataHandlers, exception binance.ErrorHandler) (*binance.WsStream, error) {
+	h := func(mt int, msg []byte) {
+		data := gjson.GetBytes(msg, "data").Raw
+		handlers.dispatch(mt, []byte(data), exception)
+	}
+	return c.Serve(ctx, c.BaseURL+listenKey, h, exception)
+}
+
+// dispatch 按事件类型解析并回调
+func (h UserDataHandlers) dispatch(mt int, msg []byte, exception binance.ErrorHandler) {
+	switch enums.UserDataEventType(gjson.GetBytes(msg, "e").String()) {
+	case enums.UserDataEventTypeAccountUpdate:
+		handle(mt, msg, h.AccountUpdate, exception)
+	case enums.UserDataEventTypeOrderTradeUpdate:
+		handle(mt, msg, h.OrderTradeUpdate, exception)
+	case enums.UserDataEventTypeTradeLite:
+		handle(mt, msg, h.TradeLite, exception)
+	case enums.UserDataEventTypeMarginCall:
+		handle(mt, msg, h.MarginCall, exception)
+	case enums.UserDataEventTypeAccountConfigUpdate:
+		handle(mt, msg, h.AccountConfigUpdate, exception)
+	case enums.UserDataEventTypeStrategyUpdate:
+		handle(mt, msg, h.StrategyUpdate, exception)
+	case enums.UserDataEventTypeGridUpdate:
+		handle(mt, msg, h.GridUpdate, exception)
+	case enums.UserDataEventTypeConditionalOrderTriggerReject:
+		handle(mt, msg, h.ConditionalOrderTriggerReject, exception)
+	case enums.UserDataEventTypeListenKeyExpired:
+		handle(mt, msg, h.ListenKeyExpired, exception)
+	}
+}
+
+func handle[T any](mt int, msg []byte, handler binance.Handler[*T], exception binance.ErrorHandler) {
+	if handler == nil {
+		return
+	}
+	event := new(T)
+	err := json.Unmarshal(msg, event)
+	if err != nil {
+		exception(mt, err)
+		return
+	}
+	handler(event)
+}
@@ -0,0 +1,137 @@
+package account
+
+import (
+	"cmp"
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+const (
+	day  = 24 * 60 * 60 * 1000
+	week = 7 * day // userTrades、forceOrders 的 startTime 和 endTime 最多相差 7 天
+)
+
+// UserTrades 账户成交历史
+type UserTrades interface {
+	Iterate() *binance.Pager[*userTradesResponse]
+	SetSymbol(symbol string) *userTradesRequest
+	SetOrderId(orderId int64) *userTradesRequest
+	SetStartTime(startTime int64) *userTradesRequest
+	SetEndTime(endTime int64) *userTradesRequest
+	SetFromId(fromId int64) *userTradesRequest
+	SetLimit(limit enums.LimitType) *userTradesRequest
+	Call(ctx context.Context) (body []*userTradesResponse, err error)
+}
+
+// 如果startTime 和 endTime 均未发送, 只会返回最近7天的数据。
+// startTime 和 endTime 的最大间隔为7天。
+// 不支持同时传入 fromId 与 startTime/endTime。
+// 仅支持查询最近6个月的数据。
+type userTradesRequest struct {
+	*binance.Client
+	symbol    string
+	orderId   *int64 //必须要和参数symbol一起使用
+	startTime *int64
+	endTime   *int64
+	fromId    *int64          //返回该fromId及之后的成交，缺省返回最近的成交
+	limit     enums.LimitType //返回的结果集数量 默认值:500 最大值:1000
+}
+type userTradesResponse struct {
+	Buyer           bool                   `json:"buyer"`           // 是否是买方
+	Commission      decimal.Decimal        `json:"commission"`      // 手续费
+	CommissionAsset string                 `json:"commissionAsset"` // 手续费计价单位
+	Id              int64                  `json:"id"`              // 交易ID
+	Maker           bool                   `json:"maker"`           // 是否是挂单方
+	OrderId         int64                  `json:"orderId"`         // 订单编号
+	Price           decimal.Decimal        `json:"price"`           // 成交价
+	Qty             decimal.Decimal        `json:"qty"`             // 成交量
+	QuoteQty        decimal.Decimal        `json:"quoteQty"`        // 成交额
+	RealizedPnl     decimal.Decimal        `json:"realizedPnl"`     // 实现盈亏
+	Side            enums.SideType         `json:"side"`            // 买卖方向
+	PositionSide    enums.PositionSideType `json:"positionSide"`    // 持仓方向
+	Symbol          string                 `json:"symbol"`          // 交易对
+	Time            int64                  `json:"time"`            // 时间
+}
+
+func NewUserTrades(client *binance.Client, symbol string) UserTrades {
+	return &userTradesRequest{Client: client, symbol: symbol}
+}
+
+func (u *userTradesRequest) SetSymbol(symbol string) *userTradesRequest {
+	u.symbol = symbol
+	return u
+}
+func (u *userTradesRequest) SetOrderId(orderId int64) *userTradesRequest {
+	u.orderId = &orderId
+	return u
+}
+func (u *userTradesRequest) SetStartTime(startTime int64) *userTradesRequest {
+	u.startTime = &startTime
+	return u
+}
+func (u *userTradesRequest) SetEndTime(endTime int64) *userTradesRequest {
+	u.endTime = &endTime
+	return u
+}
+func (u *userTradesRequest) SetFromId(fromId int64) *userTradesRequest {
+	u.fromId = &fromId
+	return u
+}
+func (u *userTradesRequest) SetLimit(limit enums.LimitType) *userTradesRequest {
+	u.limit = limit
+	return u
+}
+
+// Call 账户成交历史 (USER_DATA)
+func (u *userTradesRequest) Call(ctx context.Context) (body []*userTradesResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.FApiUserTrades,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", u.symbol)
+	req.SetOptionalParam("orderId", u.orderId)
+	req.SetOptionalParam("startTime", u.startTime)
+	req.SetOptionalParam("endTime", u.endTime)
+	req.SetOptionalParam("fromId", u.fromId)
+	req.SetOptionalParam("limit", u.limit)
+	resp, err := u.Do(ctx, req)
+	if err != nil {
+		u.Debugf("userTradesRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*userTradesResponse](resp)
+}
+
+// Iterate 遍历成交历史
+// 设置了 fromId 时从 fromId 开始按 id 翻页直到 endTime，否则按 7 天拆分 [startTime, endTime] 按时间翻页，
+// 没有设置 startTime 时从 endTime 之前 180 天开始
+func (u *userTradesRequest) Iterate() *binance.Pager[*userTradesResponse] {
+	limit := cmp.Or(u.limit, enums.Limit500)
+	start, end := binance.TimeRange(u.startTime, u.endTime)
+	if u.startTime == nil {
+		start = max(end-180*day, 0)
+	}
+	tradeId := func(trade *userTradesResponse) int64 { return trade.Id }
+	if u.fromId != nil {
+		return binance.NewPager(binance.IdCursor(int(limit), u.fromId, func(ctx context.Context, fromId *int64) ([]*userTradesResponse, error) {
+			r := *u
+			r.limit, r.fromId, r.startTime, r.endTime = limit, fromId, nil, nil
+			return r.Call(ctx)
+		}, tradeId, func(trade *userTradesResponse) bool {
+			return trade.Time > end
+		}))
+	}
+	return binance.NewPager(binance.TimeCursor(start, end, week, int(limit), func(ctx context.Context, start, end int64) ([]*userTradesResponse, error) {
+		r := *u
+		r.limit, r.startTime, r.endTime = limit, &start, &end
+		return r.Call(ctx)
+	}, func(trade *userTradesResponse) int64 {
+		return trade.Time
+	}, tradeId))
+}
@@ -15,6 +15,12 @@ type (
 	PriceMatchType     string //盘口价下单模式
 	RateLimitType      string //限制种类 (RateLimitType)
 	LimitType          int
+	UserDataEventType  string //用户数据推送事件类型
+	MarginType         string //保证金模式
+	PositionMarginType int    //调整逐仓保证金方向
+	IncomeType         string //收益类型
+	AutoCloseType      string //强平单类型
+	DownloadStatusType string //异步下载状态
 )
 
 // 合约类型 (contractType):
@@ -74,6 +80,56 @@ const (
 	PositionSideTypeShort PositionSideType = "SHORT" //空头(双向持仓下)
 )
 
+// 保证金模式 (marginType)，变换逐全仓模式时使用；持仓信息中返回小写的 isolated、cross
+const (
+	MarginTypeIsolated MarginType = "ISOLATED" //逐仓
+	MarginTypeCrossed  MarginType = "CROSSED"  //全仓
+)
+
+// 调整逐仓保证金方向 (type)
+const (
+	PositionMarginTypeAdd    PositionMarginType = 1 //增加逐仓保证金
+	PositionMarginTypeReduce PositionMarginType = 2 //减少逐仓保证金
+)
+
+// 收益类型 (incomeType)，金额为负时表示支出
+const (
+	IncomeTypeTransfer                  IncomeType = "TRANSFER"                    //转账
+	IncomeTypeWelcomeBonus              IncomeType = "WELCOME_BONUS"               //赠金
+	IncomeTypeRealizedPnl               IncomeType = "REALIZED_PNL"                //已实现盈亏
+	IncomeTypeFundingFee                IncomeType = "FUNDING_FEE"                 //资金费用
+	IncomeTypeCommission                IncomeType = "COMMISSION"                  //手续费
+	IncomeTypeInsuranceClear            IncomeType = "INSURANCE_CLEAR"             //强平
+	IncomeTypeReferralKickback          IncomeType = "REFERRAL_KICKBACK"           //推荐人返佣
+	IncomeTypeCommissionRebate          IncomeType = "COMMISSION_REBATE"           //被推荐人返佣
+	IncomeTypeApiRebate                 IncomeType = "API_REBATE"                  //API佣金回扣
+	IncomeTypeContestReward             IncomeType = "CONTEST_REWARD"              //交易大赛奖金
+	IncomeTypeCrossCollateralTransfer   IncomeType = "CROSS_COLLATERAL_TRANSFER"   //闪兑
+	IncomeTypeOptionsPremiumFee         IncomeType = "OPTIONS_PREMIUM_FEE"         //期权购置手续费
+	IncomeTypeOptionsSettleProfit       IncomeType = "OPTIONS_SETTLE_PROFIT"       //期权行权收益
+	IncomeTypeInternalTransfer          IncomeType = "INTERNAL_TRANSFER"           //内部账户，给朋友转账
+	IncomeTypeAutoExchange              IncomeType = "AUTO_EXCHANGE"               //自动兑换
+	IncomeTypeDeliveredSettlement       IncomeType = "DELIVERED_SETTELMENT"        //下架结算
+	IncomeTypeCoinSwapDeposit           IncomeType = "COIN_SWAP_DEPOSIT"           //闪兑转入
+	IncomeTypeCoinSwapWithdraw          IncomeType = "COIN_SWAP_WITHDRAW"          //闪兑转出
+	IncomeTypePositionLimitIncreaseFee  IncomeType = "POSITION_LIMIT_INCREASE_FEE" //仓位限制上调费用
+	IncomeTypeStrategyUMFuturesTransfer IncomeType = "STRATEGY_UMFUTURES_TRANSFER" //策略交易划转
+	IncomeTypeFeeReturn                 IncomeType = "FEE_RETURN"                  //手续费返还
+	IncomeTypeBFUSDReward               IncomeType = "BFUSD_REWARD"                //BFUSD 奖励
+)
+
+// 强平单类型 (autoCloseType)
+const (
+	AutoCloseTypeLiquidation AutoCloseType = "LIQUIDATION" //强平单
+	AutoCloseTypeADL         AutoCloseType = "ADL"         //ADL减仓单
+)
+
+// 异步下载状态
+const (
+	DownloadStatusTypeProcessing DownloadStatusType = "processing" //生成中
+	DownloadStatusTypeCompleted  DownloadStatusType = "completed"  //已完成，可以下载
+)
+
 // 有效方式 (timeInForce):
 const (
 	timeInForceTypeGTC TimeInForceType = "GTC" //Good Till Cancel 成交为止（下单后仅有1年有效期，1年后自动取消）
@@ -150,6 +206,19 @@ const (
 	RateLimitTypeOrders        RateLimitType = "ORDERS"         //单位时间下单(撤单)次数上限
 )
 
+// 用户数据推送事件类型
+const (
+	UserDataEventTypeAccountUpdate                 UserDataEventType = "ACCOUNT_UPDATE"                   //余额和持仓更新
+	UserDataEventTypeOrderTradeUpdate              UserDataEventType = "ORDER_TRADE_UPDATE"               //订单/交易更新
+	UserDataEventTypeTradeLite                     UserDataEventType = "TRADE_LITE"                       //精简的成交推送
+	UserDataEventTypeMarginCall                    UserDataEventType = "MARGIN_CALL"                      //追加保证金通知
+	UserDataEventTypeAccountConfigUpdate           UserDataEventType = "ACCOUNT_CONFIG_UPDATE"            //杠杆倍数、联合保证金模式更新
+	UserDataEventTypeStrategyUpdate                UserDataEventType = "STRATEGY_UPDATE"                  //策略交易更新
+	UserDataEventTypeGridUpdate                    UserDataEventType = "GRID_UPDATE"                      //网格更新
+	UserDataEventTypeConditionalOrderTriggerReject UserDataEventType = "CONDITIONAL_ORDER_TRIGGER_REJECT" //条件单触发后被拒绝
+	UserDataEventTypeListenKeyExpired              UserDataEventType = "listenKeyExpired"                 //listenKey 过期
+)
+
 // 定义可选的 limit 值的枚举
 // 可选值:[5, 10, 20, 50, 100, 500, 1000, 5000]
 const (
@@ -8,7 +8,8 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/binance/rules"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type ExchangeInfo interface {
@@ -25,15 +26,10 @@ func NewExchangeInfo(client *binance.Client) ExchangeInfo {
 }
 
 type exchangeInfoResponse struct {
-	ExchangeFilters []interface{} `json:"exchangeFilters"`
-	RateLimits      []struct {
-		Interval      string              `json:"interval"`
-		IntervalNum   int                 `json:"intervalNum"`
-		Limit         int                 `json:"limit"`
-		RateLimitType enums.RateLimitType `json:"rateLimitType"`
-	} `json:"rateLimits"`
-	ServerTime int64 `json:"serverTime"`
-	Assets     []struct {
+	ExchangeFilters []interface{}        `json:"exchangeFilters"`
+	RateLimits      []binance.RateLimits `json:"rateLimits"`
+	ServerTime      int64                `json:"serverTime"`
+	Assets          []struct {
 		Asset             string  `json:"asset"`
 		MarginAvailable   bool    `json:"marginAvailable"`
 		AutoAssetExchange *string `json:"autoAssetExchange"`
@@ -59,18 +55,18 @@ type exchangeInfoResponse struct {
 		SettlePlan            int                `json:"settlePlan"`
 		TriggerProtect        string             `json:"triggerProtect"`
 		Filters               []struct {
-			FilterType        string  `json:"filterType"`
-			MaxPrice          string  `json:"maxPrice,omitempty"`
-			MinPrice          string  `json:"minPrice,omitempty"`
-			TickSize          string  `json:"tickSize,omitempty"`
-			MaxQty            string  `json:"maxQty,omitempty"`
-			MinQty            string  `json:"minQty,omitempty"`
-			StepSize          string  `json:"stepSize,omitempty"`
-			Limit             int     `json:"limit,omitempty"`
-			Notional          string  `json:"notional,omitempty"`
-			MultiplierUp      string  `json:"multiplierUp,omitempty"`
-			MultiplierDown    string  `json:"multiplierDown,omitempty"`
-			MultiplierDecimal *string `json:"multiplierDecimal,omitempty"`
+			FilterType        string          `json:"filterType"`
+			MaxPrice          decimal.Decimal `json:"maxPrice,omitempty"`
+			MinPrice          decimal.Decimal `json:"minPrice,omitempty"`
+			TickSize          decimal.Decimal `json:"tickSize,omitempty"`
+			MaxQty            decimal.Decimal `json:"maxQty,omitempty"`
+			MinQty            decimal.Decimal `json:"minQty,omitempty"`
+			StepSize          decimal.Decimal `json:"stepSize,omitempty"`
+			Limit             int             `json:"limit,omitempty"`
+			Notional          decimal.Decimal `json:"notional,omitempty"`
+			MultiplierUp      decimal.Decimal `json:"multiplierUp,omitempty"`
+			MultiplierDown    decimal.Decimal `json:"multiplierDown,omitempty"`
+			MultiplierDecimal *string         `json:"multiplierDecimal,omitempty"`
 		} `json:"filters"`
 		OrderType       []enums.OrderType       `json:"OrderType"`
 		TimeInForce     []enums.TimeInForceType `json:"timeInForce"`
@@ -97,5 +93,64 @@ func (ex *exchangeInfoRequest) Call(ctx context.Context) (body *exchangeInfoResp
 		ex.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*exchangeInfoResponse](resp)
+	return binance.ParseHttpResponse[*exchangeInfoResponse](resp)
+}
+
+// Rules 把交易对的过滤器转换为下单规则
+func (e *exchangeInfoResponse) Rules() []*rules.Symbol {
+	list := make([]*rules.Symbol, 0, len(e.Symbols))
+	for _, symbol := range e.Symbols {
+		s := &rules.Symbol{Symbol: symbol.Symbol}
+		for _, f := range symbol.Filters {
+			switch f.FilterType {
+			case rules.FilterPrice:
+				s.Price = &rules.PriceFilter{MinPrice: f.MinPrice, MaxPrice: f.MaxPrice, TickSize: f.TickSize}
+			case rules.FilterPercentPrice:
+				s.PercentPrice = &rules.PercentPriceFilter{
+					Type:              f.FilterType,
+					BidMultiplierUp:   f.MultiplierUp,
+					BidMultiplierDown: f.MultiplierDown,
+					AskMultiplierUp:   f.MultiplierUp,
+					AskMultiplierDown: f.MultiplierDown,
+				}
+			case rules.FilterLotSize:
+				s.LotSize = &rules.LotSizeFilter{MinQty: f.MinQty, MaxQty: f.MaxQty, StepSize: f.StepSize}
+			case rules.FilterMarketLotSize:
+				s.MarketLotSize = &rules.LotSizeFilter{MinQty: f.MinQty, MaxQty: f.MaxQty, StepSize: f.StepSize}
+			case rules.FilterMinNotional:
+				// 合约的最小名义价值对市价单同样生效
+				s.Notional = &rules.NotionalFilter{Type: f.FilterType, MinNotional: f.Notional, ApplyMinToMarket: true}
+			case rules.FilterMaxNumOrders:
+				s.MaxNumOrders = f.Limit
+			case rules.FilterMaxNumAlgoOrders:
+				s.MaxNumAlgoOrders = f.Limit
+			}
+		}
+		list = append(list, s)
+	}
+	return list
+}
+
+// NewRules 由 exchangeInfo 驱动的交易对规则缓存，用于下单前检查和舍入
+func NewRules(client *binance.Client) *rules.Registry {
+	return rules.NewRegistry(func(ctx context.Context) ([]*rules.Symbol, error) {
+		res, err := NewExchangeInfo(client).Call(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return res.Rules(), nil
+	})
+}
+
+// NewRateLimiter 按 exchangeInfo 返回的 rateLimits 创建U本位合约限流器，并设置为 client 的限流器
+// 获取 exchangeInfo 失败时返回使用默认限制的限流器和错误
+func NewRateLimiter(ctx context.Context, client *binance.Client) (*binance.RateLimiter, error) {
+	limiter := binance.NewFuturesRateLimiter()
+	client.RateLimiter = limiter
+	res, err := NewExchangeInfo(client).Call(ctx)
+	if err != nil {
+		return limiter, err
+	}
+	limiter.SetLimits(res.RateLimits...)
+	return limiter, nil
 }
@@ -6,7 +6,6 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type Ping interface {
@@ -35,5 +34,5 @@ func (p *pingRequest) Call(ctx context.Context) (body *pingResponse, err error)
 		p.Debugf("pingRequest response err: %v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*pingResponse](resp)
+	return binance.ParseHttpResponse[*pingResponse](resp)
 }
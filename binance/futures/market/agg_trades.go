@@ -9,7 +9,7 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/futures/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type AggTrades interface {
@@ -51,6 +51,13 @@ type aggTradesResponse struct {
 	Placeholder           bool   `json:"M"` // 是否为最优撮合单(可忽略，目前总为最优撮合)
 }
 
+func (a aggTradesResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.Price)
+}
+func (a aggTradesResponse) QuantityDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.Quantity)
+}
+
 func NewAggTrades(client *binance.Client, symbol string, limit enums.LimitType) AggTrades {
 	return &aggTradesRequest{Client: client, symbol: symbol, limit: limit}
 }
@@ -87,7 +94,7 @@ func (a *aggTradesRequest) Call(ctx context.Context) (body []*aggTradesResponse,
 		a.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*aggTradesResponse](resp)
+	return binance.ParseHttpResponse[[]*aggTradesResponse](resp)
 }
 
 // ****************************** Websocket 行情推送 *******************************
@@ -107,24 +114,29 @@ type WsAggTradeEvent struct {
 // 归集交易与逐笔交易的区别在于，同一个taker在同一价格与多个maker成交时，会被归集为一笔成交。
 // Stream 名称: <symbol>@aggTrade
 // 更新速度: 实时
-func NewWsAggTrade(c *binance.Client, symbols []string, handler binance.Handler[WsAggTradeEvent], exception binance.ErrorHandler) error {
-	return wsAggTrade(c, symbols, handler, exception)
+func NewWsAggTrade(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[WsAggTradeEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsAggTrade(ctx, c, symbols, handler, exception)
 }
 
 // NewStreamAggTrade 归集交易
 // 归集交易与逐笔交易的区别在于，同一个taker在同一价格与多个maker成交时，会被归集为一笔成交。
 // Stream 名称: <symbol>@aggTrade
 // 更新速度: 实时
-func NewStreamAggTrade(c *binance.Client, symbols []string, handler binance.Handler[StreamAggTradeEvent], exception binance.ErrorHandler) error {
-	return wsAggTrade(c, symbols, handler, exception)
+func NewStreamAggTrade(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[StreamAggTradeEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsAggTrade(ctx, c, symbols, handler, exception)
 }
-func wsAggTrade[T WsAggTradeEvent | StreamAggTradeEvent](c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) error {
+func wsAggTrade[T WsAggTradeEvent | StreamAggTradeEvent](ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
 	endpoint := c.BaseURL
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@aggTrade", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	return binance.WsHandler(c, endpoint, handler, exception)
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeAggTrade 在组合 Stream 连接上订阅归集交易
+func SubscribeAggTrade(ctx context.Context, s *binance.WsSubscriptions, symbols []string, handler binance.Handler[WsAggTradeEvent]) error {
+	return binance.Subscribe(ctx, s, binance.SymbolStreams(symbols, "aggTrade"), handler)
 }
 
 // ****************************** Websocket Api *******************************
@@ -6,7 +6,7 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type AssetIndex interface {
@@ -50,7 +50,7 @@ func (t *assetIndexRequest) Call(ctx context.Context, symbol string) (body *asse
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*assetIndexResponse](resp)
+	return binance.ParseHttpResponse[*assetIndexResponse](resp)
 }
 func (t *assetIndexRequest) CallAll(ctx context.Context) (body []*assetIndexResponse, err error) {
 	req := &binance.Request{
@@ -62,9 +62,50 @@ func (t *assetIndexRequest) CallAll(ctx context.Context) (body []*assetIndexResp
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*assetIndexResponse](resp)
+	return binance.ParseHttpResponse[[]*assetIndexResponse](resp)
 }
 
 // ****************************** Websocket 行情推送 *******************************
 
+type StreamAllAssetIndexEvent struct {
+	Stream string              `json:"stream"`
+	Data   []WsAssetIndexEvent `json:"data"`
+}
+type WsAssetIndexEvent struct {
+	Event                 string          `json:"e"` // 事件类型 assetIndexUpdate
+	Time                  int64           `json:"E"` // 事件时间
+	Symbol                string          `json:"s"` // 资产对
+	Index                 decimal.Decimal `json:"i"` // 指数价格
+	BidBuffer             decimal.Decimal `json:"b"` // 卖价保证金折扣
+	AskBuffer             decimal.Decimal `json:"a"` // 买价保证金折扣
+	BidRate               decimal.Decimal `json:"B"` // 卖价
+	AskRate               decimal.Decimal `json:"A"` // 买价
+	AutoExchangeBidBuffer decimal.Decimal `json:"q"` // 自动兑换卖价保证金折扣
+	AutoExchangeAskBuffer decimal.Decimal `json:"g"` // 自动兑换买价保证金折扣
+	AutoExchangeBidRate   decimal.Decimal `json:"Q"` // 自动兑换卖价
+	AutoExchangeAskRate   decimal.Decimal `json:"G"` // 自动兑换买价
+}
+
+// NewWsAllAssetIndex 全市场多资产模式资产汇率指数
+// 推送联合保证金模式下所有资产的汇率指数
+//
+// Stream 名称: !assetIndex@arr
+//
+// 更新速度: 1000ms
+func NewWsAllAssetIndex(ctx context.Context, c *binance.Client, handler binance.Handler[[]WsAssetIndexEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return binance.WsHandler(ctx, c, c.BaseURL+"!assetIndex@arr", handler, exception)
+}
+
+// NewStreamAllAssetIndex 全市场多资产模式资产汇率指数
+//
+// Stream 名称: !assetIndex@arr
+func NewStreamAllAssetIndex(ctx context.Context, c *binance.Client, handler binance.Handler[StreamAllAssetIndexEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return binance.WsHandler(ctx, c, c.BaseURL+"!assetIndex@arr", handler, exception)
+}
+
+// SubscribeAllAssetIndex 在组合 Stream 连接上订阅全市场多资产模式资产汇率指数
+func SubscribeAllAssetIndex(ctx context.Context, s *binance.WsSubscriptions, handler binance.Handler[[]WsAssetIndexEvent]) error {
+	return binance.Subscribe(ctx, s, []string{"!assetIndex@arr"}, handler)
+}
+
 // ****************************** Websocket Api *******************************
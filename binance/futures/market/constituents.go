@@ -6,7 +6,6 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type Constituents interface {
@@ -44,7 +43,7 @@ func (t *constituentsRequest) Call(ctx context.Context, symbol string) (body *co
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*constituentsResponse](resp)
+	return binance.ParseHttpResponse[*constituentsResponse](resp)
 }
 
 // ****************************** Websocket 行情推送 *******************************
@@ -0,0 +1,57 @@
+package market
+
+import (
+	"context"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// ****************************** Websocket 行情推送 *******************************
+
+type StreamContractInfoEvent struct {
+	Stream string              `json:"stream"`
+	Data   WsContractInfoEvent `json:"data"`
+}
+type WsContractInfoEvent struct {
+	Event          string                   `json:"e"`  // 事件类型 contractInfo
+	Time           int64                    `json:"E"`  // 事件时间
+	Symbol         string                   `json:"s"`  // 交易对
+	Pair           string                   `json:"ps"` // 标的交易对
+	ContractType   enums.ContractType       `json:"ct"` // 合约类型
+	DeliveryDate   int64                    `json:"dt"` // 交割日期
+	OnboardDate    int64                    `json:"ot"` // 上线日期
+	ContractStatus enums.ContractStatusType `json:"cs"` // 合约状态
+	Brackets       []struct {
+		Bracket          int             `json:"bs"`  // 层级
+		NotionalFloor    decimal.Decimal `json:"bnf"` // 该层对应的名义价值下限
+		NotionalCap      decimal.Decimal `json:"bnc"` // 该层对应的名义价值上限
+		MaintMarginRatio decimal.Decimal `json:"mmr"` // 该层对应的维持保证金率
+		Cum              decimal.Decimal `json:"cf"`  // 速算数
+		MinLeverage      int             `json:"mi"`  // 最小杠杆
+		MaxLeverage      int             `json:"ma"`  // 最大杠杆
+	} `json:"bks"` // 杠杆分层，只在杠杆分层变化时推送
+}
+
+// NewWsContractInfo 合约信息
+// 交易对上下架、合约状态或杠杆分层变化时推送
+//
+// Stream 名称: !contractInfo
+//
+// 更新速度: 实时
+func NewWsContractInfo(ctx context.Context, c *binance.Client, handler binance.Handler[WsContractInfoEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return binance.WsHandler(ctx, c, c.BaseURL+"!contractInfo", handler, exception)
+}
+
+// NewStreamContractInfo 合约信息
+//
+// Stream 名称: !contractInfo
+func NewStreamContractInfo(ctx context.Context, c *binance.Client, handler binance.Handler[StreamContractInfoEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return binance.WsHandler(ctx, c, c.BaseURL+"!contractInfo", handler, exception)
+}
+
+// SubscribeContractInfo 在组合 Stream 连接上订阅合约信息
+func SubscribeContractInfo(ctx context.Context, s *binance.WsSubscriptions, handler binance.Handler[WsContractInfoEvent]) error {
+	return binance.Subscribe(ctx, s, []string{"!contractInfo"}, handler)
+}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type DeliveryPrice interface {
@@ -41,7 +40,7 @@ func (d *deliveryPriceRequest) Call(ctx context.Context) (body []*deliveryPriceR
 		d.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*deliveryPriceResponse](resp)
+	return binance.ParseHttpResponse[[]*deliveryPriceResponse](resp)
 }
 
 // ****************************** Websocket 行情推送 *******************************
@@ -7,7 +7,6 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/futures/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type GlobalLongShortAccountRatio interface {
@@ -83,7 +82,7 @@ func (t *globalLongShortAccountRatioRequest) Call(ctx context.Context, symbol st
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*globalLongShortAccountRatioResponse](resp)
+	return binance.ParseHttpResponse[[]*globalLongShortAccountRatioResponse](resp)
 }
 
 // ****************************** Websocket 行情推送 *******************************
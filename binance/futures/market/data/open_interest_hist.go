@@ -7,7 +7,6 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/futures/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type OpenInterestHist interface {
@@ -82,7 +81,7 @@ func (t *openInterestHistRequest) Call(ctx context.Context, symbol string) (body
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*openInterestHistResponse](resp)
+	return binance.ParseHttpResponse[[]*openInterestHistResponse](resp)
 }
 
 // ****************************** Websocket 行情推送 *******************************
@@ -7,7 +7,6 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/futures/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type TopLongShortAccountRatio interface {
@@ -85,7 +84,7 @@ func (t *topLongShortAccountRatioRequest) Call(ctx context.Context, symbol strin
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*topLongShortAccountRatioResponse](resp)
+	return binance.ParseHttpResponse[[]*topLongShortAccountRatioResponse](resp)
 }
 
 // ****************************** Websocket 行情推送 *******************************
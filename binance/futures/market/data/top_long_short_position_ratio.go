@@ -7,7 +7,6 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/futures/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type TopLongShortPositionRatio interface {
@@ -85,7 +84,7 @@ func (t *topLongShortPositionRatioRequest) Call(ctx context.Context, symbol stri
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*topLongShortPositionRatioResponse](resp)
+	return binance.ParseHttpResponse[[]*topLongShortPositionRatioResponse](resp)
 }
 
 // ****************************** Websocket 行情推送 *******************************
@@ -9,7 +9,7 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/futures/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/orderbook"
 )
 
 type Depth interface {
@@ -52,6 +52,16 @@ type depthResponse struct {
 	Asks         [][]string `json:"asks"` // 卖单
 }
 
+// BidLevels 解析买单档位
+func (d depthResponse) BidLevels() ([]orderbook.Level, error) {
+	return orderbook.ParseLevels(d.Bids)
+}
+
+// AskLevels 解析卖单档位
+func (d depthResponse) AskLevels() ([]orderbook.Level, error) {
+	return orderbook.ParseLevels(d.Asks)
+}
+
 // NewDepth 深度信息
 func NewDepth(c *binance.Client, symbol string, limit enums.LimitType) Depth {
 	return &depthRequest{
@@ -72,12 +82,29 @@ func (d *depthRequest) Call(ctx context.Context) (body *depthResponse, err error
 	}
 	req.SetParam("symbol", d.symbol)
 	req.SetParam("limit", d.limit)
+	req.SetWeight(depthWeight(d.limit))
 	resp, err := d.Do(ctx, req)
 	if err != nil {
 		d.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*depthResponse](resp)
+	return binance.ParseHttpResponse[*depthResponse](resp)
+}
+
+// depthWeight 权重随 limit 变化: 5、10、20、50 为 2，100 为 5，500 为 10，1000 为 20
+func depthWeight(limit enums.LimitType) int {
+	switch {
+	case limit == 0:
+		return 5
+	case limit <= 50:
+		return 2
+	case limit <= 100:
+		return 5
+	case limit <= 500:
+		return 10
+	default:
+		return 20
+	}
 }
 
 // ****************************** Websocket 行情推送 *******************************
@@ -87,27 +114,39 @@ type StreamDepthEvent struct {
 	Data   *WsDepthEvent `json:"data"`
 }
 type WsDepthEvent struct {
-	Event         string     `json:"e"`
-	Time          int64      `json:"E"`
-	Symbol        string     `json:"s"`
-	FirstUpdateID int        `json:"U"`
-	LastUpdateID  int        `json:"u"`
-	Bids          [][]string `json:"b"`
-	Asks          [][]string `json:"a"`
+	Event            string     `json:"e"`
+	Time             int64      `json:"E"`
+	TransactionTime  int64      `json:"T"` // 撮合时间
+	Symbol           string     `json:"s"`
+	FirstUpdateID    int        `json:"U"`
+	LastUpdateID     int        `json:"u"`
+	PrevLastUpdateID int        `json:"pu"` // 上一个 event 的 u
+	Bids             [][]string `json:"b"`
+	Asks             [][]string `json:"a"`
+}
+
+// BidLevels 解析买单档位
+func (e WsDepthEvent) BidLevels() ([]orderbook.Level, error) {
+	return orderbook.ParseLevels(e.Bids)
+}
+
+// AskLevels 解析卖单档位
+func (e WsDepthEvent) AskLevels() ([]orderbook.Level, error) {
+	return orderbook.ParseLevels(e.Asks)
 }
 
 // NewWsDepth 增量深度信息
 // 每秒推送orderbook的变化部分（如果有）
-func NewWsDepth(c *binance.Client, symbols []string, handler binance.Handler[*WsDepthEvent], exception binance.ErrorHandler) error {
-	return wsDepth[*WsDepthEvent](c, symbols, handler, exception)
+func NewWsDepth(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[*WsDepthEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsDepth[*WsDepthEvent](ctx, c, symbols, handler, exception)
 }
 
 // NewStreamDepth 增量深度信息
 // // 每秒推送orderbook的变化部分（如果有）
-func NewStreamDepth(c *binance.Client, symbols []string, handler binance.Handler[*StreamDepthEvent], exception binance.ErrorHandler) error {
-	return wsDepth[*StreamDepthEvent](c, symbols, handler, exception)
+func NewStreamDepth(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[*StreamDepthEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsDepth[*StreamDepthEvent](ctx, c, symbols, handler, exception)
 }
-func wsDepth[T *StreamDepthEvent | *WsDepthEvent](c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) error {
+func wsDepth[T *StreamDepthEvent | *WsDepthEvent](ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
 	endpoint := c.BaseURL
 	for _, s := range symbols {
 		if c.IsFast {
@@ -117,7 +156,16 @@ func wsDepth[T *StreamDepthEvent | *WsDepthEvent](c *binance.Client, symbols []s
 		}
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	return binance.WsHandler(c, endpoint, handler, exception)
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeDepth 在组合 Stream 连接上订阅增量深度信息
+func SubscribeDepth(ctx context.Context, s *binance.WsSubscriptions, symbols []string, handler binance.Handler[*WsDepthEvent]) error {
+	suffix := "depth"
+	if s.Client().IsFast {
+		suffix = "depth@100ms"
+	}
+	return binance.Subscribe(ctx, s, binance.SymbolStreams(symbols, suffix), handler)
 }
 
 type StreamDepthLevelsEvent struct {
@@ -130,16 +178,16 @@ type WsDepthLevelsEvent struct {
 
 // NewWsDepthLevels 有限档深度信息
 // 每秒推送有限档深度信息。levels 表示几档买卖单信息, 可选 5/10/20档
-func NewWsDepthLevels(c *binance.Client, symbolLevels map[string]enums.LimitType, handler binance.Handler[WsDepthLevelsEvent], exception binance.ErrorHandler) error {
-	return wsDepthLevels[WsDepthLevelsEvent](c, symbolLevels, handler, exception)
+func NewWsDepthLevels(ctx context.Context, c *binance.Client, symbolLevels map[string]enums.LimitType, handler binance.Handler[WsDepthLevelsEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsDepthLevels[WsDepthLevelsEvent](ctx, c, symbolLevels, handler, exception)
 }
 
 // NewStreamDepthLevels 有限档深度信息
 // 每秒推送有限档深度信息。levels 表示几档买卖单信息, 可选 5/10/20档
-func NewStreamDepthLevels(c *binance.Client, symbolLevels map[string]enums.LimitType, handler binance.Handler[StreamDepthLevelsEvent], exception binance.ErrorHandler) error {
-	return wsDepthLevels[StreamDepthLevelsEvent](c, symbolLevels, handler, exception)
+func NewStreamDepthLevels(ctx context.Context, c *binance.Client, symbolLevels map[string]enums.LimitType, handler binance.Handler[StreamDepthLevelsEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsDepthLevels[StreamDepthLevelsEvent](ctx, c, symbolLevels, handler, exception)
 }
-func wsDepthLevels[T WsDepthLevelsEvent | StreamDepthLevelsEvent](c *binance.Client, symbolLevels map[string]enums.LimitType, handler binance.Handler[T], exception binance.ErrorHandler) error {
+func wsDepthLevels[T WsDepthLevelsEvent | StreamDepthLevelsEvent](ctx context.Context, c *binance.Client, symbolLevels map[string]enums.LimitType, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
 	endpoint := c.BaseURL
 	for s, l := range symbolLevels {
 		if c.IsFast {
@@ -149,7 +197,20 @@ func wsDepthLevels[T WsDepthLevelsEvent | StreamDepthLevelsEvent](c *binance.Cli
 		}
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	return binance.WsHandler(c, endpoint, handler, exception)
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeDepthLevels 在组合 Stream 连接上订阅有限档深度信息
+func SubscribeDepthLevels(ctx context.Context, s *binance.WsSubscriptions, symbolLevels map[string]enums.LimitType, handler binance.Handler[WsDepthLevelsEvent]) error {
+	streams := make([]string, 0, len(symbolLevels))
+	for symbol, level := range symbolLevels {
+		stream := fmt.Sprintf("%s@depth%d", strings.ToLower(symbol), level)
+		if s.Client().IsFast {
+			stream += "@100ms"
+		}
+		streams = append(streams, stream)
+	}
+	return binance.Subscribe(ctx, s, streams, handler)
 }
 
 // ****************************** Websocket Api *******************************
@@ -0,0 +1,79 @@
+package market
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// ****************************** Websocket 行情推送 *******************************
+
+type StreamForceOrderEvent struct {
+	Stream string            `json:"stream"`
+	Data   WsForceOrderEvent `json:"data"`
+}
+type WsForceOrderEvent struct {
+	Event string       `json:"e"` // 事件类型 forceOrder
+	Time  int64        `json:"E"` // 事件时间
+	Order WsForceOrder `json:"o"`
+}
+type WsForceOrder struct {
+	Symbol              string                `json:"s"`  // 交易对
+	Side                enums.SideType        `json:"S"`  // 订单方向
+	Type                enums.OrderType       `json:"o"`  // 订单类型
+	TimeInForce         enums.TimeInForceType `json:"f"`  // 有效方式
+	OrigQty             decimal.Decimal       `json:"q"`  // 订单数量
+	Price               decimal.Decimal       `json:"p"`  // 订单价格
+	AvgPrice            decimal.Decimal       `json:"ap"` // 平均价格
+	Status              enums.StatusType      `json:"X"`  // 订单状态
+	LastFilledQty       decimal.Decimal       `json:"l"`  // 订单最近成交量
+	CumulativeFilledQty decimal.Decimal       `json:"z"`  // 订单累计成交量
+	TradeTime           int64                 `json:"T"`  // 交易时间
+}
+
+// NewWsForceOrder 强平订单
+// 推送指定交易对的强平订单快照信息，每个交易对 1000ms 内至多推送一条最新的强平订单
+//
+// Stream 名称: <symbol>@forceOrder
+func NewWsForceOrder(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[WsForceOrderEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsForceOrder(ctx, c, binance.SymbolStreams(symbols, "forceOrder"), handler, exception)
+}
+
+// NewStreamForceOrder 强平订单
+//
+// Stream 名称: <symbol>@forceOrder
+func NewStreamForceOrder(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[StreamForceOrderEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsForceOrder(ctx, c, binance.SymbolStreams(symbols, "forceOrder"), handler, exception)
+}
+
+// NewWsAllForceOrder 全市场强平订单
+// 推送全市场强平订单快照信息，每个交易对 1000ms 内至多推送一条最新的强平订单
+//
+// Stream 名称: !forceOrder@arr
+func NewWsAllForceOrder(ctx context.Context, c *binance.Client, handler binance.Handler[WsForceOrderEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsForceOrder(ctx, c, []string{"!forceOrder@arr"}, handler, exception)
+}
+
+// NewStreamAllForceOrder 全市场强平订单
+//
+// Stream 名称: !forceOrder@arr
+func NewStreamAllForceOrder(ctx context.Context, c *binance.Client, handler binance.Handler[StreamForceOrderEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsForceOrder(ctx, c, []string{"!forceOrder@arr"}, handler, exception)
+}
+func wsForceOrder[T WsForceOrderEvent | StreamForceOrderEvent](ctx context.Context, c *binance.Client, streams []string, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	endpoint := c.BaseURL + strings.Join(streams, "/")
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeForceOrder 在组合 Stream 连接上订阅强平订单
+func SubscribeForceOrder(ctx context.Context, s *binance.WsSubscriptions, symbols []string, handler binance.Handler[WsForceOrderEvent]) error {
+	return binance.Subscribe(ctx, s, binance.SymbolStreams(symbols, "forceOrder"), handler)
+}
+
+// SubscribeAllForceOrder 在组合 Stream 连接上订阅全市场强平订单
+func SubscribeAllForceOrder(ctx context.Context, s *binance.WsSubscriptions, handler binance.Handler[WsForceOrderEvent]) error {
+	return binance.Subscribe(ctx, s, []string{"!forceOrder@arr"}, handler)
+}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type FundingInfo interface {
@@ -43,7 +42,7 @@ func (t *fundingInfoRequest) Call(ctx context.Context) (body []*fundingInfoRespo
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*fundingInfoResponse](resp)
+	return binance.ParseHttpResponse[[]*fundingInfoResponse](resp)
 }
 
 // ****************************** Websocket 行情推送 *******************************
@@ -1,16 +1,17 @@
 package market
 
 import (
+	"cmp"
 	"context"
 	"net/http"
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/futures/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type FundingRate interface {
+	Iterate() *binance.Pager[*fundingRateResponse]
 	SetSymbol(symbol string) *fundingRateRequest
 	SetStartTime(startTime int64) *fundingRateRequest
 	SetEndTime(endTime int64) *fundingRateRequest
@@ -74,7 +75,22 @@ func (t *fundingRateRequest) Call(ctx context.Context, symbol string) (body []*f
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*fundingRateResponse](resp)
+	return binance.ParseHttpResponse[[]*fundingRateResponse](resp)
+}
+
+// Iterate 按资金费时间遍历 [startTime, endTime] 内的资金费率历史，symbol 为空时返回全部交易对
+func (t *fundingRateRequest) Iterate() *binance.Pager[*fundingRateResponse] {
+	start, end := binance.TimeRange(t.startTime, t.endTime)
+	limit := cmp.Or(t.limit, enums.Limit100)
+	return binance.NewPager(binance.TimeCursor(start, end, 0, int(limit), func(ctx context.Context, start, end int64) ([]*fundingRateResponse, error) {
+		r := *t
+		r.limit, r.startTime, r.endTime = limit, &start, &end
+		return r.Call(ctx, r.symbol)
+	}, func(rate *fundingRateResponse) int64 {
+		return rate.FundingTime
+	}, func(rate *fundingRateResponse) string {
+		return rate.Symbol
+	}))
 }
 
 // ****************************** Websocket 行情推送 *******************************
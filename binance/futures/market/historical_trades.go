@@ -7,7 +7,6 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type historyTradesRequest struct {
@@ -62,7 +61,7 @@ func (t *historyTradesRequest) Call(ctx context.Context) (body []*tradesResponse
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*tradesResponse](resp)
+	return binance.ParseHttpResponse[[]*tradesResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
@@ -3,10 +3,11 @@ package market
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type IndexInfo interface {
@@ -50,9 +51,54 @@ func (t *indexInfoRequest) Call(ctx context.Context) (body *indexInfoResponse, e
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*indexInfoResponse](resp)
+	return binance.ParseHttpResponse[*indexInfoResponse](resp)
 }
 
 // ****************************** Websocket 行情推送 *******************************
 
+type StreamCompositeIndexEvent struct {
+	Stream string                `json:"stream"`
+	Data   WsCompositeIndexEvent `json:"data"`
+}
+type WsCompositeIndexEvent struct {
+	Event       string          `json:"e"` // 事件类型 compositeIndex
+	Time        int64           `json:"E"` // 事件时间
+	Symbol      string          `json:"s"` // 交易对
+	Price       decimal.Decimal `json:"p"` // 价格
+	Component   string          `json:"C"` // 成分资产
+	Composition []struct {
+		BaseAsset          string          `json:"b"` // 基础资产
+		QuoteAsset         string          `json:"q"` // 报价资产
+		WeightInQuantity   decimal.Decimal `json:"w"` // 权重(数量)
+		WeightInPercentage decimal.Decimal `json:"W"` // 权重(比例)
+		IndexPrice         decimal.Decimal `json:"i"` // 指数价格
+	} `json:"c"`
+}
+
+// NewWsCompositeIndex 综合指数交易对信息
+// 推送综合指数交易对的成分资产价格和权重
+//
+// Stream 名称: <symbol>@compositeIndex
+//
+// 更新速度: 1000ms
+func NewWsCompositeIndex(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[WsCompositeIndexEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsCompositeIndex(ctx, c, symbols, handler, exception)
+}
+
+// NewStreamCompositeIndex 综合指数交易对信息
+//
+// Stream 名称: <symbol>@compositeIndex
+func NewStreamCompositeIndex(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[StreamCompositeIndexEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsCompositeIndex(ctx, c, symbols, handler, exception)
+}
+func wsCompositeIndex[T WsCompositeIndexEvent | StreamCompositeIndexEvent](ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	endpoint := c.BaseURL + strings.Join(binance.SymbolStreams(symbols, "compositeIndex"), "/")
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeCompositeIndex 在组合 Stream 连接上订阅综合指数交易对信息
+func SubscribeCompositeIndex(ctx context.Context, s *binance.WsSubscriptions, symbols []string, handler binance.Handler[WsCompositeIndexEvent]) error {
+	return binance.Subscribe(ctx, s, binance.SymbolStreams(symbols, "compositeIndex"), handler)
+}
+
 // ****************************** Websocket Api *******************************
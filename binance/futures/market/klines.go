@@ -9,7 +9,8 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/futures/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+	"github.com/sleep-go/coin-go/pkg/kline"
 )
 
 type Klines interface {
@@ -61,6 +62,11 @@ type klinesRequest struct {
 // ]
 type KlinesResponse [12]any
 
+// Kline 转换为 kline.Kline
+func (k *KlinesResponse) Kline() (kline.Kline, error) {
+	return kline.FromArray(*k)
+}
+
 func NewKlines(client *binance.Client, symbol string, limit enums.LimitType) Klines {
 	return &klinesRequest{Client: client, symbol: symbol, limit: limit}
 }
@@ -125,7 +131,7 @@ func (k *klinesRequest) Call(ctx context.Context) (body []*KlinesResponse, err e
 		k.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*KlinesResponse](resp)
+	return binance.ParseHttpResponse[[]*KlinesResponse](resp)
 }
 
 // CallContinuousKlines 连续合约K线数据
@@ -147,7 +153,7 @@ func (k *klinesRequest) CallContinuousKlines(ctx context.Context) (body []*Kline
 		k.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*KlinesResponse](resp)
+	return binance.ParseHttpResponse[[]*KlinesResponse](resp)
 }
 
 // CallIndexPriceKlines 价格指数K线数据
@@ -168,7 +174,7 @@ func (k *klinesRequest) CallIndexPriceKlines(ctx context.Context) (body []*Kline
 		k.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*KlinesResponse](resp)
+	return binance.ParseHttpResponse[[]*KlinesResponse](resp)
 }
 
 // CallMarkPriceKlines 标记价格K线数据
@@ -189,7 +195,7 @@ func (k *klinesRequest) CallMarkPriceKlines(ctx context.Context) (body []*Klines
 		k.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*KlinesResponse](resp)
+	return binance.ParseHttpResponse[[]*KlinesResponse](resp)
 }
 
 // CallPremiumIndexKlines 溢价指数K线数据
@@ -210,7 +216,7 @@ func (k *klinesRequest) CallPremiumIndexKlines(ctx context.Context) (body []*Kli
 		k.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*KlinesResponse](resp)
+	return binance.ParseHttpResponse[[]*KlinesResponse](resp)
 }
 
 // ****************************** Websocket 行情推送 *******************************
@@ -244,11 +250,46 @@ type WsKline struct {
 	ActiveBuyQuoteVolume string `json:"Q"`
 }
 
-func NewWsKline(c *binance.Client, symbolsInterval map[string]enums.KlineIntervalType, handler binance.Handler[WsKlineEvent], exception binance.ErrorHandler) error {
-	return wsKline(c, symbolsInterval, handler, exception)
+func (k WsKline) OpenDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(k.Open)
+}
+func (k WsKline) CloseDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(k.Close)
+}
+func (k WsKline) HighDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(k.High)
+}
+func (k WsKline) LowDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(k.Low)
+}
+func (k WsKline) VolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(k.Volume)
+}
+func (k WsKline) QuoteVolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(k.QuoteVolume)
+}
+func (k WsKline) ActiveBuyVolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(k.ActiveBuyVolume)
+}
+func (k WsKline) ActiveBuyQuoteVolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(k.ActiveBuyQuoteVolume)
+}
+
+// Kline 转换为 kline.Kline
+func (k WsKline) Kline() (kline.Kline, error) {
+	kl, err := kline.FromArray([12]any{k.StartTime, k.Open, k.High, k.Low, k.Close, k.Volume, k.EndTime, k.QuoteVolume, k.TradeNum, k.ActiveBuyVolume, k.ActiveBuyQuoteVolume})
+	if err != nil {
+		return kline.Kline{}, err
+	}
+	kl.IsFinal = k.IsFinal
+	return kl, nil
+}
+
+func NewWsKline(ctx context.Context, c *binance.Client, symbolsInterval map[string]enums.KlineIntervalType, handler binance.Handler[WsKlineEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsKline(ctx, c, symbolsInterval, handler, exception)
 }
-func NewStreamKline(c *binance.Client, symbolsInterval map[string]enums.KlineIntervalType, handler binance.Handler[StreamKlineEvent], exception binance.ErrorHandler) error {
-	return wsKline(c, symbolsInterval, handler, exception)
+func NewStreamKline(ctx context.Context, c *binance.Client, symbolsInterval map[string]enums.KlineIntervalType, handler binance.Handler[StreamKlineEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsKline(ctx, c, symbolsInterval, handler, exception)
 }
 
 // wsKLines UTC K线
@@ -257,7 +298,7 @@ func NewStreamKline(c *binance.Client, symbolsInterval map[string]enums.KlineInt
 // 订阅Kline需要提供间隔参数，最短为分钟线，最长为月线。支持以下间隔:
 //
 // m -> 分钟; h -> 小时; d -> 天; w -> 周; M -> 月
-func wsKline[T WsKlineEvent | StreamKlineEvent](c *binance.Client, symbolsInterval map[string]enums.KlineIntervalType, handler binance.Handler[T], exception binance.ErrorHandler) error {
+func wsKline[T WsKlineEvent | StreamKlineEvent](ctx context.Context, c *binance.Client, symbolsInterval map[string]enums.KlineIntervalType, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
 	endpoint := c.BaseURL
 	for symbol, interval := range symbolsInterval {
 		endpoint += fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval) + "/"
@@ -266,7 +307,77 @@ func wsKline[T WsKlineEvent | StreamKlineEvent](c *binance.Client, symbolsInterv
 	if c.Timezone != "" {
 		endpoint = fmt.Sprintf("%s@%s", endpoint, c.Timezone)
 	}
-	return binance.WsHandler(c, endpoint, handler, exception)
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeKline 在组合 Stream 连接上订阅K线
+func SubscribeKline(ctx context.Context, s *binance.WsSubscriptions, symbolsInterval map[string]enums.KlineIntervalType, handler binance.Handler[WsKlineEvent]) error {
+	streams := make([]string, 0, len(symbolsInterval))
+	for symbol, interval := range symbolsInterval {
+		stream := fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval)
+		if s.Client().Timezone != "" {
+			stream = fmt.Sprintf("%s@%s", stream, s.Client().Timezone)
+		}
+		streams = append(streams, stream)
+	}
+	return binance.Subscribe(ctx, s, streams, handler)
+}
+
+// ContinuousKlineStream 连续合约K线的标的交易对、合约类型和K线间隔
+type ContinuousKlineStream struct {
+	Pair         string                  // 标的交易对，如 BTCUSDT
+	ContractType enums.ContractType      // 合约类型 PERPETUAL, CURRENT_QUARTER, NEXT_QUARTER
+	Interval     enums.KlineIntervalType // K线间隔
+}
+
+func (k ContinuousKlineStream) name() string {
+	return fmt.Sprintf("%s_%s@continuousKline_%s", strings.ToLower(k.Pair), strings.ToLower(string(k.ContractType)), k.Interval)
+}
+
+type StreamContinuousKlineEvent struct {
+	Stream string                 `json:"stream"`
+	Data   WsContinuousKlineEvent `json:"data"`
+}
+type WsContinuousKlineEvent struct {
+	Event        string             `json:"e"`  // 事件类型 continuous_kline
+	Time         int64              `json:"E"`  // 事件时间
+	Pair         string             `json:"ps"` // 标的交易对
+	ContractType enums.ContractType `json:"ct"` // 合约类型
+	Kline        WsKline            `json:"k"`  // K线，没有交易对
+}
+
+// NewWsContinuousKline 连续合约K线
+// K线stream逐秒推送所请求的K线种类(最新一根K线)的更新。
+//
+// Stream 名称: <pair>_<contractType>@continuousKline_<interval>
+//
+// 更新速度: 250ms
+func NewWsContinuousKline(ctx context.Context, c *binance.Client, streams []ContinuousKlineStream, handler binance.Handler[WsContinuousKlineEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsContinuousKline(ctx, c, streams, handler, exception)
+}
+
+// NewStreamContinuousKline 连续合约K线
+//
+// Stream 名称: <pair>_<contractType>@continuousKline_<interval>
+func NewStreamContinuousKline(ctx context.Context, c *binance.Client, streams []ContinuousKlineStream, handler binance.Handler[StreamContinuousKlineEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsContinuousKline(ctx, c, streams, handler, exception)
+}
+func wsContinuousKline[T WsContinuousKlineEvent | StreamContinuousKlineEvent](ctx context.Context, c *binance.Client, streams []ContinuousKlineStream, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	endpoint := c.BaseURL + strings.Join(continuousKlineStreams(streams), "/")
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeContinuousKline 在组合 Stream 连接上订阅连续合约K线
+func SubscribeContinuousKline(ctx context.Context, s *binance.WsSubscriptions, streams []ContinuousKlineStream, handler binance.Handler[WsContinuousKlineEvent]) error {
+	return binance.Subscribe(ctx, s, continuousKlineStreams(streams), handler)
+}
+
+func continuousKlineStreams(streams []ContinuousKlineStream) []string {
+	names := make([]string, 0, len(streams))
+	for _, k := range streams {
+		names = append(names, k.name())
+	}
+	return names
 }
 
 // ****************************** Websocket Api *******************************
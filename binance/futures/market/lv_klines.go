@@ -7,7 +7,6 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/futures/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type LvKlines interface {
@@ -94,5 +93,5 @@ func (k *LvKlinesRequest) Call(ctx context.Context) (body []*lvKlinesResponse, e
 		k.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*lvKlinesResponse](resp)
+	return binance.ParseHttpResponse[[]*lvKlinesResponse](resp)
 }
@@ -6,7 +6,6 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type OpenInterest interface {
@@ -41,7 +40,7 @@ func (t *openInterestRequest) Call(ctx context.Context, symbol string) (body *op
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*openInterestResponse](resp)
+	return binance.ParseHttpResponse[*openInterestResponse](resp)
 }
 
 // ****************************** Websocket 行情推送 *******************************
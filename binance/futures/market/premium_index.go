@@ -3,10 +3,11 @@ package market
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type PremiumIndex interface {
@@ -48,7 +49,7 @@ func (t *premiumIndexRequest) Call(ctx context.Context, symbol string) (body *pr
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*premiumIndexResponse](resp)
+	return binance.ParseHttpResponse[*premiumIndexResponse](resp)
 }
 func (t *premiumIndexRequest) CallAll(ctx context.Context) (body []*premiumIndexResponse, err error) {
 	req := &binance.Request{
@@ -60,9 +61,89 @@ func (t *premiumIndexRequest) CallAll(ctx context.Context) (body []*premiumIndex
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*premiumIndexResponse](resp)
+	return binance.ParseHttpResponse[[]*premiumIndexResponse](resp)
 }
 
 // ****************************** Websocket 行情推送 *******************************
 
+type StreamMarkPriceEvent struct {
+	Stream string           `json:"stream"`
+	Data   WsMarkPriceEvent `json:"data"`
+}
+type StreamAllMarkPriceEvent struct {
+	Stream string             `json:"stream"`
+	Data   []WsMarkPriceEvent `json:"data"`
+}
+type WsMarkPriceEvent struct {
+	Event                string          `json:"e"` // 事件类型 markPriceUpdate
+	Time                 int64           `json:"E"` // 事件时间
+	Symbol               string          `json:"s"` // 交易对
+	MarkPrice            decimal.Decimal `json:"p"` // 标记价格
+	IndexPrice           decimal.Decimal `json:"i"` // 现货指数价格
+	EstimatedSettlePrice decimal.Decimal `json:"P"` // 预估结算价,仅在结算前最后一小时有参考价值
+	FundingRate          decimal.Decimal `json:"r"` // 资金费率
+	NextFundingTime      int64           `json:"T"` // 下次资金时间
+}
+
+// NewWsMarkPrice 最新标记价格
+// 推送指定交易对的标记价格、指数价格和资金费率
+//
+// Stream 名称: <symbol>@markPrice 或 <symbol>@markPrice@1s
+//
+// 更新速度: 3000ms 或 1000ms(Client.IsFast)
+func NewWsMarkPrice(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[WsMarkPriceEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsMarkPrice(ctx, c, binance.SymbolStreams(symbols, markPriceSuffix(c)), handler, exception)
+}
+
+// NewStreamMarkPrice 最新标记价格
+//
+// Stream 名称: <symbol>@markPrice 或 <symbol>@markPrice@1s
+func NewStreamMarkPrice(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[StreamMarkPriceEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsMarkPrice(ctx, c, binance.SymbolStreams(symbols, markPriceSuffix(c)), handler, exception)
+}
+
+// NewWsAllMarkPrice 全市场最新标记价格
+// 每次推送全市场所有交易对的标记价格、指数价格和资金费率
+//
+// Stream 名称: !markPrice@arr 或 !markPrice@arr@1s
+//
+// 更新速度: 3000ms 或 1000ms(Client.IsFast)
+func NewWsAllMarkPrice(ctx context.Context, c *binance.Client, handler binance.Handler[[]WsMarkPriceEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsMarkPrice(ctx, c, []string{"!" + markPriceArrSuffix(c)}, handler, exception)
+}
+
+// NewStreamAllMarkPrice 全市场最新标记价格
+//
+// Stream 名称: !markPrice@arr 或 !markPrice@arr@1s
+func NewStreamAllMarkPrice(ctx context.Context, c *binance.Client, handler binance.Handler[StreamAllMarkPriceEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsMarkPrice(ctx, c, []string{"!" + markPriceArrSuffix(c)}, handler, exception)
+}
+func wsMarkPrice[T WsMarkPriceEvent | StreamMarkPriceEvent | []WsMarkPriceEvent | StreamAllMarkPriceEvent](ctx context.Context, c *binance.Client, streams []string, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	endpoint := c.BaseURL + strings.Join(streams, "/")
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeMarkPrice 在组合 Stream 连接上订阅最新标记价格
+func SubscribeMarkPrice(ctx context.Context, s *binance.WsSubscriptions, symbols []string, handler binance.Handler[WsMarkPriceEvent]) error {
+	return binance.Subscribe(ctx, s, binance.SymbolStreams(symbols, markPriceSuffix(s.Client())), handler)
+}
+
+// SubscribeAllMarkPrice 在组合 Stream 连接上订阅全市场最新标记价格
+func SubscribeAllMarkPrice(ctx context.Context, s *binance.WsSubscriptions, handler binance.Handler[[]WsMarkPriceEvent]) error {
+	return binance.Subscribe(ctx, s, []string{"!" + markPriceArrSuffix(s.Client())}, handler)
+}
+
+func markPriceSuffix(c *binance.Client) string {
+	if c.IsFast {
+		return "markPrice@1s"
+	}
+	return "markPrice"
+}
+func markPriceArrSuffix(c *binance.Client) string {
+	if c.IsFast {
+		return "markPrice@arr@1s"
+	}
+	return "markPrice@arr"
+}
+
 // ****************************** Websocket Api *******************************
@@ -6,7 +6,7 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type Hr24 interface {
@@ -38,6 +38,37 @@ type hr24Response struct {
 	Count              int    `json:"count"`              // 成交笔数
 }
 
+func (h hr24Response) PriceChangeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.PriceChange)
+}
+func (h hr24Response) PriceChangePercentDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.PriceChangePercent)
+}
+func (h hr24Response) WeightedAvgPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.WeightedAvgPrice)
+}
+func (h hr24Response) LastPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.LastPrice)
+}
+func (h hr24Response) LastQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.LastQty)
+}
+func (h hr24Response) OpenPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.OpenPrice)
+}
+func (h hr24Response) HighPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.HighPrice)
+}
+func (h hr24Response) LowPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.LowPrice)
+}
+func (h hr24Response) VolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.Volume)
+}
+func (h hr24Response) QuoteVolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.QuoteVolume)
+}
+
 // NewHr24 24hr价格变动情况
 func NewHr24(client *binance.Client, symbol string) Hr24 {
 	return &hr24Request{
@@ -59,7 +90,7 @@ func (hr *hr24Request) Call(ctx context.Context) (body *hr24Response, err error)
 		hr.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*hr24Response](res)
+	return binance.ParseHttpResponse[*hr24Response](res)
 }
 
 // CallAll 24hr价格变动情况
@@ -69,12 +100,13 @@ func (hr *hr24Request) CallAll(ctx context.Context) (body []*hr24Response, err e
 		Method: http.MethodGet,
 		Path:   consts.FApiMarketTicker24Hr,
 	}
+	req.SetWeight(40)
 	res, err := hr.Do(ctx, req)
 	if err != nil {
 		hr.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*hr24Response](res)
+	return binance.ParseHttpResponse[[]*hr24Response](res)
 }
 
 // ****************************** Websocket Api *******************************
@@ -8,7 +8,7 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type BookTicker interface {
@@ -18,6 +18,7 @@ type BookTicker interface {
 
 type bookTickerRequest struct {
 	*binance.Client
+	symbol string
 }
 
 type bookTickerResponse struct {
@@ -29,6 +30,19 @@ type bookTickerResponse struct {
 	Time     int64  `json:"time"`
 }
 
+func (b bookTickerResponse) BidPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(b.BidPrice)
+}
+func (b bookTickerResponse) BidQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(b.BidQty)
+}
+func (b bookTickerResponse) AskPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(b.AskPrice)
+}
+func (b bookTickerResponse) AskQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(b.AskQty)
+}
+
 // NewBookTicker 当前最优挂单
 // 返回当前最优的挂单(最高买单，最低卖单)
 func NewBookTicker(client *binance.Client) BookTicker {
@@ -45,19 +59,20 @@ func (b *bookTickerRequest) Call(ctx context.Context, symbol string) (body *book
 		b.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*bookTickerResponse](res)
+	return binance.ParseHttpResponse[*bookTickerResponse](res)
 }
 func (b *bookTickerRequest) CallAll(ctx context.Context) (body []*bookTickerResponse, err error) {
 	req := &binance.Request{
 		Method: http.MethodGet,
 		Path:   consts.FApiMarketTickerBookTicker,
 	}
+	req.SetWeight(5)
 	res, err := b.Do(ctx, req)
 	if err != nil {
 		b.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*bookTickerResponse](res)
+	return binance.ParseHttpResponse[[]*bookTickerResponse](res)
 }
 
 // ****************************** Websocket 行情推送 *******************************
@@ -75,14 +90,27 @@ type WsBookTickerEvent struct {
 	BestAskQty   string `json:"A"`
 }
 
+func (b WsBookTickerEvent) BestBidPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(b.BestBidPrice)
+}
+func (b WsBookTickerEvent) BestBidQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(b.BestBidQty)
+}
+func (b WsBookTickerEvent) BestAskPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(b.BestAskPrice)
+}
+func (b WsBookTickerEvent) BestAskQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(b.BestAskQty)
+}
+
 // NewWsBookTicker 按Symbol的最优挂单信息
 // 实时推送指定交易对最优挂单信息 多个 <symbol>@bookTicker 可以订阅在一个WebSocket连接上
 //
 // Stream 名称: <symbol>@bookTicker
 //
 // 更新速度: 实时
-func NewWsBookTicker(c *binance.Client, symbols []string, handler binance.Handler[WsBookTickerEvent], exception binance.ErrorHandler) error {
-	return bookTicker(c, symbols, handler, exception)
+func NewWsBookTicker(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[WsBookTickerEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return bookTicker(ctx, c, symbols, handler, exception)
 }
 
 // NewStreamBookTicker 按Symbol的最优挂单信息
@@ -91,26 +119,32 @@ func NewWsBookTicker(c *binance.Client, symbols []string, handler binance.Handle
 // Stream 名称: <symbol>@bookTicker
 //
 // 更新速度: 实时
-func NewStreamBookTicker(c *binance.Client, symbols []string, handler binance.Handler[StreamBookTickerEvent], exception binance.ErrorHandler) error {
-	return bookTicker(c, symbols, handler, exception)
+func NewStreamBookTicker(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[StreamBookTickerEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return bookTicker(ctx, c, symbols, handler, exception)
 }
-func bookTicker[T WsBookTickerEvent | StreamBookTickerEvent](c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) error {
+func bookTicker[T WsBookTickerEvent | StreamBookTickerEvent](ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
 	endpoint := c.BaseURL
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@bookTicker", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	return binance.WsHandler(c, endpoint, handler, exception)
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeBookTicker 在组合 Stream 连接上订阅最优挂单信息
+func SubscribeBookTicker(ctx context.Context, s *binance.WsSubscriptions, symbols []string, handler binance.Handler[WsBookTickerEvent]) error {
+	return binance.Subscribe(ctx, s, binance.SymbolStreams(symbols, "bookTicker"), handler)
 }
 
 // ****************************** Websocket Api *******************************
 
 type WsApiBookTicker interface {
 	binance.WsApi[*WsApiBookTickerResponse]
+	SetSymbol(symbol string) WsApiBookTicker
 }
 type WsApiBookTickerResponse struct {
 	binance.WsApiResponse
-	Result []*bookTickerResponse `json:"result"`
+	Result binance.ObjectOrArray[*bookTickerResponse] `json:"result"` // 指定 symbol 时返回单个对象，统一解析为数组
 }
 
 // NewWsApiBookTicker 当前最优挂单
@@ -123,7 +157,18 @@ func NewWsApiBookTicker(c *binance.Client) WsApiBookTicker {
 	return &bookTickerRequest{Client: c}
 }
 
+// SetSymbol 如果未指定交易对，则返回所有交易对的最优挂单
+func (b *bookTickerRequest) SetSymbol(symbol string) WsApiBookTicker {
+	b.symbol = symbol
+	return b
+}
+
+// Send 指定交易对时 Result 只有一个元素
 func (b *bookTickerRequest) Send(ctx context.Context) (*WsApiBookTickerResponse, error) {
 	req := &binance.Request{Path: "ticker.book"}
+	req.SetOptionalParam("symbol", b.symbol)
+	if b.symbol == "" {
+		req.SetWeight(5)
+	}
 	return binance.WsApiHandler[*WsApiBookTickerResponse](ctx, b.Client, req)
 }
@@ -6,7 +6,7 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type Price interface {
@@ -18,6 +18,7 @@ type Price interface {
 
 type priceRequest struct {
 	*binance.Client
+	symbol string
 }
 
 type priceResponse struct {
@@ -26,6 +27,10 @@ type priceResponse struct {
 	Time   int64  `json:"time"`
 }
 
+func (p priceResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(p.Price)
+}
+
 // NewPrice 最新价格接口
 func NewPrice(client *binance.Client) Price {
 	return &priceRequest{Client: client}
@@ -44,19 +49,20 @@ func (t *priceRequest) CallV1(ctx context.Context, symbol string) (body *priceRe
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*priceResponse](resp)
+	return binance.ParseHttpResponse[*priceResponse](resp)
 }
 func (t *priceRequest) CallAllV1(ctx context.Context) (body []*priceResponse, err error) {
 	req := &binance.Request{
 		Method: http.MethodGet,
 		Path:   consts.FApiMarketTickerPrice,
 	}
+	req.SetWeight(2)
 	resp, err := t.Do(ctx, req)
 	if err != nil {
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*priceResponse](resp)
+	return binance.ParseHttpResponse[[]*priceResponse](resp)
 }
 
 // CallV2 最新价格接口
@@ -72,29 +78,31 @@ func (t *priceRequest) CallV2(ctx context.Context, symbol string) (body *priceRe
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*priceResponse](resp)
+	return binance.ParseHttpResponse[*priceResponse](resp)
 }
 func (t *priceRequest) CallAllV2(ctx context.Context) (body []*priceResponse, err error) {
 	req := &binance.Request{
 		Method: http.MethodGet,
 		Path:   consts.FApiMarketTickerPriceV2,
 	}
+	req.SetWeight(2)
 	resp, err := t.Do(ctx, req)
 	if err != nil {
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*priceResponse](resp)
+	return binance.ParseHttpResponse[[]*priceResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
 
 type WsApiTickerPrice interface {
 	binance.WsApi[*WsApiTickerPriceResponse]
+	SetSymbol(symbol string) WsApiTickerPrice
 }
 type WsApiTickerPriceResponse struct {
 	binance.WsApiResponse
-	Result []*priceResponse `json:"result"`
+	Result binance.ObjectOrArray[*priceResponse] `json:"result"` // 指定 symbol 时返回单个对象，统一解析为数组
 }
 
 // NewWsApiTickerPrice 最新价格
@@ -108,7 +116,18 @@ func NewWsApiTickerPrice(c *binance.Client) WsApiTickerPrice {
 	return &priceRequest{Client: c}
 }
 
+// SetSymbol 如果未指定交易对，则返回所有交易对的价格
+func (t *priceRequest) SetSymbol(symbol string) WsApiTickerPrice {
+	t.symbol = symbol
+	return t
+}
+
+// Send 最新价格V2，返回字段与 REST CallV2 一致，指定交易对时 Result 只有一个元素
 func (t *priceRequest) Send(ctx context.Context) (*WsApiTickerPriceResponse, error) {
-	req := &binance.Request{Path: "ticker.price"}
+	req := &binance.Request{Path: "v2/ticker.price"}
+	req.SetOptionalParam("symbol", t.symbol)
+	if t.symbol == "" {
+		req.SetWeight(2)
+	}
 	return binance.WsApiHandler[*WsApiTickerPriceResponse](ctx, t.Client, req)
 }
@@ -0,0 +1,142 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance"
+)
+
+// DefaultKeepAliveInterval listenKey 有效期 60 分钟，官方建议每 30 分钟延长一次
+const DefaultKeepAliveInterval = 30 * time.Minute
+
+// KeepAlive 定时延长 listenKey 有效期
+// listenKey 已失效(-1125)时重新生成，新的 listenKey 通过 OnRenew 通知，需要用它重新订阅用户数据流
+//
+//	k := stream.NewKeepAlive(client).SetOnRenew(func(listenKey string) { ... })
+//	listenKey, err := k.Start(ctx)
+//	defer k.Close(context.Background())
+type KeepAlive struct {
+	stream   UserDataStream
+	interval time.Duration
+	onRenew  func(listenKey string)
+	onError  func(err error)
+
+	mu        sync.Mutex
+	listenKey string
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+func NewKeepAlive(client *binance.Client) *KeepAlive {
+	return NewKeepAliveStream(NewUserDataStream(client))
+}
+
+// NewKeepAliveStream 使用指定的 listenKey 管理接口
+func NewKeepAliveStream(stream UserDataStream) *KeepAlive {
+	return &KeepAlive{
+		stream:   stream,
+		interval: DefaultKeepAliveInterval,
+	}
+}
+
+// SetInterval 延长间隔，需要小于 60 分钟
+func (k *KeepAlive) SetInterval(interval time.Duration) *KeepAlive {
+	k.interval = interval
+	return k
+}
+
+// SetOnRenew listenKey 失效后重新生成时回调
+func (k *KeepAlive) SetOnRenew(onRenew func(listenKey string)) *KeepAlive {
+	k.onRenew = onRenew
+	return k
+}
+
+// SetOnError 延长或重新生成失败时回调，失败后在下一个间隔重试
+func (k *KeepAlive) SetOnError(onError func(err error)) *KeepAlive {
+	k.onError = onError
+	return k
+}
+
+// Start 生成 listenKey 并开始定时延长，ctx 结束或调用 Close 时停止
+func (k *KeepAlive) Start(ctx context.Context) (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.cancel != nil {
+		return k.listenKey, nil
+	}
+	res, err := k.stream.CallCreate(ctx)
+	if err != nil {
+		return "", err
+	}
+	k.listenKey = res.ListenKey
+	ctx, k.cancel = context.WithCancel(ctx)
+	k.done = make(chan struct{})
+	go k.run(ctx, k.done)
+	return k.listenKey, nil
+}
+
+// ListenKey 当前的 listenKey
+func (k *KeepAlive) ListenKey() string {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.listenKey
+}
+
+// Close 停止延长并关闭 listenKey
+func (k *KeepAlive) Close(ctx context.Context) error {
+	k.mu.Lock()
+	cancel, done := k.cancel, k.done
+	k.cancel, k.done = nil, nil
+	k.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	<-done
+	err := k.stream.CallDelete(ctx)
+	if errors.Is(err, binance.ErrInvalidListenKey) {
+		return nil
+	}
+	return err
+}
+
+func (k *KeepAlive) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(k.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := k.extend(ctx)
+			if err != nil && ctx.Err() == nil && k.onError != nil {
+				k.onError(err)
+			}
+		}
+	}
+}
+
+// extend 延长有效期，listenKey 失效时重新生成
+func (k *KeepAlive) extend(ctx context.Context) error {
+	res, err := k.stream.CallUpdate(ctx)
+	if errors.Is(err, binance.ErrInvalidListenKey) {
+		res, err = k.stream.CallCreate(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	k.mu.Lock()
+	renewed := res.ListenKey != "" && res.ListenKey != k.listenKey
+	if renewed {
+		k.listenKey = res.ListenKey
+	}
+	k.mu.Unlock()
+	if renewed && k.onRenew != nil {
+		k.onRenew(res.ListenKey)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package stream
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+)
+
+// UserDataStream U本位合约用户数据流 listenKey 管理 (USER_STREAM)
+// 一个账户只有一个 listenKey，延长和关闭不需要传 listenKey
+type UserDataStream interface {
+	CallCreate(ctx context.Context) (body *userDataStreamResponse, err error)
+	CallUpdate(ctx context.Context) (body *userDataStreamResponse, err error)
+	CallDelete(ctx context.Context) (err error)
+}
+type userDataStreamRequest struct {
+	*binance.Client
+	path string
+}
+
+type userDataStreamResponse struct {
+	ListenKey string `json:"listenKey"` //用于订阅的数据流名
+}
+
+func NewUserDataStream(client *binance.Client) UserDataStream {
+	return NewUserDataStreamPath(client, consts.FApiStreamListenKey)
+}
+
+// NewUserDataStreamPath 指定 listenKey 接口路径，币本位合约(/dapi)的接口与U本位一致
+func NewUserDataStreamPath(client *binance.Client, path string) UserDataStream {
+	return &userDataStreamRequest{Client: client, path: path}
+}
+
+// CallCreate 生成 listenKey (USER_STREAM)
+// 创建一个新的user data stream，返回值为一个listenKey，即websocket订阅的stream名称。
+// 如果该帐户具有有效的listenKey，则将返回该listenKey并将其有效期延长60分钟。
+func (o *userDataStreamRequest) CallCreate(ctx context.Context) (body *userDataStreamResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodPost,
+		Path:   o.path,
+	}
+	resp, err := o.Do(ctx, req)
+	if err != nil {
+		o.Debugf("userDataStreamRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*userDataStreamResponse](resp)
+}
+
+// CallUpdate 延长 listenKey 有效期 (USER_STREAM)
+// 有效期延长至本次调用后60分钟，建议每30分钟调用一次；listenKey 已失效时返回 -1125
+func (o *userDataStreamRequest) CallUpdate(ctx context.Context) (body *userDataStreamResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodPut,
+		Path:   o.path,
+	}
+	resp, err := o.Do(ctx, req)
+	if err != nil {
+		o.Debugf("userDataStreamRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*userDataStreamResponse](resp)
+}
+
+// CallDelete 关闭 listenKey (USER_STREAM)
+func (o *userDataStreamRequest) CallDelete(ctx context.Context) (err error) {
+	req := &binance.Request{
+		Method: http.MethodDelete,
+		Path:   o.path,
+	}
+	resp, err := o.Do(ctx, req)
+	if err != nil {
+		o.Debugf("userDataStreamRequest response err:%v", err)
+		return err
+	}
+	_, err = binance.ParseHttpResponse[struct{}](resp)
+	return err
+}
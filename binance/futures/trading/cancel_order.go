@@ -6,7 +6,6 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type CancelOrder interface {
@@ -62,7 +61,7 @@ func (d *cancelOrderRequest) CallCountdownCancelAll(ctx context.Context, countdo
 		d.Debugf("CallCountdownCancelAll response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*cancelOrderResponse](resp)
+	return binance.ParseHttpResponse[*cancelOrderResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
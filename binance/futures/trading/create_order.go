@@ -8,7 +8,8 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/futures/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/binance/rules"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type CreateOrder interface {
@@ -31,6 +32,8 @@ type CreateOrder interface {
 	SetStopPrice(stopPrice string) *CreateOrderRequest
 	SetNewOrderRespType(newOrderRespType enums.NewOrderRespType) *CreateOrderRequest
 	SetSelfTradePreventionMode(selfTradePreventionMode enums.StpModeType) *CreateOrderRequest
+	SetRules(registry *rules.Registry, round bool) *CreateOrderRequest
+	Validate(ctx context.Context) error
 	Call(ctx context.Context) (body *createOrderResponse, err error)
 	CallTest(ctx context.Context) (body *createOrderResponse, err error)
 	CallBatch(ctx context.Context, data []*CreateOrderRequest) (body []*createOrderResponse, err error)
@@ -56,6 +59,7 @@ type CreateOrderRequest struct {
 	PriceMatch              enums.PriceMatchType   `json:"priceMatch,omitempty"`              //OPPONENT/ OPPONENT_5/ OPPONENT_10/ OPPONENT_20/QUEUE/ QUEUE_5/ QUEUE_10/ QUEUE_20；不能与price同时传
 	SelfTradePreventionMode enums.StpModeType      `json:"selfTradePreventionMode,omitempty"` //允许的 ENUM 取决于交易对的配置。
 	GoodTillDate            *int                   `json:"goodTillDate,omitempty"`            //TIF为GTD时订单的自动取消时间， 当timeInforce为GTD时必传；传入的时间戳仅保留秒级精度，毫秒级部分会被自动忽略，时间戳需大于当前时间+600s且小于253402300799000
+	validator               *rules.Validator
 }
 type createOrderResponse struct {
 	Code                    int                    `json:"code,omitempty"`
@@ -88,6 +92,34 @@ type createOrderResponse struct {
 	GoodTillDate            int64                  `json:"goodTillDate"`
 }
 
+func (c createOrderResponse) CumQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.CumQty)
+}
+func (c createOrderResponse) CumQuoteDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.CumQuote)
+}
+func (c createOrderResponse) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.ExecutedQty)
+}
+func (c createOrderResponse) AvgPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.AvgPrice)
+}
+func (c createOrderResponse) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.OrigQty)
+}
+func (c createOrderResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.Price)
+}
+func (c createOrderResponse) StopPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.StopPrice)
+}
+func (c createOrderResponse) ActivatePriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.ActivatePrice)
+}
+func (c createOrderResponse) PriceRateDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.PriceRate)
+}
+
 func (c *CreateOrderRequest) SetSymbol(symbol string) *CreateOrderRequest {
 	c.Symbol = symbol
 	return c
@@ -168,12 +200,9 @@ func (c *CreateOrderRequest) SetGoodTillDate(goodTillDate int) *CreateOrderReque
 func NewOrder(client *binance.Client, symbol string) CreateOrder {
 	return &CreateOrderRequest{Client: client, Symbol: symbol}
 }
-func (c *CreateOrderRequest) Call(ctx context.Context) (body *createOrderResponse, err error) {
-	req := &binance.Request{
-		Method: http.MethodPost,
-		Path:   consts.FApiOrder,
-	}
-	req.SetNeedSign(true)
+
+// setParams 下单参数，REST 和 WS API 共用
+func (c *CreateOrderRequest) setParams(req *binance.Request) {
 	req.SetParam("symbol", c.Symbol)
 	req.SetParam("side", c.Side)
 	req.SetOptionalParam("positionSide", c.PositionSide)
@@ -193,16 +222,40 @@ func (c *CreateOrderRequest) Call(ctx context.Context) (body *createOrderRespons
 	req.SetOptionalParam("priceMatch", c.PriceMatch)
 	req.SetOptionalParam("selfTradePreventionMode", c.SelfTradePreventionMode)
 	req.SetOptionalParam("goodTillDate", c.GoodTillDate)
+}
+
+func (c *CreateOrderRequest) Call(ctx context.Context) (body *createOrderResponse, err error) {
+	err = c.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := &binance.Request{
+		Method: http.MethodPost,
+		Path:   consts.FApiOrder,
+	}
+	req.SetNeedSign(true)
+	c.setParams(req)
 	resp, err := c.Do(ctx, req)
 	if err != nil {
 		c.Debugf("createOrderRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*createOrderResponse](resp)
+	return binance.ParseHttpResponse[*createOrderResponse](resp)
 }
 
 // CallBatch 批量下单(TRADE)
 func (c *CreateOrderRequest) CallBatch(ctx context.Context, data []*CreateOrderRequest) (body []*createOrderResponse, err error) {
+	for _, d := range data {
+		// 未单独设置规则的订单使用 c 的规则
+		v := d.validator
+		if v == nil {
+			v = c.validator
+		}
+		err = d.validate(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+	}
 	req := &binance.Request{
 		Method: http.MethodPost,
 		Path:   consts.FApiBatchOrders,
@@ -213,47 +266,58 @@ func (c *CreateOrderRequest) CallBatch(ctx context.Context, data []*CreateOrderR
 		return nil, err
 	}
 	req.SetParam("batchOrders", string(bytes))
+	req.SetOrderCount(len(data))
 	resp, err := c.Do(ctx, req)
 	if err != nil {
 		c.Debugf("CallBatch response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*createOrderResponse](resp)
+	return binance.ParseHttpResponse[[]*createOrderResponse](resp)
 }
 
 func (c *CreateOrderRequest) CallTest(ctx context.Context) (body *createOrderResponse, err error) {
+	err = c.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
 	// 没有 computeCommissionRates返回空
 	req := &binance.Request{
 		Method: http.MethodPost,
 		Path:   consts.FApiTradingOrderTest,
 	}
 	req.SetNeedSign(true)
-	req.SetParam("symbol", c.Symbol)
-	req.SetParam("side", c.Side)
-	req.SetOptionalParam("positionSide", c.PositionSide)
-	req.SetParam("type", c.Type)
-	req.SetOptionalParam("reduceOnly", c.ReduceOnly)
-	req.SetOptionalParam("quantity", c.Quantity)
-	req.SetOptionalParam("price", c.Price)
-	req.SetOptionalParam("newClientOrderId", c.NewClientOrderId)
-	req.SetOptionalParam("stopPrice", c.StopPrice)
-	req.SetOptionalParam("closePosition", c.ClosePosition)
-	req.SetOptionalParam("activationPrice", c.ActivationPrice)
-	req.SetOptionalParam("callbackRate", c.CallbackRate)
-	req.SetOptionalParam("timeInForce", c.TimeInForce)
-	req.SetOptionalParam("workingType", c.WorkingType)
-	req.SetOptionalParam("priceProtect", c.PriceProtect)
-	req.SetOptionalParam("newOrderRespType", c.NewOrderRespType)
-	req.SetOptionalParam("priceMatch", c.PriceMatch)
-	req.SetOptionalParam("selfTradePreventionMode", c.SelfTradePreventionMode)
-	req.SetOptionalParam("goodTillDate", c.GoodTillDate)
-
+	c.setParams(req)
 	resp, err := c.Do(ctx, req)
 	if err != nil {
 		c.Debugf("createOrderTestRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*createOrderResponse](resp)
+	return binance.ParseHttpResponse[*createOrderResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
+
+type WsApiCreateOrder interface {
+	binance.WsApi[*WsApiCreateOrderResponse]
+	CreateOrder
+}
+type WsApiCreateOrderResponse struct {
+	binance.WsApiResponse
+	Result *createOrderResponse `json:"result"`
+}
+
+func NewWsApiCreateOrder(c *binance.Client, symbol string) WsApiCreateOrder {
+	return &CreateOrderRequest{Client: c, Symbol: symbol}
+}
+
+// Send 下单 (TRADE)
+func (c *CreateOrderRequest) Send(ctx context.Context) (*WsApiCreateOrderResponse, error) {
+	err := c.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := &binance.Request{Path: "order.place"}
+	req.SetNeedSign(true)
+	c.setParams(req)
+	return binance.WsApiHandler[*WsApiCreateOrderResponse](ctx, c.Client, req)
+}
@@ -7,8 +7,8 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 	"github.com/sleep-go/coin-go/pkg/errors"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type DeleteOrder interface {
@@ -58,6 +58,31 @@ type deleteOrderResponse struct {
 	GoodTillDate            int                    `json:"goodTillDate"`            //订单TIF为GTD时的自动取消时间
 }
 
+func (d deleteOrderResponse) CumQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.CumQty)
+}
+func (d deleteOrderResponse) CumQuoteDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.CumQuote)
+}
+func (d deleteOrderResponse) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.ExecutedQty)
+}
+func (d deleteOrderResponse) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.OrigQty)
+}
+func (d deleteOrderResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.Price)
+}
+func (d deleteOrderResponse) StopPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.StopPrice)
+}
+func (d deleteOrderResponse) ActivatePriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.ActivatePrice)
+}
+func (d deleteOrderResponse) PriceRateDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.PriceRate)
+}
+
 func NewDeleteOrder(client *binance.Client, symbol string) DeleteOrder {
 	return &deleteOrderRequest{Client: client, symbol: symbol}
 }
@@ -90,7 +115,7 @@ func (d *deleteOrderRequest) Call(ctx context.Context) (body *deleteOrderRespons
 		d.Debugf("deleteOrderRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*deleteOrderResponse](resp)
+	return binance.ParseHttpResponse[*deleteOrderResponse](resp)
 }
 
 // CallBatch 批量撤销订单 (TRADE)
@@ -107,7 +132,7 @@ func (d *deleteOrderRequest) CallBatch(ctx context.Context, orderIdList []int64)
 		d.Debugf("CallBatch response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*deleteOrderResponse](resp)
+	return binance.ParseHttpResponse[[]*deleteOrderResponse](resp)
 }
 
 // CallAllOpenOrders 撤销全部订单(TRADE)
@@ -123,7 +148,30 @@ func (d *deleteOrderRequest) CallAllOpenOrders(ctx context.Context) (body *error
 		d.Debugf("CallAllOpenOrders response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*errors.Status](resp)
+	return binance.ParseHttpResponse[*errors.Status](resp)
 }
 
 // ****************************** Websocket Api *******************************
+
+type WsApiDeleteOrder interface {
+	binance.WsApi[*WsApiDeleteOrderResponse]
+	DeleteOrder
+}
+type WsApiDeleteOrderResponse struct {
+	binance.WsApiResponse
+	Result *deleteOrderResponse `json:"result"`
+}
+
+func NewWsApiDeleteOrder(c *binance.Client, symbol string) WsApiDeleteOrder {
+	return &deleteOrderRequest{Client: c, symbol: symbol}
+}
+
+// Send 撤销订单 (TRADE)
+func (d *deleteOrderRequest) Send(ctx context.Context) (*WsApiDeleteOrderResponse, error) {
+	req := &binance.Request{Path: "order.cancel"}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", d.symbol)
+	req.SetOptionalParam("orderId", d.orderId)
+	req.SetOptionalParam("origClientOrderId", d.origClientOrderId)
+	return binance.WsApiHandler[*WsApiDeleteOrderResponse](ctx, d.Client, req)
+}
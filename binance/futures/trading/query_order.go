@@ -7,13 +7,13 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/futures/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type QueryOrder interface {
-	SetSymbol(symbol string) QueryOrder
-	SetOrderId(orderId int64) QueryOrder
-	SetOrigClientOrderId(origClientOrderId string) QueryOrder
+	SetSymbol(symbol string) *queryOrderRequest
+	SetOrderId(orderId int64) *queryOrderRequest
+	SetOrigClientOrderId(origClientOrderId string) *queryOrderRequest
 	Call(ctx context.Context) (body *queryOrderResponse, err error)
 }
 
@@ -54,20 +54,45 @@ type queryOrderResponse struct {
 	GoodTillDate            int                    `json:"goodTillDate"`
 }
 
+func (q queryOrderResponse) AvgPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(q.AvgPrice)
+}
+func (q queryOrderResponse) CumQuoteDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(q.CumQuote)
+}
+func (q queryOrderResponse) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(q.ExecutedQty)
+}
+func (q queryOrderResponse) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(q.OrigQty)
+}
+func (q queryOrderResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(q.Price)
+}
+func (q queryOrderResponse) StopPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(q.StopPrice)
+}
+func (q queryOrderResponse) ActivatePriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(q.ActivatePrice)
+}
+func (q queryOrderResponse) PriceRateDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(q.PriceRate)
+}
+
 func NewQueryOrder(client *binance.Client, symbol string) QueryOrder {
 	return &queryOrderRequest{Client: client, symbol: symbol}
 }
 
-func (d *queryOrderRequest) SetSymbol(symbol string) QueryOrder {
+func (d *queryOrderRequest) SetSymbol(symbol string) *queryOrderRequest {
 	d.symbol = symbol
 	return d
 }
-func (d *queryOrderRequest) SetOrderId(orderId int64) QueryOrder {
+func (d *queryOrderRequest) SetOrderId(orderId int64) *queryOrderRequest {
 	d.orderId = &orderId
 	return d
 }
 
-func (d *queryOrderRequest) SetOrigClientOrderId(origClientOrderId string) QueryOrder {
+func (d *queryOrderRequest) SetOrigClientOrderId(origClientOrderId string) *queryOrderRequest {
 	d.origClientOrderId = &origClientOrderId
 	return d
 }
@@ -86,5 +111,30 @@ func (d *queryOrderRequest) Call(ctx context.Context) (body *queryOrderResponse,
 		d.Debugf("queryOrderRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*queryOrderResponse](resp)
+	return binance.ParseHttpResponse[*queryOrderResponse](resp)
+}
+
+// ****************************** Websocket Api *******************************
+
+type WsApiQueryOrder interface {
+	binance.WsApi[*WsApiQueryOrderResponse]
+	QueryOrder
+}
+type WsApiQueryOrderResponse struct {
+	binance.WsApiResponse
+	Result *queryOrderResponse `json:"result"`
+}
+
+func NewWsApiQueryOrder(c *binance.Client, symbol string) WsApiQueryOrder {
+	return &queryOrderRequest{Client: c, symbol: symbol}
+}
+
+// Send 查询订单 (USER_DATA)
+func (d *queryOrderRequest) Send(ctx context.Context) (*WsApiQueryOrderResponse, error) {
+	req := &binance.Request{Path: "order.status"}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", d.symbol)
+	req.SetOptionalParam("orderId", d.orderId)
+	req.SetOptionalParam("origClientOrderId", d.origClientOrderId)
+	return binance.WsApiHandler[*WsApiQueryOrderResponse](ctx, d.Client, req)
 }
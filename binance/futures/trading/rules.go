@@ -0,0 +1,45 @@
+package trading
+
+import (
+	"context"
+
+	"github.com/sleep-go/coin-go/binance/rules"
+)
+
+// SetRules 下单前按 exchangeInfo 的过滤器检查订单，round 为 true 时先把价格舍入到 tickSize、数量舍入到 stepSize
+func (c *CreateOrderRequest) SetRules(registry *rules.Registry, round bool) *CreateOrderRequest {
+	c.validator = &rules.Validator{Registry: registry, Round: round}
+	return c
+}
+
+// Validate 按交易对规则检查订单，未通过时返回 *rules.ValidationError
+func (c *CreateOrderRequest) Validate(ctx context.Context) error {
+	return c.validate(ctx, c.validator)
+}
+
+func (c *CreateOrderRequest) validate(ctx context.Context, v *rules.Validator) error {
+	if v == nil || v.Registry == nil {
+		return nil
+	}
+	o := &rules.Order{Side: string(c.Side), Type: string(c.Type)}
+	var err error
+	o.Price, err = rules.ParseDecimal("price", c.Price)
+	if err != nil {
+		return err
+	}
+	o.StopPrice, err = rules.ParseDecimal("stopPrice", c.StopPrice)
+	if err != nil {
+		return err
+	}
+	o.Quantity, err = rules.ParseDecimal("quantity", c.Quantity)
+	if err != nil {
+		return err
+	}
+	err = v.Check(ctx, c.Symbol, o)
+	if v.Round {
+		rules.FormatDecimal(o.Price, &c.Price)
+		rules.FormatDecimal(o.StopPrice, &c.StopPrice)
+		rules.FormatDecimal(o.Quantity, &c.Quantity)
+	}
+	return err
+}
@@ -8,7 +8,7 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/futures/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type UpdateOrder interface {
@@ -71,6 +71,28 @@ type updateOrderResponse struct {
 	UpdateTime              int64                  `json:"updateTime"`              // 更新时间
 }
 
+func (u updateOrderResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(u.Price)
+}
+func (u updateOrderResponse) AvgPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(u.AvgPrice)
+}
+func (u updateOrderResponse) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(u.OrigQty)
+}
+func (u updateOrderResponse) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(u.ExecutedQty)
+}
+func (u updateOrderResponse) CumQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(u.CumQty)
+}
+func (u updateOrderResponse) CumBaseDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(u.CumBase)
+}
+func (u updateOrderResponse) StopPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(u.StopPrice)
+}
+
 func (c *UpdateOrderRequest) SetOrderId(orderId int64) *UpdateOrderRequest {
 	c.OrderId = &orderId
 	return c
@@ -103,12 +125,9 @@ func (c *UpdateOrderRequest) SetPrice(price string) *UpdateOrderRequest {
 func NewUpdateOrder(client *binance.Client, symbol string) UpdateOrder {
 	return &UpdateOrderRequest{Client: client, Symbol: symbol}
 }
-func (c *UpdateOrderRequest) Call(ctx context.Context) (body *updateOrderResponse, err error) {
-	req := &binance.Request{
-		Method: http.MethodPut,
-		Path:   consts.FApiOrder,
-	}
-	req.SetNeedSign(true)
+
+// setParams 改单参数，REST 和 WS API 共用
+func (c *UpdateOrderRequest) setParams(req *binance.Request) {
 	req.SetOptionalParam("orderId", c.OrderId)
 	req.SetOptionalParam("origClientOrderId", c.OrigClientOrderId)
 	req.SetParam("symbol", c.Symbol)
@@ -116,12 +135,21 @@ func (c *UpdateOrderRequest) Call(ctx context.Context) (body *updateOrderRespons
 	req.SetParam("quantity", c.Quantity)
 	req.SetParam("price", c.Price)
 	req.SetOptionalParam("priceMatch", c.PriceMatch)
+}
+
+func (c *UpdateOrderRequest) Call(ctx context.Context) (body *updateOrderResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodPut,
+		Path:   consts.FApiOrder,
+	}
+	req.SetNeedSign(true)
+	c.setParams(req)
 	resp, err := c.Do(ctx, req)
 	if err != nil {
 		c.Debugf("UpdateOrderRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*updateOrderResponse](resp)
+	return binance.ParseHttpResponse[*updateOrderResponse](resp)
 }
 
 // CallBatch 批量下单(TRADE)
@@ -141,12 +169,35 @@ func (c *UpdateOrderRequest) CallBatch(ctx context.Context, data []*UpdateOrderR
 		return nil, err
 	}
 	req.SetParam("batchOrders", string(bytes))
+	req.SetOrderCount(len(data))
 	resp, err := c.Do(ctx, req)
 	if err != nil {
 		c.Debugf("CallBatch response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*updateOrderResponse](resp)
+	return binance.ParseHttpResponse[[]*updateOrderResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
+
+type WsApiUpdateOrder interface {
+	binance.WsApi[*WsApiUpdateOrderResponse]
+	UpdateOrder
+}
+type WsApiUpdateOrderResponse struct {
+	binance.WsApiResponse
+	Result *updateOrderResponse `json:"result"`
+}
+
+func NewWsApiUpdateOrder(c *binance.Client, symbol string) WsApiUpdateOrder {
+	return &UpdateOrderRequest{Client: c, Symbol: symbol}
+}
+
+// Send 修改订单 (TRADE)
+// 目前只支持 LIMIT 订单修改，修改后会在撮合队列里重新排序
+func (c *UpdateOrderRequest) Send(ctx context.Context) (*WsApiUpdateOrderResponse, error) {
+	req := &binance.Request{Path: "order.modify"}
+	req.SetNeedSign(true)
+	c.setParams(req)
+	return binance.WsApiHandler[*WsApiUpdateOrderResponse](ctx, c.Client, req)
+}
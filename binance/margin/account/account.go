@@ -0,0 +1,62 @@
+// Package account 杠杆账户资产、借贷和成交记录
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// Account 查询全仓杠杆账户详情
+type Account interface {
+	Call(ctx context.Context) (body *accountResponse, err error)
+}
+
+type accountRequest struct {
+	*binance.Client
+}
+
+type accountResponse struct {
+	Created                    bool            `json:"created"`                    // 是否已开通全仓杠杆账户
+	BorrowEnabled              bool            `json:"borrowEnabled"`              // 是否可以借贷
+	TradeEnabled               bool            `json:"tradeEnabled"`               // 是否可以交易
+	TransferInEnabled          bool            `json:"transferInEnabled"`          // 是否可以转入
+	TransferOutEnabled         bool            `json:"transferOutEnabled"`         // 是否可以转出
+	MarginLevel                decimal.Decimal `json:"marginLevel"`                // 风险率
+	CollateralMarginLevel      decimal.Decimal `json:"collateralMarginLevel"`      // 抵押品风险率
+	TotalAssetOfBtc            decimal.Decimal `json:"totalAssetOfBtc"`            // 总资产，以 BTC 计价
+	TotalLiabilityOfBtc        decimal.Decimal `json:"totalLiabilityOfBtc"`        // 总负债，以 BTC 计价
+	TotalNetAssetOfBtc         decimal.Decimal `json:"totalNetAssetOfBtc"`         // 净资产，以 BTC 计价
+	TotalCollateralValueInUSDT decimal.Decimal `json:"totalCollateralValueInUSDT"` // 抵押品价值，以 USDT 计价
+	AccountType                string          `json:"accountType"`                // MARGIN_1 全仓经典，MARGIN_2 全仓专业
+	UserAssets                 []struct {
+		Asset    string          `json:"asset"`
+		Borrowed decimal.Decimal `json:"borrowed"` // 已借
+		Free     decimal.Decimal `json:"free"`     // 可用
+		Interest decimal.Decimal `json:"interest"` // 未还利息
+		Locked   decimal.Decimal `json:"locked"`   // 下单冻结
+		NetAsset decimal.Decimal `json:"netAsset"` // 净资产 = free + locked - borrowed - interest
+	} `json:"userAssets"`
+}
+
+func NewAccount(client *binance.Client) Account {
+	return &accountRequest{Client: client}
+}
+
+// Call 查询全仓杠杆账户详情 (USER_DATA)
+func (a *accountRequest) Call(ctx context.Context) (body *accountResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.SApiMarginAccount,
+	}
+	req.SetNeedSign(true)
+	resp, err := a.Do(ctx, req)
+	if err != nil {
+		a.Debugf("accountRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*accountResponse](resp)
+}
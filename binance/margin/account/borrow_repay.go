@@ -0,0 +1,165 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// BorrowRepay 杠杆账户借贷/还款
+type BorrowRepay interface {
+	SetIsolatedSymbol(symbol string) *borrowRepayRequest
+	Call(ctx context.Context) (body *borrowRepayResponse, err error)
+}
+
+type borrowRepayRequest struct {
+	*binance.Client
+	asset  string
+	amount string
+	_type  enums.BorrowRepayType
+	symbol *string //逐仓交易对，全仓不传
+}
+
+type borrowRepayResponse struct {
+	TranId int64 `json:"tranId"` // 交易ID
+}
+
+// NewBorrow 借贷
+func NewBorrow(client *binance.Client, asset, amount string) BorrowRepay {
+	return &borrowRepayRequest{Client: client, asset: asset, amount: amount, _type: enums.BorrowRepayTypeBorrow}
+}
+
+// NewRepay 还款，优先归还利息
+func NewRepay(client *binance.Client, asset, amount string) BorrowRepay {
+	return &borrowRepayRequest{Client: client, asset: asset, amount: amount, _type: enums.BorrowRepayTypeRepay}
+}
+
+// SetIsolatedSymbol 在逐仓账户 symbol 中借贷/还款
+func (b *borrowRepayRequest) SetIsolatedSymbol(symbol string) *borrowRepayRequest {
+	b.symbol = &symbol
+	return b
+}
+
+// Call 杠杆账户借贷/还款 (MARGIN)
+func (b *borrowRepayRequest) Call(ctx context.Context) (body *borrowRepayResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodPost,
+		Path:   consts.SApiMarginBorrowRepay,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("asset", b.asset)
+	req.SetParam("amount", b.amount)
+	req.SetParam("type", b._type)
+	if b.symbol != nil {
+		req.SetParam("isIsolated", "TRUE")
+		req.SetParam("symbol", *b.symbol)
+	} else {
+		req.SetParam("isIsolated", "FALSE")
+	}
+	resp, err := b.Do(ctx, req)
+	if err != nil {
+		b.Debugf("borrowRepayRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*borrowRepayResponse](resp)
+}
+
+// BorrowRepayRecords 查询借贷/还款记录
+type BorrowRepayRecords interface {
+	SetAsset(asset string) *borrowRepayRecordsRequest
+	SetIsolatedSymbol(isolatedSymbol string) *borrowRepayRecordsRequest
+	SetTxId(txId int64) *borrowRepayRecordsRequest
+	SetStartTime(startTime int64) *borrowRepayRecordsRequest
+	SetEndTime(endTime int64) *borrowRepayRecordsRequest
+	SetCurrent(current int) *borrowRepayRecordsRequest
+	SetSize(size int) *borrowRepayRecordsRequest
+	Call(ctx context.Context) (body *borrowRepayRecordsResponse, err error)
+}
+
+// 只能查询最近6个月的数据；不传 startTime 和 endTime 时返回最近7天，startTime 和 endTime 最多相差30天
+type borrowRepayRecordsRequest struct {
+	*binance.Client
+	_type          enums.BorrowRepayType
+	asset          *string
+	isolatedSymbol *string
+	txId           *int64
+	startTime      *int64
+	endTime        *int64
+	current        *int //当前页，从 1 开始，默认 1
+	size           *int //每页数量，默认 10，最大 100
+}
+
+type borrowRepayRecordsResponse struct {
+	Rows []struct {
+		Type           enums.BorrowRepayType `json:"type"`
+		IsolatedSymbol string                `json:"isolatedSymbol"` // 逐仓交易对，全仓为空
+		Asset          string                `json:"asset"`
+		Amount         decimal.Decimal       `json:"amount"`    // 借贷或还款总额
+		Principal      decimal.Decimal       `json:"principal"` // 本金
+		Interest       decimal.Decimal       `json:"interest"`  // 还款中的利息
+		Status         string                `json:"status"`    // PENDING, CONFIRMED, FAILED
+		Timestamp      int64                 `json:"timestamp"`
+		TxId           int64                 `json:"txId"`
+	} `json:"rows"`
+	Total int `json:"total"`
+}
+
+func NewBorrowRepayRecords(client *binance.Client, _type enums.BorrowRepayType) BorrowRepayRecords {
+	return &borrowRepayRecordsRequest{Client: client, _type: _type}
+}
+
+func (b *borrowRepayRecordsRequest) SetAsset(asset string) *borrowRepayRecordsRequest {
+	b.asset = &asset
+	return b
+}
+func (b *borrowRepayRecordsRequest) SetIsolatedSymbol(isolatedSymbol string) *borrowRepayRecordsRequest {
+	b.isolatedSymbol = &isolatedSymbol
+	return b
+}
+func (b *borrowRepayRecordsRequest) SetTxId(txId int64) *borrowRepayRecordsRequest {
+	b.txId = &txId
+	return b
+}
+func (b *borrowRepayRecordsRequest) SetStartTime(startTime int64) *borrowRepayRecordsRequest {
+	b.startTime = &startTime
+	return b
+}
+func (b *borrowRepayRecordsRequest) SetEndTime(endTime int64) *borrowRepayRecordsRequest {
+	b.endTime = &endTime
+	return b
+}
+func (b *borrowRepayRecordsRequest) SetCurrent(current int) *borrowRepayRecordsRequest {
+	b.current = &current
+	return b
+}
+func (b *borrowRepayRecordsRequest) SetSize(size int) *borrowRepayRecordsRequest {
+	b.size = &size
+	return b
+}
+
+// Call 查询借贷/还款记录 (USER_DATA)
+func (b *borrowRepayRecordsRequest) Call(ctx context.Context) (body *borrowRepayRecordsResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.SApiMarginBorrowRepay,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("type", b._type)
+	req.SetOptionalParam("asset", b.asset)
+	req.SetOptionalParam("isolatedSymbol", b.isolatedSymbol)
+	req.SetOptionalParam("txId", b.txId)
+	req.SetOptionalParam("startTime", b.startTime)
+	req.SetOptionalParam("endTime", b.endTime)
+	req.SetOptionalParam("current", b.current)
+	req.SetOptionalParam("size", b.size)
+	resp, err := b.Do(ctx, req)
+	if err != nil {
+		b.Debugf("borrowRepayRecordsRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*borrowRepayRecordsResponse](resp)
+}
@@ -0,0 +1,97 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// InterestHistory 查询利息历史
+type InterestHistory interface {
+	SetAsset(asset string) *interestHistoryRequest
+	SetIsolatedSymbol(isolatedSymbol string) *interestHistoryRequest
+	SetStartTime(startTime int64) *interestHistoryRequest
+	SetEndTime(endTime int64) *interestHistoryRequest
+	SetCurrent(current int) *interestHistoryRequest
+	SetSize(size int) *interestHistoryRequest
+	Call(ctx context.Context) (body *interestHistoryResponse, err error)
+}
+
+// 只能查询最近6个月的数据；不传 startTime 和 endTime 时返回最近7天，startTime 和 endTime 最多相差30天
+type interestHistoryRequest struct {
+	*binance.Client
+	asset          *string
+	isolatedSymbol *string
+	startTime      *int64
+	endTime        *int64
+	current        *int //当前页，从 1 开始，默认 1
+	size           *int //每页数量，默认 10，最大 100
+}
+
+type interestHistoryResponse struct {
+	Rows []struct {
+		TxId                int64           `json:"txId"`
+		InterestAccuredTime int64           `json:"interestAccuredTime"` // 计息时间
+		Asset               string          `json:"asset"`
+		RawAsset            string          `json:"rawAsset"`       // 以 BNB 抵扣利息时为原资产
+		Principal           decimal.Decimal `json:"principal"`      // 本金
+		Interest            decimal.Decimal `json:"interest"`       // 利息
+		InterestRate        decimal.Decimal `json:"interestRate"`   // 日利率
+		Type                string          `json:"type"`           // PERIODIC 定期计息, ON_BORROW 借款时首次计息, PERIODIC_CONVERTED 以 BNB 抵扣, ON_BORROW_CONVERTED, PORTFOLIO
+		IsolatedSymbol      string          `json:"isolatedSymbol"` // 逐仓交易对，全仓为空
+	} `json:"rows"`
+	Total int `json:"total"`
+}
+
+func NewInterestHistory(client *binance.Client) InterestHistory {
+	return &interestHistoryRequest{Client: client}
+}
+
+func (i *interestHistoryRequest) SetAsset(asset string) *interestHistoryRequest {
+	i.asset = &asset
+	return i
+}
+func (i *interestHistoryRequest) SetIsolatedSymbol(isolatedSymbol string) *interestHistoryRequest {
+	i.isolatedSymbol = &isolatedSymbol
+	return i
+}
+func (i *interestHistoryRequest) SetStartTime(startTime int64) *interestHistoryRequest {
+	i.startTime = &startTime
+	return i
+}
+func (i *interestHistoryRequest) SetEndTime(endTime int64) *interestHistoryRequest {
+	i.endTime = &endTime
+	return i
+}
+func (i *interestHistoryRequest) SetCurrent(current int) *interestHistoryRequest {
+	i.current = &current
+	return i
+}
+func (i *interestHistoryRequest) SetSize(size int) *interestHistoryRequest {
+	i.size = &size
+	return i
+}
+
+// Call 查询利息历史 (USER_DATA)
+func (i *interestHistoryRequest) Call(ctx context.Context) (body *interestHistoryResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.SApiMarginInterestHistory,
+	}
+	req.SetNeedSign(true)
+	req.SetOptionalParam("asset", i.asset)
+	req.SetOptionalParam("isolatedSymbol", i.isolatedSymbol)
+	req.SetOptionalParam("startTime", i.startTime)
+	req.SetOptionalParam("endTime", i.endTime)
+	req.SetOptionalParam("current", i.current)
+	req.SetOptionalParam("size", i.size)
+	resp, err := i.Do(ctx, req)
+	if err != nil {
+		i.Debugf("interestHistoryRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*interestHistoryResponse](resp)
+}
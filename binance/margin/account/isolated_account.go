@@ -0,0 +1,81 @@
+package account
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// IsolatedAccount 查询逐仓杠杆账户信息
+type IsolatedAccount interface {
+	SetSymbols(symbols []string) *isolatedAccountRequest
+	Call(ctx context.Context) (body *isolatedAccountResponse, err error)
+}
+
+type isolatedAccountRequest struct {
+	*binance.Client
+	symbols []string //最多 5 个交易对，不传时返回所有逐仓账户
+}
+
+type isolatedAsset struct {
+	Asset         string          `json:"asset"`
+	BorrowEnabled bool            `json:"borrowEnabled"` // 是否可以借贷
+	RepayEnabled  bool            `json:"repayEnabled"`  // 是否可以还款
+	Borrowed      decimal.Decimal `json:"borrowed"`      // 已借
+	Free          decimal.Decimal `json:"free"`          // 可用
+	Interest      decimal.Decimal `json:"interest"`      // 未还利息
+	Locked        decimal.Decimal `json:"locked"`        // 下单冻结
+	NetAsset      decimal.Decimal `json:"netAsset"`      // 净资产
+	NetAssetOfBtc decimal.Decimal `json:"netAssetOfBtc"` // 净资产，以 BTC 计价
+	TotalAsset    decimal.Decimal `json:"totalAsset"`    // 总资产
+}
+
+type isolatedAccountResponse struct {
+	Assets []struct {
+		Symbol            string          `json:"symbol"`
+		BaseAsset         isolatedAsset   `json:"baseAsset"`
+		QuoteAsset        isolatedAsset   `json:"quoteAsset"`
+		IsolatedCreated   bool            `json:"isolatedCreated"`   // 是否已开通该交易对的逐仓账户
+		Enabled           bool            `json:"enabled"`           // 账户是否启用，false 时不计入 totalAssetOfBtc
+		TradeEnabled      bool            `json:"tradeEnabled"`      // 是否可以交易
+		MarginLevel       decimal.Decimal `json:"marginLevel"`       // 风险率
+		MarginLevelStatus string          `json:"marginLevelStatus"` // EXCESSIVE, NORMAL, MARGIN_CALL, PRE_LIQUIDATION, FORCE_LIQUIDATION
+		MarginRatio       decimal.Decimal `json:"marginRatio"`       // 最大杠杆倍数
+		IndexPrice        decimal.Decimal `json:"indexPrice"`        // 指数价格
+		LiquidatePrice    decimal.Decimal `json:"liquidatePrice"`    // 强平价格
+		LiquidateRate     decimal.Decimal `json:"liquidateRate"`     // 强平风险率
+	} `json:"assets"`
+	TotalAssetOfBtc     decimal.Decimal `json:"totalAssetOfBtc"`
+	TotalLiabilityOfBtc decimal.Decimal `json:"totalLiabilityOfBtc"`
+	TotalNetAssetOfBtc  decimal.Decimal `json:"totalNetAssetOfBtc"`
+}
+
+func NewIsolatedAccount(client *binance.Client) IsolatedAccount {
+	return &isolatedAccountRequest{Client: client}
+}
+
+func (i *isolatedAccountRequest) SetSymbols(symbols []string) *isolatedAccountRequest {
+	i.symbols = symbols
+	return i
+}
+
+// Call 查询逐仓杠杆账户信息 (USER_DATA)
+// 传 symbols 时不返回 totalAssetOfBtc 等汇总字段
+func (i *isolatedAccountRequest) Call(ctx context.Context) (body *isolatedAccountResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.SApiMarginIsolatedAccount,
+	}
+	req.SetNeedSign(true)
+	req.SetOptionalParam("symbols", strings.Join(i.symbols, ","))
+	resp, err := i.Do(ctx, req)
+	if err != nil {
+		i.Debugf("isolatedAccountRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*isolatedAccountResponse](resp)
+}
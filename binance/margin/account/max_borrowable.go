@@ -0,0 +1,76 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// MaxBorrowable 查询最大可借贷额度和最大可转出额
+type MaxBorrowable interface {
+	SetIsolatedSymbol(isolatedSymbol string) *maxBorrowableRequest
+	CallBorrowable(ctx context.Context) (body *maxBorrowableResponse, err error)
+	CallTransferable(ctx context.Context) (body *maxTransferableResponse, err error)
+}
+
+type maxBorrowableRequest struct {
+	*binance.Client
+	asset          string
+	isolatedSymbol *string //逐仓交易对，全仓不传
+}
+
+type maxBorrowableResponse struct {
+	Amount      decimal.Decimal `json:"amount"`      // 系统可借充足情况下用户账户当前最大可借额度
+	BorrowLimit decimal.Decimal `json:"borrowLimit"` // 平台借贷限额
+}
+
+type maxTransferableResponse struct {
+	Amount decimal.Decimal `json:"amount"` // 最大可转出额
+}
+
+func NewMaxBorrowable(client *binance.Client, asset string) MaxBorrowable {
+	return &maxBorrowableRequest{Client: client, asset: asset}
+}
+
+func (m *maxBorrowableRequest) SetIsolatedSymbol(isolatedSymbol string) *maxBorrowableRequest {
+	m.isolatedSymbol = &isolatedSymbol
+	return m
+}
+
+// CallBorrowable 查询账户最大可借贷额度 (USER_DATA)
+// 实际可借额度取 amount 和 borrowLimit 中较小的一个
+func (m *maxBorrowableRequest) CallBorrowable(ctx context.Context) (body *maxBorrowableResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.SApiMarginMaxBorrowable,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("asset", m.asset)
+	req.SetOptionalParam("isolatedSymbol", m.isolatedSymbol)
+	resp, err := m.Do(ctx, req)
+	if err != nil {
+		m.Debugf("maxBorrowableRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*maxBorrowableResponse](resp)
+}
+
+// CallTransferable 查询最大可转出额 (USER_DATA)
+func (m *maxBorrowableRequest) CallTransferable(ctx context.Context) (body *maxTransferableResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.SApiMarginMaxTransferable,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("asset", m.asset)
+	req.SetOptionalParam("isolatedSymbol", m.isolatedSymbol)
+	resp, err := m.Do(ctx, req)
+	if err != nil {
+		m.Debugf("maxBorrowableRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*maxTransferableResponse](resp)
+}
@@ -0,0 +1,103 @@
+package account
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// MyTrades 查询杠杆账户成交历史
+type MyTrades interface {
+	SetIsolated(isIsolated bool) *myTradesRequest
+	SetOrderId(orderId int64) *myTradesRequest
+	SetStartTime(startTime int64) *myTradesRequest
+	SetEndTime(endTime int64) *myTradesRequest
+	SetFromId(fromId int64) *myTradesRequest
+	SetLimit(limit enums.LimitType) *myTradesRequest
+	Call(ctx context.Context) (body []*myTradesResponse, err error)
+}
+
+// 设置了 fromId 时返回 id 大于等于 fromId 的成交，否则返回最近的成交
+// startTime 和 endTime 最多相差24小时
+type myTradesRequest struct {
+	*binance.Client
+	symbol     string
+	isIsolated bool
+	orderId    *int64
+	startTime  *int64
+	endTime    *int64
+	fromId     *int64
+	limit      enums.LimitType //默认 500，最大 1000
+}
+
+type myTradesResponse struct {
+	Symbol          string          `json:"symbol"`
+	Id              int64           `json:"id"`
+	OrderId         int64           `json:"orderId"`
+	Price           decimal.Decimal `json:"price"`
+	Qty             decimal.Decimal `json:"qty"`
+	Commission      decimal.Decimal `json:"commission"`
+	CommissionAsset string          `json:"commissionAsset"`
+	Time            int64           `json:"time"`
+	IsBuyer         bool            `json:"isBuyer"`
+	IsMaker         bool            `json:"isMaker"`
+	IsBestMatch     bool            `json:"isBestMatch"`
+	IsIsolated      bool            `json:"isIsolated"`
+}
+
+func NewMyTrades(client *binance.Client, symbol string) MyTrades {
+	return &myTradesRequest{Client: client, symbol: symbol}
+}
+
+func (m *myTradesRequest) SetIsolated(isIsolated bool) *myTradesRequest {
+	m.isIsolated = isIsolated
+	return m
+}
+func (m *myTradesRequest) SetOrderId(orderId int64) *myTradesRequest {
+	m.orderId = &orderId
+	return m
+}
+func (m *myTradesRequest) SetStartTime(startTime int64) *myTradesRequest {
+	m.startTime = &startTime
+	return m
+}
+func (m *myTradesRequest) SetEndTime(endTime int64) *myTradesRequest {
+	m.endTime = &endTime
+	return m
+}
+func (m *myTradesRequest) SetFromId(fromId int64) *myTradesRequest {
+	m.fromId = &fromId
+	return m
+}
+func (m *myTradesRequest) SetLimit(limit enums.LimitType) *myTradesRequest {
+	m.limit = limit
+	return m
+}
+
+// Call 查询杠杆账户成交历史 (USER_DATA)
+func (m *myTradesRequest) Call(ctx context.Context) (body []*myTradesResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.SApiMarginMyTrades,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", m.symbol)
+	if m.isIsolated {
+		req.SetParam("isIsolated", "TRUE")
+	}
+	req.SetOptionalParam("orderId", m.orderId)
+	req.SetOptionalParam("startTime", m.startTime)
+	req.SetOptionalParam("endTime", m.endTime)
+	req.SetOptionalParam("fromId", m.fromId)
+	req.SetOptionalParam("limit", m.limit)
+	resp, err := m.Do(ctx, req)
+	if err != nil {
+		m.Debugf("myTradesRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*myTradesResponse](resp)
+}
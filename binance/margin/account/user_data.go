@@ -0,0 +1,41 @@
+package account
+
+import (
+	"context"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/spot/account"
+)
+
+// ****************************** Websocket Stream *******************************
+
+// NewWsUserData 杠杆账户用户数据流，事件格式与现货一致
+// listenKey 由 stream.NewUserDataStream 或 stream.NewIsolatedUserDataStream 生成，c 为 consts.WS_STREAM 客户端
+func NewWsUserData(
+	ctx context.Context,
+	c *binance.Client,
+	listenKey string,
+	oap binance.Handler[*account.WsOutboundAccountPositionEvent],
+	bu binance.Handler[*account.WsBalanceUpdateEvent],
+	er binance.Handler[*account.WsExecutionReportEvent],
+	ls binance.Handler[*account.WsListStatusEvent],
+	lke binance.Handler[*account.WsListenKeyExpiredEvent],
+	exception binance.ErrorHandler,
+) (*binance.WsStream, error) {
+	return account.NewWsUserData(ctx, c, listenKey, oap, bu, er, ls, lke, exception)
+}
+
+// NewStreamUserData 杠杆账户用户数据流，组合流格式
+func NewStreamUserData(
+	ctx context.Context,
+	c *binance.Client,
+	listenKey string,
+	oap binance.Handler[*account.WsOutboundAccountPositionEvent],
+	bu binance.Handler[*account.WsBalanceUpdateEvent],
+	er binance.Handler[*account.WsExecutionReportEvent],
+	ls binance.Handler[*account.WsListStatusEvent],
+	lke binance.Handler[*account.WsListenKeyExpiredEvent],
+	exception binance.ErrorHandler,
+) (*binance.WsStream, error) {
+	return account.NewStreamUserData(ctx, c, listenKey, oap, bu, er, ls, lke, exception)
+}
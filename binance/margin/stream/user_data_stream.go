@@ -0,0 +1,95 @@
+// Package stream 杠杆账户 listenKey 管理
+package stream
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+)
+
+//	新建杠杆账户用户数据流 (USER_STREAM)
+//
+// 从创建起60分钟有效，推送通过现货 Stream 地址订阅
+type UserDataStream interface {
+	SetListenKey(listenKey string) *userDataStreamRequest
+	CallCreate(ctx context.Context) (body *userDataStreamResponse, err error)
+	CallUpdate(ctx context.Context) (err error)
+	CallDelete(ctx context.Context) (err error)
+}
+type userDataStreamRequest struct {
+	*binance.Client
+	path      string
+	symbol    *string //逐仓交易对
+	listenKey string
+}
+
+type userDataStreamResponse struct {
+	ListenKey string `json:"listenKey"` //用于订阅的数据流名
+}
+
+// NewUserDataStream 全仓杠杆账户
+func NewUserDataStream(client *binance.Client) UserDataStream {
+	return &userDataStreamRequest{Client: client, path: consts.SApiUserDataStream}
+}
+
+// NewIsolatedUserDataStream 逐仓杠杆账户，每个交易对的 listenKey 相互独立
+func NewIsolatedUserDataStream(client *binance.Client, symbol string) UserDataStream {
+	return &userDataStreamRequest{Client: client, path: consts.SApiUserDataStreamIsolated, symbol: &symbol}
+}
+
+func (o *userDataStreamRequest) SetListenKey(listenKey string) *userDataStreamRequest {
+	o.listenKey = listenKey
+	return o
+}
+
+// CallCreate 新建用户数据流 (USER_STREAM)
+// 从创建起60分钟有效
+func (o *userDataStreamRequest) CallCreate(ctx context.Context) (body *userDataStreamResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodPost,
+		Path:   o.path,
+	}
+	req.SetOptionalParam("symbol", o.symbol)
+	resp, err := o.Do(ctx, req)
+	if err != nil {
+		o.Debugf("userDataStreamRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*userDataStreamResponse](resp)
+}
+
+// CallUpdate 延长用户数据流有效期到60分钟之后。 建议每30分钟调用一次
+func (o *userDataStreamRequest) CallUpdate(ctx context.Context) (err error) {
+	req := &binance.Request{
+		Method: http.MethodPut,
+		Path:   o.path,
+	}
+	req.SetOptionalParam("symbol", o.symbol)
+	req.SetParam("listenKey", o.listenKey)
+	resp, err := o.Do(ctx, req)
+	if err != nil {
+		o.Debugf("userDataStreamRequest response err:%v", err)
+		return err
+	}
+	_, err = binance.ParseHttpResponse[struct{}](resp)
+	return err
+}
+
+// CallDelete 关闭用户数据流 (USER_STREAM)
+func (o *userDataStreamRequest) CallDelete(ctx context.Context) (err error) {
+	req := &binance.Request{
+		Method: http.MethodDelete,
+		Path:   o.path,
+	}
+	req.SetOptionalParam("symbol", o.symbol)
+	req.SetParam("listenKey", o.listenKey)
+	resp, err := o.Do(ctx, req)
+	if err != nil {
+		o.Debugf("userDataStreamRequest response err:%v", err)
+		return err
+	}
+	_, err = binance.ParseHttpResponse[struct{}](resp)
+	return err
+}
@@ -0,0 +1,192 @@
+// Package trading 杠杆账户交易
+//
+// 全仓和逐仓使用相同的接口，逐仓通过 SetIsolated(true) 指定，交易对规则与现货相同
+package trading
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/rules"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+type CreateOrder interface {
+	SetSymbol(symbol string) *createOrderRequest
+	SetIsolated(isIsolated bool) *createOrderRequest
+	SetSide(side enums.SideType) *createOrderRequest
+	SetType(orderType enums.OrderType) *createOrderRequest
+	SetTimeInForce(timeInForce enums.TimeInForceType) *createOrderRequest
+	SetQuantity(quantity string) *createOrderRequest
+	SetQuoteOrderQty(quoteOrderQty string) *createOrderRequest
+	SetPrice(price string) *createOrderRequest
+	SetStopPrice(stopPrice string) *createOrderRequest
+	SetNewClientOrderId(newClientOrderId string) *createOrderRequest
+	SetIcebergQty(icebergQty string) *createOrderRequest
+	SetNewOrderRespType(newOrderRespType enums.NewOrderRespType) *createOrderRequest
+	SetSideEffectType(sideEffectType enums.SideEffectType) *createOrderRequest
+	SetSelfTradePreventionMode(selfTradePreventionMode enums.StpModeType) *createOrderRequest
+	SetAutoRepayAtCancel(autoRepayAtCancel bool) *createOrderRequest
+	SetRules(registry *rules.Registry, round bool) *createOrderRequest
+	Validate(ctx context.Context) error
+	Call(ctx context.Context) (body *createOrderResponse, err error)
+}
+
+type createOrderRequest struct {
+	*binance.Client
+	symbol                  string
+	isIsolated              bool                  //是否逐仓杠杆，默认全仓
+	side                    enums.SideType        //订单方向
+	_type                   enums.OrderType       //订单类型
+	timeInForce             enums.TimeInForceType //生效时间
+	quantity                *string
+	quoteOrderQty           *string
+	price                   *string
+	stopPrice               *string                //仅 STOP_LOSS, STOP_LOSS_LIMIT, TAKE_PROFIT, TAKE_PROFIT_LIMIT 需要此参数。
+	newClientOrderId        *string                //用户自定义的orderId，如空缺系统会自动赋值。
+	icebergQty              *string                //仅有限价单可以使用该参数，含义为创建冰山订单并指定冰山订单的数量。
+	newOrderRespType        enums.NewOrderRespType //指定响应类型 ACK, RESULT, or FULL; MARKET 与 LIMIT 订单默认为FULL, 其他默认为ACK。
+	sideEffectType          enums.SideEffectType   //NO_SIDE_EFFECT, MARGIN_BUY, AUTO_REPAY, AUTO_BORROW_REPAY; 默认为 NO_SIDE_EFFECT
+	selfTradePreventionMode enums.StpModeType
+	autoRepayAtCancel       *bool //仅 MARGIN_BUY 和 AUTO_BORROW_REPAY 有效，撤单时是否归还借款，默认 true
+	validator               *rules.Validator
+}
+
+type createOrderResponse struct {
+	Symbol                  string                `json:"symbol"`
+	OrderId                 int64                 `json:"orderId"`
+	ClientOrderId           string                `json:"clientOrderId"`
+	TransactTime            int64                 `json:"transactTime"`
+	Price                   decimal.Decimal       `json:"price"`
+	OrigQty                 decimal.Decimal       `json:"origQty"`
+	ExecutedQty             decimal.Decimal       `json:"executedQty"`
+	CummulativeQuoteQty     decimal.Decimal       `json:"cummulativeQuoteQty"`
+	Status                  enums.OrderStatusType `json:"status"`
+	TimeInForce             enums.TimeInForceType `json:"timeInForce"`
+	Type                    enums.OrderType       `json:"type"`
+	Side                    enums.SideType        `json:"side"`
+	IsIsolated              bool                  `json:"isIsolated"`            // 是否是逐仓杠杆交易
+	MarginBuyBorrowAmount   decimal.Decimal       `json:"marginBuyBorrowAmount"` // 下单后没有发生借款则不返回该字段
+	MarginBuyBorrowAsset    string                `json:"marginBuyBorrowAsset"`  // 下单后没有发生借款则不返回该字段
+	SelfTradePreventionMode enums.StpModeType     `json:"selfTradePreventionMode"`
+	Fills                   []struct {
+		Price           decimal.Decimal `json:"price"`
+		Qty             decimal.Decimal `json:"qty"`
+		Commission      decimal.Decimal `json:"commission"`
+		CommissionAsset string          `json:"commissionAsset"`
+		TradeId         int64           `json:"tradeId"`
+	} `json:"fills"`
+}
+
+// NewOrder 杠杆账户下单 (TRADE)
+func NewOrder(client *binance.Client, symbol string) CreateOrder {
+	return &createOrderRequest{Client: client, symbol: symbol}
+}
+func (c *createOrderRequest) SetSymbol(symbol string) *createOrderRequest {
+	c.symbol = symbol
+	return c
+}
+func (c *createOrderRequest) SetIsolated(isIsolated bool) *createOrderRequest {
+	c.isIsolated = isIsolated
+	return c
+}
+func (c *createOrderRequest) SetSide(side enums.SideType) *createOrderRequest {
+	c.side = side
+	return c
+}
+func (c *createOrderRequest) SetType(_type enums.OrderType) *createOrderRequest {
+	c._type = _type
+	return c
+}
+func (c *createOrderRequest) SetTimeInForce(timeInForce enums.TimeInForceType) *createOrderRequest {
+	c.timeInForce = timeInForce
+	return c
+}
+func (c *createOrderRequest) SetQuantity(quantity string) *createOrderRequest {
+	c.quantity = &quantity
+	return c
+}
+func (c *createOrderRequest) SetQuoteOrderQty(quoteOrderQty string) *createOrderRequest {
+	c.quoteOrderQty = &quoteOrderQty
+	return c
+}
+func (c *createOrderRequest) SetPrice(price string) *createOrderRequest {
+	c.price = &price
+	return c
+}
+func (c *createOrderRequest) SetStopPrice(stopPrice string) *createOrderRequest {
+	c.stopPrice = &stopPrice
+	return c
+}
+func (c *createOrderRequest) SetNewClientOrderId(newClientOrderId string) *createOrderRequest {
+	c.newClientOrderId = &newClientOrderId
+	return c
+}
+func (c *createOrderRequest) SetIcebergQty(icebergQty string) *createOrderRequest {
+	c.icebergQty = &icebergQty
+	return c
+}
+func (c *createOrderRequest) SetNewOrderRespType(newOrderRespType enums.NewOrderRespType) *createOrderRequest {
+	c.newOrderRespType = newOrderRespType
+	return c
+}
+
+// SetSideEffectType MARGIN_BUY 余额不足时自动借款，AUTO_REPAY 成交后用所得归还借款
+func (c *createOrderRequest) SetSideEffectType(sideEffectType enums.SideEffectType) *createOrderRequest {
+	c.sideEffectType = sideEffectType
+	return c
+}
+func (c *createOrderRequest) SetSelfTradePreventionMode(selfTradePreventionMode enums.StpModeType) *createOrderRequest {
+	c.selfTradePreventionMode = selfTradePreventionMode
+	return c
+}
+func (c *createOrderRequest) SetAutoRepayAtCancel(autoRepayAtCancel bool) *createOrderRequest {
+	c.autoRepayAtCancel = &autoRepayAtCancel
+	return c
+}
+
+// Call 杠杆账户下单 (TRADE)
+func (c *createOrderRequest) Call(ctx context.Context) (body *createOrderResponse, err error) {
+	err = c.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := &binance.Request{
+		Method: http.MethodPost,
+		Path:   consts.SApiMarginOrder,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", c.symbol)
+	setIsolated(req, c.isIsolated)
+	req.SetParam("side", c.side)
+	req.SetParam("type", c._type)
+	req.SetOptionalParam("quantity", c.quantity)
+	req.SetOptionalParam("quoteOrderQty", c.quoteOrderQty)
+	req.SetOptionalParam("price", c.price)
+	req.SetOptionalParam("stopPrice", c.stopPrice)
+	req.SetOptionalParam("newClientOrderId", c.newClientOrderId)
+	req.SetOptionalParam("icebergQty", c.icebergQty)
+	req.SetOptionalParam("newOrderRespType", c.newOrderRespType)
+	req.SetOptionalParam("sideEffectType", c.sideEffectType)
+	req.SetOptionalParam("timeInForce", c.timeInForce)
+	req.SetOptionalParam("selfTradePreventionMode", c.selfTradePreventionMode)
+	if c.autoRepayAtCancel != nil {
+		req.SetParam("autoRepayAtCancel", *c.autoRepayAtCancel)
+	}
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		c.Debugf("createOrderRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*createOrderResponse](resp)
+}
+
+// setIsolated 逐仓时 isIsolated 传 "TRUE"，全仓不传
+func setIsolated(req *binance.Request, isIsolated bool) {
+	if isIsolated {
+		req.SetParam("isIsolated", "TRUE")
+	}
+}
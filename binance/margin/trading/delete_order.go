@@ -0,0 +1,121 @@
+package trading
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+type DeleteOrder interface {
+	SetSymbol(symbol string) *deleteOrderRequest
+	SetIsolated(isIsolated bool) *deleteOrderRequest
+	SetOrderId(orderId int64) *deleteOrderRequest
+	SetOrigClientOrderId(origClientOrderId string) *deleteOrderRequest
+	SetNewClientOrderId(newClientOrderId string) *deleteOrderRequest
+	Call(ctx context.Context) (body *deleteOrderResponse, err error)
+	CallOpenOrders(ctx context.Context) (body []*deleteOrderResponse, err error)
+}
+
+// deleteOrderRequest orderId 与 origClientOrderId 必须至少发送一个
+type deleteOrderRequest struct {
+	*binance.Client
+	symbol            string
+	isIsolated        bool
+	orderId           *int64
+	origClientOrderId *string
+	newClientOrderId  *string //用户自定义的本次撤销操作的ID(注意不是被撤销的订单的自定义ID)。如无指定会自动赋值
+}
+
+// deleteOrderResponse 撤销 OCO 中的订单时 orderListId 不为 -1，撤销全部挂单时还会返回 OCO 订单组
+type deleteOrderResponse struct {
+	Symbol                  string                    `json:"symbol"`
+	IsIsolated              bool                      `json:"isIsolated"`
+	OrderId                 int64                     `json:"orderId"`
+	OrderListId             int64                     `json:"orderListId"`
+	OrigClientOrderId       string                    `json:"origClientOrderId"`
+	ClientOrderId           string                    `json:"clientOrderId"`
+	Price                   decimal.Decimal           `json:"price"`
+	OrigQty                 decimal.Decimal           `json:"origQty"`
+	ExecutedQty             decimal.Decimal           `json:"executedQty"`
+	CummulativeQuoteQty     decimal.Decimal           `json:"cummulativeQuoteQty"`
+	Status                  enums.OrderStatusType     `json:"status"`
+	TimeInForce             enums.TimeInForceType     `json:"timeInForce"`
+	Type                    enums.OrderType           `json:"type"`
+	Side                    enums.SideType            `json:"side"`
+	SelfTradePreventionMode enums.StpModeType         `json:"selfTradePreventionMode"`
+	ContingencyType         enums.ContingencyType     `json:"contingencyType,omitempty"`
+	ListStatusType          enums.ListStatusType      `json:"listStatusType,omitempty"`
+	ListOrderStatus         enums.ListOrderStatusType `json:"listOrderStatus,omitempty"`
+	ListClientOrderId       string                    `json:"listClientOrderId,omitempty"`
+	Orders                  []struct {
+		Symbol        string `json:"symbol"`
+		OrderId       int64  `json:"orderId"`
+		ClientOrderId string `json:"clientOrderId"`
+	} `json:"orders,omitempty"`
+}
+
+func NewDeleteOrder(client *binance.Client, symbol string) DeleteOrder {
+	return &deleteOrderRequest{Client: client, symbol: symbol}
+}
+
+func (d *deleteOrderRequest) SetSymbol(symbol string) *deleteOrderRequest {
+	d.symbol = symbol
+	return d
+}
+func (d *deleteOrderRequest) SetIsolated(isIsolated bool) *deleteOrderRequest {
+	d.isIsolated = isIsolated
+	return d
+}
+func (d *deleteOrderRequest) SetOrderId(orderId int64) *deleteOrderRequest {
+	d.orderId = &orderId
+	return d
+}
+func (d *deleteOrderRequest) SetOrigClientOrderId(origClientOrderId string) *deleteOrderRequest {
+	d.origClientOrderId = &origClientOrderId
+	return d
+}
+func (d *deleteOrderRequest) SetNewClientOrderId(newClientOrderId string) *deleteOrderRequest {
+	d.newClientOrderId = &newClientOrderId
+	return d
+}
+
+// Call 杠杆账户撤销订单 (TRADE)
+func (d *deleteOrderRequest) Call(ctx context.Context) (body *deleteOrderResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodDelete,
+		Path:   consts.SApiMarginOrder,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", d.symbol)
+	setIsolated(req, d.isIsolated)
+	req.SetOptionalParam("orderId", d.orderId)
+	req.SetOptionalParam("origClientOrderId", d.origClientOrderId)
+	req.SetOptionalParam("newClientOrderId", d.newClientOrderId)
+	resp, err := d.Do(ctx, req)
+	if err != nil {
+		d.Debugf("deleteOrderRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*deleteOrderResponse](resp)
+}
+
+// CallOpenOrders 杠杆账户撤销单一交易对的所有挂单，包括 OCO 的挂单 (TRADE)
+func (d *deleteOrderRequest) CallOpenOrders(ctx context.Context) (body []*deleteOrderResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodDelete,
+		Path:   consts.SApiMarginOpenOrders,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", d.symbol)
+	setIsolated(req, d.isIsolated)
+	resp, err := d.Do(ctx, req)
+	if err != nil {
+		d.Debugf("deleteOrderRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*deleteOrderResponse](resp)
+}
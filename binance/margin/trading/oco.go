@@ -0,0 +1,192 @@
+package trading
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/rules"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// OCO 杠杆账户 OCO 下单 (TRADE)
+//
+// 杠杆 OCO 使用旧版参数：限价单 price 和止损单 stopPrice/stopLimitPrice，而不是现货新版的 above/below。
+// 针对价格限制：
+// 如果 OCO 订单方向是 SELL：限价单 price > 最后交易价格 > stopPrice
+// 如果 OCO 订单方向是 BUY：限价单 price < 最后交易价格 < stopPrice
+type OCO interface {
+	SetIsolated(isIsolated bool) *ocoRequest
+	SetListClientOrderId(listClientOrderId string) *ocoRequest
+	SetLimitClientOrderId(limitClientOrderId string) *ocoRequest
+	SetLimitIcebergQty(limitIcebergQty string) *ocoRequest
+	SetStopClientOrderId(stopClientOrderId string) *ocoRequest
+	SetStopLimitPrice(stopLimitPrice string) *ocoRequest
+	SetStopIcebergQty(stopIcebergQty string) *ocoRequest
+	SetStopLimitTimeInForce(stopLimitTimeInForce enums.TimeInForceType) *ocoRequest
+	SetNewOrderRespType(newOrderRespType enums.NewOrderRespType) *ocoRequest
+	SetSideEffectType(sideEffectType enums.SideEffectType) *ocoRequest
+	SetSelfTradePreventionMode(selfTradePreventionMode enums.StpModeType) *ocoRequest
+	SetAutoRepayAtCancel(autoRepayAtCancel bool) *ocoRequest
+	SetRules(registry *rules.Registry, round bool) *ocoRequest
+	Validate(ctx context.Context) error
+	Call(ctx context.Context) (body *ocoResponse, err error)
+}
+
+type ocoRequest struct {
+	*binance.Client
+	symbol                  string
+	isIsolated              bool
+	listClientOrderId       *string //整个 OCO 的唯一ID，如果未发送则自动生成
+	side                    enums.SideType
+	quantity                *string //两个订单的数量
+	limitClientOrderId      *string //限价单的唯一ID
+	price                   *string //限价单价格
+	limitIcebergQty         *string
+	stopClientOrderId       *string //止损单的唯一ID
+	stopPrice               *string //止损单触发价
+	stopLimitPrice          *string //传入时止损单为 STOP_LOSS_LIMIT，需要同时传 stopLimitTimeInForce
+	stopIcebergQty          *string
+	stopLimitTimeInForce    enums.TimeInForceType //有效值 GTC/FOK/IOC
+	newOrderRespType        enums.NewOrderRespType
+	sideEffectType          enums.SideEffectType //NO_SIDE_EFFECT, MARGIN_BUY, AUTO_REPAY, AUTO_BORROW_REPAY
+	selfTradePreventionMode enums.StpModeType
+	autoRepayAtCancel       *bool
+	validator               *rules.Validator
+}
+
+type ocoResponse struct {
+	OrderListId           int64                     `json:"orderListId"`
+	ContingencyType       enums.ContingencyType     `json:"contingencyType"`
+	ListStatusType        enums.ListStatusType      `json:"listStatusType"`
+	ListOrderStatus       enums.ListOrderStatusType `json:"listOrderStatus"`
+	ListClientOrderId     string                    `json:"listClientOrderId"`
+	TransactionTime       int64                     `json:"transactionTime"`
+	Symbol                string                    `json:"symbol"`
+	IsIsolated            bool                      `json:"isIsolated"`            // 是否是逐仓杠杆交易
+	MarginBuyBorrowAmount decimal.Decimal           `json:"marginBuyBorrowAmount"` // 下单后没有发生借款则不返回该字段
+	MarginBuyBorrowAsset  string                    `json:"marginBuyBorrowAsset"`  // 下单后没有发生借款则不返回该字段
+	Orders                []struct {
+		Symbol        string `json:"symbol"`
+		OrderId       int64  `json:"orderId"`
+		ClientOrderId string `json:"clientOrderId"`
+	} `json:"orders"`
+	OrderReports []struct {
+		Symbol                  string                `json:"symbol"`
+		OrderId                 int64                 `json:"orderId"`
+		OrderListId             int64                 `json:"orderListId"`
+		ClientOrderId           string                `json:"clientOrderId"`
+		TransactTime            int64                 `json:"transactTime"`
+		Price                   decimal.Decimal       `json:"price"`
+		OrigQty                 decimal.Decimal       `json:"origQty"`
+		ExecutedQty             decimal.Decimal       `json:"executedQty"`
+		CummulativeQuoteQty     decimal.Decimal       `json:"cummulativeQuoteQty"`
+		Status                  enums.OrderStatusType `json:"status"`
+		TimeInForce             enums.TimeInForceType `json:"timeInForce"`
+		Type                    enums.OrderType       `json:"type"`
+		Side                    enums.SideType        `json:"side"`
+		StopPrice               decimal.Decimal       `json:"stopPrice,omitempty"`
+		SelfTradePreventionMode enums.StpModeType     `json:"selfTradePreventionMode"`
+	} `json:"orderReports"`
+}
+
+// NewOCO 杠杆账户 OCO 下单，price 为限价单价格，stopPrice 为止损单触发价
+func NewOCO(client *binance.Client, symbol string, side enums.SideType, quantity, price, stopPrice string) OCO {
+	return &ocoRequest{
+		Client:    client,
+		symbol:    symbol,
+		side:      side,
+		quantity:  &quantity,
+		price:     &price,
+		stopPrice: &stopPrice,
+	}
+}
+
+func (o *ocoRequest) SetIsolated(isIsolated bool) *ocoRequest {
+	o.isIsolated = isIsolated
+	return o
+}
+func (o *ocoRequest) SetListClientOrderId(listClientOrderId string) *ocoRequest {
+	o.listClientOrderId = &listClientOrderId
+	return o
+}
+func (o *ocoRequest) SetLimitClientOrderId(limitClientOrderId string) *ocoRequest {
+	o.limitClientOrderId = &limitClientOrderId
+	return o
+}
+func (o *ocoRequest) SetLimitIcebergQty(limitIcebergQty string) *ocoRequest {
+	o.limitIcebergQty = &limitIcebergQty
+	return o
+}
+func (o *ocoRequest) SetStopClientOrderId(stopClientOrderId string) *ocoRequest {
+	o.stopClientOrderId = &stopClientOrderId
+	return o
+}
+func (o *ocoRequest) SetStopLimitPrice(stopLimitPrice string) *ocoRequest {
+	o.stopLimitPrice = &stopLimitPrice
+	return o
+}
+func (o *ocoRequest) SetStopIcebergQty(stopIcebergQty string) *ocoRequest {
+	o.stopIcebergQty = &stopIcebergQty
+	return o
+}
+func (o *ocoRequest) SetStopLimitTimeInForce(stopLimitTimeInForce enums.TimeInForceType) *ocoRequest {
+	o.stopLimitTimeInForce = stopLimitTimeInForce
+	return o
+}
+func (o *ocoRequest) SetNewOrderRespType(newOrderRespType enums.NewOrderRespType) *ocoRequest {
+	o.newOrderRespType = newOrderRespType
+	return o
+}
+func (o *ocoRequest) SetSideEffectType(sideEffectType enums.SideEffectType) *ocoRequest {
+	o.sideEffectType = sideEffectType
+	return o
+}
+func (o *ocoRequest) SetSelfTradePreventionMode(selfTradePreventionMode enums.StpModeType) *ocoRequest {
+	o.selfTradePreventionMode = selfTradePreventionMode
+	return o
+}
+func (o *ocoRequest) SetAutoRepayAtCancel(autoRepayAtCancel bool) *ocoRequest {
+	o.autoRepayAtCancel = &autoRepayAtCancel
+	return o
+}
+
+// Call 杠杆账户 OCO 下单 (TRADE)
+func (o *ocoRequest) Call(ctx context.Context) (body *ocoResponse, err error) {
+	err = o.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := &binance.Request{
+		Method: http.MethodPost,
+		Path:   consts.SApiMarginOrderOco,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", o.symbol)
+	setIsolated(req, o.isIsolated)
+	req.SetParam("side", o.side)
+	req.SetParam("quantity", o.quantity)
+	req.SetParam("price", o.price)
+	req.SetParam("stopPrice", o.stopPrice)
+	req.SetOptionalParam("listClientOrderId", o.listClientOrderId)
+	req.SetOptionalParam("limitClientOrderId", o.limitClientOrderId)
+	req.SetOptionalParam("limitIcebergQty", o.limitIcebergQty)
+	req.SetOptionalParam("stopClientOrderId", o.stopClientOrderId)
+	req.SetOptionalParam("stopLimitPrice", o.stopLimitPrice)
+	req.SetOptionalParam("stopIcebergQty", o.stopIcebergQty)
+	req.SetOptionalParam("stopLimitTimeInForce", o.stopLimitTimeInForce)
+	req.SetOptionalParam("newOrderRespType", o.newOrderRespType)
+	req.SetOptionalParam("sideEffectType", o.sideEffectType)
+	req.SetOptionalParam("selfTradePreventionMode", o.selfTradePreventionMode)
+	if o.autoRepayAtCancel != nil {
+		req.SetParam("autoRepayAtCancel", *o.autoRepayAtCancel)
+	}
+	resp, err := o.Do(ctx, req)
+	if err != nil {
+		o.Debugf("ocoRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*ocoResponse](resp)
+}
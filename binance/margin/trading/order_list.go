@@ -0,0 +1,126 @@
+package trading
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// OrderList 查询和撤销杠杆账户 OCO 订单
+type OrderList interface {
+	SetIsolated(isIsolated bool) *orderListRequest
+	SetOrderListId(orderListId int64) *orderListRequest
+	SetOrigClientOrderId(origClientOrderId string) *orderListRequest
+	SetNewClientOrderId(newClientOrderId string) *orderListRequest
+	Call(ctx context.Context) (body *orderListResponse, err error)
+	CallDelete(ctx context.Context) (body *deleteOrderListResponse, err error)
+}
+
+type orderListRequest struct {
+	*binance.Client
+	symbol            string //撤销时必填，查询时逐仓必填
+	isIsolated        bool
+	orderListId       *int64  //orderListId 或 origClientOrderId 必须提供一个。
+	origClientOrderId *string //orderListId 或 origClientOrderId 必须提供一个。
+	newClientOrderId  *string //用户自定义的本次撤销操作的ID(注意不是被撤销的订单的自定义ID)。如无指定会自动赋值。
+}
+
+type orderListResponse struct {
+	OrderListId       int64                     `json:"orderListId"`
+	ContingencyType   enums.ContingencyType     `json:"contingencyType"`
+	ListStatusType    enums.ListStatusType      `json:"listStatusType"`
+	ListOrderStatus   enums.ListOrderStatusType `json:"listOrderStatus"`
+	ListClientOrderId string                    `json:"listClientOrderId"`
+	TransactionTime   int64                     `json:"transactionTime"`
+	Symbol            string                    `json:"symbol"`
+	IsIsolated        bool                      `json:"isIsolated"`
+	Orders            []struct {
+		Symbol        string `json:"symbol"`
+		OrderId       int64  `json:"orderId"`
+		ClientOrderId string `json:"clientOrderId"`
+	} `json:"orders"`
+}
+type deleteOrderListResponse struct {
+	orderListResponse
+	OrderReports []struct {
+		Symbol              string                `json:"symbol"`
+		OrigClientOrderId   string                `json:"origClientOrderId"`
+		OrderId             int64                 `json:"orderId"`
+		OrderListId         int64                 `json:"orderListId"`
+		ClientOrderId       string                `json:"clientOrderId"`
+		Price               decimal.Decimal       `json:"price"`
+		OrigQty             decimal.Decimal       `json:"origQty"`
+		ExecutedQty         decimal.Decimal       `json:"executedQty"`
+		CummulativeQuoteQty decimal.Decimal       `json:"cummulativeQuoteQty"`
+		Status              enums.OrderStatusType `json:"status"`
+		TimeInForce         enums.TimeInForceType `json:"timeInForce"`
+		Type                enums.OrderType       `json:"type"`
+		Side                enums.SideType        `json:"side"`
+		StopPrice           decimal.Decimal       `json:"stopPrice,omitempty"`
+	} `json:"orderReports"`
+}
+
+func NewOrderList(client *binance.Client, symbol string) OrderList {
+	return &orderListRequest{Client: client, symbol: symbol}
+}
+
+func (o *orderListRequest) SetIsolated(isIsolated bool) *orderListRequest {
+	o.isIsolated = isIsolated
+	return o
+}
+func (o *orderListRequest) SetOrderListId(orderListId int64) *orderListRequest {
+	o.orderListId = &orderListId
+	return o
+}
+func (o *orderListRequest) SetOrigClientOrderId(origClientOrderId string) *orderListRequest {
+	o.origClientOrderId = &origClientOrderId
+	return o
+}
+func (o *orderListRequest) SetNewClientOrderId(newClientOrderId string) *orderListRequest {
+	o.newClientOrderId = &newClientOrderId
+	return o
+}
+
+// Call 查询杠杆账户 OCO 订单 (USER_DATA)
+func (o *orderListRequest) Call(ctx context.Context) (body *orderListResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.SApiMarginOrderList,
+	}
+	req.SetNeedSign(true)
+	req.SetOptionalParam("symbol", o.symbol)
+	setIsolated(req, o.isIsolated)
+	req.SetOptionalParam("orderListId", o.orderListId)
+	req.SetOptionalParam("origClientOrderId", o.origClientOrderId)
+	resp, err := o.Do(ctx, req)
+	if err != nil {
+		o.Debugf("orderListRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*orderListResponse](resp)
+}
+
+// CallDelete 撤销杠杆账户 OCO 订单 (TRADE)
+// 撤销其中任何一个订单都会撤销整个 OCO
+func (o *orderListRequest) CallDelete(ctx context.Context) (body *deleteOrderListResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodDelete,
+		Path:   consts.SApiMarginOrderList,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", o.symbol)
+	setIsolated(req, o.isIsolated)
+	req.SetOptionalParam("orderListId", o.orderListId)
+	req.SetOptionalParam("origClientOrderId", o.origClientOrderId)
+	req.SetOptionalParam("newClientOrderId", o.newClientOrderId)
+	resp, err := o.Do(ctx, req)
+	if err != nil {
+		o.Debugf("orderListRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*deleteOrderListResponse](resp)
+}
@@ -0,0 +1,149 @@
+package trading
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+type QueryOrder interface {
+	SetSymbol(symbol string) *queryOrderRequest
+	SetIsolated(isIsolated bool) *queryOrderRequest
+	SetOrderId(orderId int64) *queryOrderRequest
+	SetOrigClientOrderId(origClientOrderId string) *queryOrderRequest
+	SetStartTime(startTime int64) *queryOrderRequest
+	SetEndTime(endTime int64) *queryOrderRequest
+	SetLimit(limit enums.LimitType) *queryOrderRequest
+	Call(ctx context.Context) (body *queryOrderResponse, err error)
+	CallOpenOrders(ctx context.Context) (body []*queryOrderResponse, err error)
+	CallAllOrders(ctx context.Context) (body []*queryOrderResponse, err error)
+}
+
+type queryOrderRequest struct {
+	*binance.Client
+	symbol            string
+	isIsolated        bool
+	orderId           *int64
+	origClientOrderId *string
+	startTime         *int64
+	endTime           *int64
+	limit             enums.LimitType //默认 500，最大 500
+}
+
+type queryOrderResponse struct {
+	Symbol                  string                `json:"symbol"`
+	OrderId                 int64                 `json:"orderId"`
+	ClientOrderId           string                `json:"clientOrderId"`
+	Price                   decimal.Decimal       `json:"price"`
+	OrigQty                 decimal.Decimal       `json:"origQty"`
+	ExecutedQty             decimal.Decimal       `json:"executedQty"`
+	CummulativeQuoteQty     decimal.Decimal       `json:"cummulativeQuoteQty"`
+	Status                  enums.OrderStatusType `json:"status"`
+	TimeInForce             enums.TimeInForceType `json:"timeInForce"`
+	Type                    enums.OrderType       `json:"type"`
+	Side                    enums.SideType        `json:"side"`
+	StopPrice               decimal.Decimal       `json:"stopPrice"`
+	IcebergQty              decimal.Decimal       `json:"icebergQty"`
+	Time                    int64                 `json:"time"`
+	UpdateTime              int64                 `json:"updateTime"`
+	IsWorking               bool                  `json:"isWorking"`
+	IsIsolated              bool                  `json:"isIsolated"` // 是否是逐仓杠杆交易
+	SelfTradePreventionMode enums.StpModeType     `json:"selfTradePreventionMode"`
+}
+
+func NewQueryOrder(client *binance.Client, symbol string) QueryOrder {
+	return &queryOrderRequest{Client: client, symbol: symbol}
+}
+
+func (o *queryOrderRequest) SetSymbol(symbol string) *queryOrderRequest {
+	o.symbol = symbol
+	return o
+}
+func (o *queryOrderRequest) SetIsolated(isIsolated bool) *queryOrderRequest {
+	o.isIsolated = isIsolated
+	return o
+}
+func (o *queryOrderRequest) SetOrderId(orderId int64) *queryOrderRequest {
+	o.orderId = &orderId
+	return o
+}
+func (o *queryOrderRequest) SetOrigClientOrderId(origClientOrderId string) *queryOrderRequest {
+	o.origClientOrderId = &origClientOrderId
+	return o
+}
+func (o *queryOrderRequest) SetStartTime(startTime int64) *queryOrderRequest {
+	o.startTime = &startTime
+	return o
+}
+func (o *queryOrderRequest) SetEndTime(endTime int64) *queryOrderRequest {
+	o.endTime = &endTime
+	return o
+}
+func (o *queryOrderRequest) SetLimit(limit enums.LimitType) *queryOrderRequest {
+	o.limit = limit
+	return o
+}
+
+// Call 查询杠杆账户订单 (USER_DATA)
+// 至少需要发送 orderId 与 origClientOrderId 中的一个
+func (o *queryOrderRequest) Call(ctx context.Context) (body *queryOrderResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.SApiMarginOrder,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", o.symbol)
+	setIsolated(req, o.isIsolated)
+	req.SetOptionalParam("orderId", o.orderId)
+	req.SetOptionalParam("origClientOrderId", o.origClientOrderId)
+	resp, err := o.Do(ctx, req)
+	if err != nil {
+		o.Debugf("queryOrderRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[*queryOrderResponse](resp)
+}
+
+// CallOpenOrders 查询杠杆账户挂单记录 (USER_DATA)
+// 全仓不传 symbol 时返回所有交易对的挂单，逐仓必须传 symbol
+func (o *queryOrderRequest) CallOpenOrders(ctx context.Context) (body []*queryOrderResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.SApiMarginOpenOrders,
+	}
+	req.SetNeedSign(true)
+	req.SetOptionalParam("symbol", o.symbol)
+	setIsolated(req, o.isIsolated)
+	resp, err := o.Do(ctx, req)
+	if err != nil {
+		o.Debugf("queryOrderRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*queryOrderResponse](resp)
+}
+
+// CallAllOrders 查询杠杆账户的所有订单 (USER_DATA)
+// 传 orderId 时返回 >= orderId 的订单，否则返回最近的订单；startTime 和 endTime 最多相差 24 小时
+func (o *queryOrderRequest) CallAllOrders(ctx context.Context) (body []*queryOrderResponse, err error) {
+	req := &binance.Request{
+		Method: http.MethodGet,
+		Path:   consts.SApiMarginAllOrders,
+	}
+	req.SetNeedSign(true)
+	req.SetParam("symbol", o.symbol)
+	setIsolated(req, o.isIsolated)
+	req.SetOptionalParam("orderId", o.orderId)
+	req.SetOptionalParam("startTime", o.startTime)
+	req.SetOptionalParam("endTime", o.endTime)
+	req.SetOptionalParam("limit", o.limit)
+	resp, err := o.Do(ctx, req)
+	if err != nil {
+		o.Debugf("queryOrderRequest response err:%v", err)
+		return nil, err
+	}
+	return binance.ParseHttpResponse[[]*queryOrderResponse](resp)
+}
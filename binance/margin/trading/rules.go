@@ -0,0 +1,99 @@
+package trading
+
+import (
+	"context"
+
+	"github.com/sleep-go/coin-go/binance/rules"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// ruleLeg 一个子订单，fields 为请求参数与 rules.Order 字段的对应关系
+type ruleLeg struct {
+	order  *rules.Order
+	fields []ruleField
+}
+type ruleField struct {
+	name  string
+	param **string
+	value *decimal.Decimal
+}
+
+func newRuleLeg(side enums.SideType, _type enums.OrderType) *ruleLeg {
+	return &ruleLeg{order: &rules.Order{Side: string(side), Type: string(_type)}}
+}
+
+func (l *ruleLeg) field(name string, param **string, value *decimal.Decimal) *ruleLeg {
+	l.fields = append(l.fields, ruleField{name: name, param: param, value: value})
+	return l
+}
+
+// checkRules 发送前按交易对规则检查，开启舍入时把舍入后的价格和数量写回请求
+// 杠杆交易对使用现货 exchangeInfo 的规则
+func checkRules(ctx context.Context, v *rules.Validator, symbol string, legs ...*ruleLeg) error {
+	if v == nil || v.Registry == nil {
+		return nil
+	}
+	orders := make([]*rules.Order, len(legs))
+	for i, l := range legs {
+		for _, f := range l.fields {
+			d, err := rules.ParseDecimal(f.name, *f.param)
+			if err != nil {
+				return err
+			}
+			*f.value = d
+		}
+		orders[i] = l.order
+	}
+	err := v.Check(ctx, symbol, orders...)
+	if v.Round {
+		for _, l := range legs {
+			for _, f := range l.fields {
+				rules.FormatDecimal(*f.value, f.param)
+			}
+		}
+	}
+	return err
+}
+
+// SetRules 下单前按 exchangeInfo 的过滤器检查订单，round 为 true 时先把价格舍入到 tickSize、数量舍入到 stepSize
+func (c *createOrderRequest) SetRules(registry *rules.Registry, round bool) *createOrderRequest {
+	c.validator = &rules.Validator{Registry: registry, Round: round}
+	return c
+}
+
+// Validate 按交易对规则检查订单，未通过时返回 *rules.ValidationError
+func (c *createOrderRequest) Validate(ctx context.Context) error {
+	l := newRuleLeg(c.side, c._type)
+	o := l.order
+	l.field("price", &c.price, &o.Price).
+		field("stopPrice", &c.stopPrice, &o.StopPrice).
+		field("quantity", &c.quantity, &o.Quantity).
+		field("quoteOrderQty", &c.quoteOrderQty, &o.QuoteOrderQty).
+		field("icebergQty", &c.icebergQty, &o.IcebergQty)
+	return checkRules(ctx, c.validator, c.symbol, l)
+}
+
+// SetRules 下单前按 exchangeInfo 的过滤器检查订单，round 为 true 时先把价格舍入到 tickSize、数量舍入到 stepSize
+func (o *ocoRequest) SetRules(registry *rules.Registry, round bool) *ocoRequest {
+	o.validator = &rules.Validator{Registry: registry, Round: round}
+	return o
+}
+
+// Validate 按交易对规则检查限价单和止损单，未通过时返回 *rules.ValidationError
+func (o *ocoRequest) Validate(ctx context.Context) error {
+	limit := newRuleLeg(o.side, enums.OrderTypeLimitMaker)
+	limit.field("price", &o.price, &limit.order.Price).
+		field("quantity", &o.quantity, &limit.order.Quantity).
+		field("limitIcebergQty", &o.limitIcebergQty, &limit.order.IcebergQty)
+	stopType := enums.OrderTypeStopLoss
+	if o.stopLimitPrice != nil {
+		stopType = enums.OrderTypeStopLossLimit
+	}
+	stop := newRuleLeg(o.side, stopType)
+	stop.field("stopLimitPrice", &o.stopLimitPrice, &stop.order.Price).
+		field("stopPrice", &o.stopPrice, &stop.order.StopPrice).
+		field("quantity", &o.quantity, &stop.order.Quantity).
+		field("stopIcebergQty", &o.stopIcebergQty, &stop.order.IcebergQty)
+	return checkRules(ctx, o.validator, o.symbol, limit, stop)
+}
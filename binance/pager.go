@@ -0,0 +1,209 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// PageFunc 取下一页，done 为 true 时没有更多数据
+type PageFunc[T any] func(ctx context.Context) (page []T, done bool, err error)
+
+// Pager 自动翻页遍历历史数据，由各接口的 Iterate 创建
+//
+//	p := account.NewAllOrders(client, "BTCUSDT", enums.Limit1000).SetStartTime(start).Iterate()
+//	for p.Next(ctx) {
+//		order := p.Value()
+//	}
+//	err := p.Err()
+//
+// 请求经过 Client.Do，受 Client.RateLimiter 限流；本地限流或服务端返回 429 时等待 Retry-After 后重试当前页，418 直接返回错误
+type Pager[T any] struct {
+	page PageFunc[T]
+	buf  []T
+	cur  T
+	err  error
+	done bool
+}
+
+func NewPager[T any](page PageFunc[T]) *Pager[T] {
+	return &Pager[T]{page: page}
+}
+
+// Next 移动到下一条数据，没有更多数据或出错时返回 false
+func (p *Pager[T]) Next(ctx context.Context) bool {
+	for len(p.buf) == 0 {
+		if p.done || p.err != nil {
+			return false
+		}
+		page, done, err := p.page(ctx)
+		if wait, ok := retryAfter(err); ok {
+			err = sleep(ctx, wait)
+			if err == nil {
+				continue
+			}
+		}
+		if err != nil {
+			p.err = err
+			return false
+		}
+		p.buf, p.done = page, done
+	}
+	p.cur, p.buf = p.buf[0], p.buf[1:]
+	return true
+}
+
+// Value 当前数据
+func (p *Pager[T]) Value() T {
+	return p.cur
+}
+
+// Err 翻页过程中的错误
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// All 返回 func(yield func(T, error) bool)，Go 1.23 起可以直接 for range，出错时最后一次回调带有错误
+func (p *Pager[T]) All(ctx context.Context) func(yield func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		for p.Next(ctx) {
+			if !yield(p.Value(), nil) {
+				return
+			}
+		}
+		if p.err != nil {
+			var zero T
+			yield(zero, p.err)
+		}
+	}
+}
+
+// Collect 读取全部数据
+func (p *Pager[T]) Collect(ctx context.Context) ([]T, error) {
+	var all []T
+	for p.Next(ctx) {
+		all = append(all, p.Value())
+	}
+	return all, p.err
+}
+
+// IdCursor 按 id 翻页: 每页从上一页最后一条的 id + 1 开始，不足 limit 条时结束
+// from 为第一页的起始 id，为空时第一页由 fetch 按时间等其他条件查询；stop 不为空时遇到第一条满足 stop 的数据即结束
+func IdCursor[T any](limit int, from *int64, fetch func(ctx context.Context, fromId *int64) ([]T, error), id func(T) int64, stop func(T) bool) PageFunc[T] {
+	next := from
+	var last *int64
+	return func(ctx context.Context) ([]T, bool, error) {
+		rows, err := fetch(ctx, next)
+		if err != nil {
+			return nil, false, err
+		}
+		page := make([]T, 0, len(rows))
+		for _, row := range rows {
+			if last != nil && id(row) <= *last {
+				continue
+			}
+			if stop != nil && stop(row) {
+				return page, true, nil
+			}
+			page = append(page, row)
+		}
+		if len(rows) < limit || len(page) == 0 {
+			return page, true, nil
+		}
+		lastId := id(page[len(page)-1])
+		fromId := lastId + 1
+		last, next = &lastId, &fromId
+		return page, false, nil
+	}
+}
+
+// TimeCursor 按时间翻页遍历 [start, end]
+// window 大于 0 时按接口允许的最大时间跨度拆分查询；一页取满时下一页从最后一条的时间开始，
+// 按 key 去掉边界时间上已经返回过的数据，同一毫秒内超过 limit 条的数据无法取全
+func TimeCursor[T any, K comparable](start, end, window int64, limit int, fetch func(ctx context.Context, start, end int64) ([]T, error), timeOf func(T) int64, key func(T) K) PageFunc[T] {
+	seen := make(map[K]bool) // 时间为 start 的已返回数据
+	return func(ctx context.Context) ([]T, bool, error) {
+		for start <= end {
+			stop := end
+			if window > 0 && end-start >= window {
+				stop = start + window - 1
+			}
+			rows, err := fetch(ctx, start, stop)
+			if err != nil {
+				return nil, false, err
+			}
+			page := make([]T, 0, len(rows))
+			for _, row := range rows {
+				t := timeOf(row)
+				if t < start || t > stop || t == start && seen[key(row)] {
+					continue
+				}
+				page = append(page, row)
+			}
+			if len(rows) < limit {
+				start = stop + 1
+				clear(seen)
+			} else if last := min(timeOf(rows[len(rows)-1]), stop+1); last == start && len(page) == 0 {
+				// 同一毫秒的数据超过 limit 条，跳过这一毫秒
+				start++
+				clear(seen)
+			} else {
+				if last != start {
+					clear(seen)
+					start = last
+				}
+				for _, row := range page {
+					if timeOf(row) == last {
+						seen[key(row)] = true
+					}
+				}
+			}
+			if len(page) > 0 {
+				return page, start > end, nil
+			}
+		}
+		return nil, true, nil
+	}
+}
+
+// TimeRange 翻页的时间范围，start 为空时为 0，end 为空时为当前时间
+func TimeRange[T int64 | uint64](start, end *T) (int64, int64) {
+	from, to := int64(0), time.Now().UnixMilli()
+	if start != nil {
+		from = int64(*start)
+	}
+	if end != nil {
+		to = int64(*end)
+	}
+	return from, to
+}
+
+// retryAfter 需要等待频率限制窗口重置后重试的错误
+func retryAfter(err error) (time.Duration, bool) {
+	var rl *RateLimitError
+	if errors.As(err, &rl) && rl.StatusCode != http.StatusTeapot {
+		return rl.RetryAfter, true
+	}
+	var e *APIError
+	if errors.As(err, &e) && e.StatusCode == http.StatusTooManyRequests {
+		wait := time.Minute
+		if s, err := strconv.Atoi(e.Header.Get("Retry-After")); err == nil {
+			wait = time.Duration(s) * time.Second
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
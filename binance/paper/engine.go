@@ -0,0 +1,288 @@
+// Package paper 模拟盘撮合引擎
+//
+// Engine 用实时深度和逐笔成交撮合本地订单，维护余额(现货)或仓位(U本位合约)，
+// 并通过内置的 binancetest.Server 提供与交易所相同的下单接口和用户数据推送，策略只需把 base URL 指向 Engine.Server 即可切换到模拟盘:
+//
+//	e := paper.NewEngine()
+//	e.SetBalance("USDT", decimal.NewFromInt(10000))
+//	err := e.Feed(ctx, binance.NewClient("", ""), []string{"BTCUSDT"})
+//	client := binance.NewClient("key", "secret", e.Server.URL)
+//	trading.NewOrder(client, "BTCUSDT")...
+//
+// 撮合规则:
+//   - 新订单按当前深度快照作为 taker 成交，成交会消耗快照中的挂单量直到下一次深度更新
+//   - 挂单在逐笔成交价穿过挂单价，或深度中出现可以成交的对手价时按挂单价作为 maker 成交，同价位按时间优先
+//   - 条件单在最新成交价触及 stopPrice 后触发，之后按市价或限价单处理
+package paper
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+	"github.com/sleep-go/coin-go/pkg/orderbook"
+)
+
+// depthLevels 撮合使用的深度档数
+const depthLevels = 20
+
+// quoteAssets 未通过 SetSymbol 设置时，按后缀拆分交易对的计价资产
+var quoteAssets = []string{"USDT", "USDC", "FDUSD", "TUSD", "BUSD", "BTC", "ETH", "BNB", "EUR", "TRY", "BRL"}
+
+// Symbol 交易对的基础资产和计价资产
+type Symbol struct {
+	Base  string
+	Quote string
+}
+
+// Commission 手续费率
+type Commission struct {
+	Maker decimal.Decimal
+	Taker decimal.Decimal
+}
+
+// Balance 资产余额，合约的 Free 为钱包余额，Locked 为挂单占用的保证金
+type Balance struct {
+	Asset  string
+	Free   decimal.Decimal
+	Locked decimal.Decimal
+}
+
+// Engine 模拟盘撮合引擎
+type Engine struct {
+	Server *binancetest.Server // 下单接口和用户数据推送
+	// OnEvent 可选，每个用户数据推送事件都会回调
+	// 现货为 *account.WsExecutionReportEvent、*account.WsOutboundAccountPositionEvent、*account.WsListStatusEvent，
+	// 合约为 ORDER_TRADE_UPDATE、ACCOUNT_UPDATE 格式的 map
+	OnEvent func(event any)
+
+	futures bool
+	mu      sync.Mutex
+	flushMu sync.Mutex
+	events  []any
+
+	symbols   map[string]Symbol
+	books     map[string]*book
+	orders    map[int64]*order
+	lists     map[int64]*orderList
+	balances  map[string]*Balance
+	changed   map[string]bool // 本次操作中变化的资产
+	positions map[string]*Position
+	// changedPositions 本次操作中变化的仓位
+	changedPositions map[string]bool
+	leverage         map[string]int
+	commission       map[string]Commission
+	defaultRate      Commission
+	nextOrderId      int64
+	nextListId       int64
+	nextTradeId      int64
+}
+
+// book 一个交易对的行情快照和本地订单
+type book struct {
+	bids  []orderbook.Level // 深度快照，最优价在前
+	asks  []orderbook.Level
+	last  decimal.Decimal // 最新成交价
+	open  []*order        // 挂单
+	stops []*order        // 未触发的条件单
+}
+
+// NewEngine 现货模拟盘，默认手续费 maker 0.1%、taker 0.1%
+func NewEngine() *Engine {
+	return newEngine(false, Commission{Maker: decimal.New(1, 3), Taker: decimal.New(1, 3)})
+}
+
+// NewFuturesEngine U本位合约模拟盘，单向持仓、全仓，默认杠杆 20 倍，手续费 maker 0.02%、taker 0.05%
+func NewFuturesEngine() *Engine {
+	return newEngine(true, Commission{Maker: decimal.New(2, 4), Taker: decimal.New(5, 4)})
+}
+
+func newEngine(futures bool, rate Commission) *Engine {
+	e := &Engine{
+		Server:           binancetest.NewServer(),
+		futures:          futures,
+		symbols:          make(map[string]Symbol),
+		books:            make(map[string]*book),
+		orders:           make(map[int64]*order),
+		lists:            make(map[int64]*orderList),
+		balances:         make(map[string]*Balance),
+		changed:          make(map[string]bool),
+		positions:        make(map[string]*Position),
+		changedPositions: make(map[string]bool),
+		leverage:         make(map[string]int),
+		commission:       make(map[string]Commission),
+		defaultRate:      rate,
+		nextOrderId:      1,
+		nextListId:       1,
+		nextTradeId:      1,
+	}
+	if futures {
+		e.registerFutures()
+	} else {
+		e.registerSpot()
+	}
+	return e
+}
+
+// Close 关闭 Server
+func (e *Engine) Close() {
+	e.Server.Close()
+}
+
+// SetSymbol 设置交易对的基础资产和计价资产，未设置时按常见计价资产后缀拆分
+func (e *Engine) SetSymbol(symbol, base, quote string) *Engine {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.symbols[symbol] = Symbol{Base: base, Quote: quote}
+	return e
+}
+
+// SetBalance 设置资产的可用余额，合约为钱包余额
+func (e *Engine) SetBalance(asset string, free decimal.Decimal) *Engine {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.balance(asset).Free = free
+	return e
+}
+
+// SetCommission 设置交易对的手续费率，未设置的交易对使用默认费率
+func (e *Engine) SetCommission(symbol string, c Commission) *Engine {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.commission[symbol] = c
+	return e
+}
+
+// Balances 全部资产余额
+func (e *Engine) Balances() []Balance {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	balances := make([]Balance, 0, len(e.balances))
+	for _, b := range e.balances {
+		balances = append(balances, *b)
+	}
+	return balances
+}
+
+// OnDepth 更新交易对的深度快照，并撮合可以成交的挂单
+func (e *Engine) OnDepth(symbol string, bids, asks []orderbook.Level) {
+	e.mu.Lock()
+	b := e.book(symbol)
+	b.bids = append(b.bids[:0], bids...)
+	b.asks = append(b.asks[:0], asks...)
+	e.sweep(b)
+	e.unlock()
+}
+
+// OnTrade 处理一笔市场成交: 更新最新价，触发条件单，撮合被穿过的挂单
+func (e *Engine) OnTrade(symbol string, price, qty decimal.Decimal) {
+	e.mu.Lock()
+	b := e.book(symbol)
+	b.last = price
+	e.trigger(b)
+	e.fillResting(b, price, qty)
+	e.unlock()
+}
+
+// Feed 使用 rest 获取深度快照，订阅 symbols 的增量深度和逐笔成交驱动撮合，推送在后台持续到 ctx 结束
+// wsBaseURL 为行情推送的 base URL，为空时使用现货或合约的生产环境地址
+// 任一推送启动失败时停止已经启动的推送并返回错误
+func (e *Engine) Feed(ctx context.Context, rest *binance.Client, symbols []string, wsBaseURL ...string) error {
+	exception := func(messageType int, err error) {
+		rest.Println("paper feed:", err)
+	}
+	feedCtx, cancel := context.WithCancel(ctx)
+	var err error
+	if e.futures {
+		err = e.feedFutures(feedCtx, rest, symbols, exception, wsBaseURL...)
+	} else {
+		err = e.feedSpot(feedCtx, rest, symbols, exception, wsBaseURL...)
+	}
+	if err != nil {
+		cancel()
+		return err
+	}
+	// 推送持续到 ctx 结束
+	context.AfterFunc(ctx, cancel)
+	return nil
+}
+
+// unlock 释放锁并按顺序发送本次操作产生的推送
+func (e *Engine) unlock() {
+	if !e.futures && len(e.changed) > 0 {
+		e.emit(e.accountPosition())
+	} else if e.futures && len(e.changed) > 0 {
+		e.emit(e.accountUpdate())
+	}
+	clear(e.changed)
+	events := e.events
+	e.events = nil
+	e.flushMu.Lock()
+	defer e.flushMu.Unlock()
+	e.mu.Unlock()
+	if len(events) == 0 {
+		return
+	}
+	listenKeys := e.Server.ListenKeys()
+	for _, event := range events {
+		if e.OnEvent != nil {
+			e.OnEvent(event)
+		}
+		for _, listenKey := range listenKeys {
+			_, _ = e.Server.Push(listenKey, event)
+		}
+	}
+}
+
+func (e *Engine) emit(event any) {
+	e.events = append(e.events, event)
+}
+
+func (e *Engine) now() int64 {
+	return time.Now().UnixMilli()
+}
+
+func (e *Engine) book(symbol string) *book {
+	b, ok := e.books[symbol]
+	if !ok {
+		b = &book{}
+		e.books[symbol] = b
+	}
+	return b
+}
+
+func (e *Engine) balance(asset string) *Balance {
+	b, ok := e.balances[asset]
+	if !ok {
+		b = &Balance{Asset: asset}
+		e.balances[asset] = b
+	}
+	return b
+}
+
+func (e *Engine) symbol(symbol string) Symbol {
+	if s, ok := e.symbols[symbol]; ok {
+		return s
+	}
+	for _, quote := range quoteAssets {
+		if base, ok := strings.CutSuffix(symbol, quote); ok && base != "" {
+			return Symbol{Base: base, Quote: quote}
+		}
+	}
+	return Symbol{Base: symbol, Quote: ""}
+}
+
+func (e *Engine) rate(symbol string, maker bool) decimal.Decimal {
+	c, ok := e.commission[symbol]
+	if !ok {
+		c = e.defaultRate
+	}
+	if maker {
+		return c.Maker
+	}
+	return c.Taker
+}
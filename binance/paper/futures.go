@@ -0,0 +1,422 @@
+package paper
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/binance/futures/market"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// defaultLeverage 未通过 SetLeverage 设置时的杠杆倍数
+const defaultLeverage = 20
+
+// Position U本位合约仓位
+type Position struct {
+	Symbol     string
+	Amount     decimal.Decimal // 持仓数量，多仓为正，空仓为负
+	EntryPrice decimal.Decimal // 开仓均价
+	Realized   decimal.Decimal // 累计已实现盈亏，不含手续费
+}
+
+// SetLeverage 设置交易对的杠杆倍数，只影响之后的下单
+func (e *Engine) SetLeverage(symbol string, leverage int) *Engine {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.leverage[symbol] = leverage
+	return e
+}
+
+// Positions 持仓数量不为 0 的仓位
+func (e *Engine) Positions() []Position {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var positions []Position
+	for _, p := range e.positions {
+		if !p.Amount.IsZero() {
+			positions = append(positions, *p)
+		}
+	}
+	slices.SortFunc(positions, func(x, y Position) int {
+		if x.Symbol < y.Symbol {
+			return -1
+		}
+		return 1
+	})
+	return positions
+}
+
+// registerFutures U本位合约下单接口，REST 和 WS API 共用处理函数
+func (e *Engine) registerFutures() {
+	s := e.Server
+	for _, h := range []struct {
+		method, path, wsApi string
+		handler             binancetest.Handler
+	}{
+		{http.MethodPost, consts.FApiOrder, "order.place", e.placeFuturesOrder},
+		{http.MethodPut, consts.FApiOrder, "order.modify", e.modifyOrder},
+		{http.MethodGet, consts.FApiOrder, "order.status", e.queryOrder},
+		{http.MethodDelete, consts.FApiOrder, "order.cancel", e.cancelOrder},
+		{http.MethodDelete, consts.FApiAllOpenOrders, "", e.cancelAllOpenOrders},
+		{http.MethodPost, consts.FApiTradingOrderTest, "", e.testOrder},
+	} {
+		s.Handle(h.method, h.path, h.handler)
+		if h.wsApi != "" {
+			s.HandleWsApi(h.wsApi, h.handler)
+		}
+	}
+}
+
+func (e *Engine) feedFutures(ctx context.Context, rest *binance.Client, symbols []string, exception binance.ErrorHandler, wsBaseURL ...string) error {
+	if len(wsBaseURL) == 0 {
+		wsBaseURL = []string{consts.WS_FSTREAM}
+	}
+	for _, symbol := range symbols {
+		_, err := market.NewOrderBook(rest, symbol, enums.Limit100).
+			SetOnChange(func(ob *market.OrderBook) {
+				e.OnDepth(symbol, ob.Bids(depthLevels), ob.Asks(depthLevels))
+			}).
+			Run(ctx, binance.NewWsClient(false, true, wsBaseURL...), exception)
+		if err != nil {
+			return err
+		}
+	}
+	_, err := market.NewWsTrade(ctx, binance.NewWsClient(false, false, wsBaseURL...), symbols, func(event market.WsTradeEvent) {
+		e.OnTrade(event.Symbol, event.PriceDecimal(), event.QuantityDecimal())
+	}, exception)
+	return err
+}
+
+// ****************************** 下单接口 *******************************
+
+func (e *Engine) placeFuturesOrder(r *binancetest.Request) (any, error) {
+	p, err := parseParams(r, "", "")
+	if err != nil {
+		return nil, err
+	}
+	closePosition := r.Params.Get("closePosition") == "true"
+	e.mu.Lock()
+	defer e.unlock()
+	if closePosition {
+		// 按下单时的持仓数量平仓
+		p.reduceOnly = true
+		p.qty = e.position(p.symbol).Amount.Abs()
+	}
+	o, err := e.newOrder(p)
+	if err != nil {
+		return nil, err
+	}
+	o.closePosition = closePosition
+	err = e.place(o)
+	if err != nil {
+		return nil, err
+	}
+	return o.response(), nil
+}
+
+// modifyOrder 修改限价单的价格和数量，修改后重新排队
+func (e *Engine) modifyOrder(r *binancetest.Request) (any, error) {
+	orderId, err := int64Param(r, "orderId")
+	if err != nil {
+		return nil, err
+	}
+	price, err := decimalParam(r, "price")
+	if err != nil {
+		return nil, err
+	}
+	qty, err := decimalParam(r, "quantity")
+	if err != nil {
+		return nil, err
+	}
+	if !price.IsPositive() {
+		return nil, mandatory("price")
+	}
+	if !qty.IsPositive() {
+		return nil, mandatory("quantity")
+	}
+	e.mu.Lock()
+	defer e.unlock()
+	o := e.find(r.Params.Get("symbol"), orderId, r.Params.Get("origClientOrderId"))
+	if o == nil {
+		return nil, binancetest.Error(binance.ErrNoSuchOrder.Code, "Order does not exist.")
+	}
+	if !o.active() {
+		return nil, binancetest.Error(binance.ErrCancelRejected.Code, "Unknown order sent.")
+	}
+	if o.typ != "LIMIT" {
+		return nil, binancetest.Error(binance.ErrInvalidOrderType.Code, "Invalid orderType.")
+	}
+	if qty.LessThanOrEqual(o.executed) {
+		return nil, binancetest.Error(binance.ErrInvalidParameter.Code, "Quantity must be greater than executed quantity.")
+	}
+	oldPrice, oldQty := o.price, o.qty
+	e.releaseMargin(o)
+	o.price, o.qty = price, qty
+	err = e.reserveMargin(o)
+	if err != nil {
+		o.price, o.qty = oldPrice, oldQty
+		_ = e.reserveMargin(o)
+		return nil, err
+	}
+	b := e.book(o.symbol)
+	b.open = slices.DeleteFunc(b.open, func(other *order) bool { return other == o })
+	o.updateTime = e.now()
+	e.report(o, "AMENDMENT", nil)
+	e.execute(o, b)
+	return o.response(), nil
+}
+
+func (e *Engine) cancelAllOpenOrders(r *binancetest.Request) (any, error) {
+	_, err := e.cancelOpenOrders(r)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"code": 200, "msg": "The operation of cancel all open order is done."}, nil
+}
+
+// ****************************** 保证金和仓位 *******************************
+
+func (e *Engine) position(symbol string) *Position {
+	p, ok := e.positions[symbol]
+	if !ok {
+		p = &Position{Symbol: symbol}
+		e.positions[symbol] = p
+	}
+	return p
+}
+
+func (e *Engine) leverageOf(symbol string) decimal.Decimal {
+	if leverage, ok := e.leverage[symbol]; ok && leverage > 0 {
+		return decimal.NewFromInt(int64(leverage))
+	}
+	return decimal.NewFromInt(defaultLeverage)
+}
+
+// reducible 订单可以减仓的数量
+func (e *Engine) reducible(o *order) decimal.Decimal {
+	amount := e.position(o.symbol).Amount
+	if o.buy() && amount.IsNegative() || !o.buy() && amount.IsPositive() {
+		return amount.Abs()
+	}
+	return decimal.Zero
+}
+
+// available 可用保证金: 钱包余额 - 挂单保证金 - 持仓保证金 + 未实现盈亏
+func (e *Engine) available(asset string) decimal.Decimal {
+	b := e.balance(asset)
+	available := b.Free.Sub(b.Locked)
+	for symbol, p := range e.positions {
+		if p.Amount.IsZero() || e.symbol(symbol).Quote != asset {
+			continue
+		}
+		margin := p.Amount.Abs().Mul(p.EntryPrice).Div(e.leverageOf(symbol))
+		available = available.Sub(margin).Add(e.unrealized(p))
+	}
+	return available
+}
+
+// unrealized 按最新成交价计算的未实现盈亏
+func (e *Engine) unrealized(p *Position) decimal.Decimal {
+	mark := e.book(p.Symbol).last
+	if !mark.IsPositive() {
+		return decimal.Zero
+	}
+	return mark.Sub(p.EntryPrice).Mul(p.Amount)
+}
+
+// reserveMargin 冻结开仓部分的保证金，只减仓的订单不冻结
+func (e *Engine) reserveMargin(o *order) error {
+	reducible := e.reducible(o)
+	if o.reduceOnly {
+		if !reducible.IsPositive() {
+			return binancetest.Error(-2022, "ReduceOnly Order is rejected.")
+		}
+		// 超过持仓的部分自动减少
+		o.qty = decimal.Min(o.qty, o.executed.Add(reducible))
+		return nil
+	}
+	price := o.price
+	if o.market && o.cond != condNone {
+		price = o.stopPrice
+	} else if o.market {
+		b := e.book(o.symbol)
+		price = b.last
+		if levels := *e.opposite(o, b); len(levels) > 0 {
+			price = levels[0].Price
+		}
+	}
+	open := o.remaining().Sub(reducible)
+	if !open.IsPositive() || !price.IsPositive() {
+		return nil
+	}
+	margin := price.Mul(open).Div(e.leverageOf(o.symbol))
+	asset := e.symbol(o.symbol).Quote
+	if e.available(asset).LessThan(margin) {
+		return binancetest.Error(binance.ErrMarginNotSufficient.Code, "Margin is insufficient.")
+	}
+	b := e.balance(asset)
+	b.Locked = b.Locked.Add(margin)
+	o.locked = margin
+	return nil
+}
+
+// affordFutures 只减仓的订单成交数量不超过持仓
+func (e *Engine) affordFutures(o *order, qty decimal.Decimal) decimal.Decimal {
+	if o.reduceOnly {
+		return decimal.Min(qty, e.reducible(o))
+	}
+	return qty
+}
+
+// settleFutures 更新仓位，已实现盈亏和手续费计入钱包余额，按成交比例释放挂单保证金
+func (e *Engine) settleFutures(o *order, price, qty decimal.Decimal, maker bool) fill {
+	asset := e.symbol(o.symbol).Quote
+	b := e.balance(asset)
+	p := e.position(o.symbol)
+	signed := qty
+	if !o.buy() {
+		signed = qty.Neg()
+	}
+	realized := decimal.Zero
+	if p.Amount.IsZero() || p.Amount.Sign() == signed.Sign() {
+		size := p.Amount.Abs()
+		p.EntryPrice = p.EntryPrice.Mul(size).Add(price.Mul(qty)).Div(size.Add(qty))
+		p.Amount = p.Amount.Add(signed)
+	} else {
+		closed := decimal.Min(qty, p.Amount.Abs())
+		realized = price.Sub(p.EntryPrice).Mul(closed)
+		if p.Amount.IsNegative() {
+			realized = realized.Neg()
+		}
+		p.Amount = p.Amount.Add(signed)
+		if p.Amount.IsZero() {
+			p.EntryPrice = decimal.Zero
+		} else if p.Amount.Sign() == signed.Sign() {
+			// 反手开仓
+			p.EntryPrice = price
+		}
+	}
+	p.Realized = p.Realized.Add(realized)
+	fee := price.Mul(qty).Mul(e.rate(o.symbol, maker))
+	b.Free = b.Free.Add(realized).Sub(fee)
+	if o.locked.IsPositive() {
+		part := o.locked
+		if qty.LessThan(o.remaining()) {
+			part = o.locked.Mul(qty).Div(o.remaining())
+		}
+		o.locked = o.locked.Sub(part)
+		b.Locked = b.Locked.Sub(part)
+	}
+	e.changed[asset] = true
+	e.changedPositions[o.symbol] = true
+	return fill{commission: fee, asset: asset, realized: realized}
+}
+
+func (e *Engine) releaseMargin(o *order) {
+	if !o.locked.IsPositive() {
+		return
+	}
+	b := e.balance(e.symbol(o.symbol).Quote)
+	b.Locked = b.Locked.Sub(o.locked)
+	o.locked = decimal.Zero
+}
+
+// ****************************** 用户数据推送 *******************************
+
+// orderTradeUpdate ORDER_TRADE_UPDATE 事件
+func (e *Engine) orderTradeUpdate(o *order, executionType string, f *fill) map[string]any {
+	now := e.now()
+	data := map[string]any{
+		"s":   o.symbol,
+		"c":   o.clientOrderId,
+		"S":   o.side,
+		"o":   o.typ,
+		"f":   o.tif,
+		"q":   o.qty,
+		"p":   o.price,
+		"ap":  o.avgPrice(),
+		"sp":  o.stopPrice,
+		"x":   executionType,
+		"X":   o.status,
+		"i":   o.id,
+		"l":   decimal.Zero,
+		"z":   o.executed,
+		"L":   decimal.Zero,
+		"N":   e.symbol(o.symbol).Quote,
+		"n":   decimal.Zero,
+		"T":   o.updateTime,
+		"t":   0,
+		"b":   decimal.Zero,
+		"a":   decimal.Zero,
+		"m":   false,
+		"R":   o.reduceOnly,
+		"wt":  "CONTRACT_PRICE",
+		"ot":  o.typ,
+		"ps":  o.positionSide,
+		"cp":  o.closePosition,
+		"rp":  decimal.Zero,
+		"pP":  false,
+		"si":  0,
+		"ss":  0,
+		"V":   o.stp,
+		"pm":  "NONE",
+		"gtd": 0,
+	}
+	if f != nil {
+		data["l"] = f.qty
+		data["L"] = f.price
+		data["N"] = f.asset
+		data["n"] = f.commission
+		data["t"] = f.tradeId
+		data["m"] = f.maker
+		data["rp"] = f.realized
+	}
+	return map[string]any{"e": "ORDER_TRADE_UPDATE", "E": now, "T": now, "o": data}
+}
+
+// accountUpdate ACCOUNT_UPDATE 事件，包含本次操作中变化的资产和仓位
+func (e *Engine) accountUpdate() map[string]any {
+	now := e.now()
+	assets := make([]string, 0, len(e.changed))
+	for asset := range e.changed {
+		assets = append(assets, asset)
+	}
+	slices.Sort(assets)
+	balances := make([]map[string]any, len(assets))
+	for i, asset := range assets {
+		b := e.balance(asset)
+		balances[i] = map[string]any{"a": asset, "wb": b.Free, "cw": b.Free, "bc": decimal.Zero}
+	}
+	symbols := make([]string, 0, len(e.changedPositions))
+	for symbol := range e.changedPositions {
+		symbols = append(symbols, symbol)
+	}
+	slices.Sort(symbols)
+	clear(e.changedPositions)
+	positions := make([]map[string]any, len(symbols))
+	for i, symbol := range symbols {
+		p := e.position(symbol)
+		positions[i] = map[string]any{
+			"s":   symbol,
+			"pa":  p.Amount,
+			"ep":  p.EntryPrice,
+			"bep": p.EntryPrice,
+			"cr":  p.Realized,
+			"up":  e.unrealized(p),
+			"mt":  "cross",
+			"iw":  decimal.Zero,
+			"ps":  "BOTH",
+		}
+	}
+	return map[string]any{
+		"e": "ACCOUNT_UPDATE",
+		"E": now,
+		"T": now,
+		"a": map[string]any{"m": "ORDER", "B": balances, "P": positions},
+	}
+}
@@ -0,0 +1,155 @@
+package paper
+
+import (
+	"strconv"
+
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// orderList 现货订单组
+//
+// OCO 的两条腿共用一份冻结，一条腿成交或触发后另一条腿过期；
+// OTO、OTOCO 的第一个订单为生效订单，完全成交后待执行订单才冻结资金并开始撮合，生效订单撤销或过期时待执行订单一起结束
+type orderList struct {
+	id            int64
+	clientOrderId string
+	contingency   enums.ContingencyType
+	symbol        string
+	orders        []*order
+	working       *order          // OTO、OTOCO 的生效订单
+	locked        decimal.Decimal // OCO 两条腿共用的冻结
+	status        enums.ListOrderStatusType
+	time          int64
+}
+
+func newOrderList(contingency enums.ContingencyType, clientOrderId string, working *order, orders ...*order) *orderList {
+	l := &orderList{
+		clientOrderId: clientOrderId,
+		contingency:   contingency,
+		symbol:        orders[0].symbol,
+		orders:        orders,
+		working:       working,
+		status:        enums.ListOrderStatusTypeExecuting,
+	}
+	for _, o := range orders {
+		o.list = l
+	}
+	return l
+}
+
+// addList 分配 orderListId
+func (e *Engine) addList(l *orderList) {
+	l.id = e.nextListId
+	e.nextListId++
+	if l.clientOrderId == "" {
+		l.clientOrderId = "paper-list-" + strconv.FormatInt(l.id, 10)
+	}
+	l.time = e.now()
+	e.lists[l.id] = l
+}
+
+// pending 待执行订单
+func (l *orderList) pending() []*order {
+	if l.working == nil {
+		return nil
+	}
+	return l.orders[1:]
+}
+
+// listDone 订单组中的订单结束后，激活或结束待执行订单，全部结束时推送 ALL_DONE
+func (e *Engine) listDone(o *order) {
+	l := o.list
+	if o == l.working {
+		if o.status == statusFilled {
+			e.activatePending(l)
+		} else {
+			for _, p := range l.pending() {
+				if !p.final() {
+					e.end(p, o.status)
+				}
+			}
+		}
+	}
+	if l.status == enums.ListOrderStatusTypeAllDone {
+		return
+	}
+	for _, other := range l.orders {
+		if !other.final() {
+			return
+		}
+	}
+	l.status = enums.ListOrderStatusTypeAllDone
+	e.emit(e.listStatus(l))
+}
+
+// activatePending 生效订单完全成交后冻结资金并撮合待执行订单，资金不足时过期
+func (e *Engine) activatePending(l *orderList) {
+	pending := l.pending()
+	var err error
+	if len(pending) == 2 {
+		err = e.reserveShared(&l.locked, pending[0], pending[1])
+	} else {
+		err = e.reserve(pending[0])
+	}
+	for _, p := range pending {
+		if err != nil {
+			e.end(p, statusExpired)
+			continue
+		}
+		p.status = statusNew
+		p.updateTime = e.now()
+		e.report(p, "NEW", nil)
+	}
+	if err != nil {
+		return
+	}
+	for _, p := range pending {
+		if p.active() {
+			e.activate(p)
+		}
+	}
+}
+
+// findList 按 orderListId 或 listClientOrderId 查找订单组
+func (e *Engine) findList(orderListId int64, clientOrderId string) *orderList {
+	if orderListId != 0 {
+		return e.lists[orderListId]
+	}
+	var found *orderList
+	for _, l := range e.lists {
+		if clientOrderId != "" && l.clientOrderId == clientOrderId && (found == nil || l.id > found.id) {
+			found = l
+		}
+	}
+	return found
+}
+
+func (l *orderList) listStatusType() string {
+	if l.status == enums.ListOrderStatusTypeAllDone {
+		return enums.ListStatusTypeAllDone
+	}
+	return enums.ListStatusTypeExecStarted
+}
+
+// response 下单、查询和撤销订单组的响应
+func (l *orderList) response(updateTime int64) map[string]any {
+	orders := make([]map[string]any, len(l.orders))
+	reports := make([]map[string]any, len(l.orders))
+	for i, o := range l.orders {
+		orders[i] = map[string]any{"symbol": o.symbol, "orderId": o.id, "clientOrderId": o.clientOrderId}
+		reports[i] = o.response()
+		reports[i]["origClientOrderId"] = o.clientOrderId
+	}
+	return map[string]any{
+		"orderListId":       l.id,
+		"contingencyType":   l.contingency,
+		"listStatusType":    l.listStatusType(),
+		"listOrderStatus":   l.status,
+		"listClientOrderId": l.clientOrderId,
+		"transactionTime":   updateTime,
+		"symbol":            l.symbol,
+		"orders":            orders,
+		"orderReports":      reports,
+	}
+}
@@ -0,0 +1,350 @@
+package paper
+
+import (
+	"slices"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+	"github.com/sleep-go/coin-go/pkg/orderbook"
+)
+
+// check 下单前检查订单能否被接受
+func (e *Engine) check(o *order) error {
+	if o.clientOrderId != "" {
+		for _, other := range e.orders {
+			if other.symbol == o.symbol && other.clientOrderId == o.clientOrderId && !other.final() {
+				return binancetest.Error(binance.ErrNewOrderRejected.Code, "Duplicate order sent.")
+			}
+		}
+	}
+	b := e.book(o.symbol)
+	if o.cond != condNone && b.last.IsPositive() && o.triggers(b.last) {
+		if e.futures {
+			return binancetest.Error(-2021, "Order would immediately trigger.")
+		}
+		return binancetest.Error(binance.ErrNewOrderRejected.Code, "Stop price would trigger immediately.")
+	}
+	if o.typ == "LIMIT_MAKER" && e.takes(o, b) {
+		return binancetest.Error(binance.ErrNewOrderRejected.Code, "Order would immediately match and take.")
+	}
+	return nil
+}
+
+// place 检查并冻结资金后接受订单，然后立即撮合
+func (e *Engine) place(o *order) error {
+	err := e.check(o)
+	if err != nil {
+		return err
+	}
+	err = e.reserve(o)
+	if err != nil {
+		return err
+	}
+	e.accept(o, statusNew)
+	e.activate(o)
+	return nil
+}
+
+// accept 分配 orderId 并推送 NEW
+func (e *Engine) accept(o *order, status string) {
+	o.id = e.nextOrderId
+	e.nextOrderId++
+	if o.clientOrderId == "" {
+		o.clientOrderId = newClientOrderId(o.id)
+	}
+	o.status = status
+	o.time = e.now()
+	o.updateTime = o.time
+	e.orders[o.id] = o
+	e.report(o, "NEW", nil)
+}
+
+// activate 条件单等待触发，其余订单立即撮合
+func (e *Engine) activate(o *order) {
+	b := e.book(o.symbol)
+	if o.cond != condNone && !o.triggered {
+		b.stops = append(b.stops, o)
+		return
+	}
+	e.execute(o, b)
+}
+
+// execute 订单作为 taker 与深度快照成交，剩余部分挂单或过期
+func (e *Engine) execute(o *order, b *book) {
+	if o.postOnly && e.takes(o, b) {
+		e.end(o, statusExpired)
+		return
+	}
+	if o.qty.IsZero() && o.quoteQty.IsPositive() {
+		o.qty = e.quoteToQty(o, b)
+		if o.qty.IsZero() {
+			e.end(o, statusExpired)
+			return
+		}
+	}
+	if o.tif == "FOK" && e.liquidity(o, b).LessThan(o.remaining()) {
+		e.end(o, statusExpired)
+		return
+	}
+	e.match(o, b, false)
+	if !o.active() {
+		return
+	}
+	if o.market || o.tif == "IOC" || o.tif == "FOK" {
+		e.end(o, statusExpired)
+		return
+	}
+	b.open = append(b.open, o)
+}
+
+// match 订单与深度快照中可以成交的档位成交，taker 按档位价格成交，maker 按订单价格成交
+func (e *Engine) match(o *order, b *book, maker bool) {
+	for o.active() {
+		levels := e.opposite(o, b)
+		if len(*levels) == 0 || !o.crosses((*levels)[0].Price) {
+			return
+		}
+		level := (*levels)[0]
+		price := level.Price
+		if maker {
+			price = o.price
+		}
+		qty := e.afford(o, price, decimal.Min(o.remaining(), level.Quantity))
+		if !qty.IsPositive() {
+			return
+		}
+		if qty.Equal(level.Quantity) {
+			*levels = (*levels)[1:]
+		} else {
+			(*levels)[0].Quantity = level.Quantity.Sub(qty)
+		}
+		e.fill(o, price, qty, maker)
+	}
+}
+
+// opposite 订单的对手盘
+func (e *Engine) opposite(o *order, b *book) *[]orderbook.Level {
+	if o.buy() {
+		return &b.asks
+	}
+	return &b.bids
+}
+
+// takes 订单是否会立即与深度成交
+func (e *Engine) takes(o *order, b *book) bool {
+	levels := *e.opposite(o, b)
+	return len(levels) > 0 && o.crosses(levels[0].Price)
+}
+
+// liquidity 深度快照中订单可以成交的数量
+func (e *Engine) liquidity(o *order, b *book) decimal.Decimal {
+	total := decimal.Zero
+	for _, level := range *e.opposite(o, b) {
+		if !o.crosses(level.Price) {
+			break
+		}
+		total = total.Add(level.Quantity)
+	}
+	return total
+}
+
+// quoteToQty 按成交额下的市价单，按深度快照换算成数量
+func (e *Engine) quoteToQty(o *order, b *book) decimal.Decimal {
+	left, qty := o.quoteQty, decimal.Zero
+	for _, level := range *e.opposite(o, b) {
+		amount := level.Price.Mul(level.Quantity)
+		if amount.GreaterThanOrEqual(left) {
+			return qty.Add(left.DivRound(level.Price, 8, decimal.RoundDown))
+		}
+		left = left.Sub(amount)
+		qty = qty.Add(level.Quantity)
+	}
+	return qty
+}
+
+// fill 记录一笔成交并推送 TRADE
+func (e *Engine) fill(o *order, price, qty decimal.Decimal, maker bool) {
+	f := e.settle(o, price, qty, maker)
+	f.price, f.qty, f.maker = price, qty, maker
+	f.tradeId = e.nextTradeId
+	e.nextTradeId++
+	o.executed = o.executed.Add(qty)
+	o.cumQuote = o.cumQuote.Add(price.Mul(qty))
+	o.fills = append(o.fills, f)
+	o.updateTime = e.now()
+	o.status = statusPartiallyFilled
+	if !o.remaining().IsPositive() {
+		o.status = statusFilled
+	}
+	e.report(o, "TRADE", &f)
+	if o.peer != nil && o.peer.active() {
+		e.end(o.peer, statusExpired)
+	}
+	if o.status == statusFilled {
+		e.done(o)
+	}
+}
+
+// end 撤销或过期订单
+func (e *Engine) end(o *order, status string) {
+	o.status = status
+	o.updateTime = e.now()
+	e.report(o, status, nil)
+	e.done(o)
+}
+
+// done 订单结束: 移出挂单，释放冻结，处理订单组
+func (e *Engine) done(o *order) {
+	b := e.book(o.symbol)
+	b.open = slices.DeleteFunc(b.open, func(other *order) bool { return other == o })
+	b.stops = slices.DeleteFunc(b.stops, func(other *order) bool { return other == o })
+	if o.peer == nil || o.peer.final() {
+		e.release(o)
+	}
+	if o.list != nil {
+		e.listDone(o)
+	}
+}
+
+// cancel 撤销订单，订单组中的订单会撤销整个订单组
+func (e *Engine) cancel(o *order) {
+	if o.list == nil {
+		e.end(o, statusCanceled)
+		return
+	}
+	for _, other := range o.list.orders {
+		if !other.final() {
+			e.end(other, statusCanceled)
+		}
+	}
+}
+
+// trigger 最新价触发条件单，OCO 中另一条腿过期
+func (e *Engine) trigger(b *book) {
+	for _, o := range slices.Clone(b.stops) {
+		if !o.active() || !o.triggers(b.last) {
+			continue
+		}
+		b.stops = slices.DeleteFunc(b.stops, func(other *order) bool { return other == o })
+		o.triggered = true
+		o.updateTime = e.now()
+		if o.peer != nil && o.peer.active() {
+			e.end(o.peer, statusExpired)
+		}
+		e.execute(o, b)
+	}
+}
+
+// fillResting 市场成交价穿过挂单价时，挂单按挂单价成交，成交量不超过市场成交量
+func (e *Engine) fillResting(b *book, price, qty decimal.Decimal) {
+	for _, o := range e.queue(b) {
+		if !qty.IsPositive() {
+			return
+		}
+		if !o.active() || o.buy() && o.price.LessThanOrEqual(price) || !o.buy() && o.price.GreaterThanOrEqual(price) {
+			continue
+		}
+		n := e.afford(o, o.price, decimal.Min(o.remaining(), qty))
+		if !n.IsPositive() {
+			continue
+		}
+		qty = qty.Sub(n)
+		e.fill(o, o.price, n, true)
+	}
+}
+
+// sweep 深度更新后，挂单与可以成交的对手价档位成交
+func (e *Engine) sweep(b *book) {
+	for _, o := range e.queue(b) {
+		e.match(o, b, true)
+	}
+}
+
+// queue 按价格优先、时间优先排列的挂单
+func (e *Engine) queue(b *book) []*order {
+	queue := slices.Clone(b.open)
+	slices.SortFunc(queue, func(x, y *order) int {
+		if x.side != y.side {
+			if x.buy() {
+				return -1
+			}
+			return 1
+		}
+		if c := x.price.Cmp(y.price); c != 0 {
+			if x.buy() {
+				return -c
+			}
+			return c
+		}
+		return int(x.id - y.id)
+	})
+	return queue
+}
+
+// find 按 orderId 或 clientOrderId 查找订单
+func (e *Engine) find(symbol string, orderId int64, clientOrderId string) *order {
+	if orderId != 0 {
+		if o, ok := e.orders[orderId]; ok && o.symbol == symbol {
+			return o
+		}
+		return nil
+	}
+	var found *order
+	for _, o := range e.orders {
+		if o.symbol == symbol && clientOrderId != "" && o.clientOrderId == clientOrderId && (found == nil || o.id > found.id) {
+			found = o
+		}
+	}
+	return found
+}
+
+// openOrders 未结束的订单，symbol 为空时返回全部交易对
+func (e *Engine) openOrders(symbol string) []*order {
+	var orders []*order
+	for _, o := range e.orders {
+		if (symbol == "" || o.symbol == symbol) && o.active() {
+			orders = append(orders, o)
+		}
+	}
+	slices.SortFunc(orders, func(x, y *order) int { return int(x.id - y.id) })
+	return orders
+}
+
+func (e *Engine) reserve(o *order) error {
+	if e.futures {
+		return e.reserveMargin(o)
+	}
+	return e.reserveFunds(o)
+}
+
+// afford 按余额、仓位限制本次成交数量
+func (e *Engine) afford(o *order, price, qty decimal.Decimal) decimal.Decimal {
+	if e.futures {
+		return e.affordFutures(o, qty)
+	}
+	return e.affordSpot(o, price, qty)
+}
+
+func (e *Engine) settle(o *order, price, qty decimal.Decimal, maker bool) fill {
+	if e.futures {
+		return e.settleFutures(o, price, qty, maker)
+	}
+	return e.settleSpot(o, price, qty, maker)
+}
+
+func (e *Engine) release(o *order) {
+	if e.futures {
+		e.releaseMargin(o)
+	} else {
+		e.releaseFunds(o)
+	}
+}
+
+func (e *Engine) report(o *order, executionType string, f *fill) {
+	if e.futures {
+		e.emit(e.orderTradeUpdate(o, executionType, f))
+	} else {
+		e.emit(e.executionReport(o, executionType, f))
+	}
+}
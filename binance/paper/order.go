@@ -0,0 +1,347 @@
+package paper
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// 条件单种类
+const (
+	condNone       = iota
+	condStop       // 止损: 买单价格 >= stopPrice 触发，卖单价格 <= stopPrice 触发
+	condTakeProfit // 止盈: 买单价格 <= stopPrice 触发，卖单价格 >= stopPrice 触发
+)
+
+// 订单状态
+const (
+	statusPendingNew      = "PENDING_NEW"
+	statusNew             = "NEW"
+	statusPartiallyFilled = "PARTIALLY_FILLED"
+	statusFilled          = "FILLED"
+	statusCanceled        = "CANCELED"
+	statusExpired         = "EXPIRED"
+)
+
+// order 模拟盘订单
+type order struct {
+	id            int64
+	list          *orderList
+	symbol        string
+	clientOrderId string
+	side          string
+	typ           string
+	tif           string
+	market        bool // 市价成交，条件单触发后也按市价
+	cond          int
+	postOnly      bool // LIMIT_MAKER 或 GTX
+	price         decimal.Decimal
+	stopPrice     decimal.Decimal
+	qty           decimal.Decimal
+	quoteQty      decimal.Decimal // 现货按成交额下的市价单
+	executed      decimal.Decimal
+	cumQuote      decimal.Decimal
+	status        string
+	triggered     bool
+	reduceOnly    bool
+	closePosition bool
+	positionSide  string
+	stp           string
+	locked        decimal.Decimal  // 冻结的资金或保证金
+	shared        *decimal.Decimal // OCO 两条腿共用的冻结，非空时代替 locked
+	time          int64
+	updateTime    int64
+	peer          *order // OCO 中的另一条腿
+	fills         []fill
+}
+
+type fill struct {
+	price      decimal.Decimal
+	qty        decimal.Decimal
+	commission decimal.Decimal
+	asset      string
+	tradeId    int64
+	maker      bool
+	realized   decimal.Decimal // 合约的已实现盈亏
+}
+
+// orderParams 下单参数，现货订单组的每条腿使用各自前缀的参数
+type orderParams struct {
+	symbol        string
+	side          string
+	typ           string
+	tif           string
+	clientOrderId string
+	positionSide  string
+	stp           string
+	price         decimal.Decimal
+	stopPrice     decimal.Decimal
+	qty           decimal.Decimal
+	quoteQty      decimal.Decimal
+	reduceOnly    bool
+}
+
+// parseParams 读取下单参数，prefix 为订单组中每条腿的参数前缀，group 为 side、quantity 的参数前缀，普通下单都为空
+func parseParams(r *binancetest.Request, prefix, group string) (*orderParams, error) {
+	name := func(prefix, key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + strings.ToUpper(key[:1]) + key[1:]
+	}
+	p := &orderParams{
+		symbol:        r.Params.Get("symbol"),
+		side:          r.Params.Get(name(group, "side")),
+		typ:           r.Params.Get(name(prefix, "type")),
+		tif:           r.Params.Get(name(prefix, "timeInForce")),
+		clientOrderId: r.Params.Get(name(prefix, "clientOrderId")),
+		positionSide:  r.Params.Get("positionSide"),
+		stp:           r.Params.Get("selfTradePreventionMode"),
+		reduceOnly:    r.Params.Get("reduceOnly") == "true",
+	}
+	if prefix == "" {
+		p.clientOrderId = r.Params.Get("newClientOrderId")
+	}
+	var err error
+	for key, v := range map[string]*decimal.Decimal{
+		name(prefix, "price"):     &p.price,
+		name(prefix, "stopPrice"): &p.stopPrice,
+		name(group, "quantity"):   &p.qty,
+		"quoteOrderQty":           &p.quoteQty,
+	} {
+		*v, err = decimalParam(r, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if r.Params.Get(name(prefix, "trailingDelta")) != "" {
+		return nil, binancetest.Error(binance.ErrUnsupportedOp.Code, "Trailing orders are not supported in paper trading.")
+	}
+	return p, nil
+}
+
+func decimalParam(r *binancetest.Request, key string) (decimal.Decimal, error) {
+	v := r.Params.Get(key)
+	if v == "" {
+		return decimal.Zero, nil
+	}
+	d, err := decimal.NewFromString(v)
+	if err != nil || d.IsNegative() {
+		return decimal.Zero, binancetest.Error(binance.ErrIllegalChars.Code, "Illegal characters found in parameter '"+key+"'.")
+	}
+	return d, nil
+}
+
+func mandatory(key string) error {
+	return binancetest.Error(binance.ErrMandatoryParamEmpty.Code, "Mandatory parameter '"+key+"' was not sent, was empty/null, or malformed.")
+}
+
+// classify 订单类型是否按市价成交以及条件单种类，合约的 TAKE_PROFIT 为限价单
+func classify(typ string, futures bool) (market bool, cond int, ok bool) {
+	if futures {
+		switch typ {
+		case "LIMIT":
+			return false, condNone, true
+		case "MARKET":
+			return true, condNone, true
+		case "STOP":
+			return false, condStop, true
+		case "STOP_MARKET":
+			return true, condStop, true
+		case "TAKE_PROFIT":
+			return false, condTakeProfit, true
+		case "TAKE_PROFIT_MARKET":
+			return true, condTakeProfit, true
+		}
+		return false, condNone, false
+	}
+	switch typ {
+	case "LIMIT", "LIMIT_MAKER":
+		return false, condNone, true
+	case "MARKET":
+		return true, condNone, true
+	case "STOP_LOSS":
+		return true, condStop, true
+	case "STOP_LOSS_LIMIT":
+		return false, condStop, true
+	case "TAKE_PROFIT":
+		return true, condTakeProfit, true
+	case "TAKE_PROFIT_LIMIT":
+		return false, condTakeProfit, true
+	}
+	return false, condNone, false
+}
+
+// newOrder 校验参数并创建订单，不分配 orderId
+func (e *Engine) newOrder(p *orderParams) (*order, error) {
+	if p.symbol == "" {
+		return nil, mandatory("symbol")
+	}
+	if p.side != "BUY" && p.side != "SELL" {
+		return nil, binancetest.Error(binance.ErrInvalidSide.Code, "Invalid side.")
+	}
+	market, cond, ok := classify(p.typ, e.futures)
+	if !ok {
+		return nil, binancetest.Error(binance.ErrInvalidOrderType.Code, "Invalid orderType.")
+	}
+	o := &order{
+		symbol:        p.symbol,
+		clientOrderId: p.clientOrderId,
+		side:          p.side,
+		typ:           p.typ,
+		tif:           p.tif,
+		market:        market,
+		cond:          cond,
+		postOnly:      p.typ == "LIMIT_MAKER" || p.tif == "GTX",
+		price:         p.price,
+		stopPrice:     p.stopPrice,
+		qty:           p.qty,
+		reduceOnly:    p.reduceOnly,
+		positionSide:  p.positionSide,
+		stp:           p.stp,
+	}
+	if o.positionSide == "" {
+		o.positionSide = "BOTH"
+	}
+	if o.stp == "" {
+		o.stp = "NONE"
+		if e.futures {
+			o.stp = "EXPIRE_MAKER"
+		}
+	}
+	if market && cond == condNone && !e.futures && p.qty.IsZero() && p.quoteQty.IsPositive() {
+		o.quoteQty = p.quoteQty
+	} else if !p.qty.IsPositive() {
+		return nil, mandatory("quantity")
+	}
+	if !market && !p.price.IsPositive() {
+		return nil, mandatory("price")
+	}
+	if cond != condNone && !p.stopPrice.IsPositive() {
+		return nil, mandatory("stopPrice")
+	}
+	switch {
+	case market, p.typ == "LIMIT_MAKER":
+		if !e.futures {
+			o.tif = ""
+		} else if o.tif == "" {
+			o.tif = "GTC"
+		}
+	case o.tif == "":
+		o.tif = "GTC"
+	case o.tif != "GTC" && o.tif != "IOC" && o.tif != "FOK" && !(e.futures && o.tif == "GTX"):
+		return nil, binancetest.Error(binance.ErrInvalidTif.Code, "Invalid timeInForce.")
+	}
+	return o, nil
+}
+
+// listId 所属订单组，不属于订单组时为 -1
+func (o *order) listId() int64 {
+	if o.list == nil {
+		return -1
+	}
+	return o.list.id
+}
+
+func (o *order) buy() bool {
+	return o.side == "BUY"
+}
+
+func (o *order) remaining() decimal.Decimal {
+	return o.qty.Sub(o.executed)
+}
+
+func (o *order) active() bool {
+	return o.status == statusNew || o.status == statusPartiallyFilled
+}
+
+func (o *order) final() bool {
+	return !o.active() && o.status != statusPendingNew
+}
+
+// lockRef 订单冻结金额的存放位置
+func (o *order) lockRef() *decimal.Decimal {
+	if o.shared != nil {
+		return o.shared
+	}
+	return &o.locked
+}
+
+// crosses 订单能否与 price 成交
+func (o *order) crosses(price decimal.Decimal) bool {
+	if o.market {
+		return true
+	}
+	if o.buy() {
+		return o.price.GreaterThanOrEqual(price)
+	}
+	return o.price.LessThanOrEqual(price)
+}
+
+// triggers 条件单是否被 price 触发
+func (o *order) triggers(price decimal.Decimal) bool {
+	up := o.cond == condStop == o.buy()
+	if up {
+		return price.GreaterThanOrEqual(o.stopPrice)
+	}
+	return price.LessThanOrEqual(o.stopPrice)
+}
+
+func (o *order) avgPrice() decimal.Decimal {
+	if o.executed.IsZero() {
+		return decimal.Zero
+	}
+	return o.cumQuote.DivRound(o.executed, 8, decimal.RoundHalfUp)
+}
+
+// response 下单和查询接口的响应，包含现货和合约的字段
+func (o *order) response() map[string]any {
+	fills := make([]map[string]any, len(o.fills))
+	for i, f := range o.fills {
+		fills[i] = map[string]any{
+			"price":           f.price,
+			"qty":             f.qty,
+			"commission":      f.commission,
+			"commissionAsset": f.asset,
+			"tradeId":         f.tradeId,
+		}
+	}
+	return map[string]any{
+		"symbol":                  o.symbol,
+		"orderId":                 o.id,
+		"orderListId":             o.listId(),
+		"clientOrderId":           o.clientOrderId,
+		"transactTime":            o.updateTime,
+		"time":                    o.time,
+		"updateTime":              o.updateTime,
+		"workingTime":             o.time,
+		"price":                   o.price,
+		"stopPrice":               o.stopPrice,
+		"origQty":                 o.qty,
+		"executedQty":             o.executed,
+		"cummulativeQuoteQty":     o.cumQuote,
+		"origQuoteOrderQty":       o.quoteQty,
+		"cumQty":                  o.executed,
+		"cumQuote":                o.cumQuote,
+		"avgPrice":                o.avgPrice(),
+		"status":                  o.status,
+		"timeInForce":             o.tif,
+		"type":                    o.typ,
+		"origType":                o.typ,
+		"side":                    o.side,
+		"positionSide":            o.positionSide,
+		"reduceOnly":              o.reduceOnly,
+		"closePosition":           o.closePosition,
+		"isWorking":               o.active() && (o.cond == condNone || o.triggered),
+		"selfTradePreventionMode": o.stp,
+		"fills":                   fills,
+	}
+}
+
+// newClientOrderId 未指定 clientOrderId 时生成
+func newClientOrderId(id int64) string {
+	return "paper-" + strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,663 @@
+package paper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/spot/account"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/binance/spot/market"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// registerSpot 现货下单接口，REST 和 WS API 共用处理函数
+func (e *Engine) registerSpot() {
+	s := e.Server
+	for _, h := range []struct {
+		method, path, wsApi string
+		handler             binancetest.Handler
+	}{
+		{http.MethodPost, consts.ApiOrder, "order.place", e.placeOrder},
+		{http.MethodPost, consts.ApiTradingOrderTest, "order.test", e.testOrder},
+		{http.MethodGet, consts.ApiOrder, "order.status", e.queryOrder},
+		{http.MethodDelete, consts.ApiOrder, "order.cancel", e.cancelOrder},
+		{http.MethodPost, consts.ApiTradingCancelReplace, "order.cancelReplace", e.cancelReplace},
+		{http.MethodGet, consts.ApiOpenOrders, "openOrders.status", e.queryOpenOrders},
+		{http.MethodDelete, consts.ApiOpenOrders, "openOrders.cancelAll", e.cancelOpenOrders},
+		{http.MethodPost, consts.ApiTradingOrderListOCO, "orderList.place.oco", e.placeOco},
+		{http.MethodPost, consts.ApiTradingOrderListOTO, "orderList.place.oto", e.placeOto},
+		{http.MethodPost, consts.ApiTradingOrderListOTOCO, "orderList.place.otoco", e.placeOtoco},
+		{http.MethodGet, consts.ApiOrderList, "orderList.status", e.queryOrderList},
+		{http.MethodDelete, consts.ApiOrderList, "orderList.cancel", e.cancelOrderList},
+		{http.MethodGet, consts.ApiAccount, "account.status", e.account},
+		{http.MethodGet, consts.ApiAccountCommission, "account.commission", e.accountCommission},
+	} {
+		s.Handle(h.method, h.path, h.handler)
+		s.HandleWsApi(h.wsApi, h.handler)
+	}
+}
+
+// LoadCommission 通过 account.NewCommission 查询 symbols 的实际手续费率(标准费率加税费)，仅支持现货
+func (e *Engine) LoadCommission(ctx context.Context, client *binance.Client, symbols ...string) error {
+	if e.futures {
+		return errors.New("paper: LoadCommission only supports spot")
+	}
+	for _, symbol := range symbols {
+		res, err := account.NewCommission(client, symbol).Call(ctx)
+		if err != nil {
+			return err
+		}
+		var c Commission
+		for _, rate := range []struct {
+			dst   *decimal.Decimal
+			value string
+		}{
+			{&c.Maker, res.StandardCommission.Maker},
+			{&c.Maker, res.TaxCommission.Maker},
+			{&c.Taker, res.StandardCommission.Taker},
+			{&c.Taker, res.TaxCommission.Taker},
+		} {
+			if rate.value == "" {
+				continue
+			}
+			d, err := decimal.NewFromString(rate.value)
+			if err != nil {
+				return err
+			}
+			*rate.dst = rate.dst.Add(d)
+		}
+		e.SetCommission(symbol, c)
+	}
+	return nil
+}
+
+func (e *Engine) feedSpot(ctx context.Context, rest *binance.Client, symbols []string, exception binance.ErrorHandler, wsBaseURL ...string) error {
+	for _, symbol := range symbols {
+		_, err := market.NewOrderBook(rest, symbol, enums.Limit100).
+			SetOnChange(func(ob *market.OrderBook) {
+				e.OnDepth(symbol, ob.Bids(depthLevels), ob.Asks(depthLevels))
+			}).
+			Run(ctx, binance.NewWsClient(false, true, wsBaseURL...), exception)
+		if err != nil {
+			return err
+		}
+	}
+	_, err := market.NewWsTrade(ctx, binance.NewWsClient(false, false, wsBaseURL...), symbols, func(event market.WsTradeEvent) {
+		e.OnTrade(event.Symbol, event.PriceDecimal(), event.QuantityDecimal())
+	}, exception)
+	return err
+}
+
+// ****************************** 下单接口 *******************************
+
+func (e *Engine) placeOrder(r *binancetest.Request) (any, error) {
+	p, err := parseParams(r, "", "")
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.unlock()
+	o, err := e.newOrder(p)
+	if err != nil {
+		return nil, err
+	}
+	err = e.place(o)
+	if err != nil {
+		return nil, err
+	}
+	return o.response(), nil
+}
+
+func (e *Engine) testOrder(r *binancetest.Request) (any, error) {
+	p, err := parseParams(r, "", "")
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.unlock()
+	o, err := e.newOrder(p)
+	if err != nil {
+		return nil, err
+	}
+	if r.Params.Get("computeCommissionRates") != "true" {
+		return struct{}{}, nil
+	}
+	return map[string]any{
+		"standardCommissionForOrder": map[string]decimal.Decimal{"maker": e.rate(o.symbol, true), "taker": e.rate(o.symbol, false)},
+		"taxCommissionForOrder":      map[string]decimal.Decimal{"maker": decimal.Zero, "taker": decimal.Zero},
+		"discount":                   map[string]any{"enabledForAccount": false, "enabledForSymbol": false, "discountAsset": "BNB", "discount": decimal.Zero},
+	}, nil
+}
+
+func (e *Engine) queryOrder(r *binancetest.Request) (any, error) {
+	orderId, err := int64Param(r, "orderId")
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.unlock()
+	o := e.find(r.Params.Get("symbol"), orderId, r.Params.Get("origClientOrderId"))
+	if o == nil {
+		return nil, binancetest.Error(binance.ErrNoSuchOrder.Code, "Order does not exist.")
+	}
+	return o.response(), nil
+}
+
+func (e *Engine) cancelOrder(r *binancetest.Request) (any, error) {
+	orderId, err := int64Param(r, "orderId")
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.unlock()
+	o := e.find(r.Params.Get("symbol"), orderId, r.Params.Get("origClientOrderId"))
+	if o == nil || o.final() {
+		return nil, binancetest.Error(binance.ErrCancelRejected.Code, "Unknown order sent.")
+	}
+	e.cancel(o)
+	return cancelResponse(o, r.Params.Get("newClientOrderId")), nil
+}
+
+func (e *Engine) queryOpenOrders(r *binancetest.Request) (any, error) {
+	e.mu.Lock()
+	defer e.unlock()
+	orders := e.openOrders(r.Params.Get("symbol"))
+	res := make([]map[string]any, len(orders))
+	for i, o := range orders {
+		res[i] = o.response()
+	}
+	return res, nil
+}
+
+func (e *Engine) cancelOpenOrders(r *binancetest.Request) (any, error) {
+	symbol := r.Params.Get("symbol")
+	if symbol == "" {
+		return nil, mandatory("symbol")
+	}
+	e.mu.Lock()
+	defer e.unlock()
+	orders := e.openOrders(symbol)
+	res := make([]map[string]any, 0, len(orders))
+	for _, o := range orders {
+		if o.final() {
+			// 已随订单组一起撤销
+			continue
+		}
+		e.cancel(o)
+		res = append(res, cancelResponse(o, ""))
+	}
+	return res, nil
+}
+
+// cancelReplace 撤消挂单再下单，部分失败时只返回错误码
+func (e *Engine) cancelReplace(r *binancetest.Request) (any, error) {
+	p, err := parseParams(r, "", "")
+	if err != nil {
+		return nil, err
+	}
+	cancelOrderId, err := int64Param(r, "cancelOrderId")
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.unlock()
+	o, err := e.newOrder(p)
+	if err != nil {
+		return nil, err
+	}
+	var cancelErr error
+	target := e.find(p.symbol, cancelOrderId, r.Params.Get("cancelOrigClientOrderId"))
+	if target == nil || target.final() {
+		cancelErr = binancetest.Error(binance.ErrCancelRejected.Code, "Unknown order sent.")
+		if r.Params.Get("cancelReplaceMode") != "ALLOW_FAILURE" {
+			return nil, binancetest.Error(-2022, "Order cancel-replace failed.")
+		}
+	} else {
+		e.cancel(target)
+	}
+	placeErr := e.place(o)
+	switch {
+	case cancelErr != nil && placeErr != nil:
+		return nil, binancetest.Error(-2022, "Order cancel-replace failed.")
+	case cancelErr != nil || placeErr != nil:
+		return nil, binancetest.Error(-2021, "Order cancel-replace partially failed.")
+	}
+	newOrder := o.response()
+	delete(newOrder, "fills")
+	return map[string]any{
+		"cancelResult":     "SUCCESS",
+		"newOrderResult":   "SUCCESS",
+		"cancelResponse":   cancelResponse(target, r.Params.Get("cancelNewClientOrderId")),
+		"newOrderResponse": newOrder,
+	}, nil
+}
+
+func cancelResponse(o *order, newClientOrderId string) map[string]any {
+	res := o.response()
+	delete(res, "fills")
+	res["origClientOrderId"] = o.clientOrderId
+	res["cumulativeQuoteQty"] = o.cumQuote
+	if newClientOrderId != "" {
+		res["clientOrderId"] = newClientOrderId
+	}
+	return res
+}
+
+// ****************************** 订单组 *******************************
+
+func (e *Engine) placeOco(r *binancetest.Request) (any, error) {
+	below, err := parseParams(r, "below", "")
+	if err != nil {
+		return nil, err
+	}
+	above, err := parseParams(r, "above", "")
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.unlock()
+	legs, err := e.newOrders(below, above)
+	if err != nil {
+		return nil, err
+	}
+	l := newOrderList(enums.ContingencyTypeOCO, r.Params.Get("listClientOrderId"), nil, legs...)
+	err = e.reserveShared(&l.locked, legs[0], legs[1])
+	if err != nil {
+		return nil, err
+	}
+	legs[0].peer, legs[1].peer = legs[1], legs[0]
+	e.addList(l)
+	for _, o := range legs {
+		e.accept(o, statusNew)
+	}
+	e.emit(e.listStatus(l))
+	for _, o := range legs {
+		if o.active() {
+			e.activate(o)
+		}
+	}
+	return l.response(l.time), nil
+}
+
+func (e *Engine) placeOto(r *binancetest.Request) (any, error) {
+	working, err := parseParams(r, "working", "working")
+	if err != nil {
+		return nil, err
+	}
+	pending, err := parseParams(r, "pending", "pending")
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.unlock()
+	orders, err := e.newOrders(working, pending)
+	if err != nil {
+		return nil, err
+	}
+	return e.placeWorking(r, orders)
+}
+
+func (e *Engine) placeOtoco(r *binancetest.Request) (any, error) {
+	working, err := parseParams(r, "working", "working")
+	if err != nil {
+		return nil, err
+	}
+	below, err := parseParams(r, "pendingBelow", "pending")
+	if err != nil {
+		return nil, err
+	}
+	above, err := parseParams(r, "pendingAbove", "pending")
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.unlock()
+	orders, err := e.newOrders(working, below, above)
+	if err != nil {
+		return nil, err
+	}
+	orders[1].peer, orders[2].peer = orders[2], orders[1]
+	return e.placeWorking(r, orders)
+}
+
+// placeWorking 下 OTO、OTOCO 订单组，orders[0] 为生效订单
+func (e *Engine) placeWorking(r *binancetest.Request, orders []*order) (any, error) {
+	working := orders[0]
+	if working.market || working.cond != condNone {
+		return nil, binancetest.Error(binance.ErrInvalidOrderType.Code, "Invalid orderType.")
+	}
+	err := e.reserve(working)
+	if err != nil {
+		return nil, err
+	}
+	l := newOrderList(enums.ContingencyTypeOTO, r.Params.Get("listClientOrderId"), working, orders...)
+	e.addList(l)
+	e.accept(working, statusNew)
+	for _, o := range l.pending() {
+		e.accept(o, statusPendingNew)
+	}
+	e.emit(e.listStatus(l))
+	e.activate(working)
+	return l.response(l.time), nil
+}
+
+// newOrders 创建订单组中的订单并检查
+func (e *Engine) newOrders(params ...*orderParams) ([]*order, error) {
+	orders := make([]*order, len(params))
+	for i, p := range params {
+		o, err := e.newOrder(p)
+		if err != nil {
+			return nil, err
+		}
+		err = e.check(o)
+		if err != nil {
+			return nil, err
+		}
+		orders[i] = o
+	}
+	return orders, nil
+}
+
+func (e *Engine) queryOrderList(r *binancetest.Request) (any, error) {
+	orderListId, err := int64Param(r, "orderListId")
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.unlock()
+	l := e.findList(orderListId, r.Params.Get("origClientOrderId"))
+	if l == nil {
+		return nil, binancetest.Error(binance.ErrNoSuchOrder.Code, "Order list does not exist.")
+	}
+	return l.response(l.time), nil
+}
+
+func (e *Engine) cancelOrderList(r *binancetest.Request) (any, error) {
+	orderListId, err := int64Param(r, "orderListId")
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.unlock()
+	l := e.findList(orderListId, r.Params.Get("origClientOrderId"))
+	if l == nil || l.status == enums.ListOrderStatusTypeAllDone {
+		return nil, binancetest.Error(binance.ErrCancelRejected.Code, "Unknown order list sent.")
+	}
+	e.cancel(l.orders[0])
+	return l.response(e.now()), nil
+}
+
+// ****************************** 账户 *******************************
+
+func (e *Engine) account(r *binancetest.Request) (any, error) {
+	e.mu.Lock()
+	defer e.unlock()
+	omitZero := r.Params.Get("omitZeroBalances") == "true"
+	balances := make([]map[string]any, 0, len(e.balances))
+	for _, b := range e.balances {
+		if omitZero && b.Free.IsZero() && b.Locked.IsZero() {
+			continue
+		}
+		balances = append(balances, map[string]any{"asset": b.Asset, "free": b.Free, "locked": b.Locked})
+	}
+	slices.SortFunc(balances, func(x, y map[string]any) int {
+		if x["asset"].(string) < y["asset"].(string) {
+			return -1
+		}
+		return 1
+	})
+	bps := func(rate decimal.Decimal) int64 { return rate.Mul(decimal.NewFromInt(10000)).IntPart() }
+	return map[string]any{
+		"makerCommission":  bps(e.defaultRate.Maker),
+		"takerCommission":  bps(e.defaultRate.Taker),
+		"buyerCommission":  0,
+		"sellerCommission": 0,
+		"commissionRates": map[string]decimal.Decimal{
+			"maker":  e.defaultRate.Maker,
+			"taker":  e.defaultRate.Taker,
+			"buyer":  decimal.Zero,
+			"seller": decimal.Zero,
+		},
+		"canTrade":    true,
+		"canWithdraw": false,
+		"canDeposit":  false,
+		"accountType": "SPOT",
+		"balances":    balances,
+		"permissions": []string{"SPOT"},
+		"updateTime":  e.now(),
+	}, nil
+}
+
+func (e *Engine) accountCommission(r *binancetest.Request) (any, error) {
+	symbol := r.Params.Get("symbol")
+	if symbol == "" {
+		return nil, mandatory("symbol")
+	}
+	e.mu.Lock()
+	defer e.unlock()
+	zero := map[string]decimal.Decimal{"maker": decimal.Zero, "taker": decimal.Zero, "buyer": decimal.Zero, "seller": decimal.Zero}
+	return map[string]any{
+		"symbol": symbol,
+		"standardCommission": map[string]decimal.Decimal{
+			"maker":  e.rate(symbol, true),
+			"taker":  e.rate(symbol, false),
+			"buyer":  decimal.Zero,
+			"seller": decimal.Zero,
+		},
+		"taxCommission": zero,
+		"discount":      map[string]any{"enabledForAccount": false, "enabledForSymbol": false, "discountAsset": "BNB", "discount": decimal.Zero},
+	}, nil
+}
+
+func int64Param(r *binancetest.Request, key string) (int64, error) {
+	v := r.Params.Get(key)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, binancetest.Error(binance.ErrIllegalChars.Code, "Illegal characters found in parameter '"+key+"'.")
+	}
+	return n, nil
+}
+
+// ****************************** 资金 *******************************
+
+// lockNeed 现货订单需要冻结的资产和数量，卖单冻结基础资产，买单冻结计价资产，未指定价格的市价买单不冻结
+func (e *Engine) lockNeed(o *order) (string, decimal.Decimal) {
+	s := e.symbol(o.symbol)
+	switch {
+	case !o.buy():
+		return s.Base, o.qty
+	case o.quoteQty.IsPositive():
+		return s.Quote, o.quoteQty
+	case !o.market:
+		return s.Quote, o.price.Mul(o.qty)
+	case o.cond != condNone:
+		return s.Quote, o.stopPrice.Mul(o.qty)
+	}
+	return s.Quote, decimal.Zero
+}
+
+func (e *Engine) reserveFunds(o *order) error {
+	asset, need := e.lockNeed(o)
+	return e.lock(asset, need, &o.locked)
+}
+
+// reserveShared OCO 两条腿共用一份冻结，取两者中较大的
+func (e *Engine) reserveShared(locked *decimal.Decimal, a, b *order) error {
+	asset, needA := e.lockNeed(a)
+	_, needB := e.lockNeed(b)
+	err := e.lock(asset, decimal.Max(needA, needB), locked)
+	if err != nil {
+		return err
+	}
+	a.shared, b.shared = locked, locked
+	return nil
+}
+
+func (e *Engine) lock(asset string, amount decimal.Decimal, locked *decimal.Decimal) error {
+	if !amount.IsPositive() {
+		return nil
+	}
+	b := e.balance(asset)
+	if b.Free.LessThan(amount) {
+		return binancetest.Error(binance.ErrNewOrderRejected.Code, "Account has insufficient balance for requested action.")
+	}
+	b.Free = b.Free.Sub(amount)
+	b.Locked = b.Locked.Add(amount)
+	*locked = amount
+	e.changed[asset] = true
+	return nil
+}
+
+// affordSpot 冻结加可用余额能够支付的成交数量
+func (e *Engine) affordSpot(o *order, price, qty decimal.Decimal) decimal.Decimal {
+	asset, _ := e.lockNeed(o)
+	available := o.lockRef().Add(e.balance(asset).Free)
+	if o.buy() {
+		available = available.DivRound(price, 8, decimal.RoundDown)
+	}
+	return decimal.Min(qty, available)
+}
+
+// settleSpot 买单支付计价资产，手续费从收到的基础资产中扣除；卖单反之
+func (e *Engine) settleSpot(o *order, price, qty decimal.Decimal, maker bool) fill {
+	s := e.symbol(o.symbol)
+	rate := e.rate(o.symbol, maker)
+	quote := price.Mul(qty)
+	if o.buy() {
+		fee := qty.Mul(rate)
+		e.debit(o, s.Quote, quote)
+		e.credit(s.Base, qty.Sub(fee))
+		return fill{commission: fee, asset: s.Base}
+	}
+	fee := quote.Mul(rate)
+	e.debit(o, s.Base, qty)
+	e.credit(s.Quote, quote.Sub(fee))
+	return fill{commission: fee, asset: s.Quote}
+}
+
+// debit 优先从订单冻结中扣除，不足部分从可用余额扣除
+func (e *Engine) debit(o *order, asset string, amount decimal.Decimal) {
+	b := e.balance(asset)
+	locked := o.lockRef()
+	fromLock := decimal.Min(*locked, amount)
+	*locked = locked.Sub(fromLock)
+	b.Locked = b.Locked.Sub(fromLock)
+	b.Free = b.Free.Sub(amount.Sub(fromLock))
+	e.changed[asset] = true
+}
+
+func (e *Engine) credit(asset string, amount decimal.Decimal) {
+	b := e.balance(asset)
+	b.Free = b.Free.Add(amount)
+	e.changed[asset] = true
+}
+
+// releaseFunds 订单结束后退回剩余冻结
+func (e *Engine) releaseFunds(o *order) {
+	locked := o.lockRef()
+	if !locked.IsPositive() {
+		return
+	}
+	asset, _ := e.lockNeed(o)
+	b := e.balance(asset)
+	b.Locked = b.Locked.Sub(*locked)
+	b.Free = b.Free.Add(*locked)
+	*locked = decimal.Zero
+	e.changed[asset] = true
+}
+
+// ****************************** 用户数据推送 *******************************
+
+func (e *Engine) executionReport(o *order, executionType string, f *fill) *account.WsExecutionReportEvent {
+	event := &account.WsExecutionReportEvent{
+		Event:                   enums.AccountDataEventTypeExecutionReport,
+		Time:                    e.now(),
+		Symbol:                  o.symbol,
+		ClientOrderId:           o.clientOrderId,
+		Side:                    enums.SideType(o.side),
+		Type:                    enums.OrderType(o.typ),
+		TimeInForce:             enums.TimeInForceType(o.tif),
+		Volume:                  o.qty.String(),
+		Price:                   o.price.String(),
+		StopPrice:               o.stopPrice.String(),
+		OrderListId:             o.listId(),
+		ExecutionType:           executionType,
+		Status:                  enums.OrderStatusType(o.status),
+		RejectReason:            "NONE",
+		Id:                      o.id,
+		FilledVolume:            o.executed.String(),
+		TransactionTime:         o.updateTime,
+		TradeId:                 -1,
+		IsInOrderBook:           o.active() && !o.market && (o.cond == condNone || o.triggered),
+		CreateTime:              o.time,
+		FilledQuoteVolume:       o.cumQuote.String(),
+		QuoteVolume:             o.quoteQty.String(),
+		WorkingTime:             o.time,
+		SelfTradePreventionMode: enums.StpModeType(o.stp),
+	}
+	if f != nil {
+		event.LatestVolume = f.qty.String()
+		event.LatestPrice = f.price.String()
+		event.FeeAsset = f.asset
+		event.FeeCost = f.commission.String()
+		event.TradeId = f.tradeId
+		event.IsMaker = f.maker
+		event.LatestQuoteVolume = f.price.Mul(f.qty).String()
+	}
+	return event
+}
+
+func (e *Engine) listStatus(l *orderList) *account.WsListStatusEvent {
+	event := &account.WsListStatusEvent{
+		Event:           enums.AccountDataEventTypeListStatus,
+		Time:            e.now(),
+		Symbol:          l.symbol,
+		OrderListId:     l.id,
+		ContingencyType: l.contingency,
+		ListStatusType:  enums.ListStatusType(l.listStatusType()),
+		ListOrderStatus: l.status,
+		RejectReason:    "NONE",
+		ClientOrderId:   l.clientOrderId,
+	}
+	for _, o := range l.orders {
+		event.Orders = append(event.Orders, struct {
+			Symbol        string `json:"s"`
+			OrderId       int64  `json:"i"`
+			ClientOrderId string `json:"c"`
+		}{o.symbol, o.id, o.clientOrderId})
+	}
+	return event
+}
+
+// accountPosition 本次操作中变化的资产
+func (e *Engine) accountPosition() *account.WsOutboundAccountPositionEvent {
+	now := e.now()
+	event := &account.WsOutboundAccountPositionEvent{
+		Event:      enums.AccountDataEventTypeOutboundAccountPosition,
+		Time:       now,
+		UpdateTime: now,
+	}
+	assets := make([]string, 0, len(e.changed))
+	for asset := range e.changed {
+		assets = append(assets, asset)
+	}
+	slices.Sort(assets)
+	for _, asset := range assets {
+		b := e.balance(asset)
+		event.Balances = append(event.Balances, struct {
+			Asset  string `json:"a"`
+			Free   string `json:"f"`
+			Locked string `json:"l"`
+		}{b.Asset, b.Free.String(), b.Locked.String()})
+	}
+	return event
+}
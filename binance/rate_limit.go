@@ -0,0 +1,324 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 限制种类
+const (
+	RateLimitRequestWeight = "REQUEST_WEIGHT" // 单位时间请求权重之和上限
+	RateLimitOrders        = "ORDERS"         // 单位时间下单次数上限
+	RateLimitRawRequests   = "RAW_REQUESTS"   // 单位时间请求次数上限
+)
+
+// ErrRateLimited 超过频率限制，或者服务端返回 429/418 后仍在等待期内
+var ErrRateLimited = errors.New("binance: rate limit exceeded")
+
+// RateLimitError 超过频率限制时返回，errors.Is(err, ErrRateLimited) 为 true
+type RateLimitError struct {
+	RateLimits               // 将被超过的限制，服务端返回 429/418 时为空
+	StatusCode int           // 服务端返回的 429 或 418，本地判断超限时为 0
+	RetryAfter time.Duration // 需要等待的时间
+}
+
+func (e *RateLimitError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("binance: rate limited by server (%d), retry after %s", e.StatusCode, e.RetryAfter)
+	}
+	return fmt.Sprintf("binance: %s %d%s limit %d reached (used %d), retry after %s",
+		e.RateLimitType, e.IntervalNum, e.Interval, e.Limit, e.Count, e.RetryAfter)
+}
+
+func (e *RateLimitError) Unwrap() error { return ErrRateLimited }
+
+// Cost 一个接口消耗的请求权重和下单次数
+type Cost struct {
+	Weight int
+	Orders int
+}
+
+// RateLimiter 客户端限流，按接口权重和下单次数在本地计数，发送前超限则等待或直接返回错误
+// 计数以 exchangeInfo 的 rateLimits 为上限，并根据响应头 X-MBX-USED-WEIGHT-*、X-MBX-ORDER-COUNT-*
+// 和 WS API 响应中的 rateLimits 校正；收到 429/418 后在 Retry-After 之前不再发送请求
+type RateLimiter struct {
+	Block bool            // true 时等待到窗口重置，false 时立即返回 *RateLimitError
+	Costs map[string]Cost // 接口消耗，key 为 "GET /api/v3/depth" 或 WS API 方法名，未登记的接口权重为 1
+
+	mu      sync.Mutex
+	windows []*rateWindow
+	retryAt time.Time
+	status  int
+}
+
+type rateWindow struct {
+	RateLimits
+	period time.Duration
+	start  time.Time
+}
+
+// NewRateLimiter 创建限流器，limits 一般来自 exchangeInfo
+func NewRateLimiter(costs map[string]Cost, limits ...RateLimits) *RateLimiter {
+	l := &RateLimiter{Block: true, Costs: costs}
+	l.SetLimits(limits...)
+	return l
+}
+
+// NewSpotRateLimiter 使用现货默认限制和接口权重
+func NewSpotRateLimiter() *RateLimiter {
+	return NewRateLimiter(SpotCosts, SpotRateLimits...)
+}
+
+// NewFuturesRateLimiter 使用U本位合约默认限制和接口权重
+func NewFuturesRateLimiter() *RateLimiter {
+	return NewRateLimiter(FuturesCosts, FuturesRateLimits...)
+}
+
+// NewDeliveryRateLimiter 使用币本位合约默认限制和接口权重
+func NewDeliveryRateLimiter() *RateLimiter {
+	return NewRateLimiter(DeliveryCosts, DeliveryRateLimits...)
+}
+
+// NewMarginRateLimiter 使用杠杆账户默认限制和接口权重，杠杆接口和现货接口使用同一个域名，需要单独的 client
+func NewMarginRateLimiter() *RateLimiter {
+	return NewRateLimiter(MarginCosts, MarginRateLimits...)
+}
+
+// SetLimits 替换限制，已有窗口的计数保留
+func (l *RateLimiter) SetLimits(limits ...RateLimits) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	windows := make([]*rateWindow, 0, len(limits))
+	for _, limit := range limits {
+		period := intervalDuration(limit.Interval, limit.IntervalNum)
+		if period <= 0 || limit.Limit <= 0 {
+			continue
+		}
+		w := &rateWindow{RateLimits: limit, period: period}
+		w.Count = 0
+		if old := l.window(limit); old != nil {
+			w.Count, w.start = old.Count, old.start
+		}
+		windows = append(windows, w)
+	}
+	l.windows = windows
+}
+
+// Limits 当前各窗口的限制和已用计数
+func (l *RateLimiter) Limits() []RateLimits {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	limits := make([]RateLimits, len(l.windows))
+	for i, w := range l.windows {
+		w.roll(now)
+		limits[i] = w.RateLimits
+	}
+	return limits
+}
+
+// Cost 请求的消耗，Request 上通过 SetWeight、SetOrderCount 设置的值优先
+func (l *RateLimiter) Cost(r *Request) Cost {
+	cost, ok := l.Costs[r.endpoint()]
+	if !ok {
+		cost, ok = l.Costs[r.Path]
+	}
+	if !ok {
+		cost.Weight = 1
+	}
+	if r.weight != nil {
+		cost.Weight = *r.weight
+	}
+	if r.orders != nil {
+		cost.Orders = *r.orders
+	}
+	return cost
+}
+
+// Wait 发送请求前调用，预留请求的消耗
+// Block 为 true 时等待到窗口重置或 ctx 结束，否则超限时立即返回 *RateLimitError
+func (l *RateLimiter) Wait(ctx context.Context, r *Request) error {
+	cost := l.Cost(r)
+	for {
+		err := l.reserve(cost)
+		if err == nil {
+			return nil
+		}
+		var e *RateLimitError
+		if !l.Block || !errors.As(err, &e) || e.RetryAfter <= 0 {
+			return err
+		}
+		timer := time.NewTimer(e.RetryAfter)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+func (l *RateLimiter) reserve(cost Cost) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if now.Before(l.retryAt) {
+		return &RateLimitError{StatusCode: l.status, RetryAfter: l.retryAt.Sub(now)}
+	}
+	for _, w := range l.windows {
+		n := w.cost(cost)
+		if n == 0 {
+			continue
+		}
+		w.roll(now)
+		if n > w.Limit {
+			// 单个请求就超过上限，等待也没有用
+			return &RateLimitError{RateLimits: w.RateLimits}
+		}
+		if w.Count+n > w.Limit {
+			return &RateLimitError{RateLimits: w.RateLimits, RetryAfter: w.start.Add(w.period).Sub(now)}
+		}
+	}
+	for _, w := range l.windows {
+		w.Count += w.cost(cost)
+	}
+	return nil
+}
+
+// Update 根据 REST 响应校正计数，429/418 时记录 Retry-After
+func (l *RateLimiter) Update(resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	for _, w := range l.windows {
+		var key string
+		switch w.RateLimitType {
+		case RateLimitRequestWeight:
+			key = "X-Mbx-Used-Weight-"
+		case RateLimitOrders:
+			key = "X-Mbx-Order-Count-"
+		default:
+			continue
+		}
+		v := resp.Header.Get(key + w.suffix())
+		if v == "" {
+			continue
+		}
+		used, err := strconv.Atoi(v)
+		if err != nil {
+			continue
+		}
+		w.sync(now, used)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusTeapot:
+		retry := time.Minute
+		if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			retry = time.Duration(s) * time.Second
+		}
+		l.block(resp.StatusCode, now.Add(retry))
+	}
+}
+
+// UpdateWsApi 根据 WS API 响应中的 rateLimits 校正计数，响应中出现的新限制会被加入
+// retryAfter 为 429/418 错误中 data.retryAfter 的毫秒时间戳
+func (l *RateLimiter) UpdateWsApi(status int, limits []RateLimits, retryAfter int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	for _, limit := range limits {
+		w := l.window(limit)
+		if w == nil {
+			period := intervalDuration(limit.Interval, limit.IntervalNum)
+			if period <= 0 || limit.Limit <= 0 {
+				continue
+			}
+			w = &rateWindow{RateLimits: limit, period: period}
+			w.Count = 0
+			l.windows = append(l.windows, w)
+		}
+		if limit.Limit > 0 {
+			w.Limit = limit.Limit
+		}
+		w.sync(now, limit.Count)
+	}
+	switch status {
+	case http.StatusTooManyRequests, http.StatusTeapot:
+		until := now.Add(time.Minute)
+		if retryAfter > 0 {
+			until = time.UnixMilli(retryAfter)
+		}
+		l.block(status, until)
+	}
+}
+
+func (l *RateLimiter) block(status int, until time.Time) {
+	if until.After(l.retryAt) {
+		l.retryAt = until
+		l.status = status
+	}
+}
+
+func (l *RateLimiter) window(limit RateLimits) *rateWindow {
+	for _, w := range l.windows {
+		if w.RateLimitType == limit.RateLimitType && w.Interval == limit.Interval && w.IntervalNum == limit.IntervalNum {
+			return w
+		}
+	}
+	return nil
+}
+
+// roll 窗口按自然时间对齐，过期后清零
+func (w *rateWindow) roll(now time.Time) {
+	start := now.Truncate(w.period)
+	if !start.Equal(w.start) {
+		w.start = start
+		w.Count = 0
+	}
+}
+
+// sync 服务端计数包含其他进程的请求，本地计数包含尚未到达服务端的请求，取较大值
+func (w *rateWindow) sync(now time.Time, used int) {
+	w.roll(now)
+	if used > w.Count {
+		w.Count = used
+	}
+}
+
+func (w *rateWindow) cost(c Cost) int {
+	switch w.RateLimitType {
+	case RateLimitRequestWeight:
+		return c.Weight
+	case RateLimitOrders:
+		return c.Orders
+	case RateLimitRawRequests:
+		return 1
+	}
+	return 0
+}
+
+// suffix 响应头中的时间间隔，如 1M、10S、1D
+func (w *rateWindow) suffix() string {
+	return strconv.Itoa(w.IntervalNum) + strings.ToUpper(w.Interval[:1])
+}
+
+func intervalDuration(interval string, num int) time.Duration {
+	var unit time.Duration
+	switch interval {
+	case "SECOND":
+		unit = time.Second
+	case "MINUTE":
+		unit = time.Minute
+	case "HOUR":
+		unit = time.Hour
+	case "DAY":
+		unit = 24 * time.Hour
+	}
+	return unit * time.Duration(num)
+}
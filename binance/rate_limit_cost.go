@@ -0,0 +1,245 @@
+package binance
+
+import (
+	"net/http"
+
+	"github.com/sleep-go/coin-go/binance/consts"
+)
+
+// SpotRateLimits 现货默认限制，以 exchangeInfo 返回的为准
+var SpotRateLimits = []RateLimits{
+	{RateLimitType: RateLimitRequestWeight, Interval: "MINUTE", IntervalNum: 1, Limit: 6000},
+	{RateLimitType: RateLimitOrders, Interval: "SECOND", IntervalNum: 10, Limit: 100},
+	{RateLimitType: RateLimitOrders, Interval: "DAY", IntervalNum: 1, Limit: 200000},
+	{RateLimitType: RateLimitRawRequests, Interval: "MINUTE", IntervalNum: 5, Limit: 61000},
+}
+
+// FuturesRateLimits U本位合约默认限制，以 exchangeInfo 返回的为准
+var FuturesRateLimits = []RateLimits{
+	{RateLimitType: RateLimitRequestWeight, Interval: "MINUTE", IntervalNum: 1, Limit: 2400},
+	{RateLimitType: RateLimitOrders, Interval: "MINUTE", IntervalNum: 1, Limit: 1200},
+	{RateLimitType: RateLimitOrders, Interval: "SECOND", IntervalNum: 10, Limit: 300},
+}
+
+// DeliveryRateLimits 币本位合约默认限制，以 exchangeInfo 返回的为准
+var DeliveryRateLimits = []RateLimits{
+	{RateLimitType: RateLimitRequestWeight, Interval: "MINUTE", IntervalNum: 1, Limit: 2400},
+	{RateLimitType: RateLimitOrders, Interval: "MINUTE", IntervalNum: 1, Limit: 1200},
+}
+
+// MarginRateLimits 杠杆账户(/sapi)默认 IP 权重限制，与现货 /api 的权重分开计算
+// 借贷、下单等接口另有 UID 权重，不在本地计数
+var MarginRateLimits = []RateLimits{
+	{RateLimitType: RateLimitRequestWeight, Interval: "MINUTE", IntervalNum: 1, Limit: 12000},
+}
+
+func endpoint(method, path string) string {
+	return method + " " + path
+}
+
+// SpotCosts 现货 REST 和 WS API 接口的权重
+// 与参数有关的接口(depth、ticker 等)按最常用的参数登记，实际权重由请求通过 SetWeight 设置
+var SpotCosts = map[string]Cost{
+	endpoint(http.MethodGet, consts.ApiPing):                      {Weight: 1},
+	endpoint(http.MethodGet, consts.ApiTime):                      {Weight: 1},
+	endpoint(http.MethodGet, consts.ApiExchangeInfo):              {Weight: 20},
+	endpoint(http.MethodGet, consts.ApiMarketDepth):               {Weight: 5},
+	endpoint(http.MethodGet, consts.ApiMarketTrades):              {Weight: 25},
+	endpoint(http.MethodGet, consts.ApiMarketHistoricalTrades):    {Weight: 25},
+	endpoint(http.MethodGet, consts.ApiMarketAggTrades):           {Weight: 2},
+	endpoint(http.MethodGet, consts.ApiMarketKLines):              {Weight: 2},
+	endpoint(http.MethodGet, consts.ApiMarketUIKLines):            {Weight: 2},
+	endpoint(http.MethodGet, consts.ApiMarketAvgPrice):            {Weight: 2},
+	endpoint(http.MethodGet, consts.ApiMarketTicker24Hr):          {Weight: 2},
+	endpoint(http.MethodGet, consts.ApiMarketTickerTradingDay):    {Weight: 4},
+	endpoint(http.MethodGet, consts.ApiMarketTickerPrice):         {Weight: 2},
+	endpoint(http.MethodGet, consts.ApiMarketTickerBookTicker):    {Weight: 2},
+	endpoint(http.MethodGet, consts.ApiMarketTicker):              {Weight: 4},
+	endpoint(http.MethodPost, consts.ApiOrder):                    {Weight: 1, Orders: 1},
+	endpoint(http.MethodGet, consts.ApiOrder):                     {Weight: 4},
+	endpoint(http.MethodDelete, consts.ApiOrder):                  {Weight: 1},
+	endpoint(http.MethodPost, consts.ApiTradingOrderTest):         {Weight: 1},
+	endpoint(http.MethodGet, consts.ApiOpenOrders):                {Weight: 6},
+	endpoint(http.MethodDelete, consts.ApiOpenOrders):             {Weight: 1},
+	endpoint(http.MethodPost, consts.ApiTradingCancelReplace):     {Weight: 1, Orders: 1},
+	endpoint(http.MethodGet, consts.ApiTradingAllOrders):          {Weight: 20},
+	endpoint(http.MethodGet, consts.ApiOrderList):                 {Weight: 4},
+	endpoint(http.MethodDelete, consts.ApiOrderList):              {Weight: 1},
+	endpoint(http.MethodPost, consts.ApiTradingOrderListOCO):      {Weight: 1, Orders: 2},
+	endpoint(http.MethodPost, consts.ApiTradingOrderListOTO):      {Weight: 1, Orders: 2},
+	endpoint(http.MethodPost, consts.ApiTradingOrderListOTOCO):    {Weight: 1, Orders: 3},
+	endpoint(http.MethodGet, consts.ApiAccountAllOrderList):       {Weight: 20},
+	endpoint(http.MethodGet, consts.ApiTradingOpenOrderList):      {Weight: 6},
+	endpoint(http.MethodPost, consts.ApiTradingSorOrder):          {Weight: 1, Orders: 1},
+	endpoint(http.MethodPost, consts.ApiTradingSorOrderTest):      {Weight: 1},
+	endpoint(http.MethodGet, consts.ApiAccount):                   {Weight: 20},
+	endpoint(http.MethodGet, consts.ApiAccountMyTrades):           {Weight: 20},
+	endpoint(http.MethodGet, consts.ApiAccountRateLimitOrder):     {Weight: 40},
+	endpoint(http.MethodGet, consts.ApiAccountMyPreventedMatches): {Weight: 2},
+	endpoint(http.MethodGet, consts.ApiAccountMyAllocations):      {Weight: 20},
+	endpoint(http.MethodGet, consts.ApiAccountCommission):         {Weight: 20},
+	consts.ApiStreamUserDataStream:                                {Weight: 2},
+
+	// WS API
+	"ping":                      {Weight: 1},
+	"time":                      {Weight: 1},
+	"exchangeInfo":              {Weight: 20},
+	"depth":                     {Weight: 5},
+	"trades.recent":             {Weight: 25},
+	"trades.historical":         {Weight: 25},
+	"trades.aggregate":          {Weight: 2},
+	"klines":                    {Weight: 2},
+	"uiKlines":                  {Weight: 2},
+	"avgPrice":                  {Weight: 2},
+	"ticker.24hr":               {Weight: 2},
+	"ticker.tradingDay":         {Weight: 4},
+	"ticker":                    {Weight: 4},
+	"ticker.price":              {Weight: 2},
+	"ticker.book":               {Weight: 2},
+	"order.place":               {Weight: 1, Orders: 1},
+	"order.test":                {Weight: 1},
+	"order.status":              {Weight: 4},
+	"order.cancel":              {Weight: 1},
+	"order.cancelReplace":       {Weight: 1, Orders: 1},
+	"openOrders.status":         {Weight: 6},
+	"openOrders.cancelAll":      {Weight: 1},
+	"orderList.place.oco":       {Weight: 1, Orders: 2},
+	"orderList.place.oto":       {Weight: 1, Orders: 2},
+	"orderList.place.otoco":     {Weight: 1, Orders: 3},
+	"orderList.status":          {Weight: 4},
+	"orderList.cancel":          {Weight: 1},
+	"openOrderLists.status":     {Weight: 6},
+	"sor.order.place":           {Weight: 1, Orders: 1},
+	"sor.order.test":            {Weight: 1},
+	"account.status":            {Weight: 20},
+	"account.rateLimits.orders": {Weight: 40},
+	"account.commission":        {Weight: 20},
+	"allOrders":                 {Weight: 20},
+	"allOrderLists":             {Weight: 20},
+	"myTrades":                  {Weight: 20},
+	"myPreventedMatches":        {Weight: 2},
+	"myAllocations":             {Weight: 20},
+	"userDataStream.start":      {Weight: 2},
+	"userDataStream.ping":       {Weight: 2},
+	"userDataStream.stop":       {Weight: 2},
+}
+
+// FuturesCosts U本位合约 REST 和 WS API 接口的权重
+// 下单接口不计 IP 权重，只计下单次数；批量接口的下单次数由请求通过 SetOrderCount 设置
+var FuturesCosts = map[string]Cost{
+	endpoint(http.MethodGet, consts.FApiPing):                     {Weight: 1},
+	endpoint(http.MethodGet, consts.FApiTime):                     {Weight: 1},
+	endpoint(http.MethodGet, consts.FApiExchangeInfo):             {Weight: 1},
+	endpoint(http.MethodGet, consts.FApiMarketDepth):              {Weight: 5},
+	endpoint(http.MethodGet, consts.FApiMarketTrades):             {Weight: 5},
+	endpoint(http.MethodGet, consts.FApiMarketHistoricalTrades):   {Weight: 20},
+	endpoint(http.MethodGet, consts.FApiMarketAggTrades):          {Weight: 20},
+	endpoint(http.MethodGet, consts.FApiMarketKLines):             {Weight: 2},
+	endpoint(http.MethodGet, consts.FApiMarketContinuousKlines):   {Weight: 2},
+	endpoint(http.MethodGet, consts.FApiMarketIndexPriceKlines):   {Weight: 2},
+	endpoint(http.MethodGet, consts.FApiMarketPremiumIndexKlines): {Weight: 2},
+	endpoint(http.MethodGet, consts.FApiMarketPremiumIndex):       {Weight: 1},
+	endpoint(http.MethodGet, consts.FApiMarketFundingRate):        {Weight: 1},
+	endpoint(http.MethodGet, consts.FApiMarketFundingInfo):        {Weight: 1},
+	endpoint(http.MethodGet, consts.FApiMarketTicker24Hr):         {Weight: 1},
+	endpoint(http.MethodGet, consts.FApiMarketTickerPrice):        {Weight: 1},
+	endpoint(http.MethodGet, consts.FApiMarketTickerPriceV2):      {Weight: 1},
+	endpoint(http.MethodGet, consts.FApiMarketTickerBookTicker):   {Weight: 2},
+	endpoint(http.MethodGet, consts.FApiMarketOpenInterest):       {Weight: 1},
+	endpoint(http.MethodGet, consts.FApiMarketIndexInfo):          {Weight: 1},
+	endpoint(http.MethodGet, consts.FApiMarketAssetIndex):         {Weight: 1},
+	endpoint(http.MethodGet, consts.FApiMarketConstituents):       {Weight: 2},
+	endpoint(http.MethodPost, consts.FApiOrder):                   {Weight: 0, Orders: 1},
+	endpoint(http.MethodPut, consts.FApiOrder):                    {Weight: 1, Orders: 1},
+	endpoint(http.MethodGet, consts.FApiOrder):                    {Weight: 1},
+	endpoint(http.MethodDelete, consts.FApiOrder):                 {Weight: 1},
+	endpoint(http.MethodPost, consts.FApiBatchOrders):             {Weight: 5, Orders: 5},
+	endpoint(http.MethodPut, consts.FApiBatchOrders):              {Weight: 5, Orders: 5},
+	endpoint(http.MethodDelete, consts.FApiBatchOrders):           {Weight: 1},
+	endpoint(http.MethodDelete, consts.FApiAllOpenOrders):         {Weight: 1},
+	endpoint(http.MethodPost, consts.FApiCountdownCancelAll):      {Weight: 10},
+	endpoint(http.MethodPost, consts.FApiTradingOrderTest):        {Weight: 0},
+	endpoint(http.MethodGet, consts.FApiTradingAllOrders):         {Weight: 5},
+	endpoint(http.MethodGet, consts.FApiAccountOrderAmendment):    {Weight: 1},
+
+	// WS API
+	"ping":                {Weight: 1},
+	"time":                {Weight: 1},
+	"depth":               {Weight: 5},
+	"v2/ticker.price":     {Weight: 1},
+	"ticker.book":         {Weight: 2},
+	"order.place":         {Weight: 0, Orders: 1},
+	"order.modify":        {Weight: 1, Orders: 1},
+	"order.cancel":        {Weight: 1},
+	"order.status":        {Weight: 1},
+	"v2/account.position": {Weight: 5},
+	"v2/account.balance":  {Weight: 5},
+	"v2/account.status":   {Weight: 5},
+}
+
+// DeliveryCosts 币本位合约 REST 接口的权重
+// 与参数有关的接口(depth、ticker、K线等)按最常用的参数登记，实际权重由请求通过 SetWeight 设置
+var DeliveryCosts = map[string]Cost{
+	endpoint(http.MethodGet, consts.DApiPing):                   {Weight: 1},
+	endpoint(http.MethodGet, consts.DApiTime):                   {Weight: 1},
+	endpoint(http.MethodGet, consts.DApiExchangeInfo):           {Weight: 1},
+	endpoint(http.MethodGet, consts.DApiMarketDepth):            {Weight: 5},
+	endpoint(http.MethodGet, consts.DApiMarketTrades):           {Weight: 5},
+	endpoint(http.MethodGet, consts.DApiMarketKLines):           {Weight: 2},
+	endpoint(http.MethodGet, consts.DApiMarketContinuousKlines): {Weight: 2},
+	endpoint(http.MethodGet, consts.DApiMarketIndexPriceKlines): {Weight: 2},
+	endpoint(http.MethodGet, consts.DApiMarketMarkPriceKlines):  {Weight: 2},
+	endpoint(http.MethodGet, consts.DApiMarketPremiumIndex):     {Weight: 10},
+	endpoint(http.MethodGet, consts.DApiMarketFundingRate):      {Weight: 1},
+	endpoint(http.MethodGet, consts.DApiMarketTicker24Hr):       {Weight: 1},
+	endpoint(http.MethodGet, consts.DApiMarketTickerPrice):      {Weight: 1},
+	endpoint(http.MethodGet, consts.DApiMarketTickerBookTicker): {Weight: 2},
+	endpoint(http.MethodGet, consts.DApiMarketOpenInterest):     {Weight: 1},
+	endpoint(http.MethodGet, consts.DApiDataOpenInterestHist):   {Weight: 1},
+	endpoint(http.MethodGet, consts.DApiDataBasis):              {Weight: 1},
+	endpoint(http.MethodPost, consts.DApiOrder):                 {Weight: 0, Orders: 1},
+	endpoint(http.MethodPut, consts.DApiOrder):                  {Weight: 1, Orders: 1},
+	endpoint(http.MethodGet, consts.DApiOrder):                  {Weight: 1},
+	endpoint(http.MethodDelete, consts.DApiOrder):               {Weight: 1},
+	endpoint(http.MethodPost, consts.DApiBatchOrders):           {Weight: 5, Orders: 5},
+	endpoint(http.MethodPut, consts.DApiBatchOrders):            {Weight: 5, Orders: 5},
+	endpoint(http.MethodDelete, consts.DApiBatchOrders):         {Weight: 1},
+	endpoint(http.MethodDelete, consts.DApiAllOpenOrders):       {Weight: 1},
+	endpoint(http.MethodGet, consts.DApiOpenOrders):             {Weight: 1},
+	endpoint(http.MethodGet, consts.DApiAllOrders):              {Weight: 20},
+	endpoint(http.MethodGet, consts.DApiAccount):                {Weight: 5},
+	endpoint(http.MethodGet, consts.DApiBalance):                {Weight: 1},
+	endpoint(http.MethodGet, consts.DApiPositionRisk):           {Weight: 1},
+	endpoint(http.MethodPost, consts.DApiLeverage):              {Weight: 1},
+	endpoint(http.MethodPost, consts.DApiMarginType):            {Weight: 1},
+	endpoint(http.MethodPost, consts.DApiPositionMargin):        {Weight: 1},
+	endpoint(http.MethodPost, consts.DApiPositionSideDual):      {Weight: 1},
+	endpoint(http.MethodGet, consts.DApiPositionSideDual):       {Weight: 30},
+	endpoint(http.MethodGet, consts.DApiUserTrades):             {Weight: 20},
+	endpoint(http.MethodGet, consts.DApiIncome):                 {Weight: 20},
+	endpoint(http.MethodGet, consts.DApiCommissionRate):         {Weight: 20},
+	consts.DApiStreamListenKey:                                  {Weight: 1},
+}
+
+// MarginCosts 杠杆账户接口的 IP 权重
+var MarginCosts = map[string]Cost{
+	endpoint(http.MethodPost, consts.SApiMarginBorrowRepay):    {Weight: 1},
+	endpoint(http.MethodGet, consts.SApiMarginBorrowRepay):     {Weight: 10},
+	endpoint(http.MethodGet, consts.SApiMarginAccount):         {Weight: 10},
+	endpoint(http.MethodGet, consts.SApiMarginIsolatedAccount): {Weight: 10},
+	endpoint(http.MethodGet, consts.SApiMarginMaxBorrowable):   {Weight: 50},
+	endpoint(http.MethodGet, consts.SApiMarginMaxTransferable): {Weight: 50},
+	endpoint(http.MethodGet, consts.SApiMarginInterestHistory): {Weight: 1},
+	endpoint(http.MethodPost, consts.SApiMarginOrder):          {Weight: 1, Orders: 1},
+	endpoint(http.MethodGet, consts.SApiMarginOrder):           {Weight: 10},
+	endpoint(http.MethodDelete, consts.SApiMarginOrder):        {Weight: 10},
+	endpoint(http.MethodGet, consts.SApiMarginOpenOrders):      {Weight: 10},
+	endpoint(http.MethodDelete, consts.SApiMarginOpenOrders):   {Weight: 1},
+	endpoint(http.MethodGet, consts.SApiMarginAllOrders):       {Weight: 200},
+	endpoint(http.MethodGet, consts.SApiMarginMyTrades):        {Weight: 10},
+	endpoint(http.MethodPost, consts.SApiMarginOrderOco):       {Weight: 1, Orders: 2},
+	endpoint(http.MethodGet, consts.SApiMarginOrderList):       {Weight: 10},
+	endpoint(http.MethodDelete, consts.SApiMarginOrderList):    {Weight: 1},
+	consts.SApiUserDataStream:                                  {Weight: 1},
+	consts.SApiUserDataStreamIsolated:                          {Weight: 1},
+}
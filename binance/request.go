@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"maps"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -20,14 +21,18 @@ import (
 )
 
 type Request struct {
-	Method   string //请求方法
-	Path     string //请求路径
-	fullURL  string
-	query    url.Values
-	form     url.Values
-	header   http.Header
-	body     io.Reader
-	needSign bool
+	Method     string //请求方法
+	Path       string //请求路径
+	fullURL    string
+	query      url.Values
+	form       url.Values
+	header     http.Header
+	body       io.Reader
+	needSign   bool
+	weight     *int   // 请求权重，为空时按 RateLimiter.Costs 登记的值
+	orders     *int   // 下单次数，为空时按 RateLimiter.Costs 登记的值
+	recvWindow int64  // 签名请求的 recvWindow(毫秒)，0 时使用 Client 的设置
+	host       string // 本次发送使用的 base URL，为空时使用 Client.BaseURL
 }
 
 func (r *Request) SetNeedSign(needSign bool) *Request {
@@ -35,6 +40,62 @@ func (r *Request) SetNeedSign(needSign bool) *Request {
 	return r
 }
 
+// SetRecvWindow 设置签名请求的 recvWindow(毫秒)，不能大于 60000
+func (r *Request) SetRecvWindow(recvWindow int64) *Request {
+	r.recvWindow = recvWindow
+	return r
+}
+
+// SetWeight 设置请求权重，用于权重随参数变化的接口
+func (r *Request) SetWeight(weight int) *Request {
+	r.weight = &weight
+	return r
+}
+
+// SetOrderCount 设置下单次数，用于批量下单接口
+func (r *Request) SetOrderCount(orders int) *Request {
+	r.orders = &orders
+	return r
+}
+
+// Idempotent 重复发送是否不会产生副作用
+// GET、按订单ID撤单的 DELETE、带客户端订单ID的下单(重复的订单ID会被拒绝)是幂等的
+func (r *Request) Idempotent() bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodDelete:
+		return r.has("orderId", "origClientOrderId", "orderListId", "listClientOrderId", "orderIdList", "origClientOrderIdList")
+	case http.MethodPost:
+		return r.has("newClientOrderId", "listClientOrderId")
+	}
+	return false
+}
+
+func (r *Request) has(keys ...string) bool {
+	for _, key := range keys {
+		if r.query.Get(key) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Request) clone() *Request {
+	c := *r
+	c.query = maps.Clone(r.query)
+	c.form = maps.Clone(r.form)
+	return &c
+}
+
+// endpoint 限流使用的接口标识，REST 为 "方法 路径"，WS API 为方法名
+func (r *Request) endpoint() string {
+	if r.Method == "" {
+		return r.Path
+	}
+	return endpoint(r.Method, r.Path)
+}
+
 // SetParam set param with key/value to query string
 func (r *Request) SetParam(key string, value any) *Request {
 	if r.query == nil {
@@ -0,0 +1,54 @@
+package binance
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/tidwall/gjson"
+)
+
+// ParseHttpResponse 解析响应，非 200 或者返回内容为 {"code":<负数>,"msg":...} 时返回 *APIError
+func ParseHttpResponse[T any](resp *http.Response) (body T, err error) {
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return body, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return body, NewAPIError(resp.StatusCode, resp.Header, data)
+	}
+	// 部分接口出错时 HTTP 状态码仍为 200
+	if code := gjson.GetBytes(data, "code"); code.Type == gjson.Number && code.Int() < 0 {
+		return body, NewAPIError(resp.StatusCode, resp.Header, data)
+	}
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		return body, err
+	}
+	return body, nil
+}
+
+// ObjectOrArray 兼容同一接口返回单个对象或数组，统一解析为数组
+// 如合约接口指定 symbol 时返回单个对象，不指定时返回数组
+type ObjectOrArray[T any] []T
+
+func (o *ObjectOrArray[T]) UnmarshalJSON(data []byte) error {
+	raw := bytes.TrimSpace(data)
+	switch {
+	case len(raw) == 0 || bytes.Equal(raw, []byte("null")):
+		*o = nil
+		return nil
+	case raw[0] == '{':
+		var v T
+		err := json.Unmarshal(raw, &v)
+		if err != nil {
+			return err
+		}
+		*o = []T{v}
+		return nil
+	default:
+		return json.Unmarshal(raw, (*[]T)(o))
+	}
+}
@@ -0,0 +1,299 @@
+package binance
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance/consts"
+)
+
+// SpotHosts 现货 REST 接口的全部 base URL，api1-api4 性能更好但稳定性略差
+var SpotHosts = []string{consts.REST_API, consts.REST_API1, consts.REST_API2, consts.REST_API3, consts.REST_API4, consts.REST_API_GCP}
+
+// RetryPolicy REST 请求重试策略
+//
+// 请求确定没有被执行时(连接失败、429、-1003、-1021 等)任何请求都会重试；
+// 执行状态未知时(超时、5xx)只重试幂等请求: GET、按订单ID撤单的 DELETE、带 newClientOrderId 的下单。
+// 设置了 Hosts 时按顺序使用第一个健康的 host，失败的 host 在一段时间内被跳过。
+type RetryPolicy struct {
+	MaxRetries     int           // 最大重试次数，不含第一次请求，默认 3
+	InitialBackoff time.Duration // 首次重试等待时间，默认 100ms
+	MaxBackoff     time.Duration // 最大重试等待时间，默认 5s
+	Multiplier     float64       // 退避倍数，默认 2
+	Jitter         float64       // 抖动比例 [0,1]，默认 0.2
+	Hosts          []string      // 可切换的 base URL，为空时只使用 Client.BaseURL
+	Cooldown       time.Duration // host 失败后被跳过的时间，连续失败时翻倍，默认 30s
+	// Hedge 大于 0 时对不需要签名的 GET 请求启用对冲: 超过该时间未返回则向下一个 host 再发一次，取先返回的结果
+	// 对冲请求会额外消耗请求权重
+	Hedge time.Duration
+	// ShouldRetry 可选，自定义是否重试，err 为网络错误或 *APIError
+	ShouldRetry func(r *Request, err error) bool
+
+	mu     sync.Mutex
+	health map[string]*hostHealth
+}
+
+type hostHealth struct {
+	failures  int
+	downUntil time.Time
+}
+
+// NewRetryPolicy 使用默认参数创建重试策略
+func NewRetryPolicy(hosts ...string) *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		Hosts:          hosts,
+		Cooldown:       30 * time.Second,
+	}
+}
+
+func (p *RetryPolicy) do(ctx context.Context, c *Client, r *Request) (*http.Response, error) {
+	tried := map[string]bool{}
+	for attempt := 0; ; attempt++ {
+		resp, err := p.attempt(ctx, c, r, tried)
+		apiErr := err
+		if err == nil && resp.StatusCode != http.StatusOK {
+			apiErr = readAPIError(resp)
+		}
+		if apiErr == nil || attempt >= p.MaxRetries || ctx.Err() != nil || !p.shouldRetry(r, apiErr) {
+			return resp, err
+		}
+		wait := p.backoff(attempt + 1)
+		var e *APIError
+		if errors.As(apiErr, &e) && e.StatusCode == http.StatusTooManyRequests {
+			if s, err := strconv.Atoi(e.Header.Get("Retry-After")); err == nil {
+				wait = max(wait, time.Duration(s)*time.Second)
+			}
+		}
+		c.Debugf("retry %s %s after %s: %v", r.Method, r.Path, wait, apiErr)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// attempt 发送一次请求，需要时对冲
+func (p *RetryPolicy) attempt(ctx context.Context, c *Client, r *Request, tried map[string]bool) (*http.Response, error) {
+	host := p.pick(c, tried)
+	if p.Hedge <= 0 || len(p.Hosts) < 2 || r.Method != http.MethodGet || r.needSign {
+		r.host = host
+		resp, err := c.send(ctx, r)
+		p.report(host, resp, err)
+		return resp, err
+	}
+	type result struct {
+		host   string
+		resp   *http.Response
+		err    error
+		cancel context.CancelFunc
+	}
+	results := make(chan result, 2)
+	launch := func(host string) {
+		hr := r.clone()
+		hr.host = host
+		hctx, cancel := context.WithCancel(ctx)
+		go func() {
+			resp, err := c.send(hctx, hr)
+			results <- result{host: host, resp: resp, err: err, cancel: cancel}
+		}()
+	}
+	launch(host)
+	pending := 1
+	timer := time.NewTimer(p.Hedge)
+	defer timer.Stop()
+	var last result
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			if second := p.pick(c, tried); second != host {
+				c.Debugf("hedge %s %s to %s", r.Method, r.Path, second)
+				launch(second)
+				pending++
+			}
+			continue
+		case last = <-results:
+			pending--
+		}
+		p.report(last.host, last.resp, last.err)
+		if last.err == nil && last.resp.StatusCode < http.StatusInternalServerError || pending == 0 {
+			break
+		}
+		last.cancel()
+		if last.resp != nil {
+			_ = last.resp.Body.Close()
+		}
+	}
+	// 丢弃仍在进行的请求
+	go func(pending int) {
+		for ; pending > 0; pending-- {
+			res := <-results
+			res.cancel()
+			if res.resp != nil {
+				_ = res.resp.Body.Close()
+			}
+		}
+	}(pending)
+	if last.err != nil {
+		last.cancel()
+		return nil, last.err
+	}
+	last.resp.Body = &cancelOnClose{ReadCloser: last.resp.Body, cancel: last.cancel}
+	return last.resp, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+func (p *RetryPolicy) shouldRetry(r *Request, err error) bool {
+	if p.ShouldRetry != nil {
+		return p.ShouldRetry(r, err)
+	}
+	var rl *RateLimitError
+	if errors.As(err, &rl) {
+		// 本地限流由 RateLimiter 负责等待
+		return false
+	}
+	if IsRetryable(err) {
+		return true
+	}
+	return IsExecutionUnknown(err) && r.Idempotent()
+}
+
+// pick 选择下一个 host: 优先本次请求还没用过的健康 host，都不健康时选最早恢复的
+func (p *RetryPolicy) pick(c *Client, tried map[string]bool) string {
+	if len(p.Hosts) == 0 {
+		return c.BaseURL
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	best := ""
+	var bestUntil time.Time
+	for _, pass := range []bool{false, true} {
+		for _, host := range p.Hosts {
+			if tried[host] && !pass {
+				continue
+			}
+			h := p.health[host]
+			if h == nil || !now.Before(h.downUntil) {
+				tried[host] = true
+				return host
+			}
+			if best == "" || h.downUntil.Before(bestUntil) {
+				best, bestUntil = host, h.downUntil
+			}
+		}
+	}
+	tried[best] = true
+	return best
+}
+
+// report 记录 host 的健康状态，网络错误和 5xx 视为 host 故障
+func (p *RetryPolicy) report(host string, resp *http.Response, err error) {
+	if len(p.Hosts) == 0 {
+		return
+	}
+	var rl *RateLimitError
+	failed := err != nil && !errors.As(err, &rl) && !errors.Is(err, context.Canceled)
+	if err == nil {
+		failed = resp.StatusCode >= http.StatusInternalServerError
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.health == nil {
+		p.health = map[string]*hostHealth{}
+	}
+	h := p.health[host]
+	if h == nil {
+		h = &hostHealth{}
+		p.health[host] = h
+	}
+	if !failed {
+		h.failures = 0
+		h.downUntil = time.Time{}
+		return
+	}
+	h.failures++
+	cooldown := p.Cooldown
+	if cooldown <= 0 {
+		cooldown = 30 * time.Second
+	}
+	cooldown *= time.Duration(1 << min(h.failures-1, 5))
+	h.downUntil = time.Now().Add(cooldown)
+}
+
+// Healthy 当前没有被跳过的 host
+func (p *RetryPolicy) Healthy() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	var hosts []string
+	for _, host := range p.Hosts {
+		if h := p.health[host]; h == nil || !now.Before(h.downUntil) {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = 100 * time.Millisecond
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 5 * time.Second
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	d := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if d > float64(maxBackoff) {
+		d = float64(maxBackoff)
+	}
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		d = d * (1 - jitter*rand.Float64())
+	}
+	return time.Duration(d)
+}
+
+// readAPIError 读取非 200 响应的错误，读取后恢复 resp.Body
+func readAPIError(resp *http.Response) error {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	return NewAPIError(resp.StatusCode, resp.Header, body)
+}
@@ -0,0 +1,77 @@
+package rules
+
+import (
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// 交易对过滤器类型
+const (
+	FilterPrice              = "PRICE_FILTER"
+	FilterPercentPrice       = "PERCENT_PRICE"
+	FilterPercentPriceBySide = "PERCENT_PRICE_BY_SIDE"
+	FilterLotSize            = "LOT_SIZE"
+	FilterMarketLotSize      = "MARKET_LOT_SIZE"
+	FilterMinNotional        = "MIN_NOTIONAL"
+	FilterNotional           = "NOTIONAL"
+	FilterIcebergParts       = "ICEBERG_PARTS"
+	FilterMaxNumOrders       = "MAX_NUM_ORDERS"
+	FilterMaxNumAlgoOrders   = "MAX_NUM_ALGO_ORDERS"
+	FilterTrailingDelta      = "TRAILING_DELTA"
+)
+
+// PriceFilter 价格过滤器，值为 0 表示不限制
+type PriceFilter struct {
+	MinPrice decimal.Decimal
+	MaxPrice decimal.Decimal
+	TickSize decimal.Decimal
+}
+
+// PercentPriceFilter 价格振幅过滤器
+// PERCENT_PRICE 的买卖双方使用同一组乘数
+type PercentPriceFilter struct {
+	Type              string // PERCENT_PRICE 或 PERCENT_PRICE_BY_SIDE
+	BidMultiplierUp   decimal.Decimal
+	BidMultiplierDown decimal.Decimal
+	AskMultiplierUp   decimal.Decimal
+	AskMultiplierDown decimal.Decimal
+	AvgPriceMins      int
+}
+
+// LotSizeFilter 订单数量过滤器，值为 0 表示不限制
+type LotSizeFilter struct {
+	MinQty   decimal.Decimal
+	MaxQty   decimal.Decimal
+	StepSize decimal.Decimal
+}
+
+// NotionalFilter 名义价值(价格 * 数量)过滤器，值为 0 表示不限制
+type NotionalFilter struct {
+	Type             string // MIN_NOTIONAL 或 NOTIONAL
+	MinNotional      decimal.Decimal
+	MaxNotional      decimal.Decimal
+	ApplyMinToMarket bool // 市价单是否检查最小名义价值
+	ApplyMaxToMarket bool // 市价单是否检查最大名义价值
+	AvgPriceMins     int
+}
+
+// TrailingDeltaFilter 追踪止盈止损 trailingDelta 的取值范围
+type TrailingDeltaFilter struct {
+	MinTrailingAboveDelta int64
+	MaxTrailingAboveDelta int64
+	MinTrailingBelowDelta int64
+	MaxTrailingBelowDelta int64
+}
+
+// Symbol 一个交易对的下单规则，未配置的过滤器为 nil 或 0
+type Symbol struct {
+	Symbol           string
+	Price            *PriceFilter
+	PercentPrice     *PercentPriceFilter
+	LotSize          *LotSizeFilter
+	MarketLotSize    *LotSizeFilter
+	Notional         *NotionalFilter
+	TrailingDelta    *TrailingDeltaFilter
+	IcebergParts     int
+	MaxNumOrders     int
+	MaxNumAlgoOrders int
+}
@@ -0,0 +1,265 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// Order 待检查的订单，值为 0 表示未设置
+// OCO、OTO、OTOCO 等订单列表按每个子订单分别构造
+type Order struct {
+	Side          string
+	Type          string
+	Price         decimal.Decimal
+	StopPrice     decimal.Decimal
+	Quantity      decimal.Decimal
+	QuoteOrderQty decimal.Decimal
+	IcebergQty    decimal.Decimal
+	TrailingDelta int64
+}
+
+// IsMarket 是否按市价成交
+func (o *Order) IsMarket() bool {
+	switch o.Type {
+	case "MARKET", "STOP_LOSS", "TAKE_PROFIT", "STOP_MARKET", "TAKE_PROFIT_MARKET", "TRAILING_STOP_MARKET":
+		return true
+	}
+	return false
+}
+
+// IsAlgo 是否为条件单，计入 MAX_NUM_ALGO_ORDERS
+func (o *Order) IsAlgo() bool {
+	switch o.Type {
+	case "STOP_LOSS", "STOP_LOSS_LIMIT", "TAKE_PROFIT", "TAKE_PROFIT_LIMIT",
+		"STOP", "STOP_MARKET", "TAKE_PROFIT_MARKET", "TRAILING_STOP_MARKET":
+		return true
+	}
+	return false
+}
+
+// isAbove 追踪止盈止损订单是否在市场价之上触发
+func (o *Order) isAbove() bool {
+	stopLoss := strings.HasPrefix(o.Type, "STOP_LOSS")
+	return (o.Side == "BUY") == stopLoss
+}
+
+// Violation 违反的一条过滤器规则
+type Violation struct {
+	Filter string // 过滤器类型，如 PRICE_FILTER
+	Field  string // 参数名，如 price
+	Value  string
+	Msg    string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s=%s %s", v.Filter, v.Field, v.Value, v.Msg)
+}
+
+// ValidationError 订单未通过交易对规则检查，列出每一条违反的过滤器
+type ValidationError struct {
+	Symbol     string
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	s := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		s[i] = v.String()
+	}
+	return fmt.Sprintf("rules: %s order rejected: %s", e.Symbol, strings.Join(s, "; "))
+}
+
+// Has 是否违反了指定类型的过滤器
+func (e *ValidationError) Has(filter string) bool {
+	for _, v := range e.Violations {
+		if v.Filter == filter {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *ValidationError) add(filter, field string, value decimal.Decimal, format string, args ...any) {
+	e.Violations = append(e.Violations, Violation{
+		Filter: filter,
+		Field:  field,
+		Value:  value.String(),
+		Msg:    fmt.Sprintf(format, args...),
+	})
+}
+
+// Market 检查时用到的行情和账户状态，不需要的检查可以留空
+type Market struct {
+	RefPrice       decimal.Decimal // 参考价格，一般为 avgPriceMins 内的加权平均价，用于市价单名义价值和价格振幅检查
+	OpenOrders     int             // 当前挂单数量，-1 表示未知
+	OpenAlgoOrders int             // 当前条件单数量，-1 表示未知
+}
+
+// Round 按 tickSize 和 stepSize 舍入订单
+// 买单价格向下取整，卖单价格向上取整，不会得到比原价更差的价格；触发价四舍五入；数量向下取整
+func (s *Symbol) Round(o *Order) {
+	if s.Price != nil && s.Price.TickSize.IsPositive() {
+		mode := decimal.RoundDown
+		if o.Side == "SELL" {
+			mode = decimal.RoundUp
+		}
+		if !o.Price.IsZero() {
+			o.Price = roundStep(o.Price, s.Price.MinPrice, s.Price.TickSize, mode)
+		}
+		if !o.StopPrice.IsZero() {
+			o.StopPrice = roundStep(o.StopPrice, s.Price.MinPrice, s.Price.TickSize, decimal.RoundHalfUp)
+		}
+	}
+	lot := s.lotSize(o)
+	if lot != nil && lot.StepSize.IsPositive() {
+		if !o.Quantity.IsZero() {
+			o.Quantity = roundStep(o.Quantity, lot.MinQty, lot.StepSize, decimal.RoundDown)
+		}
+	}
+	if s.LotSize != nil && s.LotSize.StepSize.IsPositive() && !o.IcebergQty.IsZero() {
+		o.IcebergQty = roundStep(o.IcebergQty, s.LotSize.MinQty, s.LotSize.StepSize, decimal.RoundDown)
+	}
+}
+
+// roundStep 舍入到 min + n*step
+func roundStep(v, min, step decimal.Decimal, mode decimal.RoundingMode) decimal.Decimal {
+	if v.LessThan(min) {
+		return v
+	}
+	return v.Sub(min).RoundStep(step, mode).Add(min).Normalize()
+}
+
+// onStep (v - min) 是否为 step 的整数倍
+func onStep(v, min, step decimal.Decimal) bool {
+	return v.Sub(min).Mod(step).IsZero()
+}
+
+// lotSize 市价单优先使用 MARKET_LOT_SIZE
+func (s *Symbol) lotSize(o *Order) *LotSizeFilter {
+	if o.IsMarket() && s.MarketLotSize != nil {
+		return s.MarketLotSize
+	}
+	return s.LotSize
+}
+
+// Validate 检查一组订单，OCO 等订单列表的子订单一起检查以计算挂单数量
+// 返回 *ValidationError，全部通过时返回 nil
+func (s *Symbol) Validate(m Market, orders ...*Order) error {
+	e := &ValidationError{Symbol: s.Symbol}
+	algo := 0
+	for _, o := range orders {
+		s.validate(e, m, o)
+		if o.IsAlgo() {
+			algo++
+		}
+	}
+	if s.MaxNumOrders > 0 && m.OpenOrders >= 0 && m.OpenOrders+len(orders) > s.MaxNumOrders {
+		e.add(FilterMaxNumOrders, "orders", decimal.NewFromInt(int64(m.OpenOrders+len(orders))), "exceeds %d", s.MaxNumOrders)
+	}
+	if s.MaxNumAlgoOrders > 0 && algo > 0 && m.OpenAlgoOrders >= 0 && m.OpenAlgoOrders+algo > s.MaxNumAlgoOrders {
+		e.add(FilterMaxNumAlgoOrders, "algoOrders", decimal.NewFromInt(int64(m.OpenAlgoOrders+algo)), "exceeds %d", s.MaxNumAlgoOrders)
+	}
+	if len(e.Violations) > 0 {
+		return e
+	}
+	return nil
+}
+
+func (s *Symbol) validate(e *ValidationError, m Market, o *Order) {
+	if f := s.Price; f != nil {
+		checkPrice(e, f, "price", o.Price)
+		checkPrice(e, f, "stopPrice", o.StopPrice)
+	}
+	if f := s.lotSize(o); f != nil && !o.Quantity.IsZero() {
+		name := FilterLotSize
+		if f == s.MarketLotSize {
+			name = FilterMarketLotSize
+		}
+		checkLot(e, f, name, "quantity", o.Quantity)
+	}
+	if f := s.LotSize; f != nil && !o.IcebergQty.IsZero() {
+		checkLot(e, f, FilterLotSize, "icebergQty", o.IcebergQty)
+	}
+	if s.IcebergParts > 0 && o.IcebergQty.IsPositive() && !o.Quantity.IsZero() {
+		parts := o.Quantity.DivRound(o.IcebergQty, 0, decimal.RoundCeiling)
+		if parts.GreaterThan(decimal.NewFromInt(int64(s.IcebergParts))) {
+			e.add(FilterIcebergParts, "icebergQty", o.IcebergQty, "splits quantity into %s parts, limit %d", parts, s.IcebergParts)
+		}
+	}
+	price := o.Price
+	if o.IsMarket() || price.IsZero() {
+		price = m.RefPrice
+	}
+	if f := s.PercentPrice; f != nil && m.RefPrice.IsPositive() && !o.Price.IsZero() && !o.IsMarket() {
+		up, down := f.BidMultiplierUp, f.BidMultiplierDown
+		if o.Side == "SELL" {
+			up, down = f.AskMultiplierUp, f.AskMultiplierDown
+		}
+		if up.IsPositive() && o.Price.GreaterThan(m.RefPrice.Mul(up)) {
+			e.add(f.Type, "price", o.Price, "above %s", m.RefPrice.Mul(up))
+		}
+		if down.IsPositive() && o.Price.LessThan(m.RefPrice.Mul(down)) {
+			e.add(f.Type, "price", o.Price, "below %s", m.RefPrice.Mul(down))
+		}
+	}
+	if f := s.Notional; f != nil {
+		var notional decimal.Decimal
+		switch {
+		case !o.QuoteOrderQty.IsZero():
+			notional = o.QuoteOrderQty
+		case !o.Quantity.IsZero() && price.IsPositive():
+			notional = o.Quantity.Mul(price)
+		}
+		if !notional.IsZero() {
+			market := o.IsMarket()
+			if f.MinNotional.IsPositive() && (!market || f.ApplyMinToMarket) && notional.LessThan(f.MinNotional) {
+				e.add(f.Type, "notional", notional, "below minNotional %s", f.MinNotional)
+			}
+			if f.MaxNotional.IsPositive() && (!market || f.ApplyMaxToMarket) && notional.GreaterThan(f.MaxNotional) {
+				e.add(f.Type, "notional", notional, "above maxNotional %s", f.MaxNotional)
+			}
+		}
+	}
+	if f := s.TrailingDelta; f != nil && o.TrailingDelta != 0 {
+		lo, hi := f.MinTrailingBelowDelta, f.MaxTrailingBelowDelta
+		if o.isAbove() {
+			lo, hi = f.MinTrailingAboveDelta, f.MaxTrailingAboveDelta
+		}
+		d := decimal.NewFromInt(o.TrailingDelta)
+		if lo > 0 && o.TrailingDelta < lo {
+			e.add(FilterTrailingDelta, "trailingDelta", d, "below %d", lo)
+		}
+		if hi > 0 && o.TrailingDelta > hi {
+			e.add(FilterTrailingDelta, "trailingDelta", d, "above %d", hi)
+		}
+	}
+}
+
+func checkPrice(e *ValidationError, f *PriceFilter, field string, v decimal.Decimal) {
+	if v.IsZero() {
+		return
+	}
+	if f.MinPrice.IsPositive() && v.LessThan(f.MinPrice) {
+		e.add(FilterPrice, field, v, "below minPrice %s", f.MinPrice)
+	}
+	if f.MaxPrice.IsPositive() && v.GreaterThan(f.MaxPrice) {
+		e.add(FilterPrice, field, v, "above maxPrice %s", f.MaxPrice)
+	}
+	if f.TickSize.IsPositive() && !onStep(v, f.MinPrice, f.TickSize) {
+		e.add(FilterPrice, field, v, "not a multiple of tickSize %s", f.TickSize)
+	}
+}
+
+func checkLot(e *ValidationError, f *LotSizeFilter, filter, field string, v decimal.Decimal) {
+	if f.MinQty.IsPositive() && v.LessThan(f.MinQty) {
+		e.add(filter, field, v, "below minQty %s", f.MinQty)
+	}
+	if f.MaxQty.IsPositive() && v.GreaterThan(f.MaxQty) {
+		e.add(filter, field, v, "above maxQty %s", f.MaxQty)
+	}
+	if f.StepSize.IsPositive() && !onStep(v, f.MinQty, f.StepSize) {
+		e.add(filter, field, v, "not a multiple of stepSize %s", f.StepSize)
+	}
+}
@@ -0,0 +1,183 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// ErrUnknownSymbol exchangeInfo 中没有该交易对
+var ErrUnknownSymbol = errors.New("rules: unknown symbol")
+
+// Loader 从 exchangeInfo 加载所有交易对的规则
+type Loader func(ctx context.Context) ([]*Symbol, error)
+
+// Registry 缓存的交易对规则，过期或遇到未知交易对时从 exchangeInfo 重新加载
+// 并发的刷新共用同一次请求
+type Registry struct {
+	loader      Loader
+	TTL         time.Duration // 缓存有效期，默认 1 小时，<= 0 表示只在未知交易对时刷新
+	UnknownTTL  time.Duration // 距上次加载不足该时间时未知交易对直接返回 ErrUnknownSymbol，默认 1 分钟，<= 0 表示每次都刷新
+	LoadTimeout time.Duration // 每次加载的超时时间，默认 30 秒，<= 0 表示不限制
+
+	// RefPrice 可选，查询参考价格(如 avgPrice)，用于 PERCENT_PRICE 和市价单的名义价值检查
+	RefPrice func(ctx context.Context, symbol string) (decimal.Decimal, error)
+	// OpenOrders 可选，查询当前挂单数和条件单数，用于 MAX_NUM_ORDERS 和 MAX_NUM_ALGO_ORDERS 检查
+	OpenOrders func(ctx context.Context, symbol string) (orders, algoOrders int, err error)
+
+	mu      sync.RWMutex
+	symbols map[string]*Symbol
+	updated time.Time
+	loading *loadCall
+}
+
+// loadCall 正在进行的加载
+type loadCall struct {
+	done chan struct{}
+	err  error
+}
+
+func NewRegistry(loader Loader) *Registry {
+	return &Registry{loader: loader, TTL: time.Hour, UnknownTTL: time.Minute, LoadTimeout: 30 * time.Second}
+}
+
+// Refresh 立即从 exchangeInfo 重新加载，已有加载在进行时等待其结果
+// 加载不受 ctx 取消的影响，以免第一个调用方取消后其它等待的调用方一起失败，ctx 只决定本次调用等待多久
+func (r *Registry) Refresh(ctx context.Context) error {
+	r.mu.Lock()
+	call := r.loading
+	if call == nil {
+		call = &loadCall{done: make(chan struct{})}
+		r.loading = call
+		go r.load(context.WithoutCancel(ctx), call)
+	}
+	r.mu.Unlock()
+	select {
+	case <-call.done:
+		return call.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Registry) load(ctx context.Context, call *loadCall) {
+	if r.LoadTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.LoadTimeout)
+		defer cancel()
+	}
+	list, err := r.loader(ctx)
+	var symbols map[string]*Symbol
+	if err == nil {
+		symbols = make(map[string]*Symbol, len(list))
+		for _, s := range list {
+			symbols[s.Symbol] = s
+		}
+	}
+	r.mu.Lock()
+	if err == nil {
+		r.symbols = symbols
+		r.updated = time.Now()
+	}
+	r.loading = nil
+	call.err = err
+	r.mu.Unlock()
+	close(call.done)
+}
+
+// Symbol 查询交易对规则
+func (r *Registry) Symbol(ctx context.Context, symbol string) (*Symbol, error) {
+	r.mu.RLock()
+	s, ok := r.symbols[symbol]
+	stale := r.symbols == nil || (r.TTL > 0 && time.Since(r.updated) > r.TTL)
+	recent := r.symbols != nil && time.Since(r.updated) < r.UnknownTTL
+	r.mu.RUnlock()
+	if ok && !stale {
+		return s, nil
+	}
+	if !ok && recent {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSymbol, symbol)
+	}
+	err := r.Refresh(ctx)
+	if err != nil {
+		if ok {
+			// 刷新失败时继续使用旧的规则
+			return s, nil
+		}
+		return nil, err
+	}
+	r.mu.RLock()
+	s, ok = r.symbols[symbol]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSymbol, symbol)
+	}
+	return s, nil
+}
+
+// Check 检查同一交易对的一组订单，round 为 true 时先按 tickSize 和 stepSize 舍入
+// 违反规则时返回 *ValidationError
+func (r *Registry) Check(ctx context.Context, symbol string, round bool, orders ...*Order) error {
+	s, err := r.Symbol(ctx, symbol)
+	if err != nil {
+		return err
+	}
+	if round {
+		for _, o := range orders {
+			s.Round(o)
+		}
+	}
+	m := Market{OpenOrders: -1, OpenAlgoOrders: -1}
+	if r.RefPrice != nil {
+		m.RefPrice, err = r.RefPrice(ctx, symbol)
+		if err != nil {
+			return err
+		}
+	}
+	if r.OpenOrders != nil {
+		m.OpenOrders, m.OpenAlgoOrders, err = r.OpenOrders(ctx, symbol)
+		if err != nil {
+			return err
+		}
+	}
+	return s.Validate(m, orders...)
+}
+
+// Validator 挂在下单请求上的规则检查
+type Validator struct {
+	Registry *Registry
+	Round    bool // 发送前是否自动舍入价格和数量
+}
+
+// Check 未设置 Registry 时直接通过
+func (v *Validator) Check(ctx context.Context, symbol string, orders ...*Order) error {
+	if v == nil || v.Registry == nil {
+		return nil
+	}
+	return v.Registry.Check(ctx, symbol, v.Round, orders...)
+}
+
+// ParseDecimal 解析请求中的字符串参数，nil 返回 0
+func ParseDecimal(field string, s *string) (decimal.Decimal, error) {
+	if s == nil || *s == "" {
+		return decimal.Zero, nil
+	}
+	d, err := decimal.NewFromString(*s)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("rules: %s: %w", field, err)
+	}
+	return d, nil
+}
+
+// FormatDecimal 把舍入后的值写回请求参数，原来未设置的参数保持不变
+func FormatDecimal(d decimal.Decimal, s **string) {
+	if *s == nil || d.IsZero() {
+		return
+	}
+	v := d.String()
+	*s = &v
+}
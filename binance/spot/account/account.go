@@ -8,7 +8,7 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 	"github.com/tidwall/gjson"
 )
 
@@ -23,17 +23,13 @@ type getAccountRequest struct {
 }
 
 type getAccountResponse struct {
-	AccountType string `json:"accountType"`
-	Balances    []struct {
-		Asset  string `json:"asset"`
-		Free   string `json:"free"`
-		Locked string `json:"locked"`
-	} `json:"balances"`
-	Brokered        bool `json:"brokered"`
-	BuyerCommission int  `json:"buyerCommission"`
-	CanDeposit      bool `json:"canDeposit"`
-	CanTrade        bool `json:"canTrade"`
-	CanWithdraw     bool `json:"canWithdraw"`
+	AccountType     string           `json:"accountType"`
+	Balances        []accountBalance `json:"balances"`
+	Brokered        bool             `json:"brokered"`
+	BuyerCommission int              `json:"buyerCommission"`
+	CanDeposit      bool             `json:"canDeposit"`
+	CanTrade        bool             `json:"canTrade"`
+	CanWithdraw     bool             `json:"canWithdraw"`
 	CommissionRates struct {
 		Buyer  string `json:"buyer"`
 		Maker  string `json:"maker"`
@@ -50,6 +46,19 @@ type getAccountResponse struct {
 	UpdateTime                 int64    `json:"updateTime"`
 }
 
+type accountBalance struct {
+	Asset  string `json:"asset"`
+	Free   string `json:"free"`
+	Locked string `json:"locked"`
+}
+
+func (a accountBalance) FreeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.Free)
+}
+func (a accountBalance) LockedDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.Locked)
+}
+
 func NewGetAccount(client *binance.Client) Account {
 	return &getAccountRequest{Client: client}
 }
@@ -71,7 +80,7 @@ func (g *getAccountRequest) Call(ctx context.Context) (body *getAccountResponse,
 		g.Debugf("getAccountRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*getAccountResponse](resp)
+	return binance.ParseHttpResponse[*getAccountResponse](resp)
 }
 
 // ****************************** Websocket Stream *******************************
@@ -82,11 +91,20 @@ type WsOutboundAccountPositionEvent struct {
 	Event      enums.AccountDataEventType `json:"e"` // 事件类型
 	Time       int64                      `json:"E"` // 事件时间
 	UpdateTime int64                      `json:"u"` // 账户末次更新时间戳
-	Balances   []struct {
-		Asset  string `json:"a"`
-		Free   string `json:"f"`
-		Locked string `json:"l"`
-	} `json:"B"`
+	Balances   []outboundBalance          `json:"B"`
+}
+
+type outboundBalance struct {
+	Asset  string `json:"a"`
+	Free   string `json:"f"`
+	Locked string `json:"l"`
+}
+
+func (o outboundBalance) FreeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.Free)
+}
+func (o outboundBalance) LockedDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.Locked)
 }
 
 // WsBalanceUpdateEvent 余额更新
@@ -102,6 +120,10 @@ type WsBalanceUpdateEvent struct {
 	TransactionTime int64                      `json:"T"`
 }
 
+func (b WsBalanceUpdateEvent) ChangeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(b.Change)
+}
+
 // WsExecutionReportEvent 订单更新
 // 订单通过executionReport事件进行更新。
 // 备注: 通过将Z除以z可以找到平均价格。
@@ -144,6 +166,40 @@ type WsExecutionReportEvent struct {
 	SelfTradePreventionMode enums.StpModeType          `json:"V"`
 }
 
+func (e WsExecutionReportEvent) VolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(e.Volume)
+}
+func (e WsExecutionReportEvent) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(e.Price)
+}
+func (e WsExecutionReportEvent) StopPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(e.StopPrice)
+}
+func (e WsExecutionReportEvent) IceBergVolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(e.IceBergVolume)
+}
+func (e WsExecutionReportEvent) LatestVolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(e.LatestVolume)
+}
+func (e WsExecutionReportEvent) FilledVolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(e.FilledVolume)
+}
+func (e WsExecutionReportEvent) LatestPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(e.LatestPrice)
+}
+func (e WsExecutionReportEvent) FeeCostDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(e.FeeCost)
+}
+func (e WsExecutionReportEvent) FilledQuoteVolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(e.FilledQuoteVolume)
+}
+func (e WsExecutionReportEvent) LatestQuoteVolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(e.LatestQuoteVolume)
+}
+func (e WsExecutionReportEvent) QuoteVolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(e.QuoteVolume)
+}
+
 type WsListStatusEvent struct {
 	Event           enums.AccountDataEventType `json:"e"` // 事件类型
 	Time            int64                      `json:"E"` // 事件时间
@@ -168,6 +224,7 @@ type WsListenKeyExpiredEvent struct {
 // NewWsUserData 账户更新
 // 每当帐户余额发生更改时，都会发送一个事件outboundAccountPosition，其中包含可能由生成余额变动的事件而变动的资产。
 func NewWsUserData(
+	ctx context.Context,
 	c *binance.Client,
 	listenKey string,
 	oap binance.Handler[*WsOutboundAccountPositionEvent],
@@ -176,7 +233,7 @@ func NewWsUserData(
 	ls binance.Handler[*WsListStatusEvent],
 	lke binance.Handler[*WsListenKeyExpiredEvent],
 	exception binance.ErrorHandler,
-) error {
+) (*binance.WsStream, error) {
 	h := func(mt int, msg []byte) {
 		e := gjson.Get(string(msg), "e").String()
 		switch enums.AccountDataEventType(e) {
@@ -221,12 +278,13 @@ func NewWsUserData(
 		}
 	}
 	endpoint := c.BaseURL + listenKey
-	return c.Serve(endpoint, h, exception)
+	return c.Serve(ctx, endpoint, h, exception)
 }
 
 // NewStreamUserData 账户更新
 // 每当帐户余额发生更改时，都会发送一个事件outboundAccountPosition，其中包含可能由生成余额变动的事件而变动的资产。
 func NewStreamUserData(
+	ctx context.Context,
 	c *binance.Client,
 	listenKey string,
 	oap binance.Handler[*WsOutboundAccountPositionEvent],
@@ -235,7 +293,7 @@ func NewStreamUserData(
 	ls binance.Handler[*WsListStatusEvent],
 	lke binance.Handler[*WsListenKeyExpiredEvent],
 	exception binance.ErrorHandler,
-) error {
+) (*binance.WsStream, error) {
 	h := func(mt int, msg []byte) {
 		e := gjson.Get(string(msg), "data.e").String()
 		data := gjson.Get(string(msg), "data").String()
@@ -281,7 +339,7 @@ func NewStreamUserData(
 		}
 	}
 	endpoint := c.BaseURL + listenKey
-	return c.Serve(endpoint, h, exception)
+	return c.Serve(ctx, endpoint, h, exception)
 }
 
 // ****************************** Websocket Api *******************************
@@ -1,19 +1,20 @@
 package account
 
 import (
+	"cmp"
 	"context"
 	"net/http"
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 // AllOrderList 查询所有订单列表 (USER_DATA)
 // 根据提供的可选参数检索所有的订单列表。
 // 请注意，startTime和endTime之间的时间不能超过 24 小时。
 type AllOrderList interface {
+	Iterate() *binance.Pager[*allOrderListResponse]
 	SetLimit(limit enums.LimitType) *allOrderListRequest
 	SetFormId(fromId int64) *allOrderListRequest
 	SetStartTime(startTime int64) *allOrderListRequest
@@ -39,7 +40,7 @@ type allOrderListResponse struct {
 	Symbol            string                    `json:"symbol"`
 	Orders            []struct {
 		Symbol        string `json:"symbol"`
-		OrderId       int    `json:"fromId"`
+		OrderId       int    `json:"orderId"`
 		ClientOrderId string `json:"clientOrderId"`
 	} `json:"orders"`
 }
@@ -83,7 +84,36 @@ func (o *allOrderListRequest) Call(ctx context.Context) (body []*allOrderListRes
 		o.Debugf("allOrderListRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*allOrderListResponse](resp)
+	return binance.ParseHttpResponse[[]*allOrderListResponse](resp)
+}
+
+// Iterate 遍历订单列表
+// 设置了 fromId 或没有设置 startTime 时从 fromId(默认 0) 开始按 id 翻页直到 endTime，
+// 否则按 24 小时拆分 [startTime, endTime] 按时间翻页
+func (o *allOrderListRequest) Iterate() *binance.Pager[*allOrderListResponse] {
+	limit := cmp.Or(o.limit, enums.Limit500)
+	start, end := binance.TimeRange(o.startTime, o.endTime)
+	listId := func(list *allOrderListResponse) int64 { return int64(list.OrderListId) }
+	if o.fromId != nil || o.startTime == nil {
+		from := int64(0)
+		if o.fromId != nil {
+			from = *o.fromId
+		}
+		return binance.NewPager(binance.IdCursor(int(limit), &from, func(ctx context.Context, fromId *int64) ([]*allOrderListResponse, error) {
+			r := *o
+			r.limit, r.fromId, r.startTime, r.endTime = limit, fromId, nil, nil
+			return r.Call(ctx)
+		}, listId, func(list *allOrderListResponse) bool {
+			return list.TransactionTime > end
+		}))
+	}
+	return binance.NewPager(binance.TimeCursor(start, end, day, int(limit), func(ctx context.Context, start, end int64) ([]*allOrderListResponse, error) {
+		r := *o
+		r.limit, r.startTime, r.endTime = limit, &start, &end
+		return r.Call(ctx)
+	}, func(list *allOrderListResponse) int64 {
+		return list.TransactionTime
+	}, listId))
 }
 
 // ****************************** Websocket Api *******************************
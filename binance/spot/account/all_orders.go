@@ -1,16 +1,21 @@
 package account
 
 import (
+	"cmp"
 	"context"
 	"net/http"
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
+// day 按时间查询订单、成交时 startTime 和 endTime 的最大跨度(毫秒)
+const day = 24 * 60 * 60 * 1000
+
 type AllOrders interface {
+	Iterate() *binance.Pager[*allOrdersResponse]
 	SetSymbol(symbol string) *allOrdersRequest
 	SetOrderId(orderId int64) *allOrdersRequest
 	SetLimit(limit enums.LimitType) *allOrdersRequest
@@ -36,7 +41,7 @@ type allOrdersRequest struct {
 
 type allOrdersResponse struct {
 	Symbol                  string                `json:"symbol"`                  // 交易对
-	OrderId                 int                   `json:"fromId"`                  // 系统的订单ID
+	OrderId                 int                   `json:"orderId"`                 // 系统的订单ID
 	OrderListId             int                   `json:"orderListId"`             // 除非此单是订单列表的一部分, 否则此值为 -1
 	ClientOrderId           string                `json:"clientOrderId"`           // 客户自己设置的ID
 	Price                   string                `json:"price"`                   // 订单价格
@@ -64,6 +69,31 @@ type allOrdersResponse struct {
 	TrailingTime      int64  `json:"trailingTime,omitempty"`
 }
 
+func (a allOrdersResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.Price)
+}
+func (a allOrdersResponse) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.OrigQty)
+}
+func (a allOrdersResponse) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.ExecutedQty)
+}
+func (a allOrdersResponse) CummulativeQuoteQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.CummulativeQuoteQty)
+}
+func (a allOrdersResponse) StopPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.StopPrice)
+}
+func (a allOrdersResponse) IcebergQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.IcebergQty)
+}
+func (a allOrdersResponse) OrigQuoteOrderQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.OrigQuoteOrderQty)
+}
+func (a allOrdersResponse) PreventedQuantityDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.PreventedQuantity)
+}
+
 // NewAllOrders 账户订单历史 (USER_DATA)
 func NewAllOrders(client *binance.Client, symbol string, limit enums.LimitType) AllOrders {
 	return &allOrdersRequest{Client: client, symbol: symbol, limit: limit}
@@ -109,7 +139,37 @@ func (o *allOrdersRequest) Call(ctx context.Context) (body []*allOrdersResponse,
 		o.Debugf("allOrdersRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*allOrdersResponse](resp)
+	return binance.ParseHttpResponse[[]*allOrdersResponse](resp)
+}
+
+// Iterate 遍历订单
+// 设置了 orderId 或没有设置 startTime 时从 orderId(默认 0) 开始按 id 翻页直到 endTime，
+// 否则按 24 小时拆分 [startTime, endTime] 按时间翻页
+func (o *allOrdersRequest) Iterate() *binance.Pager[*allOrdersResponse] {
+	limit := cmp.Or(o.limit, enums.Limit500)
+	start, end := binance.TimeRange(o.startTime, o.endTime)
+	orderId := func(order *allOrdersResponse) int64 { return int64(order.OrderId) }
+	if o.orderId != nil || o.startTime == nil {
+		from := int64(0)
+		if o.orderId != nil {
+			from = *o.orderId
+		}
+		return binance.NewPager(binance.IdCursor(int(limit), &from, func(ctx context.Context, fromId *int64) ([]*allOrdersResponse, error) {
+			r := *o
+			r.limit, r.orderId, r.startTime, r.endTime = limit, fromId, nil, nil
+			return r.Call(ctx)
+		}, orderId, func(order *allOrdersResponse) bool {
+			return order.Time > end
+		}))
+	}
+	return binance.NewPager(binance.TimeCursor(start, end, day, int(limit), func(ctx context.Context, start, end int64) ([]*allOrdersResponse, error) {
+		r := *o
+		from, to := uint64(start), uint64(end)
+		r.limit, r.startTime, r.endTime = limit, &from, &to
+		return r.Call(ctx)
+	}, func(order *allOrdersResponse) int64 {
+		return order.Time
+	}, orderId))
 }
 
 // ****************************** Websocket Api *******************************
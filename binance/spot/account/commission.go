@@ -6,7 +6,6 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type Commission interface {
@@ -67,7 +66,7 @@ func (c *commissionRequest) Call(ctx context.Context) (body *commissionResponse,
 		c.Debugf("commissionRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*commissionResponse](resp)
+	return binance.ParseHttpResponse[*commissionResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
@@ -7,7 +7,6 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type MyAllocations interface {
@@ -38,7 +37,7 @@ type myAllocationsResponse struct {
 	Symbol          string `json:"symbol"`
 	AllocationId    int    `json:"allocationId"`
 	AllocationType  string `json:"allocationType"`
-	OrderId         int    `json:"fromId"`
+	OrderId         int    `json:"orderId"`
 	OrderListId     int    `json:"orderListId"`
 	Price           string `json:"price"`
 	Qty             string `json:"qty"`
@@ -110,7 +109,7 @@ func (m *myAllocationsRequest) Call(ctx context.Context) (body []*myAllocationsR
 		m.Debugf("myAllocationsRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*myAllocationsResponse](resp)
+	return binance.ParseHttpResponse[[]*myAllocationsResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
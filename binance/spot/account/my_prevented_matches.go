@@ -7,7 +7,6 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 // MyPreventedMatches 获取 Prevented Matches (USER_DATA)
@@ -102,7 +101,7 @@ func (m *myPreventedMatchesRequest) Call(ctx context.Context) (body []*myPrevent
 		m.Debugf("myPreventedMatchesRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*myPreventedMatchesResponse](resp)
+	return binance.ParseHttpResponse[[]*myPreventedMatchesResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
@@ -1,16 +1,18 @@
 package account
 
 import (
+	"cmp"
 	"context"
 	"net/http"
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type MyTrades interface {
+	Iterate() *binance.Pager[*myTradesResponse]
 	SetSymbol(symbol string) *myTradesRequest
 	SetLimit(limit enums.LimitType) *myTradesRequest
 	SetOrderId(orderId int64) *myTradesRequest
@@ -45,7 +47,7 @@ type myTradesRequest struct {
 type myTradesResponse struct {
 	Symbol          string `json:"symbol"`
 	Id              int    `json:"id"`
-	OrderId         int    `json:"fromId"`
+	OrderId         int    `json:"orderId"`
 	Price           string `json:"price"`
 	Qty             string `json:"qty"`
 	Commission      string `json:"commission"`
@@ -56,6 +58,16 @@ type myTradesResponse struct {
 	IsBestMatch     bool   `json:"isBestMatch"`
 }
 
+func (m myTradesResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(m.Price)
+}
+func (m myTradesResponse) QtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(m.Qty)
+}
+func (m myTradesResponse) CommissionDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(m.Commission)
+}
+
 func NewMyTrades(client *binance.Client, symbol string, limit enums.LimitType) MyTrades {
 	return &myTradesRequest{Client: client, symbol: symbol, limit: limit}
 }
@@ -95,7 +107,7 @@ func (m *myTradesRequest) Call(ctx context.Context) (body []*myTradesResponse, e
 	}
 	req.SetNeedSign(true)
 	req.SetParam("symbol", m.symbol)
-	req.SetOptionalParam("fromId", m.orderId)
+	req.SetOptionalParam("orderId", m.orderId)
 	req.SetOptionalParam("startTime", m.startTime)
 	req.SetOptionalParam("endTime", m.endTime)
 	req.SetOptionalParam("fromId", m.fromId)
@@ -105,7 +117,37 @@ func (m *myTradesRequest) Call(ctx context.Context) (body []*myTradesResponse, e
 		m.Debugf("myTradesRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*myTradesResponse](resp)
+	return binance.ParseHttpResponse[[]*myTradesResponse](resp)
+}
+
+// Iterate 遍历成交历史
+// 设置了 fromId 或没有设置 startTime 时从 fromId(默认 0) 开始按 id 翻页直到 endTime，
+// 否则按 24 小时拆分 [startTime, endTime] 按时间翻页
+func (m *myTradesRequest) Iterate() *binance.Pager[*myTradesResponse] {
+	limit := cmp.Or(m.limit, enums.Limit500)
+	start, end := binance.TimeRange(m.startTime, m.endTime)
+	tradeId := func(trade *myTradesResponse) int64 { return int64(trade.Id) }
+	if m.fromId != nil || m.startTime == nil {
+		from := int64(0)
+		if m.fromId != nil {
+			from = *m.fromId
+		}
+		return binance.NewPager(binance.IdCursor(int(limit), &from, func(ctx context.Context, fromId *int64) ([]*myTradesResponse, error) {
+			r := *m
+			r.limit, r.fromId, r.startTime, r.endTime = limit, fromId, nil, nil
+			return r.Call(ctx)
+		}, tradeId, func(trade *myTradesResponse) bool {
+			return trade.Time > end
+		}))
+	}
+	return binance.NewPager(binance.TimeCursor(start, end, day, int(limit), func(ctx context.Context, start, end int64) ([]*myTradesResponse, error) {
+		r := *m
+		from, to := uint64(start), uint64(end)
+		r.limit, r.startTime, r.endTime = limit, &from, &to
+		return r.Call(ctx)
+	}, func(trade *myTradesResponse) int64 {
+		return trade.Time
+	}, tradeId))
 }
 
 // ****************************** Websocket Api *******************************
@@ -144,7 +186,7 @@ func (m *myTradesRequest) Send(ctx context.Context) (*WsApiMyTradesResponse, err
 	req := &binance.Request{Path: "myTrades"}
 	req.SetNeedSign(true)
 	req.SetParam("symbol", m.symbol)
-	req.SetOptionalParam("fromId", m.orderId)
+	req.SetOptionalParam("orderId", m.orderId)
 	req.SetOptionalParam("startTime", m.startTime)
 	req.SetOptionalParam("endTime", m.endTime)
 	req.SetOptionalParam("fromId", m.fromId)
@@ -7,7 +7,6 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type OpenOrderList interface {
@@ -28,7 +27,7 @@ type openOrderListResponse struct {
 	Symbol            string                    `json:"symbol"`
 	Orders            []struct {
 		Symbol        string `json:"symbol"`
-		OrderId       int    `json:"fromId"`
+		OrderId       int    `json:"orderId"`
 		ClientOrderId string `json:"clientOrderId"`
 	} `json:"orders"`
 }
@@ -49,7 +48,7 @@ func (o *openOrderListRequest) Call(ctx context.Context) (body []*openOrderListR
 		o.Debugf("openOrderListRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*openOrderListResponse](resp)
+	return binance.ParseHttpResponse[[]*openOrderListResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
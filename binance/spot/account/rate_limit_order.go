@@ -6,7 +6,6 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type RateLimitOrder interface {
@@ -42,7 +41,7 @@ func (r *rateLimitOrderRequest) Call(ctx context.Context) (body []*rateLimitOrde
 		r.Debugf("rateLimitOrderRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*rateLimitOrderResponse](resp)
+	return binance.ParseHttpResponse[[]*rateLimitOrderResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
@@ -0,0 +1,81 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/tidwall/gjson"
+)
+
+// WsEventStreamTerminatedEvent 用户数据流已终止
+// WS API 会话登出或取消订阅后推送，之后不会再收到用户数据
+type WsEventStreamTerminatedEvent struct {
+	Event enums.AccountDataEventType `json:"e"` // 事件类型
+	Time  int64                      `json:"E"` // 事件时间
+}
+
+type WsApiUserDataSubscribeResponse struct {
+	binance.WsApiResponse
+	Result *struct {
+		SubscriptionId int `json:"subscriptionId"`
+	} `json:"result"`
+}
+
+// SubscribeWsApiUserData 在 WS API 连接上订阅用户数据推送 (USER_STREAM)
+// 需要先通过 c.SessionLogon 登录，不需要 listenKey；推送和请求响应共用同一个连接。
+// 为空的 handler 对应的事件会被忽略。
+func SubscribeWsApiUserData(
+	ctx context.Context,
+	c *binance.Client,
+	oap binance.Handler[*WsOutboundAccountPositionEvent],
+	bu binance.Handler[*WsBalanceUpdateEvent],
+	er binance.Handler[*WsExecutionReportEvent],
+	ls binance.Handler[*WsListStatusEvent],
+	est binance.Handler[*WsEventStreamTerminatedEvent],
+	exception binance.ErrorHandler,
+) (*WsApiUserDataSubscribeResponse, error) {
+	c.SetWsApiEventHandler(func(mt int, msg []byte) {
+		switch enums.AccountDataEventType(gjson.GetBytes(msg, "e").String()) {
+		case enums.AccountDataEventTypeOutboundAccountPosition:
+			handleEvent(mt, msg, oap, exception)
+		case enums.AccountDataEventTypeBalanceUpdate:
+			handleEvent(mt, msg, bu, exception)
+		case enums.AccountDataEventTypeExecutionReport:
+			handleEvent(mt, msg, er, exception)
+		case enums.AccountDataEventTypeListStatus:
+			handleEvent(mt, msg, ls, exception)
+		case enums.AccountDataEventTypeEventStreamTerminated:
+			handleEvent(mt, msg, est, exception)
+		}
+	})
+	req := &binance.Request{Path: "userDataStream.subscribe"}
+	res, err := binance.WsApiHandler[*WsApiUserDataSubscribeResponse](ctx, c, req)
+	if err != nil {
+		c.SetWsApiEventHandler(nil)
+	}
+	return res, err
+}
+
+// UnsubscribeWsApiUserData 取消 WS API 连接上的用户数据推送 (USER_STREAM)
+// 取消后服务端推送 eventStreamTerminated
+func UnsubscribeWsApiUserData(ctx context.Context, c *binance.Client) (*WsApiUserDataSubscribeResponse, error) {
+	req := &binance.Request{Path: "userDataStream.unsubscribe"}
+	return binance.WsApiHandler[*WsApiUserDataSubscribeResponse](ctx, c, req)
+}
+
+func handleEvent[T any](mt int, msg []byte, handler binance.Handler[*T], exception binance.ErrorHandler) {
+	if handler == nil {
+		return
+	}
+	event := new(T)
+	err := json.Unmarshal(msg, event)
+	if err != nil {
+		if exception != nil {
+			exception(mt, err)
+		}
+		return
+	}
+	handler(event)
+}
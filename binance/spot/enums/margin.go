@@ -0,0 +1,25 @@
+package enums
+
+type (
+	// SideEffectType 杠杆下单的借还款方式 (sideEffectType)
+	SideEffectType string
+
+	// BorrowRepayType 杠杆借贷或还款 (type)
+	BorrowRepayType string
+)
+
+const (
+	// SideEffectTypeNoSideEffect 普通下单，默认值
+	SideEffectTypeNoSideEffect SideEffectType = "NO_SIDE_EFFECT"
+	// SideEffectTypeMarginBuy 余额不足时自动借款下单
+	SideEffectTypeMarginBuy SideEffectType = "MARGIN_BUY"
+	// SideEffectTypeAutoRepay 成交后自动还款
+	SideEffectTypeAutoRepay SideEffectType = "AUTO_REPAY"
+	// SideEffectTypeAutoBorrowRepay 自动借款和还款
+	SideEffectTypeAutoBorrowRepay SideEffectType = "AUTO_BORROW_REPAY"
+)
+
+const (
+	BorrowRepayTypeBorrow BorrowRepayType = "BORROW"
+	BorrowRepayTypeRepay  BorrowRepayType = "REPAY"
+)
@@ -250,4 +250,7 @@ const (
 	//
 	//正常关闭流时不会推送该事件。
 	AccountDataEventTypeListenKeyExpired AccountDataEventType = "listenKeyExpired"
+	// AccountDataEventTypeEventStreamTerminated 用户数据流已终止
+	//通过 WS API userDataStream.subscribe 订阅时，会话登出或取消订阅后推送此事件。
+	AccountDataEventTypeEventStreamTerminated AccountDataEventType = "eventStreamTerminated"
 )
@@ -8,7 +8,8 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/binance/rules"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type ExchangeInfo interface {
@@ -33,15 +34,10 @@ func NewExchangeInfo(client *binance.Client, symbols, permissions []string) Exch
 // [["A"],["B","C"]] - 有权限"A"和权限"B"或权限"C"的账户可以下订单。（此处应用的是包含或，而不是排除或，因此账户可以同时拥有权限"B"和权限"C"。）
 // 数据源: 缓存
 type exchangeInfoResponse struct {
-	Timezone   string `json:"timezone"`
-	ServerTime int64  `json:"serverTime"`
-	RateLimits []struct {
-		RateLimitType string `json:"rateLimitType"`
-		Interval      string `json:"interval"`
-		IntervalNum   int    `json:"intervalNum"`
-		Limit         int    `json:"limit"`
-	} `json:"rateLimits"`
-	ExchangeFilters []interface{} `json:"exchangeFilters"`
+	Timezone        string               `json:"timezone"`
+	ServerTime      int64                `json:"serverTime"`
+	RateLimits      []binance.RateLimits `json:"rateLimits"`
+	ExchangeFilters []interface{}        `json:"exchangeFilters"`
 	Symbols         []struct {
 		Symbol                     string   `json:"symbol"`
 		Status                     string   `json:"status"`
@@ -62,29 +58,32 @@ type exchangeInfoResponse struct {
 		IsSpotTradingAllowed       bool     `json:"isSpotTradingAllowed"`
 		IsMarginTradingAllowed     bool     `json:"isMarginTradingAllowed"`
 		Filters                    []struct {
-			FilterType            string `json:"filterType"`
-			MinPrice              string `json:"minPrice,omitempty"`
-			MaxPrice              string `json:"maxPrice,omitempty"`
-			TickSize              string `json:"tickSize,omitempty"`
-			MinQty                string `json:"minQty,omitempty"`
-			MaxQty                string `json:"maxQty,omitempty"`
-			StepSize              string `json:"stepSize,omitempty"`
-			Limit                 int    `json:"limit,omitempty"`
-			MinTrailingAboveDelta int    `json:"minTrailingAboveDelta,omitempty"`
-			MaxTrailingAboveDelta int    `json:"maxTrailingAboveDelta,omitempty"`
-			MinTrailingBelowDelta int    `json:"minTrailingBelowDelta,omitempty"`
-			MaxTrailingBelowDelta int    `json:"maxTrailingBelowDelta,omitempty"`
-			BidMultiplierUp       string `json:"bidMultiplierUp,omitempty"`
-			BidMultiplierDown     string `json:"bidMultiplierDown,omitempty"`
-			AskMultiplierUp       string `json:"askMultiplierUp,omitempty"`
-			AskMultiplierDown     string `json:"askMultiplierDown,omitempty"`
-			AvgPriceMins          int    `json:"avgPriceMins,omitempty"`
-			MinNotional           string `json:"minNotional,omitempty"`
-			ApplyMinToMarket      bool   `json:"applyMinToMarket,omitempty"`
-			MaxNotional           string `json:"maxNotional,omitempty"`
-			ApplyMaxToMarket      bool   `json:"applyMaxToMarket,omitempty"`
-			MaxNumOrders          int    `json:"maxNumOrders,omitempty"`
-			MaxNumAlgoOrders      int    `json:"maxNumAlgoOrders,omitempty"`
+			FilterType            string          `json:"filterType"`
+			MinPrice              decimal.Decimal `json:"minPrice,omitempty"`
+			MaxPrice              decimal.Decimal `json:"maxPrice,omitempty"`
+			TickSize              decimal.Decimal `json:"tickSize,omitempty"`
+			MinQty                decimal.Decimal `json:"minQty,omitempty"`
+			MaxQty                decimal.Decimal `json:"maxQty,omitempty"`
+			StepSize              decimal.Decimal `json:"stepSize,omitempty"`
+			Limit                 int             `json:"limit,omitempty"`
+			MinTrailingAboveDelta int             `json:"minTrailingAboveDelta,omitempty"`
+			MaxTrailingAboveDelta int             `json:"maxTrailingAboveDelta,omitempty"`
+			MinTrailingBelowDelta int             `json:"minTrailingBelowDelta,omitempty"`
+			MaxTrailingBelowDelta int             `json:"maxTrailingBelowDelta,omitempty"`
+			BidMultiplierUp       decimal.Decimal `json:"bidMultiplierUp,omitempty"`
+			BidMultiplierDown     decimal.Decimal `json:"bidMultiplierDown,omitempty"`
+			AskMultiplierUp       decimal.Decimal `json:"askMultiplierUp,omitempty"`
+			AskMultiplierDown     decimal.Decimal `json:"askMultiplierDown,omitempty"`
+			MultiplierUp          decimal.Decimal `json:"multiplierUp,omitempty"`
+			MultiplierDown        decimal.Decimal `json:"multiplierDown,omitempty"`
+			AvgPriceMins          int             `json:"avgPriceMins,omitempty"`
+			ApplyToMarket         bool            `json:"applyToMarket,omitempty"`
+			MinNotional           decimal.Decimal `json:"minNotional,omitempty"`
+			ApplyMinToMarket      bool            `json:"applyMinToMarket,omitempty"`
+			MaxNotional           decimal.Decimal `json:"maxNotional,omitempty"`
+			ApplyMaxToMarket      bool            `json:"applyMaxToMarket,omitempty"`
+			MaxNumOrders          int             `json:"maxNumOrders,omitempty"`
+			MaxNumAlgoOrders      int             `json:"maxNumAlgoOrders,omitempty"`
 		} `json:"filters"`
 		Permissions                     []interface{} `json:"permissions"`
 		PermissionSets                  [][]string    `json:"permissionSets"`
@@ -118,5 +117,97 @@ func (ex *exchangeInfoRequest) Call(ctx context.Context) (body *exchangeInfoResp
 		ex.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*exchangeInfoResponse](resp)
+	return binance.ParseHttpResponse[*exchangeInfoResponse](resp)
+}
+
+// Rules 把交易对的过滤器转换为下单规则
+func (e *exchangeInfoResponse) Rules() []*rules.Symbol {
+	list := make([]*rules.Symbol, 0, len(e.Symbols))
+	for _, symbol := range e.Symbols {
+		s := &rules.Symbol{Symbol: symbol.Symbol}
+		for _, f := range symbol.Filters {
+			switch f.FilterType {
+			case rules.FilterPrice:
+				s.Price = &rules.PriceFilter{MinPrice: f.MinPrice, MaxPrice: f.MaxPrice, TickSize: f.TickSize}
+			case rules.FilterPercentPrice:
+				s.PercentPrice = &rules.PercentPriceFilter{
+					Type:              f.FilterType,
+					BidMultiplierUp:   f.MultiplierUp,
+					BidMultiplierDown: f.MultiplierDown,
+					AskMultiplierUp:   f.MultiplierUp,
+					AskMultiplierDown: f.MultiplierDown,
+					AvgPriceMins:      f.AvgPriceMins,
+				}
+			case rules.FilterPercentPriceBySide:
+				s.PercentPrice = &rules.PercentPriceFilter{
+					Type:              f.FilterType,
+					BidMultiplierUp:   f.BidMultiplierUp,
+					BidMultiplierDown: f.BidMultiplierDown,
+					AskMultiplierUp:   f.AskMultiplierUp,
+					AskMultiplierDown: f.AskMultiplierDown,
+					AvgPriceMins:      f.AvgPriceMins,
+				}
+			case rules.FilterLotSize:
+				s.LotSize = &rules.LotSizeFilter{MinQty: f.MinQty, MaxQty: f.MaxQty, StepSize: f.StepSize}
+			case rules.FilterMarketLotSize:
+				s.MarketLotSize = &rules.LotSizeFilter{MinQty: f.MinQty, MaxQty: f.MaxQty, StepSize: f.StepSize}
+			case rules.FilterMinNotional:
+				s.Notional = &rules.NotionalFilter{
+					Type:             f.FilterType,
+					MinNotional:      f.MinNotional,
+					ApplyMinToMarket: f.ApplyToMarket,
+					AvgPriceMins:     f.AvgPriceMins,
+				}
+			case rules.FilterNotional:
+				s.Notional = &rules.NotionalFilter{
+					Type:             f.FilterType,
+					MinNotional:      f.MinNotional,
+					MaxNotional:      f.MaxNotional,
+					ApplyMinToMarket: f.ApplyMinToMarket,
+					ApplyMaxToMarket: f.ApplyMaxToMarket,
+					AvgPriceMins:     f.AvgPriceMins,
+				}
+			case rules.FilterIcebergParts:
+				s.IcebergParts = f.Limit
+			case rules.FilterMaxNumOrders:
+				s.MaxNumOrders = f.MaxNumOrders
+			case rules.FilterMaxNumAlgoOrders:
+				s.MaxNumAlgoOrders = f.MaxNumAlgoOrders
+			case rules.FilterTrailingDelta:
+				s.TrailingDelta = &rules.TrailingDeltaFilter{
+					MinTrailingAboveDelta: int64(f.MinTrailingAboveDelta),
+					MaxTrailingAboveDelta: int64(f.MaxTrailingAboveDelta),
+					MinTrailingBelowDelta: int64(f.MinTrailingBelowDelta),
+					MaxTrailingBelowDelta: int64(f.MaxTrailingBelowDelta),
+				}
+			}
+		}
+		list = append(list, s)
+	}
+	return list
+}
+
+// NewRules 由 exchangeInfo 驱动的交易对规则缓存，用于下单前检查和舍入
+// symbols 为空时加载全部交易对
+func NewRules(client *binance.Client, symbols ...string) *rules.Registry {
+	return rules.NewRegistry(func(ctx context.Context) ([]*rules.Symbol, error) {
+		res, err := NewExchangeInfo(client, symbols, nil).Call(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return res.Rules(), nil
+	})
+}
+
+// NewRateLimiter 按 exchangeInfo 返回的 rateLimits 创建现货限流器，并设置为 client 的限流器
+// 获取 exchangeInfo 失败时返回使用默认限制的限流器和错误
+func NewRateLimiter(ctx context.Context, client *binance.Client) (*binance.RateLimiter, error) {
+	limiter := binance.NewSpotRateLimiter()
+	client.RateLimiter = limiter
+	res, err := NewExchangeInfo(client, nil, nil).Call(ctx)
+	if err != nil {
+		return limiter, err
+	}
+	limiter.SetLimits(res.RateLimits...)
+	return limiter, nil
 }
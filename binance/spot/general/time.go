@@ -6,7 +6,6 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type Time interface {
@@ -31,9 +30,21 @@ func (t *timeRequest) Call(ctx context.Context) (body *timeResponse, err error)
 		t.Debugf("pingRequest response err: %v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*timeResponse](res)
+	return binance.ParseHttpResponse[*timeResponse](res)
 }
 
 type timeResponse struct {
 	ServerTime int64 `json:"serverTime"`
 }
+
+// NewTimeSync 通过 NewTime 同步服务器时间，并设置为 client 的 TimeSync
+// 一般在后台运行 go s.Run(ctx)，签名请求会使用校正后的 timestamp
+func NewTimeSync(client *binance.Client) *binance.TimeSync {
+	return binance.NewTimeSync(client, func(ctx context.Context) (int64, error) {
+		res, err := NewTime(client).Call(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return res.ServerTime, nil
+	})
+}
@@ -1,6 +1,7 @@
 package market
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"net/http"
@@ -9,10 +10,11 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type AggTrades interface {
+	Iterate() *binance.Pager[*aggTradesResponse]
 	Call(ctx context.Context) (body []*aggTradesResponse, err error)
 	SetSymbol(symbol string) *aggTradesRequest
 	SetLimit(limit enums.LimitType) *aggTradesRequest
@@ -51,6 +53,13 @@ type aggTradesResponse struct {
 	Placeholder           bool   `json:"M"` // 是否为最优撮合单(可忽略，目前总为最优撮合)
 }
 
+func (a aggTradesResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.Price)
+}
+func (a aggTradesResponse) QuantityDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.Quantity)
+}
+
 func NewAggTrades(client *binance.Client, symbol string, limit enums.LimitType) AggTrades {
 	return &aggTradesRequest{Client: client, symbol: symbol, limit: limit}
 }
@@ -87,7 +96,36 @@ func (a *aggTradesRequest) Call(ctx context.Context) (body []*aggTradesResponse,
 		a.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*aggTradesResponse](resp)
+	return binance.ParseHttpResponse[[]*aggTradesResponse](resp)
+}
+
+// Iterate 遍历归集成交直到 endTime
+// 设置了 fromId 或没有设置 startTime 时从 fromId(默认 0) 开始按 id 翻页，否则第一页按 startTime 查询，之后按 id 翻页
+func (a *aggTradesRequest) Iterate() *binance.Pager[*aggTradesResponse] {
+	limit := cmp.Or(a.limit, enums.Limit500)
+	var from *int64
+	if a.fromId != nil || a.startTime == nil {
+		id := int64(0)
+		if a.fromId != nil {
+			id = int64(*a.fromId)
+		}
+		from = &id
+	}
+	end := a.endTime
+	return binance.NewPager(binance.IdCursor(int(limit), from, func(ctx context.Context, fromId *int64) ([]*aggTradesResponse, error) {
+		r := *a
+		// 同时传 startTime 和 endTime 时跨度不能超过 1 小时，endTime 由 stop 判断
+		r.limit, r.endTime = limit, nil
+		if fromId != nil {
+			id := uint64(*fromId)
+			r.fromId, r.startTime = &id, nil
+		}
+		return r.Call(ctx)
+	}, func(t *aggTradesResponse) int64 {
+		return int64(t.AggTradeID)
+	}, func(t *aggTradesResponse) bool {
+		return end != nil && t.TradeTime > *end
+	}))
 }
 
 // ****************************** Websocket 行情推送 *******************************
@@ -107,24 +145,29 @@ type WsAggTradeEvent struct {
 // 归集交易与逐笔交易的区别在于，同一个taker在同一价格与多个maker成交时，会被归集为一笔成交。
 // Stream 名称: <symbol>@aggTrade
 // 更新速度: 实时
-func NewWsAggTrade(c *binance.Client, symbols []string, handler binance.Handler[WsAggTradeEvent], exception binance.ErrorHandler) error {
-	return wsAggTrade(c, symbols, handler, exception)
+func NewWsAggTrade(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[WsAggTradeEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsAggTrade(ctx, c, symbols, handler, exception)
 }
 
 // NewStreamAggTrade 归集交易
 // 归集交易与逐笔交易的区别在于，同一个taker在同一价格与多个maker成交时，会被归集为一笔成交。
 // Stream 名称: <symbol>@aggTrade
 // 更新速度: 实时
-func NewStreamAggTrade(c *binance.Client, symbols []string, handler binance.Handler[StreamAggTradeEvent], exception binance.ErrorHandler) error {
-	return wsAggTrade(c, symbols, handler, exception)
+func NewStreamAggTrade(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[StreamAggTradeEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsAggTrade(ctx, c, symbols, handler, exception)
 }
-func wsAggTrade[T WsAggTradeEvent | StreamAggTradeEvent](c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) error {
+func wsAggTrade[T WsAggTradeEvent | StreamAggTradeEvent](ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
 	endpoint := c.BaseURL
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@aggTrade", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	return binance.WsHandler(c, endpoint, handler, exception)
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeAggTrade 在组合 Stream 连接上订阅归集交易
+func SubscribeAggTrade(ctx context.Context, s *binance.WsSubscriptions, symbols []string, handler binance.Handler[WsAggTradeEvent]) error {
+	return binance.Subscribe(ctx, s, binance.SymbolStreams(symbols, "aggTrade"), handler)
 }
 
 // ****************************** Websocket Api *******************************
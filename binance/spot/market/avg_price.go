@@ -8,7 +8,7 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type AvgPrice interface {
@@ -36,6 +36,10 @@ type AvgPriceResponse struct {
 	CloseTime int64  `json:"closeTime"`
 }
 
+func (a AvgPriceResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.Price)
+}
+
 // Call 当前平均价格
 func (a *avgPriceRequest) Call(ctx context.Context) (body *AvgPriceResponse, err error) {
 	req := &binance.Request{
@@ -48,7 +52,7 @@ func (a *avgPriceRequest) Call(ctx context.Context) (body *AvgPriceResponse, err
 		a.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*AvgPriceResponse](resp)
+	return binance.ParseHttpResponse[*AvgPriceResponse](resp)
 }
 
 // ****************************** Websocket 行情推送 *******************************
@@ -66,14 +70,18 @@ type WsAvgPriceEvent struct {
 	EndTime  int64  `json:"T"`
 }
 
+func (a WsAvgPriceEvent) AvgPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(a.AvgPrice)
+}
+
 // NewStreamAvgPrice 平均价格
 // 平均价格流推送在固定时间间隔内的平均价格变动。
 //
 // Stream 名称: <symbol>@avgPrice
 //
 // 更新速度: 1000ms
-func NewStreamAvgPrice(c *binance.Client, symbols []string, handler binance.Handler[StreamAvgPriceEvent], exception binance.ErrorHandler) error {
-	return avgPrice(c, symbols, handler, exception)
+func NewStreamAvgPrice(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[StreamAvgPriceEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return avgPrice(ctx, c, symbols, handler, exception)
 }
 
 // NewWsAvgPrice 平均价格
@@ -82,16 +90,21 @@ func NewStreamAvgPrice(c *binance.Client, symbols []string, handler binance.Hand
 // Stream 名称: <symbol>@avgPrice
 //
 // 更新速度: 1000ms
-func NewWsAvgPrice(c *binance.Client, symbols []string, handler binance.Handler[WsAvgPriceEvent], exception binance.ErrorHandler) error {
-	return avgPrice(c, symbols, handler, exception)
+func NewWsAvgPrice(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[WsAvgPriceEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return avgPrice(ctx, c, symbols, handler, exception)
 }
-func avgPrice[T WsAvgPriceEvent | StreamAvgPriceEvent](c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) error {
+func avgPrice[T WsAvgPriceEvent | StreamAvgPriceEvent](ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
 	endpoint := c.BaseURL
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@avgPrice", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	return binance.WsHandler(c, endpoint, handler, exception)
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeAvgPrice 在组合 Stream 连接上订阅平均价格
+func SubscribeAvgPrice(ctx context.Context, s *binance.WsSubscriptions, symbols []string, handler binance.Handler[WsAvgPriceEvent]) error {
+	return binance.Subscribe(ctx, s, binance.SymbolStreams(symbols, "avgPrice"), handler)
 }
 
 // ****************************** Websocket Api *******************************
@@ -1,13 +1,13 @@
 package market
 
 import (
+	"cmp"
 	"context"
 	"net/http"
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 type historyTradesRequest struct {
@@ -18,6 +18,7 @@ type historyTradesRequest struct {
 }
 
 type HistoryTrades interface {
+	Iterate() *binance.Pager[*tradesResponse]
 	Call(ctx context.Context) (body []*tradesResponse, err error)
 	SetFromId(fromId uint64) *historyTradesRequest
 	SetSymbol(symbol string) *historyTradesRequest
@@ -62,7 +63,24 @@ func (t *historyTradesRequest) Call(ctx context.Context) (body []*tradesResponse
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*tradesResponse](resp)
+	return binance.ParseHttpResponse[[]*tradesResponse](resp)
+}
+
+// Iterate 从 fromId(默认 0) 开始按 id 遍历历史成交，直到最近的成交
+func (t *historyTradesRequest) Iterate() *binance.Pager[*tradesResponse] {
+	limit := cmp.Or(t.limit, enums.Limit500)
+	from := int64(0)
+	if t.fromId != nil {
+		from = int64(*t.fromId)
+	}
+	return binance.NewPager(binance.IdCursor(int(limit), &from, func(ctx context.Context, fromId *int64) ([]*tradesResponse, error) {
+		r := *t
+		id := uint64(*fromId)
+		r.limit, r.fromId = limit, &id
+		return r.Call(ctx)
+	}, func(trade *tradesResponse) int64 {
+		return int64(trade.Id)
+	}, nil))
 }
 
 // ****************************** Websocket Api *******************************
@@ -1,6 +1,7 @@
 package market
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"net/http"
@@ -9,10 +10,12 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+	"github.com/sleep-go/coin-go/pkg/kline"
 )
 
 type Klines interface {
+	Iterate() *binance.Pager[*KlinesResponse]
 	Call(ctx context.Context) (body []*KlinesResponse, err error)
 	// CallUI 请求参数与响应和k线接口相同。
 	// uiKlines 返回修改后的k线数据，针对k线图的呈现进行了优化。
@@ -62,6 +65,11 @@ func (k *klinesRequest) SetLimit(limit enums.LimitType) *klinesRequest {
 // ]
 type KlinesResponse [12]any
 
+// Kline 转换为 kline.Kline
+func (k *KlinesResponse) Kline() (kline.Kline, error) {
+	return kline.FromArray(*k)
+}
+
 func NewKlines(client *binance.Client, symbol string, limit enums.LimitType) Klines {
 	return &klinesRequest{Client: client, symbol: symbol, limit: limit}
 }
@@ -112,7 +120,7 @@ func (k *klinesRequest) Call(ctx context.Context) (body []*KlinesResponse, err e
 		k.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*KlinesResponse](resp)
+	return binance.ParseHttpResponse[[]*KlinesResponse](resp)
 }
 func (k *klinesRequest) CallUI(ctx context.Context) (body []*KlinesResponse, err error) {
 	req := &binance.Request{
@@ -130,7 +138,39 @@ func (k *klinesRequest) CallUI(ctx context.Context) (body []*KlinesResponse, err
 		k.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*KlinesResponse](resp)
+	return binance.ParseHttpResponse[[]*KlinesResponse](resp)
+}
+
+// Iterate 按开盘时间遍历 [startTime, endTime] 内的K线，startTime 为空时从最早的K线开始，endTime 为空时到当前时间
+func (k *klinesRequest) Iterate() *binance.Pager[*KlinesResponse] {
+	start, end := binance.TimeRange(k.startTime, k.endTime)
+	limit := cmp.Or(k.limit, enums.Limit500)
+	openTimes := make(map[*KlinesResponse]int64) // 当前页的开盘时间
+	openTime := func(row *KlinesResponse) int64 { return openTimes[row] }
+	return binance.NewPager(binance.TimeCursor(start, end, 0, int(limit), func(ctx context.Context, start, end int64) ([]*KlinesResponse, error) {
+		r := *k
+		r.limit, r.startTime, r.endTime = limit, &start, &end
+		rows, err := r.Call(ctx)
+		if err != nil {
+			return nil, err
+		}
+		clear(openTimes)
+		for _, row := range rows {
+			openTimes[row], err = klineOpenTime(row)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return rows, nil
+	}, openTime, openTime))
+}
+
+func klineOpenTime(k *KlinesResponse) (int64, error) {
+	kl, err := k.Kline()
+	if err != nil {
+		return 0, err
+	}
+	return kl.OpenTime, nil
 }
 
 // ****************************** Websocket 行情推送 *******************************
@@ -164,11 +204,46 @@ type WsKline struct {
 	ActiveBuyQuoteVolume string `json:"Q"`
 }
 
-func NewWsKline(c *binance.Client, symbolsInterval map[string]enums.KlineIntervalType, handler binance.Handler[WsKlineEvent], exception binance.ErrorHandler) error {
-	return wsKline(c, symbolsInterval, handler, exception)
+func (k WsKline) OpenDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(k.Open)
+}
+func (k WsKline) CloseDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(k.Close)
+}
+func (k WsKline) HighDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(k.High)
+}
+func (k WsKline) LowDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(k.Low)
+}
+func (k WsKline) VolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(k.Volume)
+}
+func (k WsKline) QuoteVolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(k.QuoteVolume)
 }
-func NewStreamKline(c *binance.Client, symbolsInterval map[string]enums.KlineIntervalType, handler binance.Handler[StreamKlineEvent], exception binance.ErrorHandler) error {
-	return wsKline(c, symbolsInterval, handler, exception)
+func (k WsKline) ActiveBuyVolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(k.ActiveBuyVolume)
+}
+func (k WsKline) ActiveBuyQuoteVolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(k.ActiveBuyQuoteVolume)
+}
+
+// Kline 转换为 kline.Kline
+func (k WsKline) Kline() (kline.Kline, error) {
+	kl, err := kline.FromArray([12]any{k.StartTime, k.Open, k.High, k.Low, k.Close, k.Volume, k.EndTime, k.QuoteVolume, k.TradeNum, k.ActiveBuyVolume, k.ActiveBuyQuoteVolume})
+	if err != nil {
+		return kline.Kline{}, err
+	}
+	kl.IsFinal = k.IsFinal
+	return kl, nil
+}
+
+func NewWsKline(ctx context.Context, c *binance.Client, symbolsInterval map[string]enums.KlineIntervalType, handler binance.Handler[WsKlineEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsKline(ctx, c, symbolsInterval, handler, exception)
+}
+func NewStreamKline(ctx context.Context, c *binance.Client, symbolsInterval map[string]enums.KlineIntervalType, handler binance.Handler[StreamKlineEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsKline(ctx, c, symbolsInterval, handler, exception)
 }
 
 // wsKLines UTC K线
@@ -177,7 +252,7 @@ func NewStreamKline(c *binance.Client, symbolsInterval map[string]enums.KlineInt
 // 订阅Kline需要提供间隔参数，最短为分钟线，最长为月线。支持以下间隔:
 //
 // m -> 分钟; h -> 小时; d -> 天; w -> 周; M -> 月
-func wsKline[T WsKlineEvent | StreamKlineEvent](c *binance.Client, symbolsInterval map[string]enums.KlineIntervalType, handler binance.Handler[T], exception binance.ErrorHandler) error {
+func wsKline[T WsKlineEvent | StreamKlineEvent](ctx context.Context, c *binance.Client, symbolsInterval map[string]enums.KlineIntervalType, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
 	endpoint := c.BaseURL
 	for symbol, interval := range symbolsInterval {
 		endpoint += fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval) + "/"
@@ -186,7 +261,20 @@ func wsKline[T WsKlineEvent | StreamKlineEvent](c *binance.Client, symbolsInterv
 	if c.Timezone != "" {
 		endpoint = fmt.Sprintf("%s@%s", endpoint, c.Timezone)
 	}
-	return binance.WsHandler(c, endpoint, handler, exception)
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeKline 在组合 Stream 连接上订阅K线
+func SubscribeKline(ctx context.Context, s *binance.WsSubscriptions, symbolsInterval map[string]enums.KlineIntervalType, handler binance.Handler[WsKlineEvent]) error {
+	streams := make([]string, 0, len(symbolsInterval))
+	for symbol, interval := range symbolsInterval {
+		stream := fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval)
+		if s.Client().Timezone != "" {
+			stream = fmt.Sprintf("%s@%s", stream, s.Client().Timezone)
+		}
+		streams = append(streams, stream)
+	}
+	return binance.Subscribe(ctx, s, streams, handler)
 }
 
 // ****************************** Websocket Api *******************************
@@ -0,0 +1,141 @@
+package market
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+	"github.com/sleep-go/coin-go/pkg/orderbook"
+)
+
+// OrderBook 本地维护的 orderbook 副本
+//
+// 按文档 "如何正确在本地维护一个orderbook副本" 实现:
+//  1. 订阅 <symbol>@depth 并缓存收到的更新
+//  2. 获取深度快照，丢弃 u <= lastUpdateId 的缓存
+//  3. 从第一个 U <= lastUpdateId+1 且 u >= lastUpdateId+1 的 event 开始更新
+//  4. 每一个新 event 的 U 应该恰好等于上一个 event 的 u+1，否则重新从步骤 2 开始初始化
+type OrderBook struct {
+	symbol   string
+	m        *orderbook.Maintainer
+	onChange binance.Handler[*OrderBook]
+	onResync binance.Handler[error]
+}
+
+// NewOrderBook 创建本地 orderbook
+// c 用于获取 REST 深度快照，limit 为快照档数，默认 1000
+func NewOrderBook(c *binance.Client, symbol string, limit enums.LimitType) *OrderBook {
+	if limit == 0 {
+		limit = enums.Limit1000
+	}
+	o := &OrderBook{symbol: symbol}
+	o.m = orderbook.NewMaintainer(symbol, func(ctx context.Context) (*orderbook.Snapshot, error) {
+		snapshot, err := NewDepth(c, symbol, limit).Call(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &orderbook.Snapshot{LastUpdateId: snapshot.LastUpdateId, Bids: snapshot.Bids, Asks: snapshot.Asks}, nil
+	}, sequenceDepth).SetDebugf(c.Debugf).SetOnChange(func() {
+		if o.onChange != nil {
+			o.onChange(o)
+		}
+	}).SetOnResync(func(err error) {
+		if o.onResync != nil {
+			o.onResync(err)
+		}
+	})
+	return o
+}
+
+// SetOnChange 每次 orderbook 更新后回调，回调串行执行
+func (o *OrderBook) SetOnChange(onChange binance.Handler[*OrderBook]) *OrderBook {
+	o.onChange = onChange
+	return o
+}
+
+// SetOnResync 检测到丢包、缓存溢出或获取快照失败需要重新初始化时回调
+// 回调时不持有锁，可以读取 orderbook
+func (o *OrderBook) SetOnResync(onResync binance.Handler[error]) *OrderBook {
+	o.onResync = onResync
+	return o
+}
+
+// Run 订阅增量深度并开始维护 orderbook
+// wsClient 为 NewWsClient 创建的客户端，组合 Stream 和普通 Stream 均可
+func (o *OrderBook) Run(ctx context.Context, wsClient *binance.Client, exception binance.ErrorHandler) (*binance.WsStream, error) {
+	o.m.SetContext(ctx)
+	symbols := []string{o.symbol}
+	if wsClient.IsCombined {
+		return NewStreamDepth(ctx, wsClient, symbols, func(event *StreamDepthEvent) {
+			o.Update(event.Data)
+		}, exception)
+	}
+	return NewWsDepth(ctx, wsClient, symbols, o.Update, exception)
+}
+
+// Update 处理一个增量深度 event
+// 也可以配合 SubscribeDepth 使用，自行把 event 交给 Update，不能在回调中调用
+func (o *OrderBook) Update(event *WsDepthEvent) {
+	if event == nil {
+		return
+	}
+	o.m.Update(&orderbook.Diff{
+		FirstUpdateId: event.FirstUpdateID,
+		LastUpdateId:  event.LastUpdateID,
+		Bids:          event.Bids,
+		Asks:          event.Asks,
+	})
+}
+
+// sequenceDepth 丢弃 u <= lastUpdateId 的 event，快照之后的第一个 event 需满足 U <= lastUpdateId+1，之后的 U 应该等于上一个 u+1
+func sequenceDepth(d *orderbook.Diff, lastUpdateId int, first bool) (bool, error) {
+	if d.LastUpdateId <= lastUpdateId {
+		// 过期的更新
+		return false, nil
+	}
+	if first {
+		if d.FirstUpdateId > lastUpdateId+1 {
+			return false, fmt.Errorf("snapshot %d is older than first event U=%d", lastUpdateId, d.FirstUpdateId)
+		}
+	} else if d.FirstUpdateId != lastUpdateId+1 {
+		return false, fmt.Errorf("sequence gap, expected U=%d got U=%d", lastUpdateId+1, d.FirstUpdateId)
+	}
+	return true, nil
+}
+
+// Ready 是否已完成初始化
+func (o *OrderBook) Ready() bool {
+	return o.m.Ready()
+}
+
+// LastUpdateId 最后一次更新的ID
+func (o *OrderBook) LastUpdateId() int {
+	return o.m.LastUpdateId()
+}
+
+// BestBid 买一
+func (o *OrderBook) BestBid() (orderbook.Level, bool) {
+	return o.m.BestBid()
+}
+
+// BestAsk 卖一
+func (o *OrderBook) BestAsk() (orderbook.Level, bool) {
+	return o.m.BestAsk()
+}
+
+// Bids 前 n 档买单，n <= 0 返回全部
+func (o *OrderBook) Bids(n int) []orderbook.Level {
+	return o.m.Bids(n)
+}
+
+// Asks 前 n 档卖单，n <= 0 返回全部
+func (o *OrderBook) Asks(n int) []orderbook.Level {
+	return o.m.Asks(n)
+}
+
+// CumulativeVolume 从最优价到 price (含) 的累计挂单量
+func (o *OrderBook) CumulativeVolume(side orderbook.Side, price decimal.Decimal) decimal.Decimal {
+	return o.m.CumulativeVolume(side, price)
+}
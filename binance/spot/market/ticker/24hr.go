@@ -9,7 +9,7 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type Hr24 interface {
@@ -46,6 +46,52 @@ type hr24Response struct {
 	Count              int    `json:"count"`       // 统计时间内交易笔数
 }
 
+func (h hr24Response) PriceChangeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.PriceChange)
+}
+func (h hr24Response) PriceChangePercentDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.PriceChangePercent)
+}
+func (h hr24Response) WeightedAvgPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.WeightedAvgPrice)
+}
+func (h hr24Response) PrevClosePriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.PrevClosePrice)
+}
+func (h hr24Response) LastPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.LastPrice)
+}
+func (h hr24Response) LastQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.LastQty)
+}
+func (h hr24Response) BidPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.BidPrice)
+}
+func (h hr24Response) BidQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.BidQty)
+}
+func (h hr24Response) AskPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.AskPrice)
+}
+func (h hr24Response) AskQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.AskQty)
+}
+func (h hr24Response) OpenPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.OpenPrice)
+}
+func (h hr24Response) HighPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.HighPrice)
+}
+func (h hr24Response) LowPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.LowPrice)
+}
+func (h hr24Response) VolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.Volume)
+}
+func (h hr24Response) QuoteVolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(h.QuoteVolume)
+}
+
 func NewHr24(client *binance.Client, symbols []string, _type enums.TickerType) Hr24 {
 	return &hr24Request{
 		Client:  client,
@@ -65,13 +111,14 @@ func (hr *hr24Request) Call(ctx context.Context) (body []*hr24Response, err erro
 		result := fmt.Sprintf(`["%s"]`, strings.Join(hr.symbols, `","`))
 		req.SetParam("symbols", result)
 	}
+	req.SetWeight(hr24Weight(len(hr.symbols)))
 	req.SetParam("type", hr._type.String())
 	res, err := hr.Do(ctx, req)
 	if err != nil {
 		hr.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*hr24Response](res)
+	return binance.ParseHttpResponse[[]*hr24Response](res)
 }
 
 // ****************************** Websocket Api *******************************
@@ -116,5 +163,6 @@ func (hr *hr24Request) Send(ctx context.Context) (*WsApiHr24Response, error) {
 		result := fmt.Sprintf(`["%s"]`, strings.Join(hr.symbols, `","`))
 		req.SetParam("symbols", result)
 	}
+	req.SetWeight(hr24Weight(len(hr.symbols)))
 	return binance.WsApiHandler[*WsApiHr24Response](ctx, hr.Client, req)
 }
@@ -8,7 +8,7 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type BookTicker interface {
@@ -28,6 +28,19 @@ type bookTickerResponse struct {
 	AskQty   string `json:"askQty"`
 }
 
+func (b bookTickerResponse) BidPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(b.BidPrice)
+}
+func (b bookTickerResponse) BidQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(b.BidQty)
+}
+func (b bookTickerResponse) AskPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(b.AskPrice)
+}
+func (b bookTickerResponse) AskQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(b.AskQty)
+}
+
 func NewBookTicker(client *binance.Client, symbols []string) BookTicker {
 	return &bookTickerRequest{Client: client, symbols: symbols}
 }
@@ -40,12 +53,13 @@ func (b *bookTickerRequest) Call(ctx context.Context) (body []*bookTickerRespons
 		result := fmt.Sprintf(`["%s"]`, strings.Join(b.symbols, `","`))
 		req.SetParam("symbols", result)
 	}
+	req.SetWeight(priceWeight(len(b.symbols)))
 	res, err := b.Do(ctx, req)
 	if err != nil {
 		b.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*bookTickerResponse](res)
+	return binance.ParseHttpResponse[[]*bookTickerResponse](res)
 }
 
 // ****************************** Websocket 行情推送 *******************************
@@ -63,14 +77,27 @@ type WsBookTickerEvent struct {
 	BestAskQty   string `json:"A"`
 }
 
+func (b WsBookTickerEvent) BestBidPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(b.BestBidPrice)
+}
+func (b WsBookTickerEvent) BestBidQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(b.BestBidQty)
+}
+func (b WsBookTickerEvent) BestAskPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(b.BestAskPrice)
+}
+func (b WsBookTickerEvent) BestAskQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(b.BestAskQty)
+}
+
 // NewWsBookTicker 按Symbol的最优挂单信息
 // 实时推送指定交易对最优挂单信息 多个 <symbol>@bookTicker 可以订阅在一个WebSocket连接上
 //
 // Stream 名称: <symbol>@bookTicker
 //
 // 更新速度: 实时
-func NewWsBookTicker(c *binance.Client, symbols []string, handler binance.Handler[WsBookTickerEvent], exception binance.ErrorHandler) error {
-	return bookTicker(c, symbols, handler, exception)
+func NewWsBookTicker(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[WsBookTickerEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return bookTicker(ctx, c, symbols, handler, exception)
 }
 
 // NewStreamBookTicker 按Symbol的最优挂单信息
@@ -79,16 +106,21 @@ func NewWsBookTicker(c *binance.Client, symbols []string, handler binance.Handle
 // Stream 名称: <symbol>@bookTicker
 //
 // 更新速度: 实时
-func NewStreamBookTicker(c *binance.Client, symbols []string, handler binance.Handler[StreamBookTickerEvent], exception binance.ErrorHandler) error {
-	return bookTicker(c, symbols, handler, exception)
+func NewStreamBookTicker(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[StreamBookTickerEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return bookTicker(ctx, c, symbols, handler, exception)
 }
-func bookTicker[T WsBookTickerEvent | StreamBookTickerEvent](c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) error {
+func bookTicker[T WsBookTickerEvent | StreamBookTickerEvent](ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
 	endpoint := c.BaseURL
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@bookTicker", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	return binance.WsHandler(c, endpoint, handler, exception)
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeBookTicker 在组合 Stream 连接上订阅最优挂单信息
+func SubscribeBookTicker(ctx context.Context, s *binance.WsSubscriptions, symbols []string, handler binance.Handler[WsBookTickerEvent]) error {
+	return binance.Subscribe(ctx, s, binance.SymbolStreams(symbols, "bookTicker"), handler)
 }
 
 // ****************************** Websocket Api *******************************
@@ -122,5 +154,6 @@ func (b *bookTickerRequest) Send(ctx context.Context) (*WsApiBookTickerResponse,
 		result := fmt.Sprintf(`["%s"]`, strings.Join(b.symbols, `","`))
 		req.SetParam("symbols", result)
 	}
+	req.SetWeight(priceWeight(len(b.symbols)))
 	return binance.WsApiHandler[*WsApiBookTickerResponse](ctx, b.Client, req)
 }
@@ -8,7 +8,7 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type Price interface {
@@ -25,6 +25,10 @@ type priceResponse struct {
 	Price  string `json:"price"`
 }
 
+func (p priceResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(p.Price)
+}
+
 // NewPrice 最新价格接口
 func NewPrice(client *binance.Client, symbols []string) Price {
 	return &priceRequest{Client: client, symbols: symbols}
@@ -40,12 +44,13 @@ func (t *priceRequest) Call(ctx context.Context) (body []*priceResponse, err err
 		result := fmt.Sprintf(`["%s"]`, strings.Join(t.symbols, `","`))
 		req.SetParam("symbols", result)
 	}
+	req.SetWeight(priceWeight(len(t.symbols)))
 	resp, err := t.Do(ctx, req)
 	if err != nil {
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*priceResponse](resp)
+	return binance.ParseHttpResponse[[]*priceResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
@@ -81,5 +86,6 @@ func (t *priceRequest) Send(ctx context.Context) (*WsApiTickerPriceResponse, err
 		result := fmt.Sprintf(`["%s"]`, strings.Join(t.symbols, `","`))
 		req.SetParam("symbols", result)
 	}
+	req.SetWeight(priceWeight(len(t.symbols)))
 	return binance.WsApiHandler[*WsApiTickerPriceResponse](ctx, t.Client, req)
 }
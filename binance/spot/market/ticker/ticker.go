@@ -9,7 +9,7 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 // Ticker
@@ -59,6 +59,34 @@ type tickerResponse struct {
 	Count              int    `json:"count"` // 统计时间内交易笔数
 }
 
+func (t tickerResponse) PriceChangeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.PriceChange)
+}
+func (t tickerResponse) PriceChangePercentDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.PriceChangePercent)
+}
+func (t tickerResponse) WeightedAvgPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.WeightedAvgPrice)
+}
+func (t tickerResponse) OpenPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.OpenPrice)
+}
+func (t tickerResponse) HighPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.HighPrice)
+}
+func (t tickerResponse) LowPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.LowPrice)
+}
+func (t tickerResponse) LastPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.LastPrice)
+}
+func (t tickerResponse) VolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.Volume)
+}
+func (t tickerResponse) QuoteVolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.QuoteVolume)
+}
+
 func NewTicker(client *binance.Client, symbols []string, _type enums.TickerType) Ticker {
 	return &tickerRequest{Client: client, symbols: symbols, _type: _type}
 }
@@ -108,6 +136,7 @@ func (t *tickerRequest) Call(ctx context.Context) (body []*tickerResponse, err e
 		result := fmt.Sprintf(`["%s"]`, strings.Join(t.symbols, `","`))
 		req.SetParam("symbols", result)
 	}
+	req.SetWeight(tickerWeight(len(t.symbols)))
 	req.SetParam("windowSize", t.windowSize)
 	req.SetParam("type", t._type.String())
 	resp, err := t.Do(ctx, req)
@@ -115,7 +144,7 @@ func (t *tickerRequest) Call(ctx context.Context) (body []*tickerResponse, err e
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*tickerResponse](resp)
+	return binance.ParseHttpResponse[[]*tickerResponse](resp)
 }
 
 // ****************************** Websocket 行情推送 *******************************
@@ -140,6 +169,25 @@ type WsMiniTickerEvent struct {
 	QuoteVolume string `json:"q"`
 }
 
+func (m WsMiniTickerEvent) LastPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(m.LastPrice)
+}
+func (m WsMiniTickerEvent) OpenPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(m.OpenPrice)
+}
+func (m WsMiniTickerEvent) HighPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(m.HighPrice)
+}
+func (m WsMiniTickerEvent) LowPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(m.LowPrice)
+}
+func (m WsMiniTickerEvent) BaseVolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(m.BaseVolume)
+}
+func (m WsMiniTickerEvent) QuoteVolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(m.QuoteVolume)
+}
+
 // StreamTickerEvent 按Symbol的完整Ticker
 // 按Symbol逐秒刷新的24小时完整ticker信息
 type StreamTickerEvent struct {
@@ -168,14 +216,42 @@ type WsTickerEvent struct {
 	Count              int64  `json:"n"`
 }
 
+func (t WsTickerEvent) PriceChangeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.PriceChange)
+}
+func (t WsTickerEvent) PriceChangePercentDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.PriceChangePercent)
+}
+func (t WsTickerEvent) WeightedAvgPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.WeightedAvgPrice)
+}
+func (t WsTickerEvent) PrevClosePriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.PrevClosePrice)
+}
+func (t WsTickerEvent) CloseQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.CloseQty)
+}
+func (t WsTickerEvent) BidPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.BidPrice)
+}
+func (t WsTickerEvent) BidQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.BidQty)
+}
+func (t WsTickerEvent) AskPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.AskPrice)
+}
+func (t WsTickerEvent) AskQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.AskQty)
+}
+
 // NewWsMiniTicker 按Symbol的精简Ticker
 // 按Symbol逐秒刷新的24小时精简ticker信息
 //
 // Stream 名称: <symbol>@miniTicker
 //
 // 更新速度: 1000ms
-func NewWsMiniTicker(c *binance.Client, symbols []string, handler binance.Handler[WsMiniTickerEvent], exception binance.ErrorHandler) error {
-	return miniTicker(c, symbols, handler, exception)
+func NewWsMiniTicker(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[WsMiniTickerEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return miniTicker(ctx, c, symbols, handler, exception)
 }
 
 // NewStreamMiniTicker 按Symbol的精简Ticker
@@ -184,16 +260,21 @@ func NewWsMiniTicker(c *binance.Client, symbols []string, handler binance.Handle
 // Stream 名称: <symbol>@miniTicker
 //
 // 更新速度: 1000ms
-func NewStreamMiniTicker(c *binance.Client, symbols []string, handler binance.Handler[StreamMiniTickerEvent], exception binance.ErrorHandler) error {
-	return miniTicker(c, symbols, handler, exception)
+func NewStreamMiniTicker(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[StreamMiniTickerEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return miniTicker(ctx, c, symbols, handler, exception)
 }
-func miniTicker[T WsMiniTickerEvent | StreamMiniTickerEvent](c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) error {
+func miniTicker[T WsMiniTickerEvent | StreamMiniTickerEvent](ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
 	endpoint := c.BaseURL
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@miniTicker", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	return binance.WsHandler(c, endpoint, handler, exception)
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeMiniTicker 在组合 Stream 连接上订阅精简Ticker
+func SubscribeMiniTicker(ctx context.Context, s *binance.WsSubscriptions, symbols []string, handler binance.Handler[WsMiniTickerEvent]) error {
+	return binance.Subscribe(ctx, s, binance.SymbolStreams(symbols, "miniTicker"), handler)
 }
 
 // NewWsAllMiniTicker 全市场所有Symbol的精简Ticker
@@ -202,8 +283,8 @@ func miniTicker[T WsMiniTickerEvent | StreamMiniTickerEvent](c *binance.Client,
 // Stream名称: !miniTicker@arr
 //
 // 更新速度: 1000ms
-func NewWsAllMiniTicker(c *binance.Client, handler binance.Handler[[]WsMiniTickerEvent], exception binance.ErrorHandler) error {
-	return allMiniTicker(c, handler, exception)
+func NewWsAllMiniTicker(ctx context.Context, c *binance.Client, handler binance.Handler[[]WsMiniTickerEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return allMiniTicker(ctx, c, handler, exception)
 }
 
 // NewStreamAllMiniTicker 全市场所有Symbol的精简Ticker
@@ -212,13 +293,18 @@ func NewWsAllMiniTicker(c *binance.Client, handler binance.Handler[[]WsMiniTicke
 // Stream名称: !miniTicker@arr
 //
 // 更新速度: 1000ms
-func NewStreamAllMiniTicker(c *binance.Client, handler binance.Handler[StreamAllMiniTickerEvent], exception binance.ErrorHandler) error {
-	return allMiniTicker(c, handler, exception)
+func NewStreamAllMiniTicker(ctx context.Context, c *binance.Client, handler binance.Handler[StreamAllMiniTickerEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return allMiniTicker(ctx, c, handler, exception)
 }
-func allMiniTicker[T []WsMiniTickerEvent | StreamAllMiniTickerEvent](c *binance.Client, handler binance.Handler[T], exception binance.ErrorHandler) error {
+func allMiniTicker[T []WsMiniTickerEvent | StreamAllMiniTickerEvent](ctx context.Context, c *binance.Client, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
 	endpoint := c.BaseURL
 	endpoint += "!miniTicker@arr"
-	return binance.WsHandler(c, endpoint, handler, exception)
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeAllMiniTicker 在组合 Stream 连接上订阅全市场所有Symbol的精简Ticker
+func SubscribeAllMiniTicker(ctx context.Context, s *binance.WsSubscriptions, handler binance.Handler[[]WsMiniTickerEvent]) error {
+	return binance.Subscribe(ctx, s, []string{"!miniTicker@arr"}, handler)
 }
 
 // NewWsTicker 按Symbol的完整Ticker
@@ -227,8 +313,8 @@ func allMiniTicker[T []WsMiniTickerEvent | StreamAllMiniTickerEvent](c *binance.
 // Stream 名称: <symbol>@ticker
 //
 // 更新速度: 1000ms
-func NewWsTicker(c *binance.Client, symbols []string, handler binance.Handler[WsTickerEvent], exception binance.ErrorHandler) error {
-	return ticker(c, symbols, handler, exception)
+func NewWsTicker(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[WsTickerEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return ticker(ctx, c, symbols, handler, exception)
 }
 
 // NewStreamTicker 按Symbol的完整Ticker
@@ -237,16 +323,21 @@ func NewWsTicker(c *binance.Client, symbols []string, handler binance.Handler[Ws
 // Stream 名称: <symbol>@ticker
 //
 // 更新速度: 1000ms
-func NewStreamTicker(c *binance.Client, symbols []string, handler binance.Handler[StreamTickerEvent], exception binance.ErrorHandler) error {
-	return ticker(c, symbols, handler, exception)
+func NewStreamTicker(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[StreamTickerEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return ticker(ctx, c, symbols, handler, exception)
 }
-func ticker[T WsTickerEvent | StreamTickerEvent](c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) error {
+func ticker[T WsTickerEvent | StreamTickerEvent](ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
 	endpoint := c.BaseURL
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@ticker", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	return binance.WsHandler(c, endpoint, handler, exception)
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeTicker 在组合 Stream 连接上订阅完整Ticker
+func SubscribeTicker(ctx context.Context, s *binance.WsSubscriptions, symbols []string, handler binance.Handler[WsTickerEvent]) error {
+	return binance.Subscribe(ctx, s, binance.SymbolStreams(symbols, "ticker"), handler)
 }
 
 // NewWsAllTicker 全市场所有交易对的完整Ticker
@@ -255,8 +346,8 @@ func ticker[T WsTickerEvent | StreamTickerEvent](c *binance.Client, symbols []st
 // Stream 名称: !ticker@arr
 //
 // 更新速度: 1000ms
-func NewWsAllTicker(c *binance.Client, handler binance.Handler[[]WsTickerEvent], exception binance.ErrorHandler) error {
-	return allTicker(c, handler, exception)
+func NewWsAllTicker(ctx context.Context, c *binance.Client, handler binance.Handler[[]WsTickerEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return allTicker(ctx, c, handler, exception)
 }
 
 // NewStreamAllTicker 全市场所有交易对的完整Ticker
@@ -265,14 +356,19 @@ func NewWsAllTicker(c *binance.Client, handler binance.Handler[[]WsTickerEvent],
 // Stream 名称: !ticker@arr
 //
 // 更新速度: 1000ms
-func NewStreamAllTicker(c *binance.Client, handler binance.Handler[StreamAllTickerEvent], exception binance.ErrorHandler) error {
-	return allTicker(c, handler, exception)
+func NewStreamAllTicker(ctx context.Context, c *binance.Client, handler binance.Handler[StreamAllTickerEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return allTicker(ctx, c, handler, exception)
 }
 
-func allTicker[T []WsTickerEvent | StreamAllTickerEvent](c *binance.Client, handler binance.Handler[T], exception binance.ErrorHandler) error {
+func allTicker[T []WsTickerEvent | StreamAllTickerEvent](ctx context.Context, c *binance.Client, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
 	endpoint := c.BaseURL
 	endpoint += "!ticker@arr"
-	return binance.WsHandler(c, endpoint, handler, exception)
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeAllTicker 在组合 Stream 连接上订阅全市场所有交易对的完整Ticker
+func SubscribeAllTicker(ctx context.Context, s *binance.WsSubscriptions, handler binance.Handler[[]WsTickerEvent]) error {
+	return binance.Subscribe(ctx, s, []string{"!ticker@arr"}, handler)
 }
 
 // ****************************** Websocket Api *******************************
@@ -314,6 +410,7 @@ func (t *tickerRequest) Send(ctx context.Context) (*WsApiTickerResponse, error)
 		result := fmt.Sprintf(`["%s"]`, strings.Join(t.symbols, `","`))
 		req.SetParam("symbols", result)
 	}
+	req.SetWeight(tickerWeight(len(t.symbols)))
 	req.SetOptionalParam("windowSize", t.windowSize)
 	req.SetOptionalParam("type", t._type)
 	return binance.WsApiHandler[*WsApiTickerResponse](ctx, t.Client, req)
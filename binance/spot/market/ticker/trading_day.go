@@ -9,7 +9,7 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type TradingDay interface {
@@ -59,6 +59,34 @@ type tradingDayResponse struct {
 	Count              int    `json:"count"`
 }
 
+func (t tradingDayResponse) PriceChangeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.PriceChange)
+}
+func (t tradingDayResponse) PriceChangePercentDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.PriceChangePercent)
+}
+func (t tradingDayResponse) WeightedAvgPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.WeightedAvgPrice)
+}
+func (t tradingDayResponse) OpenPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.OpenPrice)
+}
+func (t tradingDayResponse) HighPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.HighPrice)
+}
+func (t tradingDayResponse) LowPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.LowPrice)
+}
+func (t tradingDayResponse) LastPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.LastPrice)
+}
+func (t tradingDayResponse) VolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.Volume)
+}
+func (t tradingDayResponse) QuoteVolumeDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.QuoteVolume)
+}
+
 func (t *tradingDayRequest) Call(ctx context.Context) (body []*tradingDayResponse, err error) {
 	req := &binance.Request{
 		Method: http.MethodGet,
@@ -68,6 +96,7 @@ func (t *tradingDayRequest) Call(ctx context.Context) (body []*tradingDayRespons
 		result := fmt.Sprintf(`["%s"]`, strings.Join(t.symbols, `","`))
 		req.SetParam("symbols", result)
 	}
+	req.SetWeight(tickerWeight(len(t.symbols)))
 	req.SetParam("timeZone", t.timeZone)
 	req.SetParam("type", t._type.String())
 	resp, err := t.Do(ctx, req)
@@ -75,7 +104,7 @@ func (t *tradingDayRequest) Call(ctx context.Context) (body []*tradingDayRespons
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*tradingDayResponse](resp)
+	return binance.ParseHttpResponse[[]*tradingDayResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
@@ -130,6 +159,7 @@ func (t *tradingDayRequest) Send(ctx context.Context) (*WsApiTradingDayResponse,
 		result := fmt.Sprintf(`["%s"]`, strings.Join(t.symbols, `","`))
 		req.SetParam("symbols", result)
 	}
+	req.SetWeight(tickerWeight(len(t.symbols)))
 	req.SetOptionalParam("timeZone", t.timeZone)
 	req.SetOptionalParam("type", t._type.String())
 	return binance.WsApiHandler[*WsApiTradingDayResponse](ctx, t.Client, req)
@@ -0,0 +1,31 @@
+package ticker
+
+// hr24Weight 不带交易对为 80，1-20 个为 2，21-100 个为 40，100 个以上为 80
+func hr24Weight(symbols int) int {
+	switch {
+	case symbols == 0:
+		return 80
+	case symbols <= 20:
+		return 2
+	case symbols <= 100:
+		return 40
+	default:
+		return 80
+	}
+}
+
+// priceWeight 单个交易对为 2，多个或不带交易对为 4，bookTicker 相同
+func priceWeight(symbols int) int {
+	if symbols == 1 {
+		return 2
+	}
+	return 4
+}
+
+// tickerWeight 每个交易对为 4，超过 50 个时为 200，tradingDay 相同
+func tickerWeight(symbols int) int {
+	if symbols > 50 {
+		return 200
+	}
+	return 4 * symbols
+}
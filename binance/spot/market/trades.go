@@ -9,7 +9,7 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type Trades interface {
@@ -44,6 +44,13 @@ type tradesResponse struct {
 	IsBestMatch  bool   `json:"isBestMatch"`
 }
 
+func (t tradesResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.Price)
+}
+func (t tradesResponse) QtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.Qty)
+}
+
 // Call 获取近期成交
 // 名称	类型	是否必需	描述
 // symbol	STRING	YES
@@ -60,7 +67,7 @@ func (t *tradesRequest) Call(ctx context.Context) (body []*tradesResponse, err e
 		t.Debugf("response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*tradesResponse](resp)
+	return binance.ParseHttpResponse[[]*tradesResponse](resp)
 }
 
 // ****************************** Websocket 行情推送 *******************************
@@ -83,14 +90,21 @@ type WsTradeEvent struct {
 	Placeholder  bool  `json:"M"` // 请忽略该字段
 }
 
+func (t WsTradeEvent) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.Price)
+}
+func (t WsTradeEvent) QuantityDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(t.Quantity)
+}
+
 // NewWsTrade 逐笔交易
 // 逐笔交易推送每一笔成交的信息。成交，或者说交易的定义是仅有一个吃单者与一个挂单者相互交易。
 //
 // Stream 名称: <symbol>@trade
 //
 // 更新速度: 实时
-func NewWsTrade(c *binance.Client, symbols []string, handler binance.Handler[WsTradeEvent], exception binance.ErrorHandler) error {
-	return wsTrade(c, symbols, handler, exception)
+func NewWsTrade(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[WsTradeEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsTrade(ctx, c, symbols, handler, exception)
 }
 
 // NewStreamTrade 逐笔交易
@@ -99,16 +113,21 @@ func NewWsTrade(c *binance.Client, symbols []string, handler binance.Handler[WsT
 // Stream 名称: <symbol>@trade
 //
 // 更新速度: 实时
-func NewStreamTrade(c *binance.Client, symbols []string, handler binance.Handler[StreamTradeEvent], exception binance.ErrorHandler) error {
-	return wsTrade(c, symbols, handler, exception)
+func NewStreamTrade(ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[StreamTradeEvent], exception binance.ErrorHandler) (*binance.WsStream, error) {
+	return wsTrade(ctx, c, symbols, handler, exception)
 }
-func wsTrade[T WsTradeEvent | StreamTradeEvent](c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) error {
+func wsTrade[T WsTradeEvent | StreamTradeEvent](ctx context.Context, c *binance.Client, symbols []string, handler binance.Handler[T], exception binance.ErrorHandler) (*binance.WsStream, error) {
 	endpoint := c.BaseURL
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@trade", strings.ToLower(s)) + "/"
 	}
 	endpoint = endpoint[:len(endpoint)-1]
-	return binance.WsHandler(c, endpoint, handler, exception)
+	return binance.WsHandler(ctx, c, endpoint, handler, exception)
+}
+
+// SubscribeTrade 在组合 Stream 连接上订阅逐笔交易
+func SubscribeTrade(ctx context.Context, s *binance.WsSubscriptions, symbols []string, handler binance.Handler[WsTradeEvent]) error {
+	return binance.Subscribe(ctx, s, binance.SymbolStreams(symbols, "trade"), handler)
 }
 
 // ****************************** Websocket Api *******************************
@@ -0,0 +1,268 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/spot/account"
+)
+
+// ErrListenKeyExpired 收到 listenKeyExpired 推送
+var ErrListenKeyExpired = errors.New("user data stream: listenKey expired")
+
+// UserDataGap 用户数据流中断后恢复，From 到 To 之间的推送可能丢失
+type UserDataGap struct {
+	From      time.Time // 中断前最后一次收到推送或建立连接的时间
+	To        time.Time // 重新连接成功的时间
+	Reason    error     // 中断原因: 连接错误、ErrListenKeyExpired 或 -1125
+	ListenKey string    // 重新连接使用的 listenKey
+	Renewed   bool      // 是否重新生成了 listenKey
+}
+
+// UserDataSession 自动维护的现货用户数据流
+//
+// 生成 listenKey 并每 30 分钟延长一次；listenKey 过期或连接断开时重新生成 listenKey 并重连，
+// 恢复后通过 OnGap 通知，期间的 executionReport 可能丢失，需要通过 NewQueryOrder/NewOpenOrders 核对订单。
+// ws 由 session 负责重连，不要设置 Supervisor。
+//
+//	s := stream.NewUserDataSession(client, binance.NewWsClient(false, false))
+//	s.OnExecutionReport = func(event *account.WsExecutionReportEvent) { ... }
+//	s.OnGap = func(gap stream.UserDataGap) { ... }
+//	err := s.Start(ctx)
+//	defer s.Close(context.Background())
+type UserDataSession struct {
+	OnOutboundAccountPosition binance.Handler[*account.WsOutboundAccountPositionEvent]
+	OnBalanceUpdate           binance.Handler[*account.WsBalanceUpdateEvent]
+	OnExecutionReport         binance.Handler[*account.WsExecutionReportEvent]
+	OnListStatus              binance.Handler[*account.WsListStatusEvent]
+	OnGap                     func(gap UserDataGap)
+	OnError                   func(err error) // 延长、重连或解析推送失败
+
+	client   *binance.Client
+	ws       *binance.Client
+	interval time.Duration
+
+	mu        sync.Mutex
+	listenKey string
+	cancel    context.CancelFunc
+	done      chan struct{}
+	last      atomic.Int64 // 最后一次收到推送的时间，纳秒
+	expired   chan string  // 收到 listenKeyExpired 推送的 listenKey
+}
+
+// NewUserDataSession client 用于管理 listenKey，ws 用于订阅推送，组合流和单一流均可
+func NewUserDataSession(client, ws *binance.Client) *UserDataSession {
+	return &UserDataSession{
+		client:   client,
+		ws:       ws,
+		interval: 30 * time.Minute,
+		expired:  make(chan string, 1),
+	}
+}
+
+// SetInterval 延长 listenKey 的间隔，需要小于 60 分钟
+func (s *UserDataSession) SetInterval(interval time.Duration) *UserDataSession {
+	s.interval = interval
+	return s
+}
+
+// Start 生成 listenKey 并连接，之后在后台维护，ctx 结束或调用 Close 时停止
+// 已经在运行时直接返回，ctx 结束后可以再次调用 Start
+func (s *UserDataSession) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return nil
+	}
+	res, err := NewUserDataStream(s.client).CallCreate(ctx)
+	if err != nil {
+		return err
+	}
+	s.listenKey = res.ListenKey
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := s.serve(ctx, s.listenKey)
+	if err != nil {
+		cancel()
+		return err
+	}
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	go s.run(ctx, stream, s.done)
+	return nil
+}
+
+// ListenKey 当前的 listenKey
+func (s *UserDataSession) ListenKey() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.listenKey
+}
+
+// Close 断开连接并关闭 listenKey
+func (s *UserDataSession) Close(ctx context.Context) error {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.cancel, s.done = nil, nil
+	s.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	<-done
+	err := NewUserDataStream(s.client).SetListenKey(s.ListenKey()).CallDelete(ctx)
+	if errors.Is(err, binance.ErrInvalidListenKey) {
+		return nil
+	}
+	return err
+}
+
+func (s *UserDataSession) run(ctx context.Context, stream *binance.WsStream, done chan struct{}) {
+	defer func() {
+		s.mu.Lock()
+		// ctx 结束时退出，之后可以重新 Start；Close 已经清除时不再处理
+		if s.done == done {
+			s.cancel()
+			s.cancel, s.done = nil, nil
+		}
+		s.mu.Unlock()
+		close(done)
+	}()
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		var reason error
+		renew := false
+		select {
+		case <-ctx.Done():
+			_ = stream.Close()
+			return
+		case <-ticker.C:
+			err := s.ping(ctx)
+			if !errors.Is(err, binance.ErrInvalidListenKey) {
+				if err != nil && ctx.Err() == nil {
+					s.error(err)
+				}
+				continue
+			}
+			reason, renew = err, true
+		case listenKey := <-s.expired:
+			if listenKey != s.ListenKey() {
+				continue
+			}
+			reason, renew = ErrListenKeyExpired, true
+		case <-stream.Done():
+			reason = stream.Err()
+		}
+		from := time.Unix(0, s.last.Load())
+		_ = stream.Close()
+		var renewed bool
+		stream, renewed = s.reconnect(ctx, renew)
+		if stream == nil {
+			return
+		}
+		ticker.Reset(s.interval)
+		if s.OnGap != nil {
+			s.OnGap(UserDataGap{From: from, To: time.Now(), Reason: reason, ListenKey: s.ListenKey(), Renewed: renewed})
+		}
+	}
+}
+
+// reconnect 重新连接直到成功或 ctx 结束，listenKey 已失效时重新生成
+func (s *UserDataSession) reconnect(ctx context.Context, renew bool) (*binance.WsStream, bool) {
+	renewed := false
+	var err error
+	for backoff := time.Second; ; backoff = min(backoff*2, time.Minute) {
+		if !renew {
+			err = s.ping(ctx)
+			renew = errors.Is(err, binance.ErrInvalidListenKey)
+		}
+		if renew {
+			err = s.renew(ctx)
+			if err == nil {
+				renew, renewed = false, true
+			}
+		}
+		if err == nil {
+			var stream *binance.WsStream
+			stream, err = s.serve(ctx, s.ListenKey())
+			if err == nil {
+				return stream, renewed
+			}
+		}
+		if ctx.Err() != nil {
+			return nil, renewed
+		}
+		s.error(err)
+		t := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, renewed
+		case <-t.C:
+		}
+	}
+}
+
+// renew 生成新的 listenKey
+func (s *UserDataSession) renew(ctx context.Context) error {
+	res, err := NewUserDataStream(s.client).CallCreate(ctx)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.listenKey = res.ListenKey
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *UserDataSession) ping(ctx context.Context) error {
+	return NewUserDataStream(s.client).SetListenKey(s.ListenKey()).CallUpdate(ctx)
+}
+
+func (s *UserDataSession) serve(ctx context.Context, listenKey string) (*binance.WsStream, error) {
+	s.last.Store(time.Now().UnixNano())
+	lke := func(event *account.WsListenKeyExpiredEvent) {
+		select {
+		case s.expired <- event.ListenKey:
+		default:
+		}
+	}
+	// 连接错误通过 OnGap 通知，这里只处理推送解析错误
+	exception := func(mt int, err error) {
+		if mt > 0 {
+			s.error(err)
+		}
+	}
+	newUserData := account.NewWsUserData
+	if s.ws.IsCombined {
+		newUserData = account.NewStreamUserData
+	}
+	return newUserData(ctx, s.ws, listenKey,
+		received(s, s.OnOutboundAccountPosition),
+		received(s, s.OnBalanceUpdate),
+		received(s, s.OnExecutionReport),
+		received(s, s.OnListStatus),
+		received(s, lke),
+		exception,
+	)
+}
+
+func (s *UserDataSession) error(err error) {
+	if s.OnError != nil {
+		s.OnError(err)
+	}
+}
+
+// received 记录收到推送的时间，handler 为空时忽略推送
+func received[T any](s *UserDataSession, handler binance.Handler[T]) binance.Handler[T] {
+	return func(event T) {
+		s.last.Store(time.Now().UnixNano())
+		if handler != nil {
+			handler(event)
+		}
+	}
+}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
-	"github.com/sleep-go/coin-go/pkg/utils"
 )
 
 //	新建用户数据流 (USER_STREAM)
@@ -48,22 +47,24 @@ func (o *userDataStreamRequest) CallCreate(ctx context.Context) (body *userDataS
 		o.Debugf("userDataStreamRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*userDataStreamResponse](resp)
+	return binance.ParseHttpResponse[*userDataStreamResponse](resp)
 }
 
 // CallUpdate 延长用户数据流有效期到60分钟之后。 建议每30分钟调用一次
+// listenKey 已失效时返回 -1125
 func (o *userDataStreamRequest) CallUpdate(ctx context.Context) (err error) {
 	req := &binance.Request{
 		Method: http.MethodPut,
 		Path:   consts.ApiStreamUserDataStream,
 	}
 	req.SetParam("listenKey", o.listenKey)
-	_, err = o.Do(ctx, req)
+	resp, err := o.Do(ctx, req)
 	if err != nil {
 		o.Debugf("userDataStreamRequest response err:%v", err)
 		return err
 	}
-	return nil
+	_, err = binance.ParseHttpResponse[struct{}](resp)
+	return err
 }
 
 // CallDelete 关闭用户数据流 (USER_STREAM)
@@ -73,12 +74,13 @@ func (o *userDataStreamRequest) CallDelete(ctx context.Context) (err error) {
 		Path:   consts.ApiStreamUserDataStream,
 	}
 	req.SetParam("listenKey", o.listenKey)
-	_, err = o.Do(ctx, req)
+	resp, err := o.Do(ctx, req)
 	if err != nil {
 		o.Debugf("userDataStreamRequest response err:%v", err)
 		return err
 	}
-	return nil
+	_, err = binance.ParseHttpResponse[struct{}](resp)
+	return err
 }
 
 // ****************************** Websocket Api *******************************
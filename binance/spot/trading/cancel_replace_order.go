@@ -8,6 +8,7 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type CancelReplace interface {
@@ -55,90 +56,150 @@ type cancelReplaceRequest struct {
 }
 
 type cancelReplaceResponse struct {
-	Code           int64  `json:"code,omitempty"`
-	Msg            string `json:"msg,omitempty"`
-	CancelResult   string `json:"cancelResult,omitempty"`
-	NewOrderResult string `json:"newOrderResult,omitempty"`
-	CancelResponse *struct {
-		Code                    int    `json:"code,omitempty"`
-		Msg                     string `json:"msg,omitempty"`
-		Symbol                  string `json:"symbol,omitempty"`
-		OrigClientOrderId       string `json:"origClientOrderId,omitempty"`
-		OrderId                 int64  `json:"orderId,omitempty"`
-		OrderListId             int64  `json:"orderListId,omitempty"`
-		ClientOrderId           string `json:"clientOrderId,omitempty"`
-		Price                   string `json:"price,omitempty"`
-		OrigQty                 string `json:"origQty,omitempty"`
-		ExecutedQty             string `json:"executedQty,omitempty"`
-		CumulativeQuoteQty      string `json:"cumulativeQuoteQty,omitempty"`
-		Status                  string `json:"status,omitempty"`
-		TimeInForce             string `json:"timeInForce,omitempty"`
-		Type                    string `json:"type,omitempty"`
-		Side                    string `json:"side,omitempty"`
-		SelfTradePreventionMode string `json:"selfTradePreventionMode,omitempty"`
-	} `json:"cancelResponse,omitempty"`
-	NewOrderResponse *struct {
-		Code                    int64    `json:"code,omitempty"`
-		Msg                     string   `json:"msg,omitempty"`
-		Symbol                  string   `json:"symbol,omitempty"`
-		OrderId                 int64    `json:"orderId,omitempty"`
-		OrderListId             int64    `json:"orderListId,omitempty"`
-		ClientOrderId           string   `json:"clientOrderId,omitempty"`
-		TransactTime            uint64   `json:"transactTime,omitempty"`
-		Price                   string   `json:"price,omitempty"`
-		OrigQty                 string   `json:"origQty,omitempty"`
-		ExecutedQty             string   `json:"executedQty,omitempty"`
-		CumulativeQuoteQty      string   `json:"cumulativeQuoteQty,omitempty"`
-		Status                  string   `json:"status,omitempty"`
-		TimeInForce             string   `json:"timeInForce,omitempty"`
-		Type                    string   `json:"type,omitempty"`
-		Side                    string   `json:"side,omitempty"`
-		Fills                   []string `json:"fills,omitempty"`
-		SelfTradePreventionMode string   `json:"selfTradePreventionMode,omitempty"`
-	} `json:"newOrderResponse,omitempty"`
-	Data *struct {
-		CancelResult   string `json:"cancelResult,omitempty"`
-		NewOrderResult string `json:"newOrderResult,omitempty"`
-		CancelResponse *struct {
-			Code                    int64  `json:"code,omitempty"`
-			Msg                     string `json:"msg,omitempty"`
-			Symbol                  string `json:"symbol,omitempty"`
-			OrigClientOrderId       string `json:"origClientOrderId,omitempty"`
-			OrderId                 int64  `json:"orderId,omitempty"`
-			OrderListId             int64  `json:"orderListId,omitempty"`
-			ClientOrderId           string `json:"clientOrderId,omitempty"`
-			Price                   string `json:"price,omitempty"`
-			OrigQty                 string `json:"origQty,omitempty"`
-			ExecutedQty             string `json:"executedQty,omitempty"`
-			CumulativeQuoteQty      string `json:"cumulativeQuoteQty,omitempty"`
-			Status                  string `json:"status,omitempty"`
-			TimeInForce             string `json:"timeInForce,omitempty"`
-			Type                    string `json:"type,omitempty"`
-			Side                    string `json:"side,omitempty"`
-			SelfTradePreventionMode string `json:"selfTradePreventionMode,omitempty"`
-		} `json:"cancelResponse,omitempty"`
-		NewOrderResponse struct {
-			Code                    int64    `json:"code,omitempty"`
-			Msg                     string   `json:"msg,omitempty"`
-			Symbol                  string   `json:"symbol,omitempty"`
-			OrderId                 int64    `json:"orderId,omitempty"`
-			OrderListId             int64    `json:"orderListId,omitempty"`
-			ClientOrderId           string   `json:"clientOrderId,omitempty"`
-			TransactTime            uint64   `json:"transactTime,omitempty"`
-			Price                   string   `json:"price,omitempty"`
-			OrigQty                 string   `json:"origQty,omitempty"`
-			ExecutedQty             string   `json:"executedQty,omitempty"`
-			CumulativeQuoteQty      string   `json:"cumulativeQuoteQty,omitempty"`
-			Status                  string   `json:"status,omitempty"`
-			TimeInForce             string   `json:"timeInForce,omitempty"`
-			Type                    string   `json:"type,omitempty"`
-			Side                    string   `json:"side,omitempty"`
-			Fills                   []string `json:"fills,omitempty"`
-			SelfTradePreventionMode string   `json:"selfTradePreventionMode,omitempty"`
-		} `json:"newOrderResponse"`
+	Code             int64                          `json:"code,omitempty"`
+	Msg              string                         `json:"msg,omitempty"`
+	CancelResult     string                         `json:"cancelResult,omitempty"`
+	NewOrderResult   string                         `json:"newOrderResult,omitempty"`
+	CancelResponse   *cancelReplaceCancelResponse   `json:"cancelResponse,omitempty"`
+	NewOrderResponse *cancelReplaceNewOrderResponse `json:"newOrderResponse,omitempty"`
+	Data             *struct {
+		CancelResult     string                            `json:"cancelResult,omitempty"`
+		NewOrderResult   string                            `json:"newOrderResult,omitempty"`
+		CancelResponse   *cancelReplaceDataCancelResponse  `json:"cancelResponse,omitempty"`
+		NewOrderResponse cancelReplaceDataNewOrderResponse `json:"newOrderResponse"`
 	} `json:"data,omitempty"`
 }
 
+type cancelReplaceCancelResponse struct {
+	Code                    int    `json:"code,omitempty"`
+	Msg                     string `json:"msg,omitempty"`
+	Symbol                  string `json:"symbol,omitempty"`
+	OrigClientOrderId       string `json:"origClientOrderId,omitempty"`
+	OrderId                 int64  `json:"orderId,omitempty"`
+	OrderListId             int64  `json:"orderListId,omitempty"`
+	ClientOrderId           string `json:"clientOrderId,omitempty"`
+	Price                   string `json:"price,omitempty"`
+	OrigQty                 string `json:"origQty,omitempty"`
+	ExecutedQty             string `json:"executedQty,omitempty"`
+	CumulativeQuoteQty      string `json:"cumulativeQuoteQty,omitempty"`
+	Status                  string `json:"status,omitempty"`
+	TimeInForce             string `json:"timeInForce,omitempty"`
+	Type                    string `json:"type,omitempty"`
+	Side                    string `json:"side,omitempty"`
+	SelfTradePreventionMode string `json:"selfTradePreventionMode,omitempty"`
+}
+
+func (c cancelReplaceCancelResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.Price)
+}
+func (c cancelReplaceCancelResponse) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.OrigQty)
+}
+func (c cancelReplaceCancelResponse) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.ExecutedQty)
+}
+func (c cancelReplaceCancelResponse) CumulativeQuoteQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.CumulativeQuoteQty)
+}
+
+type cancelReplaceNewOrderResponse struct {
+	Code                    int64    `json:"code,omitempty"`
+	Msg                     string   `json:"msg,omitempty"`
+	Symbol                  string   `json:"symbol,omitempty"`
+	OrderId                 int64    `json:"orderId,omitempty"`
+	OrderListId             int64    `json:"orderListId,omitempty"`
+	ClientOrderId           string   `json:"clientOrderId,omitempty"`
+	TransactTime            uint64   `json:"transactTime,omitempty"`
+	Price                   string   `json:"price,omitempty"`
+	OrigQty                 string   `json:"origQty,omitempty"`
+	ExecutedQty             string   `json:"executedQty,omitempty"`
+	CumulativeQuoteQty      string   `json:"cumulativeQuoteQty,omitempty"`
+	Status                  string   `json:"status,omitempty"`
+	TimeInForce             string   `json:"timeInForce,omitempty"`
+	Type                    string   `json:"type,omitempty"`
+	Side                    string   `json:"side,omitempty"`
+	Fills                   []string `json:"fills,omitempty"`
+	SelfTradePreventionMode string   `json:"selfTradePreventionMode,omitempty"`
+}
+
+func (c cancelReplaceNewOrderResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.Price)
+}
+func (c cancelReplaceNewOrderResponse) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.OrigQty)
+}
+func (c cancelReplaceNewOrderResponse) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.ExecutedQty)
+}
+func (c cancelReplaceNewOrderResponse) CumulativeQuoteQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.CumulativeQuoteQty)
+}
+
+type cancelReplaceDataCancelResponse struct {
+	Code                    int64  `json:"code,omitempty"`
+	Msg                     string `json:"msg,omitempty"`
+	Symbol                  string `json:"symbol,omitempty"`
+	OrigClientOrderId       string `json:"origClientOrderId,omitempty"`
+	OrderId                 int64  `json:"orderId,omitempty"`
+	OrderListId             int64  `json:"orderListId,omitempty"`
+	ClientOrderId           string `json:"clientOrderId,omitempty"`
+	Price                   string `json:"price,omitempty"`
+	OrigQty                 string `json:"origQty,omitempty"`
+	ExecutedQty             string `json:"executedQty,omitempty"`
+	CumulativeQuoteQty      string `json:"cumulativeQuoteQty,omitempty"`
+	Status                  string `json:"status,omitempty"`
+	TimeInForce             string `json:"timeInForce,omitempty"`
+	Type                    string `json:"type,omitempty"`
+	Side                    string `json:"side,omitempty"`
+	SelfTradePreventionMode string `json:"selfTradePreventionMode,omitempty"`
+}
+
+func (c cancelReplaceDataCancelResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.Price)
+}
+func (c cancelReplaceDataCancelResponse) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.OrigQty)
+}
+func (c cancelReplaceDataCancelResponse) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.ExecutedQty)
+}
+func (c cancelReplaceDataCancelResponse) CumulativeQuoteQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.CumulativeQuoteQty)
+}
+
+type cancelReplaceDataNewOrderResponse struct {
+	Code                    int64    `json:"code,omitempty"`
+	Msg                     string   `json:"msg,omitempty"`
+	Symbol                  string   `json:"symbol,omitempty"`
+	OrderId                 int64    `json:"orderId,omitempty"`
+	OrderListId             int64    `json:"orderListId,omitempty"`
+	ClientOrderId           string   `json:"clientOrderId,omitempty"`
+	TransactTime            uint64   `json:"transactTime,omitempty"`
+	Price                   string   `json:"price,omitempty"`
+	OrigQty                 string   `json:"origQty,omitempty"`
+	ExecutedQty             string   `json:"executedQty,omitempty"`
+	CumulativeQuoteQty      string   `json:"cumulativeQuoteQty,omitempty"`
+	Status                  string   `json:"status,omitempty"`
+	TimeInForce             string   `json:"timeInForce,omitempty"`
+	Type                    string   `json:"type,omitempty"`
+	Side                    string   `json:"side,omitempty"`
+	Fills                   []string `json:"fills,omitempty"`
+	SelfTradePreventionMode string   `json:"selfTradePreventionMode,omitempty"`
+}
+
+func (c cancelReplaceDataNewOrderResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.Price)
+}
+func (c cancelReplaceDataNewOrderResponse) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.OrigQty)
+}
+func (c cancelReplaceDataNewOrderResponse) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.ExecutedQty)
+}
+func (c cancelReplaceDataNewOrderResponse) CumulativeQuoteQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.CumulativeQuoteQty)
+}
+
 func NewCancelReplace(client *binance.Client, symbol string) CancelReplace {
 	return &cancelReplaceRequest{
 		Client:             client,
@@ -6,8 +6,9 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/rules"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type CreateOrder interface {
@@ -26,6 +27,8 @@ type CreateOrder interface {
 	SetIcebergQty(icebergQty string) *createOrderRequest
 	SetNewOrderRespType(newOrderRespType enums.NewOrderRespType) *createOrderRequest
 	SetSelfTradePreventionMode(selfTradePreventionMode enums.StpModeType) *createOrderRequest
+	SetRules(registry *rules.Registry, round bool) *createOrderRequest
+	Validate(ctx context.Context) error
 	Call(ctx context.Context) (body *createOrderResponse, err error)
 	CallTest(ctx context.Context, computeCommissionRates bool) (body *createOrderTestResponse, err error)
 }
@@ -47,30 +50,56 @@ type createOrderRequest struct {
 	icebergQty              *string                //仅有限价单(包括条件限价单与限价做事单)可以使用该参数，含义为创建冰山订单并指定冰山订单的数量。
 	newOrderRespType        enums.NewOrderRespType //指定响应类型 ACK, RESULT, or FULL; MARKET 与 LIMIT 订单默认为FULL, 其他默认为ACK。
 	selfTradePreventionMode enums.StpModeType      //允许的 ENUM 取决于交易对的配置。
+	validator               *rules.Validator
 }
 type createOrderResponse struct {
-	Symbol                  string `json:"symbol"`
-	OrderId                 int    `json:"orderId"`
-	OrderListId             int    `json:"orderListId"`
-	ClientOrderId           string `json:"clientOrderId"`
-	TransactTime            int64  `json:"transactTime"`
-	Price                   string `json:"price"`
-	OrigQty                 string `json:"origQty"`
-	ExecutedQty             string `json:"executedQty"`
-	CummulativeQuoteQty     string `json:"cummulativeQuoteQty"`
-	Status                  string `json:"status"`
-	TimeInForce             string `json:"timeInForce"`
-	Type                    string `json:"type"`
-	Side                    string `json:"side"`
-	WorkingTime             int64  `json:"workingTime"`
-	SelfTradePreventionMode string `json:"selfTradePreventionMode"`
-	Fills                   []struct {
-		Price           string `json:"price"`
-		Qty             string `json:"qty"`
-		Commission      string `json:"commission"`
-		CommissionAsset string `json:"commissionAsset"`
-		TradeId         int    `json:"tradeId"`
-	} `json:"fills"`
+	Symbol                  string      `json:"symbol"`
+	OrderId                 int         `json:"orderId"`
+	OrderListId             int         `json:"orderListId"`
+	ClientOrderId           string      `json:"clientOrderId"`
+	TransactTime            int64       `json:"transactTime"`
+	Price                   string      `json:"price"`
+	OrigQty                 string      `json:"origQty"`
+	ExecutedQty             string      `json:"executedQty"`
+	CummulativeQuoteQty     string      `json:"cummulativeQuoteQty"`
+	Status                  string      `json:"status"`
+	TimeInForce             string      `json:"timeInForce"`
+	Type                    string      `json:"type"`
+	Side                    string      `json:"side"`
+	WorkingTime             int64       `json:"workingTime"`
+	SelfTradePreventionMode string      `json:"selfTradePreventionMode"`
+	Fills                   []orderFill `json:"fills"`
+}
+
+func (c createOrderResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.Price)
+}
+func (c createOrderResponse) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.OrigQty)
+}
+func (c createOrderResponse) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.ExecutedQty)
+}
+func (c createOrderResponse) CummulativeQuoteQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(c.CummulativeQuoteQty)
+}
+
+type orderFill struct {
+	Price           string `json:"price"`
+	Qty             string `json:"qty"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	TradeId         int    `json:"tradeId"`
+}
+
+func (o orderFill) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.Price)
+}
+func (o orderFill) QtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.Qty)
+}
+func (o orderFill) CommissionDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.Commission)
 }
 
 func NewOrder(client *binance.Client, symbol string) CreateOrder {
@@ -137,6 +166,10 @@ func (c *createOrderRequest) SetSelfTradePreventionMode(selfTradePreventionMode
 	return c
 }
 func (c *createOrderRequest) Call(ctx context.Context) (body *createOrderResponse, err error) {
+	err = c.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
 	req := &binance.Request{
 		Method: http.MethodPost,
 		Path:   consts.ApiOrder,
@@ -161,7 +194,7 @@ func (c *createOrderRequest) Call(ctx context.Context) (body *createOrderRespons
 		c.Debugf("createOrderRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*createOrderResponse](resp)
+	return binance.ParseHttpResponse[*createOrderResponse](resp)
 }
 
 type createOrderTestResponse struct {
@@ -185,6 +218,10 @@ type createOrderTestResponse struct {
 }
 
 func (c *createOrderRequest) CallTest(ctx context.Context, computeCommissionRates bool) (body *createOrderTestResponse, err error) {
+	err = c.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
 	// 没有 computeCommissionRates返回空
 	if computeCommissionRates == false {
 		return nil, nil
@@ -214,7 +251,7 @@ func (c *createOrderRequest) CallTest(ctx context.Context, computeCommissionRate
 		c.Debugf("createOrderRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*createOrderTestResponse](resp)
+	return binance.ParseHttpResponse[*createOrderTestResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
@@ -239,6 +276,10 @@ func NewWsApiCreateOrder(c *binance.Client) WsApiCreateOrder {
 
 // Send 下新的订单 (TRADE)
 func (c *createOrderRequest) Send(ctx context.Context) (*WsApiCreateOrderResponse, error) {
+	err := c.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
 	req := &binance.Request{Path: "order.place"}
 	req.SetNeedSign(true)
 	req.SetParam("symbol", c.symbol)
@@ -260,6 +301,10 @@ func (c *createOrderRequest) Send(ctx context.Context) (*WsApiCreateOrderRespons
 
 // SendTest 测试下单 (TRADE)
 func (c *createOrderRequest) SendTest(ctx context.Context, computeCommissionRates bool) (*WsApiCreateOrderTestResponse, error) {
+	err := c.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
 	req := &binance.Request{Path: "order.test"}
 	req.SetNeedSign(true)
 	req.SetParam("symbol", c.symbol)
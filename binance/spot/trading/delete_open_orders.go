@@ -7,7 +7,7 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 // DeleteOpenOrders 撤销单一交易对下所有挂单。这也包括了来自订单列表的挂单。
@@ -47,25 +47,59 @@ type deleteOpenOrdersResponse struct {
 		OrderId       int    `json:"orderId"`
 		ClientOrderId string `json:"clientOrderId"`
 	} `json:"orders,omitempty"`
-	OrderReports []struct {
-		Symbol                  string                `json:"symbol"`
-		OrigClientOrderId       string                `json:"origClientOrderId"`
-		OrderId                 int                   `json:"orderId"`
-		OrderListId             int                   `json:"orderListId"`
-		ClientOrderId           string                `json:"clientOrderId"`
-		TransactTime            int64                 `json:"transactTime"`
-		Price                   string                `json:"price"`
-		OrigQty                 string                `json:"origQty"`
-		ExecutedQty             string                `json:"executedQty"`
-		CummulativeQuoteQty     string                `json:"cummulativeQuoteQty"`
-		Status                  enums.OrderStatusType `json:"status"`
-		TimeInForce             enums.TimeInForceType `json:"timeInForce"`
-		Type                    enums.OrderType       `json:"type"`
-		Side                    enums.SideType        `json:"side"`
-		StopPrice               string                `json:"stopPrice,omitempty"`
-		IcebergQty              string                `json:"icebergQty"`
-		SelfTradePreventionMode enums.StpModeType     `json:"selfTradePreventionMode"`
-	} `json:"orderReports,omitempty"`
+	OrderReports []deleteOpenOrdersReport `json:"orderReports,omitempty"`
+}
+
+func (d deleteOpenOrdersResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.Price)
+}
+func (d deleteOpenOrdersResponse) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.OrigQty)
+}
+func (d deleteOpenOrdersResponse) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.ExecutedQty)
+}
+func (d deleteOpenOrdersResponse) CummulativeQuoteQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.CummulativeQuoteQty)
+}
+
+type deleteOpenOrdersReport struct {
+	Symbol                  string                `json:"symbol"`
+	OrigClientOrderId       string                `json:"origClientOrderId"`
+	OrderId                 int                   `json:"orderId"`
+	OrderListId             int                   `json:"orderListId"`
+	ClientOrderId           string                `json:"clientOrderId"`
+	TransactTime            int64                 `json:"transactTime"`
+	Price                   string                `json:"price"`
+	OrigQty                 string                `json:"origQty"`
+	ExecutedQty             string                `json:"executedQty"`
+	CummulativeQuoteQty     string                `json:"cummulativeQuoteQty"`
+	Status                  enums.OrderStatusType `json:"status"`
+	TimeInForce             enums.TimeInForceType `json:"timeInForce"`
+	Type                    enums.OrderType       `json:"type"`
+	Side                    enums.SideType        `json:"side"`
+	StopPrice               string                `json:"stopPrice,omitempty"`
+	IcebergQty              string                `json:"icebergQty"`
+	SelfTradePreventionMode enums.StpModeType     `json:"selfTradePreventionMode"`
+}
+
+func (d deleteOpenOrdersReport) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.Price)
+}
+func (d deleteOpenOrdersReport) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.OrigQty)
+}
+func (d deleteOpenOrdersReport) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.ExecutedQty)
+}
+func (d deleteOpenOrdersReport) CummulativeQuoteQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.CummulativeQuoteQty)
+}
+func (d deleteOpenOrdersReport) StopPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.StopPrice)
+}
+func (d deleteOpenOrdersReport) IcebergQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.IcebergQty)
 }
 
 func NewDeleteOpenOrders(client *binance.Client, symbol string) DeleteOpenOrders {
@@ -88,7 +122,7 @@ func (d *deleteOpenOrdersRequest) Call(ctx context.Context) (body []*deleteOpenO
 		d.Debugf("deleteOpenOrdersRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*deleteOpenOrdersResponse](resp)
+	return binance.ParseHttpResponse[[]*deleteOpenOrdersResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
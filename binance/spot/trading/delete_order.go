@@ -7,7 +7,7 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type DeleteOrder interface {
@@ -54,25 +54,59 @@ type deleteOrderResponse struct {
 		OrderId       int    `json:"orderId"`
 		ClientOrderId string `json:"clientOrderId"`
 	} `json:"orders,omitempty"`
-	OrderReports []struct {
-		Symbol                  string                `json:"symbol"`
-		OrigClientOrderId       string                `json:"origClientOrderId"`
-		OrderId                 int                   `json:"orderId"`
-		OrderListId             int                   `json:"orderListId"`
-		ClientOrderId           string                `json:"clientOrderId"`
-		TransactTime            int64                 `json:"transactTime"`
-		Price                   string                `json:"price"`
-		OrigQty                 string                `json:"origQty"`
-		ExecutedQty             string                `json:"executedQty"`
-		CummulativeQuoteQty     string                `json:"cummulativeQuoteQty"`
-		Status                  enums.OrderStatusType `json:"status"`
-		TimeInForce             enums.TimeInForceType `json:"timeInForce"`
-		Type                    enums.OrderType       `json:"type"`
-		Side                    enums.SideType        `json:"side"`
-		StopPrice               string                `json:"stopPrice,omitempty"`
-		IcebergQty              string                `json:"icebergQty"`
-		SelfTradePreventionMode enums.StpModeType     `json:"selfTradePreventionMode"`
-	} `json:"orderReports,omitempty"`
+	OrderReports []deleteOrderReport `json:"orderReports,omitempty"`
+}
+
+func (d deleteOrderResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.Price)
+}
+func (d deleteOrderResponse) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.OrigQty)
+}
+func (d deleteOrderResponse) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.ExecutedQty)
+}
+func (d deleteOrderResponse) CummulativeQuoteQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.CummulativeQuoteQty)
+}
+
+type deleteOrderReport struct {
+	Symbol                  string                `json:"symbol"`
+	OrigClientOrderId       string                `json:"origClientOrderId"`
+	OrderId                 int                   `json:"orderId"`
+	OrderListId             int                   `json:"orderListId"`
+	ClientOrderId           string                `json:"clientOrderId"`
+	TransactTime            int64                 `json:"transactTime"`
+	Price                   string                `json:"price"`
+	OrigQty                 string                `json:"origQty"`
+	ExecutedQty             string                `json:"executedQty"`
+	CummulativeQuoteQty     string                `json:"cummulativeQuoteQty"`
+	Status                  enums.OrderStatusType `json:"status"`
+	TimeInForce             enums.TimeInForceType `json:"timeInForce"`
+	Type                    enums.OrderType       `json:"type"`
+	Side                    enums.SideType        `json:"side"`
+	StopPrice               string                `json:"stopPrice,omitempty"`
+	IcebergQty              string                `json:"icebergQty"`
+	SelfTradePreventionMode enums.StpModeType     `json:"selfTradePreventionMode"`
+}
+
+func (d deleteOrderReport) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.Price)
+}
+func (d deleteOrderReport) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.OrigQty)
+}
+func (d deleteOrderReport) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.ExecutedQty)
+}
+func (d deleteOrderReport) CummulativeQuoteQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.CummulativeQuoteQty)
+}
+func (d deleteOrderReport) StopPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.StopPrice)
+}
+func (d deleteOrderReport) IcebergQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.IcebergQty)
 }
 
 func NewDeleteOrder(client *binance.Client, symbol string) DeleteOrder {
@@ -119,7 +153,7 @@ func (d *deleteOrderRequest) Call(ctx context.Context) (body *deleteOrderRespons
 		d.Debugf("deleteOrderRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*deleteOrderResponse](resp)
+	return binance.ParseHttpResponse[*deleteOrderResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
@@ -6,8 +6,9 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/rules"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 // OCO 发送新 one-cancels-the-other (OCO) 订单，激活其中一个订单会立即取消另一个订单。
@@ -43,6 +44,8 @@ type OCO interface {
 	SetBelowStrategyType(belowStrategyType int64) *ocoRequest
 	SetNewOrderRespType(newOrderRespType enums.NewOrderRespType) *ocoRequest
 	SetSelfTradePreventionMode(selfTradePreventionMode enums.StpModeType) *ocoRequest
+	SetRules(registry *rules.Registry, round bool) *ocoRequest
+	Validate(ctx context.Context) error
 	Call(ctx context.Context) (body *ocoResponse, err error)
 }
 
@@ -79,6 +82,7 @@ type ocoRequest struct {
 	belowStrategyType       *int64                 //下方订单策略的任意数值。 小于 1000000 的值被保留，无法使用。
 	newOrderRespType        enums.NewOrderRespType //响应格式可选值: ACK, RESULT, FULL。
 	selfTradePreventionMode enums.StpModeType      //允许的 ENUM 取决于交易对上的配置。 支持值：STP 模式。
+	validator               *rules.Validator
 }
 
 type ocoResponse struct {
@@ -94,25 +98,46 @@ type ocoResponse struct {
 		OrderId       int    `json:"orderId"`
 		ClientOrderId string `json:"clientOrderId"`
 	} `json:"orders"`
-	OrderReports []struct {
-		Symbol                  string                `json:"symbol"`
-		OrderId                 int                   `json:"orderId"`
-		OrderListId             int                   `json:"orderListId"`
-		ClientOrderId           string                `json:"clientOrderId"`
-		TransactTime            int64                 `json:"transactTime"`
-		Price                   string                `json:"price"`
-		OrigQty                 string                `json:"origQty"`
-		ExecutedQty             string                `json:"executedQty"`
-		CummulativeQuoteQty     string                `json:"cummulativeQuoteQty"`
-		Status                  enums.OrderStatusType `json:"status"`
-		TimeInForce             string                `json:"timeInForce"`
-		Type                    enums.OrderType       `json:"type"`
-		Side                    enums.SideType        `json:"side"`
-		StopPrice               string                `json:"stopPrice,omitempty"`
-		WorkingTime             int64                 `json:"workingTime"`
-		IcebergQty              string                `json:"icebergQty,omitempty"`
-		SelfTradePreventionMode enums.StpModeType     `json:"selfTradePreventionMode"`
-	} `json:"orderReports"`
+	OrderReports []ocoOrderReport `json:"orderReports"`
+}
+
+type ocoOrderReport struct {
+	Symbol                  string                `json:"symbol"`
+	OrderId                 int                   `json:"orderId"`
+	OrderListId             int                   `json:"orderListId"`
+	ClientOrderId           string                `json:"clientOrderId"`
+	TransactTime            int64                 `json:"transactTime"`
+	Price                   string                `json:"price"`
+	OrigQty                 string                `json:"origQty"`
+	ExecutedQty             string                `json:"executedQty"`
+	CummulativeQuoteQty     string                `json:"cummulativeQuoteQty"`
+	Status                  enums.OrderStatusType `json:"status"`
+	TimeInForce             string                `json:"timeInForce"`
+	Type                    enums.OrderType       `json:"type"`
+	Side                    enums.SideType        `json:"side"`
+	StopPrice               string                `json:"stopPrice,omitempty"`
+	WorkingTime             int64                 `json:"workingTime"`
+	IcebergQty              string                `json:"icebergQty,omitempty"`
+	SelfTradePreventionMode enums.StpModeType     `json:"selfTradePreventionMode"`
+}
+
+func (o ocoOrderReport) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.Price)
+}
+func (o ocoOrderReport) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.OrigQty)
+}
+func (o ocoOrderReport) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.ExecutedQty)
+}
+func (o ocoOrderReport) CummulativeQuoteQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.CummulativeQuoteQty)
+}
+func (o ocoOrderReport) StopPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.StopPrice)
+}
+func (o ocoOrderReport) IcebergQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.IcebergQty)
 }
 
 func (o *ocoRequest) SetSymbol(symbol string) *ocoRequest {
@@ -216,6 +241,10 @@ func NewOco(client *binance.Client, symbol string) OCO {
 	return &ocoRequest{Client: client, symbol: symbol}
 }
 func (o *ocoRequest) Call(ctx context.Context) (body *ocoResponse, err error) {
+	err = o.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
 	req := &binance.Request{
 		Method: http.MethodPost,
 		Path:   consts.ApiTradingOrderListOCO,
@@ -250,7 +279,7 @@ func (o *ocoRequest) Call(ctx context.Context) (body *ocoResponse, err error) {
 		o.Debugf("ocoRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*ocoResponse](resp)
+	return binance.ParseHttpResponse[*ocoResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
@@ -270,6 +299,10 @@ func NewWsApiOCO(c *binance.Client) WsApiOCO {
 
 // Send 下新的订单 (TRADE)
 func (o *ocoRequest) Send(ctx context.Context) (*WsApiOCOResponse, error) {
+	err := o.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
 	req := &binance.Request{Path: "orderList.place.oco"}
 	req.SetNeedSign(true)
 	req.SetParam("symbol", o.symbol)
@@ -7,7 +7,7 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type OrderList interface {
@@ -52,23 +52,41 @@ type deleteOrderListResponse struct {
 		OrderId       int    `json:"orderId"`
 		ClientOrderId string `json:"clientOrderId"`
 	} `json:"orders"`
-	OrderReports []struct {
-		Symbol              string                `json:"symbol"`
-		OrigClientOrderId   string                `json:"origClientOrderId"`
-		OrderId             int                   `json:"orderId"`
-		OrderListId         int                   `json:"orderListId"`
-		ClientOrderId       string                `json:"clientOrderId"`
-		TransactTime        int64                 `json:"transactTime"`
-		Price               string                `json:"price"`
-		OrigQty             string                `json:"origQty"`
-		ExecutedQty         string                `json:"executedQty"`
-		CummulativeQuoteQty string                `json:"cummulativeQuoteQty"`
-		Status              enums.OrderStatusType `json:"status"`
-		TimeInForce         enums.TimeInForceType `json:"timeInForce"`
-		Type                enums.OrderType       `json:"type"`
-		Side                enums.SideType        `json:"side"`
-		StopPrice           string                `json:"stopPrice,omitempty"`
-	} `json:"orderReports"`
+	OrderReports []deleteOrderListReport `json:"orderReports"`
+}
+
+type deleteOrderListReport struct {
+	Symbol              string                `json:"symbol"`
+	OrigClientOrderId   string                `json:"origClientOrderId"`
+	OrderId             int                   `json:"orderId"`
+	OrderListId         int                   `json:"orderListId"`
+	ClientOrderId       string                `json:"clientOrderId"`
+	TransactTime        int64                 `json:"transactTime"`
+	Price               string                `json:"price"`
+	OrigQty             string                `json:"origQty"`
+	ExecutedQty         string                `json:"executedQty"`
+	CummulativeQuoteQty string                `json:"cummulativeQuoteQty"`
+	Status              enums.OrderStatusType `json:"status"`
+	TimeInForce         enums.TimeInForceType `json:"timeInForce"`
+	Type                enums.OrderType       `json:"type"`
+	Side                enums.SideType        `json:"side"`
+	StopPrice           string                `json:"stopPrice,omitempty"`
+}
+
+func (d deleteOrderListReport) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.Price)
+}
+func (d deleteOrderListReport) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.OrigQty)
+}
+func (d deleteOrderListReport) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.ExecutedQty)
+}
+func (d deleteOrderListReport) CummulativeQuoteQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.CummulativeQuoteQty)
+}
+func (d deleteOrderListReport) StopPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(d.StopPrice)
 }
 
 func (o *orderListRequest) SetNewClientOrderId(newClientOrderId string) *orderListRequest {
@@ -102,7 +120,7 @@ func (o *orderListRequest) Call(ctx context.Context) (body *orderListResponse, e
 		o.Debugf("orderListRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*orderListResponse](resp)
+	return binance.ParseHttpResponse[*orderListResponse](resp)
 }
 
 // CallDelete 取消订单列表 (TRADE)
@@ -124,7 +142,7 @@ func (o *orderListRequest) CallDelete(ctx context.Context) (body *deleteOrderLis
 		o.Debugf("orderListRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*deleteOrderListResponse](resp)
+	return binance.ParseHttpResponse[*deleteOrderListResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
@@ -6,8 +6,9 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/rules"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 // OTO 发送一个新的 OTO 订单。
@@ -43,6 +44,8 @@ type OTO interface {
 	SetPendingTimeInForce(pendingTimeInForce enums.TimeInForceType) *otoRequest
 	SetPendingStrategyId(pendingStrategyId int64) *otoRequest
 	SetPendingStrategyType(pendingStrategyType int64) *otoRequest
+	SetRules(registry *rules.Registry, round bool) *otoRequest
+	Validate(ctx context.Context) error
 	Call(ctx context.Context) (body *otoResponse, err error)
 }
 
@@ -75,6 +78,7 @@ type otoRequest struct {
 	pendingTimeInForce      enums.TimeInForceType
 	pendingStrategyId       *int64 //订单策略中用于标识待处理订单的 ID。
 	pendingStrategyType     *int64 //用于标识待处理订单策略的任意数值。小于 1000000 的值被保留，无法使用。
+	validator               *rules.Validator
 }
 
 type otoResponse struct {
@@ -90,25 +94,46 @@ type otoResponse struct {
 		OrderId       int    `json:"orderId"`
 		ClientOrderId string `json:"clientOrderId"`
 	} `json:"orders"`
-	OrderReports []struct {
-		Symbol                  string                `json:"symbol"`
-		OrderId                 int                   `json:"orderId"`
-		OrderListId             int                   `json:"orderListId"`
-		ClientOrderId           string                `json:"clientOrderId"`
-		TransactTime            int64                 `json:"transactTime"`
-		Price                   string                `json:"price"`
-		OrigQty                 string                `json:"origQty"`
-		ExecutedQty             string                `json:"executedQty"`
-		CummulativeQuoteQty     string                `json:"cummulativeQuoteQty"`
-		Status                  enums.OrderStatusType `json:"status"`
-		TimeInForce             enums.TimeInForceType `json:"timeInForce"`
-		Type                    enums.OrderType       `json:"type"`
-		Side                    enums.SideType        `json:"side"`
-		StopPrice               string                `json:"stopPrice,omitempty"`
-		WorkingTime             int64                 `json:"workingTime"`
-		IcebergQty              string                `json:"icebergQty,omitempty"`
-		SelfTradePreventionMode enums.StpModeType     `json:"selfTradePreventionMode"`
-	} `json:"orderReports"`
+	OrderReports []otoOrderReport `json:"orderReports"`
+}
+
+type otoOrderReport struct {
+	Symbol                  string                `json:"symbol"`
+	OrderId                 int                   `json:"orderId"`
+	OrderListId             int                   `json:"orderListId"`
+	ClientOrderId           string                `json:"clientOrderId"`
+	TransactTime            int64                 `json:"transactTime"`
+	Price                   string                `json:"price"`
+	OrigQty                 string                `json:"origQty"`
+	ExecutedQty             string                `json:"executedQty"`
+	CummulativeQuoteQty     string                `json:"cummulativeQuoteQty"`
+	Status                  enums.OrderStatusType `json:"status"`
+	TimeInForce             enums.TimeInForceType `json:"timeInForce"`
+	Type                    enums.OrderType       `json:"type"`
+	Side                    enums.SideType        `json:"side"`
+	StopPrice               string                `json:"stopPrice,omitempty"`
+	WorkingTime             int64                 `json:"workingTime"`
+	IcebergQty              string                `json:"icebergQty,omitempty"`
+	SelfTradePreventionMode enums.StpModeType     `json:"selfTradePreventionMode"`
+}
+
+func (o otoOrderReport) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.Price)
+}
+func (o otoOrderReport) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.OrigQty)
+}
+func (o otoOrderReport) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.ExecutedQty)
+}
+func (o otoOrderReport) CummulativeQuoteQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.CummulativeQuoteQty)
+}
+func (o otoOrderReport) StopPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.StopPrice)
+}
+func (o otoOrderReport) IcebergQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.IcebergQty)
 }
 
 func (o *otoRequest) SetSymbol(symbol string) *otoRequest {
@@ -235,6 +260,10 @@ func NewOTO(client *binance.Client, symbol string) OTO {
 }
 
 func (o *otoRequest) Call(ctx context.Context) (body *otoResponse, err error) {
+	err = o.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
 	req := &binance.Request{
 		Method: http.MethodPost,
 		Path:   consts.ApiTradingOrderListOTO,
@@ -269,7 +298,7 @@ func (o *otoRequest) Call(ctx context.Context) (body *otoResponse, err error) {
 		o.Debugf("queryOrderRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*otoResponse](resp)
+	return binance.ParseHttpResponse[*otoResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
@@ -289,6 +318,10 @@ func NewWsApiOTO(c *binance.Client) WsApiOTO {
 
 // Send 下新的订单 (TRADE)
 func (o *otoRequest) Send(ctx context.Context) (*WsApiOTOResponse, error) {
+	err := o.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
 	req := &binance.Request{Path: "orderList.place.oto"}
 	req.SetNeedSign(true)
 	req.SetParam("symbol", o.symbol)
@@ -6,8 +6,9 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/rules"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 // OTOCO 发送一个新的 OTOCO 订单。
@@ -52,6 +53,8 @@ type OTOCO interface {
 	SetPendingBelowTimeInForce(pendingBelowTimeInForce enums.TimeInForceType) *otocoRequest
 	SetPendingBelowStrategyId(pendingBelowStrategyId int64) *otocoRequest
 	SetPendingBelowStrategyType(pendingBelowStrategyType int64) *otocoRequest
+	SetRules(registry *rules.Registry, round bool) *otocoRequest
+	Validate(ctx context.Context) error
 	Call(ctx context.Context) (body *otocoResponse, err error)
 }
 
@@ -93,6 +96,7 @@ type otocoRequest struct {
 	pendingBelowTimeInForce   enums.TimeInForceType
 	pendingBelowStrategyId    *int64
 	pendingBelowStrategyType  *int64
+	validator                 *rules.Validator
 }
 
 type otocoResponse struct {
@@ -108,24 +112,42 @@ type otocoResponse struct {
 		OrderId       int    `json:"orderId"`
 		ClientOrderId string `json:"clientOrderId"`
 	} `json:"orders"`
-	OrderReports []struct {
-		Symbol                  string                `json:"symbol"`
-		OrderId                 int                   `json:"orderId"`
-		OrderListId             int                   `json:"orderListId"`
-		ClientOrderId           string                `json:"clientOrderId"`
-		TransactTime            int64                 `json:"transactTime"`
-		Price                   string                `json:"price"`
-		OrigQty                 string                `json:"origQty"`
-		ExecutedQty             string                `json:"executedQty"`
-		CummulativeQuoteQty     string                `json:"cummulativeQuoteQty"`
-		Status                  enums.OrderStatusType `json:"status"`
-		TimeInForce             enums.TimeInForceType `json:"timeInForce"`
-		Type                    enums.OrderType       `json:"type"`
-		Side                    enums.SideType        `json:"side"`
-		WorkingTime             int64                 `json:"workingTime"`
-		SelfTradePreventionMode enums.StpModeType     `json:"selfTradePreventionMode"`
-		StopPrice               string                `json:"stopPrice,omitempty"`
-	} `json:"orderReports"`
+	OrderReports []otocoOrderReport `json:"orderReports"`
+}
+
+type otocoOrderReport struct {
+	Symbol                  string                `json:"symbol"`
+	OrderId                 int                   `json:"orderId"`
+	OrderListId             int                   `json:"orderListId"`
+	ClientOrderId           string                `json:"clientOrderId"`
+	TransactTime            int64                 `json:"transactTime"`
+	Price                   string                `json:"price"`
+	OrigQty                 string                `json:"origQty"`
+	ExecutedQty             string                `json:"executedQty"`
+	CummulativeQuoteQty     string                `json:"cummulativeQuoteQty"`
+	Status                  enums.OrderStatusType `json:"status"`
+	TimeInForce             enums.TimeInForceType `json:"timeInForce"`
+	Type                    enums.OrderType       `json:"type"`
+	Side                    enums.SideType        `json:"side"`
+	WorkingTime             int64                 `json:"workingTime"`
+	SelfTradePreventionMode enums.StpModeType     `json:"selfTradePreventionMode"`
+	StopPrice               string                `json:"stopPrice,omitempty"`
+}
+
+func (o otocoOrderReport) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.Price)
+}
+func (o otocoOrderReport) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.OrigQty)
+}
+func (o otocoOrderReport) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.ExecutedQty)
+}
+func (o otocoOrderReport) CummulativeQuoteQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.CummulativeQuoteQty)
+}
+func (o otocoOrderReport) StopPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(o.StopPrice)
 }
 
 func NewOtoco(client *binance.Client, symbol string) OTOCO {
@@ -297,6 +319,10 @@ func (o *otocoRequest) SetPendingBelowStrategyType(pendingBelowStrategyType int6
 }
 
 func (o *otocoRequest) Call(ctx context.Context) (body *otocoResponse, err error) {
+	err = o.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
 	req := &binance.Request{
 		Method: http.MethodPost,
 		Path:   consts.ApiTradingOrderListOTOCO,
@@ -340,7 +366,7 @@ func (o *otocoRequest) Call(ctx context.Context) (body *otocoResponse, err error
 		o.Debugf("queryOrderRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*otocoResponse](resp)
+	return binance.ParseHttpResponse[*otocoResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
@@ -360,6 +386,10 @@ func NewWsApiOTOCO(c *binance.Client) WsApiOTOCO {
 
 // Send 下新的订单 (TRADE)
 func (o *otocoRequest) Send(ctx context.Context) (*WsApiOTOCOResponse, error) {
+	err := o.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
 	req := &binance.Request{Path: "orderList.place.otoco"}
 	req.SetNeedSign(true)
 	req.SetParam("symbol", o.symbol)
@@ -7,7 +7,7 @@ import (
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 type QueryOrder interface {
@@ -57,6 +57,31 @@ type queryOrderResponse struct {
 	TrailingTime      int64  `json:"trailingTime,omitempty"`
 }
 
+func (q queryOrderResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(q.Price)
+}
+func (q queryOrderResponse) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(q.OrigQty)
+}
+func (q queryOrderResponse) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(q.ExecutedQty)
+}
+func (q queryOrderResponse) CummulativeQuoteQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(q.CummulativeQuoteQty)
+}
+func (q queryOrderResponse) StopPriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(q.StopPrice)
+}
+func (q queryOrderResponse) IcebergQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(q.IcebergQty)
+}
+func (q queryOrderResponse) OrigQuoteOrderQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(q.OrigQuoteOrderQty)
+}
+func (q queryOrderResponse) PreventedQuantityDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(q.PreventedQuantity)
+}
+
 func NewQueryOrder(client *binance.Client, symbol string) QueryOrder {
 	return &queryOrderRequest{Client: client, symbol: symbol}
 }
@@ -93,7 +118,7 @@ func (o *queryOrderRequest) Call(ctx context.Context) (body *queryOrderResponse,
 		o.Debugf("queryOrderRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*queryOrderResponse](resp)
+	return binance.ParseHttpResponse[*queryOrderResponse](resp)
 }
 func (o *queryOrderRequest) CallOpenOrders(ctx context.Context) (body []*queryOrderResponse, err error) {
 	req := &binance.Request{
@@ -107,7 +132,7 @@ func (o *queryOrderRequest) CallOpenOrders(ctx context.Context) (body []*queryOr
 		o.Debugf("queryOrderRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[[]*queryOrderResponse](resp)
+	return binance.ParseHttpResponse[[]*queryOrderResponse](resp)
 }
 
 // ****************************** Websocket Api *******************************
@@ -0,0 +1,184 @@
+package trading
+
+import (
+	"context"
+
+	"github.com/sleep-go/coin-go/binance/rules"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// ruleLeg 一个子订单，fields 为请求参数与 rules.Order 字段的对应关系
+type ruleLeg struct {
+	order  *rules.Order
+	fields []ruleField
+}
+type ruleField struct {
+	name  string
+	param **string
+	value *decimal.Decimal
+}
+
+func newRuleLeg(side enums.SideType, _type enums.OrderType) *ruleLeg {
+	return &ruleLeg{order: &rules.Order{Side: string(side), Type: string(_type)}}
+}
+
+func (l *ruleLeg) field(name string, param **string, value *decimal.Decimal) *ruleLeg {
+	l.fields = append(l.fields, ruleField{name: name, param: param, value: value})
+	return l
+}
+
+func (l *ruleLeg) trailingDelta(trailingDelta *int64) *ruleLeg {
+	if trailingDelta != nil {
+		l.order.TrailingDelta = *trailingDelta
+	}
+	return l
+}
+
+func (l *ruleLeg) trailingDeltaString(name string, trailingDelta *string) (*ruleLeg, error) {
+	d, err := rules.ParseDecimal(name, trailingDelta)
+	if err != nil {
+		return nil, err
+	}
+	l.order.TrailingDelta = d.IntPart()
+	return l, nil
+}
+
+// checkRules 发送前按交易对规则检查，开启舍入时把舍入后的价格和数量写回请求
+func checkRules(ctx context.Context, v *rules.Validator, symbol string, legs ...*ruleLeg) error {
+	if v == nil || v.Registry == nil {
+		return nil
+	}
+	orders := make([]*rules.Order, len(legs))
+	for i, l := range legs {
+		for _, f := range l.fields {
+			d, err := rules.ParseDecimal(f.name, *f.param)
+			if err != nil {
+				return err
+			}
+			*f.value = d
+		}
+		orders[i] = l.order
+	}
+	err := v.Check(ctx, symbol, orders...)
+	if v.Round {
+		for _, l := range legs {
+			for _, f := range l.fields {
+				rules.FormatDecimal(*f.value, f.param)
+			}
+		}
+	}
+	return err
+}
+
+// SetRules 下单前按 exchangeInfo 的过滤器检查订单，round 为 true 时先把价格舍入到 tickSize、数量舍入到 stepSize
+func (c *createOrderRequest) SetRules(registry *rules.Registry, round bool) *createOrderRequest {
+	c.validator = &rules.Validator{Registry: registry, Round: round}
+	return c
+}
+
+// Validate 按交易对规则检查订单，未通过时返回 *rules.ValidationError
+func (c *createOrderRequest) Validate(ctx context.Context) error {
+	l := newRuleLeg(c.side, c._type).trailingDelta(c.trailingDelta)
+	o := l.order
+	l.field("price", &c.price, &o.Price).
+		field("stopPrice", &c.stopPrice, &o.StopPrice).
+		field("quantity", &c.quantity, &o.Quantity).
+		field("quoteOrderQty", &c.quoteOrderQty, &o.QuoteOrderQty).
+		field("icebergQty", &c.icebergQty, &o.IcebergQty)
+	return checkRules(ctx, c.validator, c.symbol, l)
+}
+
+// SetRules 下单前按 exchangeInfo 的过滤器检查订单，round 为 true 时先把价格舍入到 tickSize、数量舍入到 stepSize
+func (o *ocoRequest) SetRules(registry *rules.Registry, round bool) *ocoRequest {
+	o.validator = &rules.Validator{Registry: registry, Round: round}
+	return o
+}
+
+// Validate 按交易对规则检查上方和下方订单，未通过时返回 *rules.ValidationError
+func (o *ocoRequest) Validate(ctx context.Context) error {
+	above := newRuleLeg(o.side, o.aboveType).trailingDelta(o.aboveTrailingDelta)
+	above.field("abovePrice", &o.abovePrice, &above.order.Price).
+		field("aboveStopPrice", &o.aboveStopPrice, &above.order.StopPrice).
+		field("quantity", &o.quantity, &above.order.Quantity)
+	if o.aboveIcebergQty != nil {
+		above.order.IcebergQty = decimal.NewFromInt(*o.aboveIcebergQty)
+	}
+	below := newRuleLeg(o.side, o.belowType).trailingDelta(o.belowTrailingDelta)
+	below.field("belowPrice", &o.belowPrice, &below.order.Price).
+		field("belowStopPrice", &o.belowStopPrice, &below.order.StopPrice).
+		field("quantity", &o.quantity, &below.order.Quantity)
+	if o.belowIcebergQty != nil {
+		below.order.IcebergQty = decimal.NewFromInt(*o.belowIcebergQty)
+	}
+	return checkRules(ctx, o.validator, o.symbol, above, below)
+}
+
+// SetRules 下单前按 exchangeInfo 的过滤器检查订单，round 为 true 时先把价格舍入到 tickSize、数量舍入到 stepSize
+func (o *otoRequest) SetRules(registry *rules.Registry, round bool) *otoRequest {
+	o.validator = &rules.Validator{Registry: registry, Round: round}
+	return o
+}
+
+// Validate 按交易对规则检查生效订单和待处理订单，未通过时返回 *rules.ValidationError
+func (o *otoRequest) Validate(ctx context.Context) error {
+	working := newRuleLeg(o.workingSide, o.workingType)
+	working.field("workingPrice", &o.workingPrice, &working.order.Price).
+		field("workingQuantity", &o.workingQuantity, &working.order.Quantity).
+		field("workingIcebergQty", &o.workingIcebergQty, &working.order.IcebergQty)
+	pending, err := newRuleLeg(o.pendingSide, o.pendingType).trailingDeltaString("pendingTrailingDelta", o.pendingTrailingDelta)
+	if err != nil {
+		return err
+	}
+	pending.field("pendingPrice", &o.pendingPrice, &pending.order.Price).
+		field("pendingStopPrice", &o.pendingStopPrice, &pending.order.StopPrice).
+		field("pendingQuantity", &o.pendingQuantity, &pending.order.Quantity).
+		field("pendingIcebergQty", &o.pendingIcebergQty, &pending.order.IcebergQty)
+	return checkRules(ctx, o.validator, o.symbol, working, pending)
+}
+
+// SetRules 下单前按 exchangeInfo 的过滤器检查订单，round 为 true 时先把价格舍入到 tickSize、数量舍入到 stepSize
+func (o *otocoRequest) SetRules(registry *rules.Registry, round bool) *otocoRequest {
+	o.validator = &rules.Validator{Registry: registry, Round: round}
+	return o
+}
+
+// Validate 按交易对规则检查生效订单和两个待处理订单，未通过时返回 *rules.ValidationError
+func (o *otocoRequest) Validate(ctx context.Context) error {
+	working := newRuleLeg(o.workingSide, o.workingType)
+	working.field("workingPrice", &o.workingPrice, &working.order.Price).
+		field("workingQuantity", &o.workingQuantity, &working.order.Quantity).
+		field("workingIcebergQty", &o.workingIcebergQty, &working.order.IcebergQty)
+	above, err := newRuleLeg(o.pendingSide, o.pendingAboveType).trailingDeltaString("pendingAboveTrailingDelta", o.pendingAboveTrailingDelta)
+	if err != nil {
+		return err
+	}
+	above.field("pendingAbovePrice", &o.pendingAbovePrice, &above.order.Price).
+		field("pendingAboveStopPrice", &o.pendingAboveStopPrice, &above.order.StopPrice).
+		field("pendingQuantity", &o.pendingQuantity, &above.order.Quantity).
+		field("pendingAboveIcebergQty", &o.pendingAboveIcebergQty, &above.order.IcebergQty)
+	below, err := newRuleLeg(o.pendingSide, o.pendingBelowType).trailingDeltaString("pendingBelowTrailingDelta", o.pendingBelowTrailingDelta)
+	if err != nil {
+		return err
+	}
+	below.field("pendingBelowPrice", &o.pendingBelowPrice, &below.order.Price).
+		field("pendingBelowStopPrice", &o.pendingBelowStopPrice, &below.order.StopPrice).
+		field("pendingQuantity", &o.pendingQuantity, &below.order.Quantity).
+		field("pendingBelowIcebergQty", &o.pendingBelowIcebergQty, &below.order.IcebergQty)
+	return checkRules(ctx, o.validator, o.symbol, working, above, below)
+}
+
+// SetRules 下单前按 exchangeInfo 的过滤器检查订单，round 为 true 时先把价格舍入到 tickSize、数量舍入到 stepSize
+func (s *sorRequest) SetRules(registry *rules.Registry, round bool) *sorRequest {
+	s.validator = &rules.Validator{Registry: registry, Round: round}
+	return s
+}
+
+// Validate 按交易对规则检查订单，未通过时返回 *rules.ValidationError
+func (s *sorRequest) Validate(ctx context.Context) error {
+	l := newRuleLeg(s.side, s._type)
+	l.field("price", &s.price, &l.order.Price).
+		field("quantity", &s.quantity, &l.order.Quantity).
+		field("icebergQty", &s.icebergQty, &l.order.IcebergQty)
+	return checkRules(ctx, s.validator, s.symbol, l)
+}
@@ -6,8 +6,9 @@ import (
 
 	"github.com/sleep-go/coin-go/binance"
 	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/rules"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
-	"github.com/sleep-go/coin-go/pkg/utils"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 )
 
 // SOR 下 SOR 订单 (TRADE)
@@ -26,6 +27,8 @@ type SOR interface {
 	SetIcebergQty(icebergQty string) *sorRequest
 	SetNewOrderRespType(newOrderRespType enums.NewOrderRespType) *sorRequest
 	SetSelfTradePreventionMode(selfTradePreventionMode enums.StpModeType) *sorRequest
+	SetRules(registry *rules.Registry, round bool) *sorRequest
+	Validate(ctx context.Context) error
 	Call(ctx context.Context) (body *sorResponse, err error)
 	CallTest(ctx context.Context, computeCommissionRates bool) (body *sorTestResponse, err error)
 }
@@ -45,35 +48,61 @@ type sorRequest struct {
 	icebergQty              *string
 	newOrderRespType        enums.NewOrderRespType //指定响应类型 ACK, RESULT 或 FULL; 默认为 FULL。
 	selfTradePreventionMode enums.StpModeType
+	validator               *rules.Validator
 }
 
 type sorResponse struct {
-	Symbol              string                `json:"symbol"`
-	OrderId             int                   `json:"orderId"`
-	OrderListId         int                   `json:"orderListId"`
-	ClientOrderId       string                `json:"clientOrderId"`
-	TransactTime        int64                 `json:"transactTime"`
-	Price               string                `json:"price"`
-	OrigQty             string                `json:"origQty"`
-	ExecutedQty         string                `json:"executedQty"`
-	CummulativeQuoteQty string                `json:"cummulativeQuoteQty"`
-	Status              enums.OrderStatusType `json:"status"`
-	TimeInForce         enums.TimeInForceType `json:"timeInForce"`
-	Type                enums.OrderType       `json:"type"`
-	Side                enums.SideType        `json:"side"`
-	WorkingTime         int64                 `json:"workingTime"`
-	Fills               []struct {
-		MatchType       string `json:"matchType"`
-		Price           string `json:"price"`
-		Qty             string `json:"qty"`
-		Commission      string `json:"commission"`
-		CommissionAsset string `json:"commissionAsset"`
-		TradeId         int    `json:"tradeId"`
-		AllocId         int    `json:"allocId"`
-	} `json:"fills"`
-	WorkingFloor            string            `json:"workingFloor"`
-	SelfTradePreventionMode enums.StpModeType `json:"selfTradePreventionMode"`
-	UsedSor                 bool              `json:"usedSor"`
+	Symbol                  string                `json:"symbol"`
+	OrderId                 int                   `json:"orderId"`
+	OrderListId             int                   `json:"orderListId"`
+	ClientOrderId           string                `json:"clientOrderId"`
+	TransactTime            int64                 `json:"transactTime"`
+	Price                   string                `json:"price"`
+	OrigQty                 string                `json:"origQty"`
+	ExecutedQty             string                `json:"executedQty"`
+	CummulativeQuoteQty     string                `json:"cummulativeQuoteQty"`
+	Status                  enums.OrderStatusType `json:"status"`
+	TimeInForce             enums.TimeInForceType `json:"timeInForce"`
+	Type                    enums.OrderType       `json:"type"`
+	Side                    enums.SideType        `json:"side"`
+	WorkingTime             int64                 `json:"workingTime"`
+	Fills                   []sorFill             `json:"fills"`
+	WorkingFloor            string                `json:"workingFloor"`
+	SelfTradePreventionMode enums.StpModeType     `json:"selfTradePreventionMode"`
+	UsedSor                 bool                  `json:"usedSor"`
+}
+
+func (s sorResponse) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(s.Price)
+}
+func (s sorResponse) OrigQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(s.OrigQty)
+}
+func (s sorResponse) ExecutedQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(s.ExecutedQty)
+}
+func (s sorResponse) CummulativeQuoteQtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(s.CummulativeQuoteQty)
+}
+
+type sorFill struct {
+	MatchType       string `json:"matchType"`
+	Price           string `json:"price"`
+	Qty             string `json:"qty"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	TradeId         int    `json:"tradeId"`
+	AllocId         int    `json:"allocId"`
+}
+
+func (s sorFill) PriceDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(s.Price)
+}
+func (s sorFill) QtyDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(s.Qty)
+}
+func (s sorFill) CommissionDecimal() decimal.Decimal {
+	return decimal.NewFromStringOrZero(s.Commission)
 }
 
 func (s *sorRequest) SetSymbol(symbol string) *sorRequest {
@@ -140,6 +169,10 @@ func NewSor(client *binance.Client, symbol string) SOR {
 	return &sorRequest{Client: client, symbol: symbol}
 }
 func (s *sorRequest) Call(ctx context.Context) (body *sorResponse, err error) {
+	err = s.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
 	req := &binance.Request{
 		Method: http.MethodPost,
 		Path:   consts.ApiTradingSorOrder,
@@ -162,12 +195,16 @@ func (s *sorRequest) Call(ctx context.Context) (body *sorResponse, err error) {
 		s.Debugf("sorRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*sorResponse](resp)
+	return binance.ParseHttpResponse[*sorResponse](resp)
 }
 
 // CallTest 测试 SOR 下单接口 (TRADE)
 // 用于测试使用智能订单路由 (SOR) 的订单请求，但不会提交到撮合引擎
 func (s *sorRequest) CallTest(ctx context.Context, computeCommissionRates bool) (body *sorTestResponse, err error) {
+	err = s.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
 	req := &binance.Request{
 		Method: http.MethodPost,
 		Path:   consts.ApiTradingSorOrderTest,
@@ -191,7 +228,7 @@ func (s *sorRequest) CallTest(ctx context.Context, computeCommissionRates bool)
 		s.Debugf("sorRequest response err:%v", err)
 		return nil, err
 	}
-	return utils.ParseHttpResponse[*sorTestResponse](resp)
+	return binance.ParseHttpResponse[*sorTestResponse](resp)
 }
 
 type sorTestResponse struct {
@@ -235,6 +272,10 @@ func NewWsApiSOR(c *binance.Client) WsApiSOR {
 // 下使用智能订单路由 (SOR) 的新订单。
 // 注意: sor.order.place 只支持 限价 和 市场 单， 并不支持 quoteOrderQty。
 func (s *sorRequest) Send(ctx context.Context) (*WsApiSORResponse, error) {
+	err := s.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
 	req := &binance.Request{Path: "sor.order.place"}
 	req.SetNeedSign(true)
 	req.SetParam("symbol", s.symbol)
@@ -254,6 +295,10 @@ func (s *sorRequest) Send(ctx context.Context) (*WsApiSORResponse, error) {
 
 // SendTest 测试 SOR 下单接口 (TRADE)
 func (s *sorRequest) SendTest(ctx context.Context, computeCommissionRates bool) (*WsApiSORTestResponse, error) {
+	err := s.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
 	req := &binance.Request{Path: "sor.order.test"}
 	req.SetNeedSign(true)
 	req.SetParam("symbol", s.symbol)
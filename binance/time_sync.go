@@ -0,0 +1,143 @@
+package binance
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TimeSync 定期查询服务器时间，估算本地时钟与服务器的偏差并设置到 Client.TimeOffset
+// 签名请求的 timestamp 使用校正后的时间，遇到 -1021 时重新同步并重试一次
+type TimeSync struct {
+	Interval time.Duration // Run 的同步间隔，默认 10 分钟
+	Samples  int           // 每次同步的采样次数，取往返时间最短的一次，默认 3
+
+	client *Client
+	query  func(ctx context.Context) (int64, error)
+	mu     sync.Mutex
+	rtt    time.Duration
+	synced time.Time
+}
+
+// NewTimeSync 创建时间同步并设置为 c 的 TimeSync，query 返回服务器时间(毫秒)
+// 现货和合约分别使用 general.NewTimeSync
+func NewTimeSync(c *Client, query func(ctx context.Context) (int64, error)) *TimeSync {
+	s := &TimeSync{
+		Interval: 10 * time.Minute,
+		Samples:  3,
+		client:   c,
+		query:    query,
+	}
+	c.TimeSync = s
+	return s
+}
+
+// Sync 立即同步一次
+// 偏差 = 服务器时间 - (发送时间 + 往返时间/2)
+func (s *TimeSync) Sync(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sync(ctx)
+}
+
+func (s *TimeSync) sync(ctx context.Context) error {
+	samples := max(s.Samples, 1)
+	var offset int64
+	rtt := time.Duration(-1)
+	for i := 0; i < samples; i++ {
+		start := time.Now()
+		serverTime, err := s.query(ctx)
+		if err != nil {
+			if rtt >= 0 {
+				break
+			}
+			return err
+		}
+		d := time.Since(start)
+		if rtt < 0 || d < rtt {
+			rtt = d
+			offset = serverTime - start.Add(d/2).UnixMilli()
+		}
+	}
+	s.client.SetTimeOffset(offset)
+	s.rtt = rtt
+	s.synced = time.Now()
+	s.client.Debugf("time sync: offset %dms rtt %s", offset, rtt)
+	return nil
+}
+
+// resync 请求返回 -1021 后重新同步，sent 之后已经同步过的不再重复
+func (s *TimeSync) resync(ctx context.Context, sent time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.synced.After(sent) {
+		return nil
+	}
+	return s.sync(ctx)
+}
+
+// Run 立即同步一次，之后每隔 Interval 同步，直到 ctx 结束
+func (s *TimeSync) Run(ctx context.Context) error {
+	interval := s.Interval
+	if interval <= 0 {
+		interval = 10 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		err := s.Sync(ctx)
+		if err != nil && ctx.Err() == nil {
+			s.client.Println("time sync:", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Offset 当前的时钟偏差
+func (s *TimeSync) Offset() time.Duration {
+	return time.Duration(s.client.timeOffset()) * time.Millisecond
+}
+
+// RTT 最近一次同步的往返时间
+func (s *TimeSync) RTT() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rtt
+}
+
+// SetTimeOffset 设置服务器时间与本地时间的差值(毫秒)
+func (c *Client) SetTimeOffset(offset int64) {
+	atomic.StoreInt64(&c.TimeOffset, offset)
+}
+
+func (c *Client) timeOffset() int64 {
+	return atomic.LoadInt64(&c.TimeOffset)
+}
+
+// timestamp 按时钟偏差校正后的当前时间(毫秒)
+func (c *Client) timestamp() int64 {
+	return time.Now().UnixMilli() + c.timeOffset()
+}
+
+type recvWindowKey struct{}
+
+// WithRecvWindow 为 ctx 中发出的签名请求设置 recvWindow(毫秒)，优先于 Client.RecvWindow
+func WithRecvWindow(ctx context.Context, recvWindow int64) context.Context {
+	return context.WithValue(ctx, recvWindowKey{}, recvWindow)
+}
+
+// recvWindow 优先级: Request.SetRecvWindow > WithRecvWindow > Client.RecvWindow
+func (c *Client) recvWindow(ctx context.Context, r *Request) int64 {
+	if r.recvWindow > 0 {
+		return r.recvWindow
+	}
+	if v, ok := ctx.Value(recvWindowKey{}).(int64); ok && v > 0 {
+		return v
+	}
+	return c.RecvWindow
+}
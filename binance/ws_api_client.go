@@ -3,27 +3,25 @@ package binance
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"time"
 
-	"github.com/sleep-go/coin-go/pkg/errors"
-
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/tidwall/gjson"
 )
 
 type WsApi[T any] interface {
 	Send(ctx context.Context) (T, error)
 }
 type WsApiResponse struct {
-	Id         string         `json:"id"`
-	Status     int            `json:"status"`
-	Error      *errors.Status `json:"error"`
-	RateLimits []RateLimits   `json:"rateLimits"`
+	Id         string       `json:"id"`
+	Status     int          `json:"status"`
+	Error      *APIError    `json:"error"` // StatusCode 只在 Send 返回的 error 中有值
+	RateLimits []RateLimits `json:"rateLimits"`
 }
 
 func NewWsApiHMACClient(apiKey, secretKey string, baseURL ...string) *Client {
@@ -99,7 +97,7 @@ func NewWsApiED25519Client(apiKey, privateKeyPath string, baseURL ...string) *Cl
 	if err != nil {
 		panic(err)
 	}
-	cli := &Client{
+	return &Client{
 		BaseURL:    url,
 		APIKey:     apiKey,
 		PrivateKey: privateKey,
@@ -110,57 +108,102 @@ func NewWsApiED25519Client(apiKey, privateKeyPath string, baseURL ...string) *Cl
 			EnableCompression: false,
 		},
 	}
-	cli.connect()
-	return cli
 }
-func (c *Client) connect() error {
-	if c.dialer == nil {
-		c.dialer = websocket.DefaultDialer
+
+// NewFuturesWsApiHMACClient U本位合约 WS API 客户端，默认连接 consts.WS_FAPI
+func NewFuturesWsApiHMACClient(apiKey, secretKey string, baseURL ...string) *Client {
+	if len(baseURL) == 0 {
+		baseURL = []string{consts.WS_FAPI}
+	}
+	return NewWsApiHMACClient(apiKey, secretKey, baseURL...)
+}
+
+// NewFuturesWsApiRSAClient U本位合约 WS API 客户端，默认连接 consts.WS_FAPI
+func NewFuturesWsApiRSAClient(apiKey, privateKeyPath string, baseURL ...string) *Client {
+	if len(baseURL) == 0 {
+		baseURL = []string{consts.WS_FAPI}
 	}
-	conn, _, err := c.dialer.Dial(c.BaseURL, nil)
+	return NewWsApiRSAClient(apiKey, privateKeyPath, baseURL...)
+}
+
+// NewFuturesWsApiED25519Client U本位合约 WS API 客户端，默认连接 consts.WS_FAPI
+func NewFuturesWsApiED25519Client(apiKey, privateKeyPath string, baseURL ...string) *Client {
+	if len(baseURL) == 0 {
+		baseURL = []string{consts.WS_FAPI}
+	}
+	return NewWsApiED25519Client(apiKey, privateKeyPath, baseURL...)
+}
+
+// wsApiTimeout 请求等待响应的超时时间
+func (c *Client) wsApiTimeout() time.Duration {
+	if c.WsApiTimeout > 0 {
+		return c.WsApiTimeout
+	}
+	return DefaultWsApiTimeout
+}
+func (c *Client) sendWsApiMsg(ctx context.Context, r *Request) (res []byte, err error) {
+	sent := time.Now()
+	res, err = c.roundTripWsApi(ctx, r)
+	if err != nil || !r.needSign || c.TimeSync == nil || gjson.GetBytes(res, "error.code").Int() != int64(ErrTimestampOutsideRecvWindow.Code) {
+		return res, err
+	}
+	// -1021 本地时钟与服务器偏差过大，重新同步后重试一次
+	c.Debugf("timestamp outside of recvWindow, resync and retry")
+	err = c.TimeSync.resync(ctx, sent)
 	if err != nil {
-		return err
-	}
-	conn.SetReadLimit(655350)
-	c.conn = conn
-	c.ReqResponseMap = make(map[string]chan []byte)
-	go func() {
-		for {
-			_, message, err := c.conn.ReadMessage()
-			if err != nil {
-				log.Println("Error reading:", err)
-				return
-			}
-			var response WsApiResponse
-			err = json.Unmarshal(message, &response)
-			if err != nil {
-				log.Println("Error unmarshaling:", err)
-				return
-			}
-			// Send the message to the corresponding request
-			if channel, ok := c.ReqResponseMap[response.Id]; ok {
-				channel <- message
-			}
+		c.Debugf("time sync err:%v", err)
+		return res, nil
+	}
+	return c.roundTripWsApi(ctx, r)
+}
+func (c *Client) roundTripWsApi(ctx context.Context, r *Request) (res []byte, err error) {
+	if c.RateLimiter != nil {
+		err = c.RateLimiter.Wait(ctx, r)
+		if err != nil {
+			return nil, err
 		}
-	}()
-	return nil
+	}
+	ctx, cancel := context.WithTimeoutCause(ctx, c.wsApiTimeout(), ErrWsApiTimeout)
+	defer cancel()
+	t := c.wsApi()
+	conn, err := t.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := c.wsApiMsg(ctx, r, t.isLoggedOn())
+	if err != nil {
+		return nil, err
+	}
+	res, err = conn.roundTrip(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	if wsApiError(res) == nil {
+		t.session(r.Path)
+	}
+	return res, nil
 }
-func (c *Client) sendWsApiMsg(ctx context.Context, r *Request) (res []byte, err error) {
-	//获取 query url
-	queryString := r.query.Encode()
-	r.SetParam("apiKey", c.APIKey)
+
+// wsApiMsg 生成请求，loggedOn 为 true 时使用 session.logon 的会话，不带 apiKey 和 signature
+func (c *Client) wsApiMsg(ctx context.Context, r *Request, loggedOn bool) (*WsReqMsg, error) {
+	if !loggedOn {
+		r.SetParam("apiKey", c.APIKey)
+	}
 	if r.needSign {
-		r.SetOptionalParam("recvWindow", c.TimeOffset)
-		r.SetParam("timestamp", time.Now().UnixMilli())
+		// 重试时去掉上一次的签名
+		r.query.Del("signature")
+		r.SetOptionalParam("recvWindow", c.recvWindow(ctx, r))
+		r.SetParam("timestamp", c.timestamp())
 		//获取 query url
-		queryString = r.query.Encode()
+		raw := r.query.Encode()
 		//设置签名参数
-		raw := fmt.Sprintf("%s", queryString)
-		if c.SecretKey != "" {
+		switch {
+		case loggedOn:
+		case c.SecretKey != "":
 			r.SetParam("signature", signPayload(raw, c.SecretKey))
-		} else if c.PrivateKey != nil {
+		case c.PrivateKey != nil:
 			r.SetParam("signature", signPayload(raw, c.PrivateKey))
-		} else {
+		default:
 			c.Println("signature is empty")
 		}
 	}
@@ -178,19 +221,17 @@ func (c *Client) sendWsApiMsg(ctx context.Context, r *Request) (res []byte, err
 		return nil, err
 	}
 	c.Debugf("%s", marshal)
-	err = c.conn.WriteJSON(msg)
-	if err != nil {
-		return nil, err
-	}
-	defer delete(c.ReqResponseMap, msg.Id)
-	messageCh := make(chan []byte)
-	c.ReqResponseMap[msg.Id] = messageCh
-	select {
-	case response := <-messageCh:
-		return response, nil
-	case <-ctx.Done():
-		return nil, ctx.Err()
+	return msg, nil
+}
+
+// wsApiError 响应中的错误，没有错误时返回 nil
+func wsApiError(msg []byte) *APIError {
+	var head WsApiResponse
+	if json.Unmarshal(msg, &head) == nil && head.Error != nil {
+		head.Error.StatusCode = head.Status
+		return head.Error
 	}
+	return nil
 }
 func WsApiHandler[T any](ctx context.Context, c *Client, r *Request) (res T, err error) {
 	msg, err := c.sendWsApiMsg(ctx, r)
@@ -201,5 +242,9 @@ func WsApiHandler[T any](ctx context.Context, c *Client, r *Request) (res T, err
 	if err != nil {
 		return res, err
 	}
+	// 出错时同时返回响应和 *APIError
+	if e := wsApiError(msg); e != nil {
+		return res, e
+	}
 	return res, nil
 }
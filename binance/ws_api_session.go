@@ -0,0 +1,64 @@
+package binance
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+)
+
+// ErrSessionKeyType session.logon 只支持 Ed25519 API Key
+var ErrSessionKeyType = errors.New("ws api: session.logon requires an Ed25519 key")
+
+type WsApiSessionResponse struct {
+	WsApiResponse
+	Result *WsApiSessionStatus `json:"result"`
+}
+
+// WsApiSessionStatus 当前 WS API 连接的登录状态
+type WsApiSessionStatus struct {
+	ApiKey           *string `json:"apiKey"`           // 登录使用的 API Key，未登录时为空
+	AuthorizedSince  *int64  `json:"authorizedSince"`  // 登录时间，未登录时为空
+	ConnectedSince   int64   `json:"connectedSince"`   // 连接建立时间
+	ReturnRateLimits bool    `json:"returnRateLimits"` // 响应中是否返回 rateLimits
+	ServerTime       int64   `json:"serverTime"`
+	UserDataStream   bool    `json:"userDataStream"` // 是否已订阅用户数据推送
+}
+
+// SessionLogon 登录 WS API 连接 (SIGNED)
+// 使用 Ed25519 私钥签名，登录后同一连接上的请求不需要 apiKey 和 signature，签名接口仍然带 timestamp。
+// 连接断开重连后自动重新登录。
+func (c *Client) SessionLogon(ctx context.Context) (*WsApiSessionResponse, error) {
+	if _, ok := c.PrivateKey.(ed25519.PrivateKey); !ok || c.SecretKey != "" {
+		return nil, ErrSessionKeyType
+	}
+	req := &Request{Path: "session.logon"}
+	req.SetNeedSign(true)
+	return WsApiHandler[*WsApiSessionResponse](ctx, c, req)
+}
+
+// SessionStatus 查询 WS API 连接的登录状态
+func (c *Client) SessionStatus(ctx context.Context) (*WsApiSessionResponse, error) {
+	req := &Request{Path: "session.status"}
+	return WsApiHandler[*WsApiSessionResponse](ctx, c, req)
+}
+
+// SessionLogout 登出 WS API 连接，之后的请求重新使用 apiKey 和签名
+// 已订阅的用户数据推送会停止
+func (c *Client) SessionLogout(ctx context.Context) (*WsApiSessionResponse, error) {
+	req := &Request{Path: "session.logout"}
+	return WsApiHandler[*WsApiSessionResponse](ctx, c, req)
+}
+
+// SetWsApiEventHandler 设置 WS API 连接上的用户数据推送回调，msg 为推送中的 event
+// 由各市场的 userDataStream.subscribe 调用
+func (c *Client) SetWsApiEventHandler(handler func(messageType int, msg []byte)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.wsApiEvent = handler
+}
+
+func (c *Client) getWsApiEvent() messageHandler {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.wsApiEvent
+}
@@ -0,0 +1,335 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/tidwall/gjson"
+)
+
+var (
+	// ErrWsApiDisconnected 请求发出后连接断开，没有收到响应；下单等请求可能已经执行，需要查询确认
+	ErrWsApiDisconnected = errors.New("ws api: connection lost before response")
+	// ErrWsApiTimeout 在 Client.WsApiTimeout 内没有收到响应
+	ErrWsApiTimeout = errors.New("ws api: request timeout")
+	// ErrWsApiClosed 客户端已经关闭
+	ErrWsApiClosed = errors.New("ws api: client closed")
+)
+
+// DefaultWsApiTimeout WS API 请求等待响应的默认超时时间
+const DefaultWsApiTimeout = 10 * time.Second
+
+// wsApiTransport WS API 连接管理
+//
+// 第一次请求时建立连接，多个 goroutine 可以同时发送请求，写入串行化，响应按 id 分发。
+// 连接断开时等待中的请求返回 ErrWsApiDisconnected，之后在后台按指数退避重连，
+// 重连后恢复 session.logon 登录和 userDataStream.subscribe 订阅。
+type wsApiTransport struct {
+	c      *Client
+	ctx    context.Context // Close 时取消，结束后台重连
+	cancel context.CancelFunc
+
+	mu         sync.Mutex
+	conn       *wsApiConn
+	dialing    chan struct{} // 正在连接时非空，每次连接尝试结束后关闭
+	dialErr    error         // 最近一次连接失败的原因
+	closed     bool
+	loggedOn   bool // 已通过 session.logon 登录
+	subscribed bool // 已通过 userDataStream.subscribe 订阅用户数据
+}
+
+// wsApiConn 一个 WS API 连接和等待响应的请求
+type wsApiConn struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+	mu      sync.Mutex
+	pending map[string]chan []byte
+	done    chan struct{} // 连接断开时关闭
+	err     error
+}
+
+// wsApi 返回客户端的 WS API 连接管理，不存在时创建
+func (c *Client) wsApi() *wsApiTransport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.wsApiTransport == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		c.wsApiTransport = &wsApiTransport{c: c, ctx: ctx, cancel: cancel}
+	}
+	return c.wsApiTransport
+}
+
+// get 返回可用的连接，没有连接时发起连接并等待本次连接尝试结束
+func (t *wsApiTransport) get(ctx context.Context) (*wsApiConn, error) {
+	for {
+		t.mu.Lock()
+		if t.closed {
+			t.mu.Unlock()
+			return nil, ErrWsApiClosed
+		}
+		if t.conn != nil {
+			conn := t.conn
+			t.mu.Unlock()
+			return conn, nil
+		}
+		dialing := t.dialing
+		if dialing == nil {
+			dialing = make(chan struct{})
+			t.dialing = dialing
+			go t.dial(dialing, false)
+		}
+		t.mu.Unlock()
+		select {
+		case <-dialing:
+		case <-ctx.Done():
+			return nil, context.Cause(ctx)
+		}
+		t.mu.Lock()
+		conn, err := t.conn, t.dialErr
+		t.mu.Unlock()
+		if conn == nil && err != nil {
+			return nil, err
+		}
+	}
+}
+
+// dial 建立连接，retry 为 true 时失败后按指数退避重试直到成功或客户端关闭
+func (t *wsApiTransport) dial(dialing chan struct{}, retry bool) {
+	for backoff := time.Second; ; backoff = min(backoff*2, time.Minute) {
+		conn, err := t.open()
+		t.mu.Lock()
+		if conn != nil {
+			// 恢复会话期间连接已经断开
+			select {
+			case <-conn.done:
+				conn, err = nil, conn.err
+			default:
+			}
+		}
+		if t.closed {
+			t.mu.Unlock()
+			if conn != nil {
+				conn.close(ErrWsApiClosed)
+			}
+			close(dialing)
+			return
+		}
+		t.conn, t.dialErr = conn, err
+		if err == nil || !retry {
+			t.dialing = nil
+			t.mu.Unlock()
+			close(dialing)
+			return
+		}
+		// 唤醒等待的请求，返回本次失败的原因
+		close(dialing)
+		dialing = make(chan struct{})
+		t.dialing = dialing
+		t.mu.Unlock()
+		t.c.Debugf("ws api reconnect err:%v, retry in %s", err, backoff)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-t.ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// open 连接并恢复登录和用户数据订阅
+func (t *wsApiTransport) open() (*wsApiConn, error) {
+	ctx, cancel := context.WithTimeout(t.ctx, t.c.wsApiTimeout())
+	defer cancel()
+	c := t.c
+	if c.dialer == nil {
+		c.dialer = websocket.DefaultDialer
+	}
+	ws, _, err := c.dialer.DialContext(ctx, c.BaseURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	ws.SetReadLimit(655350)
+	conn := &wsApiConn{conn: ws, pending: make(map[string]chan []byte), done: make(chan struct{})}
+	go t.read(conn)
+	t.mu.Lock()
+	loggedOn, subscribed := t.loggedOn, t.subscribed
+	t.mu.Unlock()
+	if loggedOn {
+		req := &Request{Path: "session.logon"}
+		req.SetNeedSign(true)
+		err = t.restore(ctx, conn, req)
+		if err != nil {
+			// 登录失败时之后的请求重新使用 apiKey 和签名
+			c.Debugf("ws api session.logon err:%v", err)
+			t.mu.Lock()
+			t.loggedOn, t.subscribed = false, false
+			t.mu.Unlock()
+			subscribed = false
+		}
+	}
+	if subscribed {
+		err = t.restore(ctx, conn, &Request{Path: "userDataStream.subscribe"})
+		if err != nil {
+			c.Debugf("ws api userDataStream.subscribe err:%v", err)
+		}
+	}
+	return conn, nil
+}
+
+// restore 在新连接上重新发送会话请求
+func (t *wsApiTransport) restore(ctx context.Context, conn *wsApiConn, r *Request) error {
+	msg, err := t.c.wsApiMsg(ctx, r, false)
+	if err != nil {
+		return err
+	}
+	res, err := conn.roundTrip(ctx, msg)
+	if err != nil {
+		return err
+	}
+	if e := wsApiError(res); e != nil {
+		return e
+	}
+	t.session(r.Path)
+	return nil
+}
+
+// read 读取响应和推送，连接断开后通知等待的请求并在后台重连
+func (t *wsApiTransport) read(conn *wsApiConn) {
+	c := t.c
+	for {
+		mt, message, err := conn.conn.ReadMessage()
+		if err != nil {
+			conn.close(fmt.Errorf("%w: %w", ErrWsApiDisconnected, err))
+			t.disconnected(conn)
+			return
+		}
+		c.Debugf("read: %d %s", mt, message)
+		// userDataStream.subscribe 之后的推送没有 id，格式为 {"subscriptionId":0,"event":{...}}
+		if event := gjson.GetBytes(message, "event"); event.IsObject() {
+			if h := c.getWsApiEvent(); h != nil {
+				h(mt, []byte(event.Raw))
+			}
+			continue
+		}
+		var response WsApiResponse
+		err = json.Unmarshal(message, &response)
+		if err != nil {
+			c.Debugf("ws api unmarshal err:%v", err)
+			continue
+		}
+		if c.RateLimiter != nil {
+			c.RateLimiter.UpdateWsApi(response.Status, response.RateLimits, gjson.GetBytes(message, "error.data.retryAfter").Int())
+		}
+		conn.deliver(response.Id, message)
+	}
+}
+
+// disconnected 连接断开后在后台重连
+func (t *wsApiTransport) disconnected(conn *wsApiConn) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.conn != conn || t.closed {
+		return
+	}
+	t.conn = nil
+	if t.dialing == nil {
+		t.dialing = make(chan struct{})
+		go t.dial(t.dialing, true)
+	}
+}
+
+// session 请求成功后记录需要在重连后恢复的会话状态
+func (t *wsApiTransport) session(method string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch method {
+	case "session.logon":
+		t.loggedOn = true
+	case "session.logout":
+		t.loggedOn, t.subscribed = false, false
+	case "userDataStream.subscribe":
+		t.subscribed = true
+	case "userDataStream.unsubscribe":
+		t.subscribed = false
+	}
+}
+
+func (t *wsApiTransport) isLoggedOn() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.loggedOn
+}
+
+func (t *wsApiTransport) close() error {
+	t.mu.Lock()
+	conn := t.conn
+	t.closed, t.conn = true, nil
+	t.mu.Unlock()
+	t.cancel()
+	if conn != nil {
+		conn.close(ErrWsApiClosed)
+	}
+	return nil
+}
+
+// roundTrip 发送请求并等待同一 id 的响应
+func (conn *wsApiConn) roundTrip(ctx context.Context, msg *WsReqMsg) ([]byte, error) {
+	ch := make(chan []byte, 1)
+	conn.mu.Lock()
+	if conn.err != nil {
+		conn.mu.Unlock()
+		return nil, conn.err
+	}
+	conn.pending[msg.Id] = ch
+	conn.mu.Unlock()
+	defer func() {
+		conn.mu.Lock()
+		delete(conn.pending, msg.Id)
+		conn.mu.Unlock()
+	}()
+	conn.writeMu.Lock()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.conn.SetWriteDeadline(deadline)
+	}
+	err := conn.conn.WriteJSON(msg)
+	conn.writeMu.Unlock()
+	if err != nil {
+		// 读取循环随之退出，触发重连
+		_ = conn.conn.Close()
+		return nil, fmt.Errorf("%w: %w", ErrWsApiDisconnected, err)
+	}
+	select {
+	case res := <-ch:
+		return res, nil
+	case <-conn.done:
+		return nil, conn.err
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	}
+}
+
+func (conn *wsApiConn) deliver(id string, message []byte) {
+	conn.mu.Lock()
+	ch, ok := conn.pending[id]
+	conn.mu.Unlock()
+	if ok {
+		ch <- message
+	}
+}
+
+// close 关闭连接，等待中的请求返回 err
+func (conn *wsApiConn) close(err error) {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	if conn.err != nil {
+		return
+	}
+	conn.err = err
+	close(conn.done)
+	_ = conn.conn.Close()
+}
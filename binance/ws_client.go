@@ -1,11 +1,11 @@
 package binance
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"net/http"
-	"os"
-	"os/signal"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -49,76 +49,232 @@ func NewWsClient(isCombined, isFast bool, baseURL ...string) *Client {
 	}
 }
 
-func (c *Client) Serve(endpoint string, handler messageHandler, exception ErrorHandler) error {
-	if c.dialer == nil {
-		c.dialer = websocket.DefaultDialer
+// NewDeliveryWsClient 币本位合约行情推送客户端，默认连接 consts.WS_DSTREAM
+func NewDeliveryWsClient(isCombined, isFast bool, baseURL ...string) *Client {
+	if len(baseURL) == 0 {
+		baseURL = []string{consts.WS_DSTREAM}
+	}
+	return NewWsClient(isCombined, isFast, baseURL...)
+}
+
+// WsStream websocket 推送句柄
+// 由 NewWs*/NewStream* 返回，推送在后台运行，通过 Close 或取消 ctx 结束
+type WsStream struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	mu     sync.Mutex
+	closed bool
+	err    error
+}
+
+func newWsStream(ctx context.Context) (context.Context, *WsStream) {
+	ctx, cancel := context.WithCancel(ctx)
+	return ctx, &WsStream{cancel: cancel, done: make(chan struct{})}
+}
+
+// Close 关闭连接并等待后台读取退出
+func (s *WsStream) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	s.cancel()
+	<-s.done
+	return nil
+}
+
+// Done 推送结束时关闭
+func (s *WsStream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err 推送结束的原因，Done 关闭前返回 nil
+// 调用 Close 结束返回 nil，ctx 被取消返回 ctx.Err()，否则返回连接错误
+func (s *WsStream) Err() error {
+	select {
+	case <-s.done:
+	default:
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *WsStream) finish(ctx context.Context, err error) {
+	s.mu.Lock()
+	switch {
+	case s.closed:
+		s.err = nil
+	case ctx.Err() != nil && err == nil:
+		s.err = context.Cause(ctx)
+	default:
+		s.err = err
+	}
+	s.mu.Unlock()
+	s.cancel()
+	close(s.done)
+}
+
+// Serve 连接 endpoint 并在后台读取消息，立即返回
+// 未设置 Supervisor 时拨号失败直接返回错误，连接断开后推送结束；
+// 设置了 Supervisor 时由其负责拨号和重连。
+func (c *Client) Serve(ctx context.Context, endpoint string, handler messageHandler, exception ErrorHandler) (*WsStream, error) {
+	ctx, stream := newWsStream(ctx)
+	if c.Supervisor != nil {
+		go func() {
+			stream.finish(ctx, c.Supervisor.run(ctx, c, endpoint, handler, exception))
+		}()
+		return stream, nil
 	}
-	conn, _, err := c.dialer.Dial(endpoint, nil)
+	conn, err := c.dialContext(ctx, endpoint)
 	if err != nil {
-		panic(err)
+		stream.cancel()
+		return nil, err
 	}
-	defer conn.Close()
-	conn.SetReadLimit(655350)
-	c.conn = conn
-	done := make(chan struct{})
+	c.setConn(conn)
+	c.keepAlive(conn, DefaultPingInterval)
+	readErr := make(chan error, 1)
 	go func() {
-		defer close(done)
-		go c.keepAlive(time.Minute)
 		for {
 			mt, message, err := conn.ReadMessage()
 			if err != nil {
 				exception(mt, err)
-				log.Println("read:", err)
+				readErr <- err
 				return
 			}
+			c.Debugf("read: %d %s", mt, message)
 			handler(mt, message)
-			log.Println("read:", mt, string(message))
 		}
 	}()
-	return c.waitClose(done, exception)
+	go func() {
+		select {
+		case err := <-readErr:
+			_ = conn.Close()
+			stream.finish(ctx, err)
+		case <-ctx.Done():
+			closeConn(conn)
+			<-readErr
+			stream.finish(ctx, nil)
+		}
+	}()
+	return stream, nil
 }
-func (c *Client) waitClose(done chan struct{}, exception ErrorHandler) error {
-	stopCh := make(chan os.Signal, 1)
-	signal.Notify(stopCh, os.Interrupt)
-	select {
-	case <-done:
-		log.Println("websocket closed")
-		return nil
-	case <-stopCh:
-		log.Println("stopCh")
-		err := c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+
+// dialContext 建立一个新的 websocket 连接
+func (c *Client) dialContext(ctx context.Context, endpoint string) (*websocket.Conn, error) {
+	if c.dialer == nil {
+		c.dialer = websocket.DefaultDialer
+	}
+	conn, _, err := c.dialer.DialContext(ctx, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetReadLimit(655350)
+	c.mu.Lock()
+	onDial := c.onDial
+	c.mu.Unlock()
+	for _, f := range onDial {
+		err = f(conn)
 		if err != nil {
-			log.Println("write close:", err)
-			exception(websocket.CloseMessage, err)
-			return err
+			_ = conn.Close()
+			return nil, err
 		}
 	}
-	return nil
+	return conn, nil
+}
+
+// addOnDial 追加新连接建立后的回调
+func (c *Client) addOnDial(f func(conn *websocket.Conn) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onDial = append(c.onDial, f)
+}
+
+// limitControl 设置了 wsLimiter 时等待发送控制帧的配额
+func (c *Client) limitControl() {
+	c.mu.Lock()
+	l := c.wsLimiter
+	c.mu.Unlock()
+	if l != nil {
+		_ = l.wait(context.Background())
+	}
 }
-func (c *Client) keepAlive(timeout time.Duration) {
-	ticker := time.NewTicker(timeout)
+func (c *Client) setConn(conn *websocket.Conn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn = conn
+}
+func (c *Client) getConn() *websocket.Conn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn
+}
+
+// closeConn 发送关闭帧后关闭连接
+func closeConn(conn *websocket.Conn) {
+	msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	err := conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+	if err != nil {
+		log.Println("write close:", err)
+	}
+	_ = conn.Close()
+}
+
+// keepAlive 每隔 interval 发送 ping，收到 pong 或服务端 ping 时把读超时延长到 2*interval 之后
+// 半开连接上 ReadMessage 会因读超时返回错误；ping 发出后 interval 内没有收到 pong 时直接关闭连接
+func (c *Client) keepAlive(conn *websocket.Conn, interval time.Duration) {
+	var mu sync.Mutex
 	lastResponse := time.Now()
-	c.conn.SetPongHandler(func(msg string) error {
+	alive := func() {
+		mu.Lock()
 		lastResponse = time.Now()
+		mu.Unlock()
+		_ = conn.SetReadDeadline(time.Now().Add(2 * interval))
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(2 * interval))
+	conn.SetPongHandler(func(msg string) error {
+		alive()
+		return nil
+	})
+	conn.SetPingHandler(func(msg string) error {
+		alive()
+		c.limitControl()
+		// 回复失败时不再延长读超时，由读超时断开连接
+		_ = conn.WriteControl(websocket.PongMessage, []byte(msg), time.Now().Add(10*time.Second))
 		return nil
 	})
 	go func() {
-		defer ticker.Stop()
 		for {
-			deadline := time.Now().Add(10 * time.Second)
-			err := c.conn.WriteControl(websocket.PingMessage, []byte{}, deadline)
+			c.limitControl()
+			sent := time.Now()
+			err := conn.WriteControl(websocket.PingMessage, []byte{}, sent.Add(10*time.Second))
 			if err != nil {
 				return
 			}
-			<-ticker.C
-			if time.Since(lastResponse) > timeout {
+			// 等待限流后才发出 ping，从发出时开始计时
+			time.Sleep(interval)
+			mu.Lock()
+			expired := lastResponse.Before(sent)
+			mu.Unlock()
+			if expired {
+				_ = conn.Close()
 				return
 			}
 		}
 	}()
 }
 func (c *Client) Close() error {
-	return c.conn.Close()
+	c.mu.Lock()
+	t := c.wsApiTransport
+	c.mu.Unlock()
+	if t != nil {
+		_ = t.close()
+	}
+	conn := c.getConn()
+	if conn == nil {
+		return nil
+	}
+	return conn.Close()
 }
 
 type WsReqMsg struct {
@@ -127,8 +283,8 @@ type WsReqMsg struct {
 	Params any    `json:"params"`
 }
 
-func WsHandler[T any](c *Client, endpoint string, handler Handler[T], exception ErrorHandler) error {
-	log.Println(endpoint)
+func WsHandler[T any](ctx context.Context, c *Client, endpoint string, handler Handler[T], exception ErrorHandler) (*WsStream, error) {
+	c.Debugf("endpoint: %s", endpoint)
 	h := func(mt int, msg []byte) {
 		event := new(T)
 		err := json.Unmarshal(msg, &event)
@@ -138,5 +294,5 @@ func WsHandler[T any](c *Client, endpoint string, handler Handler[T], exception
 		}
 		handler(*event)
 	}
-	return c.Serve(endpoint, h, exception)
+	return c.Serve(ctx, endpoint, h, exception)
 }
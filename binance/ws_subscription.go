@@ -0,0 +1,391 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	// MaxStreamsPerConnection 单个连接最多可以订阅 1024 个 Streams
+	MaxStreamsPerConnection = 1024
+	// MaxMessagesPerSecond 每秒最多可以发送 5 个消息(包括 PING/PONG 帧和订阅等 JSON 控制消息)
+	MaxMessagesPerSecond = 5
+)
+
+var (
+	// ErrTooManyStreams 订阅后超过单连接 1024 个 Streams 的限制
+	ErrTooManyStreams = errors.New("websocket stream: too many streams on one connection")
+	// ErrSubscriptionTimeout 在等待时间内未收到订阅请求的响应
+	ErrSubscriptionTimeout = errors.New("websocket stream: subscription request timeout")
+	// ErrNotConnected 当前没有可用的连接
+	ErrNotConnected = errors.New("websocket stream: not connected")
+)
+
+type wsStreamReqMsg struct {
+	Id     uint64 `json:"id"`
+	Method string `json:"method"`
+	Params []any  `json:"params,omitempty"`
+}
+type wsStreamRespMsg struct {
+	Id     uint64          `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Code   int             `json:"code"`
+	Msg    string          `json:"msg"`
+	Error  *APIError       `json:"error"`
+}
+
+// WsSubscriptions 组合 Stream 订阅管理
+//
+// 在一个组合 Stream 连接上通过 SUBSCRIBE/UNSUBSCRIBE/LIST_SUBSCRIPTIONS/SET_PROPERTY 实时增减订阅，
+// 不需要断开重连。每个请求按 id 等待服务端的确认，发送频率限制在每秒 5 个消息，单个连接最多 1024 个 Streams。
+// 收到的推送按 stream 名称分发给对应的 handler。
+// 客户端设置了 Supervisor 时，重连或轮换到新连接后会自动重新订阅当前所有 Streams。
+type WsSubscriptions struct {
+	*WsStream
+	c         *Client
+	exception ErrorHandler
+	Timeout   time.Duration // 等待确认的超时时间，默认 10s
+
+	mu       sync.Mutex
+	nextId   uint64
+	pending  map[uint64]chan *wsStreamRespMsg
+	handlers map[string]messageHandler
+	dialed   *websocket.Conn // 最近建立的连接，轮换期间是还未切换的新连接
+
+	writeMu sync.Mutex
+	limiter *msgLimiter
+}
+
+// NewWsSubscriptions 建立一个不带任何 Stream 的组合 Stream 连接，之后通过 Subscribe 增加订阅
+// c 必须是 NewWsClient(true, ...) 创建的组合 Stream 客户端
+func NewWsSubscriptions(ctx context.Context, c *Client, exception ErrorHandler) (*WsSubscriptions, error) {
+	if !c.IsCombined {
+		return nil, errors.New("websocket stream: subscriptions require a combined stream client")
+	}
+	s := &WsSubscriptions{
+		c:         c,
+		exception: exception,
+		Timeout:   10 * time.Second,
+		pending:   make(map[uint64]chan *wsStreamRespMsg),
+		handlers:  make(map[string]messageHandler),
+	}
+	c.mu.Lock()
+	if c.wsLimiter == nil {
+		c.wsLimiter = &msgLimiter{}
+	}
+	s.limiter = c.wsLimiter
+	c.mu.Unlock()
+	c.addOnDial(s.resubscribe)
+	endpoint := strings.TrimSuffix(c.BaseURL, "?streams=")
+	stream, err := c.Serve(ctx, endpoint, s.dispatch, exception)
+	if err != nil {
+		return nil, err
+	}
+	s.WsStream = stream
+	return s, nil
+}
+
+// Client 订阅所在的客户端
+func (s *WsSubscriptions) Client() *Client {
+	return s.c
+}
+
+// Streams 当前已订阅的 Stream 名称
+func (s *WsSubscriptions) Streams() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	streams := make([]string, 0, len(s.handlers))
+	for name := range s.handlers {
+		streams = append(streams, name)
+	}
+	return streams
+}
+
+// Subscribe 订阅一个或多个 Stream，收到确认后返回
+// 推送的 data 部分解析为 T 后交给 handler
+func Subscribe[T any](ctx context.Context, s *WsSubscriptions, streams []string, handler Handler[T]) error {
+	h := func(mt int, msg []byte) {
+		event := new(T)
+		err := json.Unmarshal(msg, event)
+		if err != nil {
+			s.exception(mt, err)
+			return
+		}
+		handler(*event)
+	}
+	return s.subscribe(ctx, streams, h)
+}
+
+func (s *WsSubscriptions) subscribe(ctx context.Context, streams []string, handler messageHandler) error {
+	s.mu.Lock()
+	// previous 订阅前的 handler，订阅失败时恢复，已经在推送的 Stream 不受影响
+	previous := make(map[string]messageHandler, len(streams))
+	added := 0
+	for _, name := range streams {
+		if _, ok := previous[name]; ok {
+			continue
+		}
+		h, ok := s.handlers[name]
+		if !ok {
+			added++
+		}
+		previous[name] = h
+	}
+	if len(s.handlers)+added > MaxStreamsPerConnection {
+		s.mu.Unlock()
+		return ErrTooManyStreams
+	}
+	for _, name := range streams {
+		s.handlers[name] = handler
+	}
+	s.mu.Unlock()
+	_, err := s.send(ctx, "SUBSCRIBE", toParams(streams))
+	if err != nil {
+		s.mu.Lock()
+		for name, h := range previous {
+			if h == nil {
+				delete(s.handlers, name)
+			} else {
+				s.handlers[name] = h
+			}
+		}
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Unsubscribe 取消订阅一个或多个 Stream
+func (s *WsSubscriptions) Unsubscribe(ctx context.Context, streams ...string) error {
+	// 先移除 handler，轮换时新连接不会重新订阅这些 Stream，失败时恢复
+	s.mu.Lock()
+	previous := make(map[string]messageHandler, len(streams))
+	for _, name := range streams {
+		if h, ok := s.handlers[name]; ok {
+			previous[name] = h
+			delete(s.handlers, name)
+		}
+	}
+	s.mu.Unlock()
+	_, err := s.send(ctx, "UNSUBSCRIBE", toParams(streams))
+	if err != nil {
+		s.mu.Lock()
+		for name, h := range previous {
+			if _, ok := s.handlers[name]; !ok {
+				s.handlers[name] = h
+			}
+		}
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// ListSubscriptions 查询服务端当前的订阅
+func (s *WsSubscriptions) ListSubscriptions(ctx context.Context) ([]string, error) {
+	result, err := s.send(ctx, "LIST_SUBSCRIPTIONS", nil)
+	if err != nil {
+		return nil, err
+	}
+	var streams []string
+	err = json.Unmarshal(result, &streams)
+	if err != nil {
+		return nil, err
+	}
+	return streams, nil
+}
+
+// SetProperty 设置连接属性，目前仅支持 combined
+// 注意: 按 stream 名称分发依赖组合格式的推送，将 combined 设为 false 后推送无法分发
+func (s *WsSubscriptions) SetProperty(ctx context.Context, property string, value any) error {
+	_, err := s.send(ctx, "SET_PROPERTY", []any{property, value})
+	return err
+}
+
+// GetProperty 查询连接属性
+func (s *WsSubscriptions) GetProperty(ctx context.Context, property string) (json.RawMessage, error) {
+	return s.send(ctx, "GET_PROPERTY", []any{property})
+}
+
+// send 发送请求并等待同 id 的响应
+func (s *WsSubscriptions) send(ctx context.Context, method string, params []any) (json.RawMessage, error) {
+	s.mu.Lock()
+	s.nextId++
+	msg := &wsStreamReqMsg{Id: s.nextId, Method: method, Params: params}
+	ch := make(chan *wsStreamRespMsg, 1)
+	s.pending[msg.Id] = ch
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.pending, msg.Id)
+		s.mu.Unlock()
+	}()
+	conn := s.c.getConn()
+	if conn == nil {
+		return nil, ErrNotConnected
+	}
+	err := s.write(ctx, conn, msg)
+	if err != nil {
+		return nil, err
+	}
+	s.forward(ctx, conn, method, params)
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case resp := <-ch:
+		if resp.Error != nil {
+			return nil, resp.Error
+		}
+		if resp.Code != 0 || resp.Msg != "" {
+			return nil, &APIError{Code: resp.Code, Msg: resp.Msg}
+		}
+		return resp.Result, nil
+	case <-timer.C:
+		return nil, ErrSubscriptionTimeout
+	case <-s.Done():
+		return nil, ErrNotConnected
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// forward 轮换期间把订阅变更同样发到还未切换的新连接
+// 新连接的响应在切换前不会分发，使用不等待响应的新 id
+func (s *WsSubscriptions) forward(ctx context.Context, current *websocket.Conn, method string, params []any) {
+	if method != "SUBSCRIBE" && method != "UNSUBSCRIBE" {
+		return
+	}
+	s.mu.Lock()
+	next := s.dialed
+	if next == nil || next == current {
+		s.mu.Unlock()
+		return
+	}
+	s.nextId++
+	msg := &wsStreamReqMsg{Id: s.nextId, Method: method, Params: params}
+	s.mu.Unlock()
+	err := s.write(ctx, next, msg)
+	if err != nil {
+		// 新连接失败时由 Supervisor 重新建立，建立后会重新订阅
+		s.c.Debugf("forward subscription request err:%v", err)
+	}
+}
+
+// write 按每秒 5 个消息的限制串行写入
+func (s *WsSubscriptions) write(ctx context.Context, conn *websocket.Conn, msg *wsStreamReqMsg) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	err := s.limiter.wait(ctx)
+	if err != nil {
+		return err
+	}
+	s.c.Debugf("subscription request: %+v", msg)
+	return conn.WriteJSON(msg)
+}
+
+// resubscribe 新连接建立后立即重新订阅所有 Stream
+// 之后的订阅变更在切换到该连接前由 forward 同样发给它
+func (s *WsSubscriptions) resubscribe(conn *websocket.Conn) error {
+	s.mu.Lock()
+	s.dialed = conn
+	streams := make([]string, 0, len(s.handlers))
+	for name := range s.handlers {
+		streams = append(streams, name)
+	}
+	if len(streams) == 0 {
+		s.mu.Unlock()
+		return nil
+	}
+	s.nextId++
+	msg := &wsStreamReqMsg{Id: s.nextId, Method: "SUBSCRIBE", Params: toParams(streams)}
+	s.mu.Unlock()
+	return s.write(context.Background(), conn, msg)
+}
+
+// msgLimiter 按每秒 MaxMessagesPerSecond 个消息限制发送频率，订阅请求和 ping/pong 控制帧共用
+type msgLimiter struct {
+	mu   sync.Mutex
+	sent []time.Time // 最近一秒内发送消息的时间
+}
+
+// wait 等待发送配额
+func (l *msgLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for {
+		now := time.Now()
+		for len(l.sent) > 0 && now.Sub(l.sent[0]) >= time.Second {
+			l.sent = l.sent[1:]
+		}
+		if len(l.sent) < MaxMessagesPerSecond {
+			break
+		}
+		wait := time.NewTimer(time.Second - now.Sub(l.sent[0]))
+		select {
+		case <-ctx.Done():
+			wait.Stop()
+			return ctx.Err()
+		case <-wait.C:
+		}
+	}
+	l.sent = append(l.sent, time.Now())
+	return nil
+}
+
+// dispatch 区分请求的响应和推送，推送按 stream 名称分发
+func (s *WsSubscriptions) dispatch(mt int, msg []byte) {
+	stream := gjson.GetBytes(msg, "stream")
+	if !stream.Exists() {
+		resp := new(wsStreamRespMsg)
+		err := json.Unmarshal(msg, resp)
+		if err != nil {
+			s.exception(mt, err)
+			return
+		}
+		s.mu.Lock()
+		ch, ok := s.pending[resp.Id]
+		s.mu.Unlock()
+		if ok {
+			ch <- resp
+		}
+		return
+	}
+	s.mu.Lock()
+	handler, ok := s.handlers[stream.String()]
+	s.mu.Unlock()
+	if !ok {
+		// 取消订阅之后仍可能收到少量推送
+		s.c.Debugf("no handler for stream %s", stream.String())
+		return
+	}
+	data := gjson.GetBytes(msg, "data")
+	handler(mt, []byte(data.Raw))
+}
+
+func toParams(streams []string) []any {
+	params := make([]any, len(streams))
+	for i, name := range streams {
+		params[i] = name
+	}
+	return params
+}
+
+// SymbolStreams 按 <symbol>@<suffix> 生成 Stream 名称
+func SymbolStreams(symbols []string, suffix string) []string {
+	streams := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		streams = append(streams, strings.ToLower(symbol)+"@"+suffix)
+	}
+	return streams
+}
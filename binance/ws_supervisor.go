@@ -0,0 +1,277 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// StreamEventType 连接生命周期事件类型
+type StreamEventType string
+
+const (
+	// StreamEventConnected 连接建立成功
+	StreamEventConnected StreamEventType = "CONNECTED"
+	// StreamEventDisconnected 连接断开(读错误或拨号失败)
+	StreamEventDisconnected StreamEventType = "DISCONNECTED"
+	// StreamEventReconnecting 等待退避时间后重新拨号
+	StreamEventReconnecting StreamEventType = "RECONNECTING"
+	// StreamEventRotated 在 24 小时强制断开之前已切换到新连接
+	StreamEventRotated StreamEventType = "ROTATED"
+	// StreamEventGaveUp 超过最大重试次数，不再重连
+	StreamEventGaveUp StreamEventType = "GAVE_UP"
+)
+
+// StreamEvent 连接生命周期事件
+type StreamEvent struct {
+	Type     StreamEventType
+	Endpoint string
+	Attempt  int           // 当前连续失败次数
+	Backoff  time.Duration // 仅 RECONNECTING 事件有值
+	Err      error
+	Time     time.Time
+}
+
+type StreamEventHandler func(event StreamEvent)
+
+// DefaultPingInterval websocket 连接发送 ping 的默认间隔
+const DefaultPingInterval = time.Minute
+
+// ErrStreamGaveUp 超过最大重试次数
+var ErrStreamGaveUp = errors.New("websocket stream: max retries exceeded")
+
+// Supervisor websocket 行情推送守护
+//
+// 连接断开后按带抖动的指数退避重新连接同一个 endpoint，
+// 并在币安 24 小时强制断开之前提前建立新连接，新连接收到首条消息后再关闭旧连接，保证数据不中断。
+// 切换的瞬间新旧连接可能推送同一条消息，对重复敏感的调用方需自行按事件时间或更新ID去重。
+type Supervisor struct {
+	InitialBackoff time.Duration // 首次重连等待时间，默认 1s
+	MaxBackoff     time.Duration // 最大重连等待时间，默认 60s
+	Multiplier     float64       // 退避倍数，默认 2
+	Jitter         float64       // 抖动比例 [0,1]，默认 0.2
+	MaxRetries     int           // 连续失败的最大重试次数，0 表示无限重试
+	RotateAfter    time.Duration // 连接存活多久后主动轮换，默认 23h，<0 表示不轮换
+	RotateGrace    time.Duration // 新连接在此时间内未收到消息也强制切换，默认 30s
+	PingInterval   time.Duration // 发送 ping 的间隔，超过间隔未收到 pong 视为连接已断开并重连，默认 1m
+	OnEvent        StreamEventHandler
+}
+
+// NewSupervisor 使用默认参数创建守护
+func NewSupervisor(onEvent StreamEventHandler) *Supervisor {
+	return &Supervisor{
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RotateAfter:    23 * time.Hour,
+		RotateGrace:    30 * time.Second,
+		OnEvent:        onEvent,
+	}
+}
+
+func (s *Supervisor) emit(event StreamEvent) {
+	if s.OnEvent == nil {
+		return
+	}
+	event.Time = time.Now()
+	s.OnEvent(event)
+}
+
+// backoff 第 attempt 次重连的等待时间
+func (s *Supervisor) backoff(attempt int) time.Duration {
+	initial := s.InitialBackoff
+	if initial <= 0 {
+		initial = time.Second
+	}
+	maxBackoff := s.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = time.Minute
+	}
+	multiplier := s.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	d := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if d > float64(maxBackoff) {
+		d = float64(maxBackoff)
+	}
+	if s.Jitter > 0 {
+		jitter := math.Min(s.Jitter, 1)
+		d = d * (1 - jitter*rand.Float64())
+	}
+	return time.Duration(d)
+}
+
+func (s *Supervisor) rotateAfter() time.Duration {
+	if s.RotateAfter == 0 {
+		return 23 * time.Hour
+	}
+	return s.RotateAfter
+}
+
+func (s *Supervisor) pingInterval() time.Duration {
+	if s.PingInterval <= 0 {
+		return DefaultPingInterval
+	}
+	return s.PingInterval
+}
+
+func (s *Supervisor) rotateGrace() time.Duration {
+	if s.RotateGrace <= 0 {
+		return 30 * time.Second
+	}
+	return s.RotateGrace
+}
+
+// run 守护循环，直到 ctx 取消或超过最大重试次数
+func (s *Supervisor) run(ctx context.Context, c *Client, endpoint string, handler messageHandler, exception ErrorHandler) error {
+	attempt := 0
+	for {
+		conn, err := c.dialContext(ctx, endpoint)
+		if err == nil {
+			attempt = 0
+			s.emit(StreamEvent{Type: StreamEventConnected, Endpoint: endpoint})
+			err = s.serveConn(ctx, c, endpoint, conn, handler)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+		attempt++
+		exception(websocket.CloseMessage, err)
+		s.emit(StreamEvent{Type: StreamEventDisconnected, Endpoint: endpoint, Attempt: attempt, Err: err})
+		if s.MaxRetries > 0 && attempt > s.MaxRetries {
+			s.emit(StreamEvent{Type: StreamEventGaveUp, Endpoint: endpoint, Attempt: attempt, Err: err})
+			return ErrStreamGaveUp
+		}
+		wait := s.backoff(attempt)
+		s.emit(StreamEvent{Type: StreamEventReconnecting, Endpoint: endpoint, Attempt: attempt, Backoff: wait, Err: err})
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+type connResult struct {
+	conn *websocket.Conn
+	err  error
+}
+
+// serveConn 读取一个连接直到出错，期间按 RotateAfter 轮换到新连接
+func (s *Supervisor) serveConn(ctx context.Context, c *Client, endpoint string, conn *websocket.Conn, handler messageHandler) error {
+	var (
+		mu      sync.Mutex
+		current = conn
+		next    *websocket.Conn
+	)
+	c.setConn(current)
+	errCh := make(chan connResult, 2)
+	promoted := make(chan struct{}, 1)
+	// promote 将 next 切换为当前连接，调用方需持有 mu
+	promote := func() {
+		old := current
+		current, next = next, nil
+		c.setConn(current)
+		_ = old.Close()
+		s.emit(StreamEvent{Type: StreamEventRotated, Endpoint: endpoint})
+		select {
+		case promoted <- struct{}{}:
+		default:
+		}
+	}
+	read := func(cn *websocket.Conn) {
+		for {
+			mt, message, err := cn.ReadMessage()
+			if err != nil {
+				errCh <- connResult{conn: cn, err: err}
+				return
+			}
+			mu.Lock()
+			if cn == next {
+				promote()
+			}
+			if cn == current {
+				handler(mt, message)
+			}
+			mu.Unlock()
+		}
+	}
+	c.keepAlive(conn, s.pingInterval())
+	go read(conn)
+
+	var rotate, grace <-chan time.Time
+	if s.RotateAfter >= 0 {
+		rotate = time.After(s.rotateAfter())
+	}
+	var graceTimer *time.Timer
+	defer func() {
+		if graceTimer != nil {
+			graceTimer.Stop()
+		}
+		mu.Lock()
+		if next != nil {
+			_ = next.Close()
+		}
+		closeConn(current)
+		mu.Unlock()
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-rotate:
+			nc, err := c.dialContext(ctx, endpoint)
+			if err != nil {
+				// 旧连接仍然可用，稍后再试
+				rotate = time.After(s.backoff(1))
+				continue
+			}
+			mu.Lock()
+			next = nc
+			mu.Unlock()
+			c.keepAlive(nc, s.pingInterval())
+			go read(nc)
+			graceTimer = time.NewTimer(s.rotateGrace())
+			grace = graceTimer.C
+			rotate = nil
+		case <-grace:
+			mu.Lock()
+			if next != nil {
+				promote()
+			}
+			mu.Unlock()
+		case <-promoted:
+			if graceTimer != nil {
+				graceTimer.Stop()
+			}
+			grace = nil
+			rotate = time.After(s.rotateAfter())
+		case r := <-errCh:
+			mu.Lock()
+			switch r.conn {
+			case current:
+				if next == nil {
+					mu.Unlock()
+					return r.err
+				}
+				// 旧连接已被服务端断开，直接切换到新连接
+				promote()
+			case next:
+				next = nil
+				rotate = time.After(s.backoff(1))
+			}
+			mu.Unlock()
+		}
+	}
+}
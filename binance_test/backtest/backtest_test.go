@@ -0,0 +1,258 @@
+package backtest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/backtest"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/binance/spot/market"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+	"github.com/sleep-go/coin-go/pkg/kline"
+)
+
+const (
+	BTCUSDT = "BTCUSDT"
+	t0      = 1699999980000 // 整分钟
+	minute  = 60000
+)
+
+func d(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func param(r *binancetest.Request, key string) int64 {
+	n, _ := strconv.ParseInt(r.Params.Get(key), 10, 64)
+	return n
+}
+
+// newServer 模拟 2500 根 1 分钟K线和 2500 笔每秒一笔的归集成交
+func newServer() *binancetest.Server {
+	s := binancetest.NewServer()
+	s.Handle(http.MethodGet, consts.ApiMarketKLines, func(r *binancetest.Request) (any, error) {
+		var rows [][12]any
+		open := max(param(r, "startTime"), t0)
+		open += (minute - (open-t0)%minute) % minute
+		for ; open < t0+2500*minute && open <= param(r, "endTime") && len(rows) < int(param(r, "limit")); open += minute {
+			price := strconv.FormatInt(100+(open-t0)/minute%10, 10)
+			rows = append(rows, [12]any{open, price, price, price, price, "1", open + minute - 1, price, 1, "0", "0", "0"})
+		}
+		return rows, nil
+	})
+	s.Handle(http.MethodGet, consts.ApiMarketAggTrades, func(r *binancetest.Request) (any, error) {
+		id := param(r, "fromId")
+		if id == 0 {
+			id = max(1, (param(r, "startTime")-t0+999)/1000)
+		}
+		var rows []map[string]any
+		for ; id <= 2500 && len(rows) < int(param(r, "limit")); id++ {
+			rows = append(rows, map[string]any{"a": id, "p": "100", "q": "1", "f": id, "l": id, "T": t0 + id*1000, "m": false, "M": true})
+		}
+		return rows, nil
+	})
+	return s
+}
+
+func TestLoaderKlines(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	dir := t.TempDir()
+	loader := backtest.NewLoader(binance.NewClient("", "", s.URL)).SetCacheDir(dir)
+	klines, err := loader.Klines(context.Background(), BTCUSDT, enums.KlineIntervalType1m, t0, t0+2100*minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(klines) != 2101 || klines[0].OpenTime != t0 || klines[2100].OpenTime != t0+2100*minute {
+		t.Fatalf("klines: %d", len(klines))
+	}
+	if n := len(s.Requests()); n != 3 {
+		t.Fatalf("requests: %d", n)
+	}
+	// 第二次从缓存读取
+	cached, err := loader.Klines(context.Background(), BTCUSDT, enums.KlineIntervalType1m, t0, t0+2100*minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cached) != len(klines) || !cached[5].Close.Equal(klines[5].Close) || len(s.Requests()) != 3 {
+		t.Fatalf("cached: %d requests: %d", len(cached), len(s.Requests()))
+	}
+}
+
+func TestLoaderSkipsIncomplete(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	dir := t.TempDir()
+	loader := backtest.NewLoader(binance.NewClient("", "", s.URL)).SetCacheDir(dir)
+	// end 还未过去，之后可能有新的数据
+	end := time.Now().Add(time.Hour).UnixMilli()
+	for i := 0; i < 2; i++ {
+		if _, err := loader.Klines(context.Background(), BTCUSDT, enums.KlineIntervalType1m, t0+2400*minute, end); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loader.AggTrades(context.Background(), BTCUSDT, t0+2400*1000, end); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := len(s.Requests()); n != 4 {
+		t.Fatalf("requests: %d", n)
+	}
+	if files, _ := os.ReadDir(dir); len(files) != 0 {
+		t.Fatalf("cached: %v", files)
+	}
+}
+
+func TestLoaderAggTrades(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	loader := backtest.NewLoader(binance.NewClient("", "", s.URL))
+	trades, err := loader.AggTrades(context.Background(), BTCUSDT, t0+1000, t0+1500*1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 1500 || trades[0].AggTradeID != 1 || trades[1499].AggTradeID != 1500 || trades[0].Symbol != BTCUSDT {
+		t.Fatalf("trades: %d", len(trades))
+	}
+	if got := s.Requests()[1].Params.Get("fromId"); got != "1001" {
+		t.Fatalf("fromId: %s", got)
+	}
+}
+
+func bar(i int64, open, high, low, closePrice string) kline.Kline {
+	return kline.Kline{
+		OpenTime:  t0 + i*minute,
+		CloseTime: t0 + (i+1)*minute - 1,
+		Open:      d(open),
+		High:      d(high),
+		Low:       d(low),
+		Close:     d(closePrice),
+		Volume:    d("1"),
+		IsFinal:   true,
+	}
+}
+
+func TestRun(t *testing.T) {
+	klines := []kline.Kline{
+		bar(0, "100", "101", "99", "100"),
+		bar(1, "100", "102", "99", "101"), // 市价买入按开盘价 100 成交
+		bar(2, "101", "101", "90", "95"),
+		bar(3, "95", "111", "95", "110"), // 限价卖出 110 作为 maker 成交
+		bar(4, "110", "110", "100", "105"),
+	}
+	bt := backtest.New(d("1000")).
+		SetFee(backtest.RateFee(d("0"), d("0.001"))).
+		SetSlippage(backtest.FixedSlippage(d("0.01"))).
+		AddKlines(BTCUSDT, enums.KlineIntervalType1m, klines)
+	var events int
+	bt.OnKline = func(event market.WsKlineEvent) {
+		events++
+		switch event.Kline.StartTime {
+		case t0:
+			_, err := bt.Buy(BTCUSDT, d("5"))
+			if err != nil {
+				t.Fatal(err)
+			}
+		case t0 + minute:
+			_, err := bt.Limit(BTCUSDT, enums.SideTypeSell, d("110"), d("5"))
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	result, err := bt.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events != 5 || len(result.Trades) != 2 || len(result.Equity) != 5 {
+		t.Fatalf("events: %d trades: %+v", events, result.Trades)
+	}
+	// 买入价 100 * 1.01 = 101，手续费 0.505；卖出 maker 无手续费
+	buy, sell := result.Trades[0], result.Trades[1]
+	if !buy.Price.Equal(d("101")) || !buy.Fee.Equal(d("0.505")) || buy.Maker {
+		t.Fatalf("buy: %+v", buy)
+	}
+	if !sell.Price.Equal(d("110")) || !sell.Maker || !sell.Realized.Equal(d("45")) {
+		t.Fatalf("sell: %+v", sell)
+	}
+	if !result.Final.Equal(d("1044.495")) || !result.PnL.Equal(d("44.495")) || !result.WinRate.Equal(d("1")) {
+		t.Fatalf("result: %+v", result)
+	}
+	// 最高 1000，第 3 根K线收盘权益 494.495 + 5 * 95 = 969.495
+	if !result.MaxDrawdown.Equal(d("0.030505")) || result.Sharpe <= 0 {
+		t.Fatalf("drawdown: %s sharpe: %f", result.MaxDrawdown, result.Sharpe)
+	}
+	// 同一份数据再次回放结果相同
+	again, err := bt.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.Final.Equal(result.Final) || again.Sharpe != result.Sharpe {
+		t.Fatalf("again: %+v", again)
+	}
+}
+
+func TestPlaceBeforeRun(t *testing.T) {
+	bt := backtest.New(d("1000")).AddKlines(BTCUSDT, enums.KlineIntervalType1m, []kline.Kline{
+		bar(0, "100", "101", "99", "100"),
+		bar(1, "100", "102", "99", "101"),
+	})
+	if _, err := bt.Buy(BTCUSDT, d("1")); !errors.Is(err, backtest.ErrNotRunning) {
+		t.Fatalf("buy before run: %v", err)
+	}
+	if err := bt.Cancel(1); !errors.Is(err, backtest.ErrNotRunning) {
+		t.Fatalf("cancel before run: %v", err)
+	}
+	var ids []int64
+	bt.OnKline = func(event market.WsKlineEvent) {
+		o, err := bt.Buy(BTCUSDT, d("1"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, o.Id)
+	}
+	result, err := bt.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 || len(result.Trades) != 1 {
+		t.Fatalf("ids: %v trades: %+v", ids, result.Trades)
+	}
+	if _, err := bt.Sell(BTCUSDT, d("1")); !errors.Is(err, backtest.ErrNotRunning) {
+		t.Fatalf("sell after run: %v", err)
+	}
+}
+
+func TestRunAggTrades(t *testing.T) {
+	trades := make([]market.WsAggTradeEvent, 4)
+	for i, price := range []string{"100", "99", "98", "97"} {
+		trades[i].Symbol = BTCUSDT
+		trades[i].TradeTime = t0 + int64(i)*1000
+		trades[i].Price = price
+		trades[i].Quantity = "1"
+	}
+	bt := backtest.New(d("100")).AddAggTrades(trades)
+	bt.OnAggTrade = func(event market.WsAggTradeEvent) {
+		if event.TradeTime == t0 {
+			// 超过可用资金，成交时过期
+			_, _ = bt.Buy(BTCUSDT, d("2"))
+			_, _ = bt.Limit(BTCUSDT, enums.SideTypeBuy, d("98"), d("0.5"))
+		}
+	}
+	result, err := bt.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Trades) != 1 || !result.Trades[0].Price.Equal(d("98")) || !result.Trades[0].Maker || len(result.Equity) != 4 {
+		t.Fatalf("trades: %+v", result.Trades)
+	}
+	if p := bt.Position(BTCUSDT); !p.Amount.Equal(d("0.5")) {
+		t.Fatalf("position: %+v", p)
+	}
+}
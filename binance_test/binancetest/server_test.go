@@ -0,0 +1,266 @@
+package binancetest_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/binance/spot/general"
+	"github.com/sleep-go/coin-go/binance/spot/market"
+	"github.com/sleep-go/coin-go/binance/spot/stream"
+	"github.com/sleep-go/coin-go/binance/spot/trading"
+)
+
+const BTCUSDT = "BTCUSDT"
+
+func TestOrder(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	s.AddKey("key", "secret")
+	client := binance.NewClient("key", "secret", s.URL)
+
+	order, err := trading.NewOrder(client, BTCUSDT).
+		SetSide(enums.SideTypeBuy).
+		SetType(enums.OrderTypeLimit).
+		SetTimeInForce(enums.TimeInForceTypeGTC).
+		SetQuantity("0.01").
+		SetPrice("60000").
+		SetNewClientOrderId("test-1").
+		Call(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("%+v\n", order)
+	res, err := trading.NewQueryOrder(client, BTCUSDT).SetOrigClientOrderId("test-1").Call(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.OrderId != order.OrderId || res.Status != "NEW" {
+		t.Fatalf("query order: %+v", res)
+	}
+	_, err = trading.NewDeleteOrder(client, BTCUSDT).SetOrderId(int64(order.OrderId)).Call(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = trading.NewDeleteOrder(client, BTCUSDT).SetOrderId(int64(order.OrderId)).Call(context.Background())
+	if !errors.Is(err, binance.ErrUnknownOrder) {
+		t.Fatalf("cancel twice: %v", err)
+	}
+}
+
+func TestSignature(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	s.AddKey("key", "secret")
+	rsaPath, rsaKey, err := binancetest.GenerateRSAKey(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.AddPublicKey("rsa", rsaKey)
+	edPath, edKey, err := binancetest.GenerateED25519Key(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.AddPublicKey("ed25519", edKey)
+
+	tests := []struct {
+		name   string
+		client *binance.Client
+		want   error
+	}{
+		{"hmac", binance.NewClient("key", "secret", s.URL), nil},
+		{"rsa", binance.NewRsaClient("rsa", rsaPath, s.URL), nil},
+		{"ed25519", binance.NewED25519Client("ed25519", edPath, s.URL), nil},
+		{"bad secret", binance.NewClient("key", "wrong", s.URL), binance.ErrInvalidSignature},
+		{"unknown key", binance.NewClient("unknown", "secret", s.URL), binance.ErrRejectedAPIKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := trading.NewQueryOrder(tt.client, BTCUSDT).SetOrderId(1).Call(context.Background())
+			if tt.want == nil && !errors.Is(err, binance.ErrUnknownOrder) || tt.want != nil && !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+			fmt.Println(tt.name, err)
+		})
+	}
+}
+
+// TestSignatureRawQuery 按原始 query 校验签名，参数顺序不同签名不一致
+func TestSignatureRawQuery(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	s.AddKey("key", "secret")
+	send := func(query, signature string) int {
+		req, err := http.NewRequest(http.MethodGet, s.URL+consts.ApiOrder+"?"+query+"&signature="+signature, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("X-MBX-APIKEY", "key")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		var res binance.APIError
+		err = json.NewDecoder(resp.Body).Decode(&res)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.Code
+	}
+	timestamp := fmt.Sprintf("timestamp=%d", s.Now())
+	query := "symbol=BTCUSDT&orderId=1&" + timestamp
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(query))
+	signature := hex.EncodeToString(mac.Sum(nil))
+	if code := send(query, signature); code != binance.ErrUnknownOrder.Code {
+		t.Fatalf("raw order: %d", code)
+	}
+	if code := send("orderId=1&symbol=BTCUSDT&"+timestamp, signature); code != binance.ErrInvalidSignature.Code {
+		t.Fatalf("reordered: %d", code)
+	}
+}
+
+func TestTimeSync(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	s.TimeOffset = 10000
+	client := binance.NewClient("key", "secret", s.URL)
+
+	_, err := trading.NewQueryOrder(client, BTCUSDT).SetOrderId(1).Call(context.Background())
+	if !errors.Is(err, binance.ErrTimestampOutsideRecvWindow) {
+		t.Fatalf("without time sync: %v", err)
+	}
+	general.NewTimeSync(client)
+	_, err = trading.NewQueryOrder(client, BTCUSDT).SetOrderId(1).Call(context.Background())
+	if !errors.Is(err, binance.ErrUnknownOrder) {
+		t.Fatalf("with time sync: %v", err)
+	}
+	fmt.Println("offset", client.TimeSync.Offset())
+}
+
+func TestWsApi(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	path, key, err := binancetest.GenerateED25519Key(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.AddPublicKey("ed25519", key)
+	s.WsApiFixture("depth", map[string]any{
+		"lastUpdateId": 100,
+		"bids":         [][]string{{"60000.00", "1.5"}},
+		"asks":         [][]string{{"60001.00", "2"}},
+	})
+	client := binance.NewWsApiED25519Client("ed25519", path, s.WsApiURL())
+	defer client.Close()
+
+	depth, err := market.NewWsApiDepth(client).SetSymbol(BTCUSDT).SetLimit(enums.Limit5).Send(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if depth.Result.LastUpdateId != 100 || len(depth.Result.Bids) != 1 {
+		t.Fatalf("depth: %+v", depth.Result)
+	}
+	order, err := trading.NewWsApiCreateOrder(client).
+		SetSymbol(BTCUSDT).
+		SetSide(enums.SideTypeBuy).
+		SetType(enums.OrderTypeLimit).
+		SetTimeInForce(enums.TimeInForceTypeGTC).
+		SetQuantity("0.01").
+		SetPrice("60000").
+		Send(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Printf("%+v\n", order.Result)
+	res, err := trading.NewWsApiQueryOrder(client).SetSymbol(BTCUSDT).SetOrderId(int64(order.Result.OrderId)).Send(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Result.ClientOrderId != order.Result.ClientOrderId {
+		t.Fatalf("query order: %+v", res.Result)
+	}
+	listenKey, err := stream.NewWsApiUserDataStream(client).SendStart(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.NewWsApiUserDataStream(client).SetListenKey(listenKey.Result.ListenKey).SendPing(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range s.Requests() {
+		fmt.Println(r.Path, r.Signed)
+	}
+}
+
+func TestStream(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	err := s.StreamFixture("btcusdt@depth", map[string]any{"e": "depthUpdate", "s": BTCUSDT, "U": 1, "u": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events := make(chan *market.WsDepthEvent, 2)
+	ws, err := market.NewWsDepth(ctx, binance.NewWsClient(false, false, s.WsURL()), []string{BTCUSDT}, func(event *market.WsDepthEvent) {
+		events <- event
+	}, func(messageType int, err error) {
+		fmt.Println(err)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	err = s.WaitSubscribed(ctx, "btcusdt@depth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Push("btcusdt@depth", map[string]any{"e": "depthUpdate", "s": BTCUSDT, "U": 3, "u": 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []int{2, 4} {
+		select {
+		case event := <-events:
+			if event.LastUpdateID != want {
+				t.Fatalf("got u=%d, want %d", event.LastUpdateID, want)
+			}
+		case <-ctx.Done():
+			t.Fatal(ctx.Err())
+		}
+	}
+}
+
+func TestLoadFixtures(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	err := s.LoadFixtures("testdata/fixtures.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := binance.NewClient("", "", s.URL)
+	res, err := market.NewDepth(client, BTCUSDT, enums.Limit5).Call(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.LastUpdateId != 1027024 || len(res.Asks) != 1 {
+		t.Fatalf("depth: %+v", res)
+	}
+	_, err = general.NewPing(client).Call(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,162 @@
+package delivery_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/delivery/account"
+	"github.com/sleep-go/coin-go/binance/delivery/market/data"
+	"github.com/sleep-go/coin-go/binance/delivery/stream"
+	"github.com/sleep-go/coin-go/binance/delivery/trading"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+const (
+	BTCUSD       = "BTCUSD"
+	BTCUSD_PERP  = "BTCUSD_PERP"
+	contractSize = 100
+)
+
+func TestOrder(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	s.AddKey("key", "secret")
+	client := binance.NewClient("key", "secret", s.URL)
+	ctx := context.Background()
+
+	price := decimal.RequireFromString("50000")
+	contracts := trading.Contracts(decimal.RequireFromString("0.0065"), price, contractSize)
+	if contracts != 3 {
+		t.Fatalf("contracts: %d", contracts)
+	}
+	if qty := trading.BaseQty(contracts, price, contractSize); !qty.Equal(decimal.RequireFromString("0.006")) {
+		t.Fatalf("baseQty: %s", qty)
+	}
+
+	order, err := trading.NewOrder(client, BTCUSD_PERP).
+		SetSide(enums.SideTypeBuy).
+		SetType(enums.OrderTypeLimit).
+		SetQuantity(contracts).
+		SetPrice(price.String()).
+		Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !order.OrigQty.Equal(decimal.NewFromInt(3)) {
+		t.Fatalf("order: %+v", order)
+	}
+	reqs := s.Requests()
+	last := reqs[len(reqs)-1]
+	if last.Path != consts.DApiOrder || !last.Signed || last.Params.Get("quantity") != "3" {
+		t.Fatalf("request: %s %v", last.Path, last.Params)
+	}
+
+	query, err := trading.NewQueryOrder(client, BTCUSD_PERP).SetOrderId(order.OrderId).Call(ctx)
+	if err != nil || query.Status != enums.StatusTypeNew {
+		t.Fatalf("query: %+v %v", query, err)
+	}
+
+	s.Handle(http.MethodGet, consts.DApiOpenOrders, func(r *binancetest.Request) (any, error) {
+		if r.Params.Get("pair") != BTCUSD || r.Params.Has("symbol") {
+			t.Errorf("openOrders: %v", r.Params)
+		}
+		return []any{}, nil
+	})
+	_, err = trading.NewQueryOrder(client, "").SetPair(BTCUSD).CallOpenOrders(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cancel, err := trading.NewDeleteOrder(client, BTCUSD_PERP).SetOrderId(order.OrderId).Call(ctx)
+	if err != nil || cancel.Status != "CANCELED" {
+		t.Fatalf("cancel: %+v %v", cancel, err)
+	}
+}
+
+func TestPairParams(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	s.AddKey("key", "secret")
+	client := binance.NewClient("key", "secret", s.URL)
+	ctx := context.Background()
+
+	s.Handle(http.MethodGet, consts.DApiDataBasis, func(r *binancetest.Request) (any, error) {
+		if r.Params.Get("pair") != BTCUSD || r.Params.Get("contractType") != "CURRENT_QUARTER" || r.Params.Get("period") != "5m" {
+			t.Errorf("basis: %v", r.Params)
+		}
+		return []byte(`[{"indexPrice":"50000","contractType":"CURRENT_QUARTER","basisRate":"0.01","futuresPrice":"50500","annualizedBasisRate":"0.12","basis":"500","pair":"BTCUSD","timestamp":1}]`), nil
+	})
+	basis, err := data.NewBasis(client, BTCUSD, enums.ContractTypeCurrentQuarter, enums.KlineIntervalType5m).Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(basis) != 1 || !basis[0].Basis.Equal(decimal.NewFromInt(500)) || basis[0].ContractType != enums.ContractTypeCurrentQuarter {
+		t.Fatalf("basis: %+v", basis)
+	}
+
+	s.Handle(http.MethodGet, consts.DApiPositionRisk, func(r *binancetest.Request) (any, error) {
+		if !r.Signed || r.Params.Get("pair") != BTCUSD {
+			t.Errorf("positionRisk: %v", r.Params)
+		}
+		return []byte(`[{"symbol":"BTCUSD_PERP","positionAmt":"3","notionalValue":"0.006","maxQty":"100"}]`), nil
+	})
+	positions, err := account.NewPositionRisk(client).SetPair(BTCUSD).Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(positions) != 1 || !positions[0].PositionAmt.Equal(decimal.NewFromInt(3)) {
+		t.Fatalf("positionRisk: %+v", positions)
+	}
+
+	s.Handle(http.MethodGet, consts.DApiUserTrades, func(r *binancetest.Request) (any, error) {
+		if r.Params.Get("pair") != BTCUSD {
+			t.Errorf("userTrades: %v", r.Params)
+		}
+		return []byte(`[{"symbol":"BTCUSD_PERP","pair":"BTCUSD","qty":"3","baseQty":"0.006"}]`), nil
+	})
+	trades, err := account.NewUserTrades(client).SetPair(BTCUSD).Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 1 || !trades[0].BaseQty.Equal(decimal.RequireFromString("0.006")) {
+		t.Fatalf("userTrades: %+v", trades)
+	}
+}
+
+func TestUserDataStream(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	s.AddKey("key", "secret")
+	client := binance.NewClient("key", "secret", s.URL)
+	ctx := context.Background()
+
+	k := stream.NewKeepAlive(client)
+	listenKey, err := k.Start(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys := s.ListenKeys(); len(keys) != 1 || keys[0] != listenKey {
+		t.Fatalf("listenKeys: %v", keys)
+	}
+	res, err := stream.NewUserDataStream(client).CallUpdate(ctx)
+	if err != nil || res.ListenKey != listenKey {
+		t.Fatalf("update: %+v %v", res, err)
+	}
+	err = k.Close(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys := s.ListenKeys(); len(keys) != 0 {
+		t.Fatalf("listenKeys: %v", keys)
+	}
+	for _, r := range s.Requests() {
+		if r.Path != consts.DApiStreamListenKey {
+			t.Fatalf("path: %s", r.Path)
+		}
+	}
+}
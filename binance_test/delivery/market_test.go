@@ -0,0 +1,134 @@
+package delivery_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/delivery/market"
+	"github.com/sleep-go/coin-go/binance/delivery/market/ticker"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+)
+
+// receive 等待 Stream 连接订阅后推送 event，返回 handler 收到的数据
+func receive[T any](t *testing.T, s *binancetest.Server, stream string, event any, connect func(ctx context.Context, handler binance.Handler[T]) (*binance.WsStream, error)) T {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ch := make(chan T, 1)
+	ws, err := connect(ctx, func(event T) { ch <- event })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	err = s.WaitSubscribed(ctx, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Push(stream, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case v := <-ch:
+		return v
+	case <-ctx.Done():
+		t.Fatalf("%s: %v", stream, ctx.Err())
+	}
+	panic("unreachable")
+}
+
+func TestDeliveryWsClient(t *testing.T) {
+	if c := binance.NewDeliveryWsClient(true, false); c.BaseURL != consts.WS_DSTREAM+"/stream?streams=" {
+		t.Fatalf("baseURL: %s", c.BaseURL)
+	}
+}
+
+func TestMarketStreams(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	exception := func(messageType int, err error) { t.Log(err) }
+	c := binance.NewDeliveryWsClient(false, false, s.WsURL())
+
+	kline := receive(t, s, "btcusd_perp@kline_1m", map[string]any{"e": "kline", "E": 1, "s": BTCUSD_PERP, "k": map[string]any{
+		"t": 1, "T": 60000, "s": BTCUSD_PERP, "i": "1m", "o": "1", "c": "2", "h": "3", "l": "0.5", "v": "10", "q": "5", "x": true,
+	}}, func(ctx context.Context, handler binance.Handler[market.WsKlineEvent]) (*binance.WsStream, error) {
+		return market.NewWsKline(ctx, c, map[string]enums.KlineIntervalType{BTCUSD_PERP: enums.KlineIntervalType1m}, handler, exception)
+	})
+	if kline.Symbol != BTCUSD_PERP || kline.Kline.Volume != "10" || !kline.Kline.IsFinal {
+		t.Fatalf("kline: %+v", kline)
+	}
+
+	aggTrade := receive(t, s, "btcusd_perp@aggTrade", map[string]any{"e": "aggTrade", "E": 1, "a": 5933014, "s": BTCUSD_PERP, "p": "9643.5", "q": "2", "f": 1, "l": 2, "T": 1, "m": true},
+		func(ctx context.Context, handler binance.Handler[market.StreamAggTradeEvent]) (*binance.WsStream, error) {
+			return market.NewStreamAggTrade(ctx, binance.NewDeliveryWsClient(true, false, s.WsURL()), []string{BTCUSD_PERP}, handler, exception)
+		})
+	if aggTrade.Stream != "btcusd_perp@aggTrade" || aggTrade.Data.Symbol != BTCUSD_PERP {
+		t.Fatalf("aggTrade: %+v", aggTrade)
+	}
+
+	depth := receive(t, s, "btcusd_perp@depth@100ms", map[string]any{"e": "depthUpdate", "E": 1, "T": 1, "s": BTCUSD_PERP, "ps": BTCUSD, "U": 1, "u": 2, "pu": 0, "b": [][]string{{"9517.6", "10"}}, "a": [][]string{}},
+		func(ctx context.Context, handler binance.Handler[*market.WsDepthEvent]) (*binance.WsStream, error) {
+			return market.NewWsDepth(ctx, binance.NewDeliveryWsClient(false, true, s.WsURL()), []string{BTCUSD_PERP}, handler, exception)
+		})
+	if bids, err := depth.BidLevels(); err != nil || depth.Pair != BTCUSD || len(bids) != 1 || bids[0].Quantity.String() != "10" {
+		t.Fatalf("depth: %+v %v", depth, err)
+	}
+
+	bookTicker := receive(t, s, "btcusd_perp@bookTicker", map[string]any{"e": "bookTicker", "u": 17242169, "s": BTCUSD_PERP, "ps": BTCUSD, "b": "9548.1", "B": "52", "a": "9548.5", "A": "11", "T": 1, "E": 2},
+		func(ctx context.Context, handler binance.Handler[ticker.WsBookTickerEvent]) (*binance.WsStream, error) {
+			return ticker.NewWsBookTicker(ctx, c, []string{BTCUSD_PERP}, handler, exception)
+		})
+	if bookTicker.Pair != BTCUSD || bookTicker.BestBidQty.String() != "52" || bookTicker.BestAskPrice.String() != "9548.5" {
+		t.Fatalf("bookTicker: %+v", bookTicker)
+	}
+}
+
+func TestOrderBook(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	s.Handle(http.MethodGet, consts.DApiMarketDepth, func(r *binancetest.Request) (any, error) {
+		return map[string]any{"lastUpdateId": 100, "symbol": BTCUSD_PERP, "pair": BTCUSD, "bids": [][]string{{"100", "1"}}, "asks": [][]string{{"101", "1"}}}, nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	changed := make(chan struct{}, 10)
+	book := market.NewOrderBook(binance.NewClient("", "", s.URL), BTCUSD_PERP, enums.Limit5).
+		SetOnChange(func(*market.OrderBook) { changed <- struct{}{} })
+	ws, err := book.Run(ctx, binance.NewDeliveryWsClient(true, true, s.WsURL()), func(messageType int, err error) { t.Log(err) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	stream := "btcusd_perp@depth@100ms"
+	err = s.WaitSubscribed(ctx, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range []map[string]any{
+		{"e": "depthUpdate", "s": BTCUSD_PERP, "ps": BTCUSD, "U": 95, "u": 102, "pu": 94, "b": [][]string{{"99", "2"}}},
+		{"e": "depthUpdate", "s": BTCUSD_PERP, "ps": BTCUSD, "U": 103, "u": 105, "pu": 102, "a": [][]string{{"101", "0"}, {"102", "3"}}},
+	} {
+		_, err = s.Push(stream, e)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for book.LastUpdateId() != 105 {
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			t.Fatalf("lastUpdateId: %d", book.LastUpdateId())
+		}
+	}
+	if bids := book.Bids(0); len(bids) != 2 || bids[1].Price.String() != "99" {
+		t.Fatalf("bids: %v", bids)
+	}
+	if ask, ok := book.BestAsk(); !ok || ask.Price.String() != "102" || ask.Quantity.String() != "3" {
+		t.Fatalf("best ask: %v", ask)
+	}
+}
@@ -1,14 +1,14 @@
-package spot_test
+package futures_test
 
 import (
 	"context"
-	"fmt"
-	"os"
-	"strings"
+	"encoding/json"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/futures/account"
 	"github.com/sleep-go/coin-go/binance/futures/enums"
@@ -17,335 +17,384 @@ import (
 	"github.com/sleep-go/coin-go/binance/futures/market/data"
 	"github.com/sleep-go/coin-go/binance/futures/market/ticker"
 	"github.com/sleep-go/coin-go/binance/futures/trading"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+	"github.com/sleep-go/coin-go/pkg/kline"
 	"github.com/spf13/cast"
 )
 
-var client *binance.Client
-
 const (
 	BTCUSDT = "BTCUSDT"
 	ETHUSDT = "ETHUSDT"
 )
 
-func init() {
-	// 设置身份验证
-	file, err := os.ReadFile("./.env")
-	if err != nil {
-		panic(err)
-		return
-	}
-	API_KEY := strings.TrimSpace(string(file))
-	PRIVATE_KEY_PATH := "./private.pem"
-	fmt.Println(API_KEY)
-	client = binance.NewRsaClient(API_KEY, PRIVATE_KEY_PATH, consts.REST_FAPI_TEST)
-	client = binance.NewClient(
-		"eb17c7e97c0651d9651679ff19810c74ebccf63ed2419a50a9d221e8e1759b60",
-		"33b1aba85d60f60e59b1a9fb93618c48f7e75b00522a986344b94c04f336de73",
-		consts.REST_FAPI_TEST,
-	)
-	client.Debug = true
+// newClient 启动模拟服务器并加载 testdata/fixtures.json 中录制的响应
+func newClient(t *testing.T) (*binancetest.Server, *binance.Client) {
+	t.Helper()
+	s := binancetest.NewServer()
+	t.Cleanup(s.Close)
+	err := s.LoadFixtures("testdata/fixtures.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.AddKey("key", "secret")
+	return s, binance.NewClient("key", "secret", s.URL)
 }
+
+// lastRequest 服务器收到的最后一个请求
+func lastRequest(t *testing.T, s *binancetest.Server) *binancetest.Request {
+	t.Helper()
+	requests := s.Requests()
+	if len(requests) == 0 {
+		t.Fatal("no request")
+	}
+	return requests[len(requests)-1]
+}
+
+// bySymbol 带 symbol 参数时返回 one，否则返回 all
+func bySymbol(one, all string) binancetest.Handler {
+	return func(r *binancetest.Request) (any, error) {
+		if r.Params.Get("symbol") != "" {
+			return []byte(one), nil
+		}
+		return []byte(all), nil
+	}
+}
+
+// placeOrder 通过默认下单接口创建一个挂单
+func placeOrder(t *testing.T, client *binance.Client, symbol string) int64 {
+	t.Helper()
+	res, err := trading.NewOrder(client, symbol).
+		SetSide(enums.SideTypeSell).
+		SetType(enums.OrderTypeLimit).
+		SetTimeInForce(enums.TimeInForceTypeGTX).
+		SetQuantity("0.01").
+		SetPrice("97000").
+		Call(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return int64(res.OrderId)
+}
+
 func TestPing(t *testing.T) {
-	res, err := general.NewPing(client).Call(context.Background())
+	s, client := newClient(t)
+	_, err := general.NewPing(client).Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(res)
+	if r := lastRequest(t, s); r.Path != consts.FApiPing {
+		t.Fatalf("path: %s", r.Path)
+	}
 }
 func TestTime(t *testing.T) {
+	s, client := newClient(t)
+	s.TimeOffset = -time.Hour.Milliseconds()
+	before := s.Now()
 	res, err := general.NewTime(client).Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(res)
+	if res.ServerTime < before || res.ServerTime > s.Now() {
+		t.Fatalf("serverTime: %d", res.ServerTime)
+	}
 }
 func TestNewExchangeInfo(t *testing.T) {
+	_, client := newClient(t)
 	response, err := general.NewExchangeInfo(client).
 		Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
-		return
 	}
-	fmt.Println(len(response.Symbols))
-	fmt.Printf("%+v\n", response)
+	if len(response.Symbols) != 1 || response.Symbols[0].ContractType != enums.ContractTypePerpetual || len(response.Symbols[0].Filters) != 3 {
+		t.Fatalf("exchangeInfo: %+v", response)
+	}
+	if f := response.Symbols[0].Filters[1]; f.FilterType != "LOT_SIZE" || f.StepSize.String() != "0.001" {
+		t.Fatalf("filter: %+v", f)
+	}
 }
 func TestDepth(t *testing.T) {
+	s, client := newClient(t)
 	res, err := market.NewDepth(client, ETHUSDT, enums.Limit20).Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(res.Asks)
-	fmt.Println(len(res.Asks))
-	fmt.Println(len(res.Bids))
-	fmt.Println(res.LastUpdateId)
+	if res.LastUpdateId != 1027024 || len(res.Bids) != 1 || len(res.Asks) != 1 {
+		t.Fatalf("depth: %+v", res)
+	}
+	asks, err := res.AskLevels()
+	if err != nil || !asks[0].Quantity.Equal(decimal.NewFromInt(12)) {
+		t.Fatalf("asks: %v %v", asks, err)
+	}
+	if r := lastRequest(t, s); r.Params.Get("symbol") != ETHUSDT || r.Params.Get("limit") != "20" {
+		t.Fatalf("params: %v", r.Params)
+	}
 }
 func TestTrades(t *testing.T) {
+	_, client := newClient(t)
 	res, err := market.NewTrades(client, BTCUSDT, enums.Limit20).Call(context.Background())
 	if err != nil {
-		fmt.Println(res)
 		t.Fatal(err)
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 1 || res[0].Id != 28457 || !res[0].IsBuyerMaker {
+		t.Fatalf("trades: %+v", res)
 	}
 }
 func TestHistoryTrades(t *testing.T) {
+	s, client := newClient(t)
 	res, err := market.NewHistoryTrades(client, BTCUSDT, 1).
 		SetFromId(290414224).
 		Call(context.Background())
 	if err != nil {
-		t.Fatal(err.Error())
-		return
+		t.Fatal(err)
 	}
-	for _, r := range res {
-		fmt.Printf("%+v\n", r)
+	if len(res) != 1 || res[0].Id != 290414224 {
+		t.Fatalf("historicalTrades: %+v", res)
+	}
+	if r := lastRequest(t, s); r.Params.Get("fromId") != "290414224" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestAggTrades(t *testing.T) {
+	s, client := newClient(t)
+	end := time.Now().UnixMilli()
 	res, err := market.NewAggTrades(client, BTCUSDT, enums.Limit20).
-		SetStartTime(time.Now().UnixMilli() - 60*60*24*30*365*5).
-		SetEndTime(time.Now().UnixMilli()).
-		//SetFromId(3031206).
+		SetStartTime(end - time.Hour.Milliseconds()).
+		SetEndTime(end).
 		Call(context.Background())
 	if err != nil {
-		t.Fatal(err.Error())
-		return
+		t.Fatal(err)
 	}
-	for _, r := range res {
-		fmt.Printf("%+v\n", r)
+	if len(res) != 1 || res[0].AggTradeID != 26129 || res[0].Quantity != "4.70443515" {
+		t.Fatalf("aggTrades: %+v", res)
+	}
+	if r := lastRequest(t, s); r.Params.Get("endTime") != cast.ToString(end) {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestKlines(t *testing.T) {
-	k := market.NewKlines(client, BTCUSDT, enums.Limit100).
+	s, client := newClient(t)
+	res, err := market.NewKlines(client, BTCUSDT, enums.Limit100).
 		SetInterval(enums.KlineIntervalType1M).
-		SetStartTime(time.Now().UnixMilli() - 60*60*24*30*365*5).
-		SetEndTime(time.Now().UnixMilli()).
-		SetTimeZone("0")
-	res, err := k.Call(context.Background())
+		SetTimeZone("0").
+		Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
-		return
 	}
-	for i, r := range res {
-		fmt.Print(time.UnixMilli(cast.ToInt64(r[0])).Format(time.DateTime), "开盘时间 ") // 开盘时间
-		fmt.Print(r[1], " ", res[i][1], "开盘价 ")                                      // 开盘价
-		fmt.Print(r[2], " ", res[i][2], "最高价 ")                                      // 最高价
-		fmt.Print(r[3], " ", res[i][3], "最低价 ")                                      // 最低价
-		fmt.Print(r[4], " ", res[i][4], "收盘价 ")                                      // 收盘价(当前K线未结束的即为最新价)
-		fmt.Print(r[5], "成交量 ")                                                      // 成交量
-		fmt.Print(time.UnixMilli(cast.ToInt64(r[6])), "收盘时间 ")                       // 收盘时间
-		fmt.Print(r[7], "成交额 ")                                                      // 成交额
-		fmt.Print(r[8], "成交笔数 ")                                                     // 成交笔数
-		fmt.Print(r[9], "主动买入成交量 ")                                                  // 主动买入成交量
-		fmt.Print(r[10], "主动买入成交额 ")                                                 // 主动买入成交额
-		fmt.Println(r[11])                                                           // 请忽略该参数
+	if len(res) != 1 || cast.ToInt64(res[0][0]) != 1499040000000 || res[0][1] != "0.01634790" {
+		t.Fatalf("klines: %+v", res)
+	}
+	if r := lastRequest(t, s); r.Params.Get("interval") != "1M" || r.Params.Get("timeZone") != "0" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestContinuousKlines(t *testing.T) {
+	s, client := newClient(t)
 	res, err := market.NewKlines(client, BTCUSDT, enums.Limit100).
 		SetContractType(enums.ContractTypePerpetual).
-		SetInterval(enums.KlineIntervalType1M).
-		SetStartTime(time.Now().UnixMilli() - 60*60*24*30*365*5).
-		SetEndTime(time.Now().UnixMilli()).
-		SetTimeZone("0").
-		CallMarkPriceKlines(context.Background())
+		SetInterval(enums.KlineIntervalType1m).
+		CallContinuousKlines(context.Background())
 	if err != nil {
 		t.Fatal(err)
-		return
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 1 || res[0][4] != "18896.13" {
+		t.Fatalf("continuousKlines: %+v", res)
+	}
+	if r := lastRequest(t, s); r.Path != consts.FApiMarketContinuousKlines || r.Params.Get("pair") != BTCUSDT || r.Params.Get("contractType") != "PERPETUAL" {
+		t.Fatalf("request: %s %v", r.Path, r.Params)
 	}
 }
 func TestCallIndexPriceKlines(t *testing.T) {
+	_, client := newClient(t)
 	res, err := market.NewKlines(client, BTCUSDT, enums.Limit100).
 		SetContractType(enums.ContractTypePerpetual).
-		SetInterval(enums.KlineIntervalType1M).
-		SetStartTime(time.Now().UnixMilli() - 60*60*24*30*365*5).
-		SetEndTime(time.Now().UnixMilli()).
-		SetTimeZone("0").
+		SetInterval(enums.KlineIntervalType1m).
 		CallIndexPriceKlines(context.Background())
 	if err != nil {
 		t.Fatal(err)
-		return
 	}
-	for _, r := range res {
-		fmt.Print("开盘时间:", time.UnixMilli(cast.ToInt64(r[0])).Format(time.DateTime), " ") // 开盘时间
-		fmt.Print("开盘价:", r[1], " ")                                                      // 开盘价
-		fmt.Print("最高价:", r[2], " ")                                                      // 最高价
-		fmt.Print("最低价:", r[3], " ")                                                      // 最低价
-		fmt.Print("收盘价:", r[4], " ")                                                      // 收盘价(当前K线未结束的即为最新价)
-		fmt.Print("成交量:", r[5], " ")                                                      // 成交量
-		fmt.Print("收盘时间:", time.UnixMilli(cast.ToInt64(r[6])), " ")                       // 收盘时间
-		fmt.Print("成交额:", r[7], " ")                                                      // 成交额
-		fmt.Print("成交笔数:", r[8], " ")                                                     // 成交笔数
-		fmt.Print("主动买入成交量:", r[9], " ")                                                  // 主动买入成交量
-		fmt.Print("主动买入成交额:", r[10], " ")                                                 // 主动买入成交额
-		fmt.Println(r[11])                                                                // 请忽略该参数
+	if len(res) != 1 || res[0][1] != "9653.69440000" || cast.ToInt(res[0][8]) != 60 {
+		t.Fatalf("indexPriceKlines: %+v", res)
 	}
 }
-func TestCallMarkPriceKlines(t *testing.T) {
+func TestContinuousKlinesConvert(t *testing.T) {
+	_, client := newClient(t)
 	res, err := market.NewKlines(client, BTCUSDT, enums.Limit100).
 		SetContractType(enums.ContractTypePerpetual).
-		SetInterval(enums.KlineIntervalType1M).
-		SetStartTime(time.Now().UnixMilli() - 60*60*24*30*365*5).
-		SetEndTime(time.Now().UnixMilli()).
-		SetTimeZone("0").
-		CallIndexPriceKlines(context.Background())
+		SetInterval(enums.KlineIntervalType1m).
+		CallContinuousKlines(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	klines, err := kline.FromArrays(res)
 	if err != nil {
 		t.Fatal(err)
-		return
 	}
-	for _, r := range res {
-		fmt.Print("开盘时间:", time.UnixMilli(cast.ToInt64(r[0])).Format(time.DateTime), " ") // 开盘时间
-		fmt.Print("开盘价:", r[1], " ")                                                      // 开盘价
-		fmt.Print("最高价:", r[2], " ")                                                      // 最高价
-		fmt.Print("最低价:", r[3], " ")                                                      // 最低价
-		fmt.Print("收盘价:", r[4], " ")                                                      // 收盘价(当前K线未结束的即为最新价)
-		fmt.Print("成交量:", r[5], " ")                                                      // 成交量
-		fmt.Print("收盘时间:", time.UnixMilli(cast.ToInt64(r[6])), " ")                       // 收盘时间
-		fmt.Print("成交额:", r[7], " ")                                                      // 成交额
-		fmt.Print("成交笔数:", r[8], " ")                                                     // 成交笔数
-		fmt.Print("主动买入成交量:", r[9], " ")                                                  // 主动买入成交量
-		fmt.Print("主动买入成交额:", r[10], " ")                                                 // 主动买入成交额
-		fmt.Println(r[11])                                                                // 请忽略该参数
+	if len(klines) != 1 {
+		t.Fatalf("klines: %+v", klines)
+	}
+	k := klines[0]
+	if k.OpenTime != 1607444700000 || k.CloseTime != 1607444759999 || k.TradeCount != 1874 {
+		t.Fatalf("kline: %+v", k)
+	}
+	if !k.Close.Equal(decimal.NewFromStringOrZero("18896.13")) || !k.Volume.Equal(decimal.NewFromStringOrZero("492.363")) || !k.TakerBuyQuoteVolume.Equal(decimal.NewFromStringOrZero("7292402.33267")) {
+		t.Fatalf("kline: %+v", k)
+	}
+}
+func TestCallMarkPriceKlines(t *testing.T) {
+	s, client := newClient(t)
+	res, err := market.NewKlines(client, BTCUSDT, enums.Limit100).
+		SetInterval(enums.KlineIntervalType1m).
+		CallMarkPriceKlines(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 1 {
+		t.Fatalf("markPriceKlines: %+v", res)
+	}
+	if r := lastRequest(t, s); r.Path != consts.FApiMarketMarkPriceKlines {
+		t.Fatalf("path: %s", r.Path)
 	}
 }
 func TestCallPremiumIndexKlines(t *testing.T) {
+	_, client := newClient(t)
 	res, err := market.NewKlines(client, BTCUSDT, enums.Limit100).
-		SetContractType(enums.ContractTypePerpetual).
-		SetInterval(enums.KlineIntervalType1M).
-		SetStartTime(time.Now().UnixMilli() - 60*60*24*30*365*5).
-		SetEndTime(time.Now().UnixMilli()).
-		SetTimeZone("0").
+		SetInterval(enums.KlineIntervalType1m).
 		CallPremiumIndexKlines(context.Background())
 	if err != nil {
 		t.Fatal(err)
-		return
 	}
-	for _, r := range res {
-		fmt.Print("开盘时间:", time.UnixMilli(cast.ToInt64(r[0])).Format(time.DateTime), " ") // 开盘时间
-		fmt.Print("开盘价:", r[1], " ")                                                      // 开盘价
-		fmt.Print("最高价:", r[2], " ")                                                      // 最高价
-		fmt.Print("最低价:", r[3], " ")                                                      // 最低价
-		fmt.Print("收盘价:", r[4], " ")                                                      // 收盘价(当前K线未结束的即为最新价)
-		fmt.Print("成交量:", r[5], " ")                                                      // 成交量
-		fmt.Print("收盘时间:", time.UnixMilli(cast.ToInt64(r[6])), " ")                       // 收盘时间
-		fmt.Print("成交额:", r[7], " ")                                                      // 成交额
-		fmt.Print("成交笔数:", r[8], " ")                                                     // 成交笔数
-		fmt.Print("主动买入成交量:", r[9], " ")                                                  // 主动买入成交量
-		fmt.Print("主动买入成交额:", r[10], " ")                                                 // 主动买入成交额
-		fmt.Println(r[11])                                                                // 请忽略该参数
+	k, err := res[0].Kline()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.Open.String() != "-0.00042931" || k.OpenTime != 1691603820000 {
+		t.Fatalf("premiumIndexKlines: %+v", k)
 	}
 }
 func TestCallPremiumIndex(t *testing.T) {
+	s, client := newClient(t)
+	index := `{"symbol":"BTCUSDT","markPrice":"11793.63104562","indexPrice":"11781.80495970","estimatedSettlePrice":"11781.16138815",
+		"lastFundingRate":"0.00038246","interestRate":"0.00010000","nextFundingTime":1597392000000,"time":1597370495002}`
+	s.Handle(http.MethodGet, consts.FApiMarketPremiumIndex, bySymbol(index, "["+index+"]"))
 	resp, err := market.NewPremiumIndex(client).
 		Call(context.Background(), BTCUSDT)
 	if err != nil {
 		t.Fatal(err)
-		return
 	}
-	fmt.Println(resp)
+	if resp.MarkPrice != "11793.63104562" || resp.NextFundingTime != 1597392000000 {
+		t.Fatalf("premiumIndex: %+v", resp)
+	}
 	res, err := market.NewPremiumIndex(client).
 		CallAll(context.Background())
 	if err != nil {
 		t.Fatal(err)
-		return
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 1 || res[0].LastFundingRate != "0.00038246" {
+		t.Fatalf("premiumIndex all: %+v", res)
 	}
 }
 func TestCallFundingRate(t *testing.T) {
+	_, client := newClient(t)
 	res, err := market.NewFundingRate(client).
 		Call(context.Background(), ETHUSDT)
 	if err != nil {
 		t.Fatal(err)
-		return
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 1 || res[0].FundingRate != "-0.03750000" {
+		t.Fatalf("fundingRate: %+v", res)
 	}
 }
 func TestCallFundingInfo(t *testing.T) {
-	client.BaseURL = consts.REST_FAPI
+	_, client := newClient(t)
 	res, err := market.NewFundingInfo(client).Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
-		return
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 1 || res[0].FundingIntervalHours != 8 {
+		t.Fatalf("fundingInfo: %+v", res)
 	}
 }
 func TestHr24(t *testing.T) {
+	s, client := newClient(t)
+	hr24 := `{"symbol":"ETHUSDT","priceChange":"-94.99999800","priceChangePercent":"-95.960","weightedAvgPrice":"0.29628482",
+		"lastPrice":"4.00000200","lastQty":"200.00000000","openPrice":"99.00000000","highPrice":"100.00000000","lowPrice":"0.10000000",
+		"volume":"8913.30000000","quoteVolume":"15.30000000","openTime":1499783499040,"closeTime":1499869899040,"firstId":28385,"lastId":28460,"count":76}`
+	s.Handle(http.MethodGet, consts.FApiMarketTicker24Hr, bySymbol(hr24, "["+hr24+"]"))
 	res, err := ticker.NewHr24(client, ETHUSDT).Call(context.Background())
 	if err != nil {
-		t.Fatal(err.Error())
-		return
+		t.Fatal(err)
 	}
-	fmt.Println(res)
-	ress, err := ticker.NewHr24(client, ETHUSDT).CallAll(context.Background())
+	if res.Symbol != ETHUSDT || res.Count != 76 {
+		t.Fatalf("hr24: %+v", res)
+	}
+	all, err := ticker.NewHr24(client, ETHUSDT).CallAll(context.Background())
 	if err != nil {
-		t.Fatal(err.Error())
-		return
+		t.Fatal(err)
 	}
-	for _, v := range ress {
-		fmt.Printf("%+v\n", v)
+	if len(all) != 1 || all[0].LastPrice != "4.00000200" {
+		t.Fatalf("hr24 all: %+v", all)
 	}
 }
 func TestNewPrice(t *testing.T) {
+	s, client := newClient(t)
+	price := `{"symbol":"ETHUSDT","price":"6000.01","time":1589437530011}`
+	s.Handle(http.MethodGet, consts.FApiMarketTickerPriceV2, bySymbol(price, "["+price+"]"))
 	res, err := ticker.NewPrice(client).CallAllV2(context.Background())
 	if err != nil {
 		t.Fatal(err)
-		return
 	}
-	for _, v := range res {
-		fmt.Println(v)
+	if len(res) != 1 || res[0].Price != "6000.01" {
+		t.Fatalf("price all: %+v", res)
 	}
 	resp, err := ticker.NewPrice(client).CallV2(context.Background(), ETHUSDT)
 	if err != nil {
 		t.Fatal(err)
-		return
 	}
-	fmt.Println(resp)
+	if resp.Symbol != ETHUSDT || resp.Time != 1589437530011 {
+		t.Fatalf("price: %+v", resp)
+	}
 }
 func TestBookTicker(t *testing.T) {
+	s, client := newClient(t)
+	book := `{"symbol":"ETHUSDT","bidPrice":"4.00000000","bidQty":"431.00000000","askPrice":"4.00000200","askQty":"9.00000000","time":1589437530011}`
+	s.Handle(http.MethodGet, consts.FApiMarketTickerBookTicker, bySymbol(book, "["+book+"]"))
 	res, err := ticker.NewBookTicker(client).Call(context.Background(), ETHUSDT)
 	if err != nil {
-		t.Fatal(err.Error())
-		return
+		t.Fatal(err)
+	}
+	if !res.BidQtyDecimal().Equal(decimal.NewFromInt(431)) {
+		t.Fatalf("bookTicker: %+v", res)
 	}
-	fmt.Printf("%+v\n", res)
 	resp, err := ticker.NewBookTicker(client).CallAll(context.Background())
 	if err != nil {
-		t.Fatal(err.Error())
-		return
+		t.Fatal(err)
 	}
-	for _, v := range resp {
-		fmt.Println(v)
+	if len(resp) != 1 || resp[0].AskPrice != "4.00000200" {
+		t.Fatalf("bookTicker all: %+v", resp)
 	}
 }
 func TestDeliveryPrice(t *testing.T) {
-	client.BaseURL = consts.REST_FAPI
+	_, client := newClient(t)
 	res, err := data.NewDeliveryPrice(client, ETHUSDT).Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
-		return
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 1 || res[0].DeliveryPrice != 27103 {
+		t.Fatalf("deliveryPrice: %+v", res)
 	}
 }
 func TestOpenInterest(t *testing.T) {
+	_, client := newClient(t)
 	res, err := market.NewOpenInterest(client).Call(context.Background(), ETHUSDT)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(res)
+	if res.OpenInterest != "10659.509" {
+		t.Fatalf("openInterest: %+v", res)
+	}
 }
 func TestOpenInterestHist(t *testing.T) {
-	client.BaseURL = consts.REST_FAPI
+	s, client := newClient(t)
 	res, err := data.NewOpenInterestHist(client).
 		SetPeriod(enums.KlineIntervalType5m).
 		SetLimit(enums.Limit5).
@@ -353,12 +402,15 @@ func TestOpenInterestHist(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 1 || res[0].SumOpenInterest != "20403.63700000" {
+		t.Fatalf("openInterestHist: %+v", res)
+	}
+	if r := lastRequest(t, s); r.Params.Get("period") != "5m" || r.Params.Get("limit") != "5" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestTopLongShortPositionRatio(t *testing.T) {
-	client.BaseURL = consts.REST_FAPI
+	_, client := newClient(t)
 	res, err := data.NewTopLongShortPositionRatio(client).
 		SetPeriod(enums.KlineIntervalType5m).
 		SetLimit(enums.Limit5).
@@ -366,12 +418,12 @@ func TestTopLongShortPositionRatio(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 1 || res[0].LongShortRatio != "1.4342" {
+		t.Fatalf("topLongShortPositionRatio: %+v", res)
 	}
 }
 func TestTopLongShortAccountRatio(t *testing.T) {
-	client.BaseURL = consts.REST_FAPI
+	_, client := newClient(t)
 	res, err := data.NewTopLongShortAccountRatio(client).
 		SetPeriod(enums.KlineIntervalType5m).
 		SetLimit(enums.Limit5).
@@ -379,12 +431,12 @@ func TestTopLongShortAccountRatio(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 1 || res[0].LongShortRatio != "1.8105" {
+		t.Fatalf("topLongShortAccountRatio: %+v", res)
 	}
 }
 func TestGlobalLongShortAccountRatio(t *testing.T) {
-	client.BaseURL = consts.REST_FAPI
+	_, client := newClient(t)
 	res, err := data.NewGlobalLongShortAccountRatio(client).
 		SetPeriod(enums.KlineIntervalType5m).
 		SetLimit(enums.Limit5).
@@ -392,12 +444,12 @@ func TestGlobalLongShortAccountRatio(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 1 || res[0].ShortAccount != "0.8361" {
+		t.Fatalf("globalLongShortAccountRatio: %+v", res)
 	}
 }
 func TestTakerLongShortRatio(t *testing.T) {
-	client.BaseURL = consts.REST_FAPI
+	_, client := newClient(t)
 	res, err := data.NewTakerLongShortRatio(client).
 		SetPeriod(enums.KlineIntervalType5m).
 		SetLimit(enums.Limit5).
@@ -405,40 +457,53 @@ func TestTakerLongShortRatio(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 1 || res[0].BuySellRatio != "1.5586" {
+		t.Fatalf("takerLongShortRatio: %+v", res)
 	}
 }
 func TestIndexInfo(t *testing.T) {
+	_, client := newClient(t)
 	res, err := market.NewIndexInfo(client, "DEFIUSDT").Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
+	if res.Symbol != "DEFIUSDT" || len(res.BaseAssetList) != 1 || res.BaseAssetList[0].BaseAsset != "BAL" {
+		t.Fatalf("indexInfo: %+v", res)
+	}
 }
 func TestAssetIndex(t *testing.T) {
-	resp, err := market.NewAssetIndex(client).Call(context.Background(), "BTCUSD")
+	s, client := newClient(t)
+	index := `{"symbol":"ADAUSD","time":1635740268004,"index":"1.92957370","bidBuffer":"0.10000000","askBuffer":"0.10000000",
+		"bidRate":"1.73661633","askRate":"2.12253107","autoExchangeBidBuffer":"0.05000000","autoExchangeAskBuffer":"0.05000000",
+		"autoExchangeBidRate":"1.83309501","autoExchangeAskRate":"2.02605238"}`
+	s.Handle(http.MethodGet, consts.FApiMarketAssetIndex, bySymbol(index, "["+index+"]"))
+	resp, err := market.NewAssetIndex(client).Call(context.Background(), "ADAUSD")
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", resp)
+	if resp.Index != "1.92957370" {
+		t.Fatalf("assetIndex: %+v", resp)
+	}
 	res, err := market.NewAssetIndex(client).CallAll(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 1 || res[0].Symbol != "ADAUSD" {
+		t.Fatalf("assetIndex all: %+v", res)
 	}
-
 }
 func TestConstituents(t *testing.T) {
+	_, client := newClient(t)
 	resp, err := market.NewConstituents(client).Call(context.Background(), ETHUSDT)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", resp)
+	if len(resp.Constituents) != 2 || resp.Constituents[1].Exchange != "okex" {
+		t.Fatalf("constituents: %+v", resp)
+	}
 }
 func TestCreateOrder(t *testing.T) {
+	s, client := newClient(t)
 	res, err := trading.NewOrder(client, BTCUSDT).
 		SetSide(enums.SideTypeSell).
 		SetType(enums.OrderTypeMarket).
@@ -447,9 +512,27 @@ func TestCreateOrder(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
+	if res.Status != enums.StatusTypeNew || res.OrigQty != "0.01" || res.Side != enums.SideTypeSell {
+		t.Fatalf("order: %+v", res)
+	}
+	if r := lastRequest(t, s); !r.Signed || r.APIKey != "key" {
+		t.Fatalf("request: %+v", r)
+	}
 }
 func TestBatchOrder(t *testing.T) {
+	s, client := newClient(t)
+	s.Handle(http.MethodPost, consts.FApiBatchOrders, func(r *binancetest.Request) (any, error) {
+		var orders []map[string]any
+		err := json.Unmarshal([]byte(r.Params.Get("batchOrders")), &orders)
+		if err != nil {
+			return nil, binancetest.Error(binance.ErrInvalidParameter.Code, err.Error())
+		}
+		res := make([]map[string]any, len(orders))
+		for i, o := range orders {
+			res[i] = map[string]any{"orderId": i + 1, "symbol": o["symbol"], "side": o["side"], "type": o["type"], "origQty": o["quantity"], "status": "NEW"}
+		}
+		return res, nil
+	})
 	var quantity = "0.01"
 	var requests = []*trading.CreateOrderRequest{
 		{
@@ -470,12 +553,13 @@ func TestBatchOrder(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 2 || res[1].Symbol != ETHUSDT || res[1].OrigQty != quantity {
+		t.Fatalf("batchOrders: %+v", res)
 	}
 }
 func TestCreateOrderTest(t *testing.T) {
-	res, err := trading.NewOrder(client, BTCUSDT).
+	s, client := newClient(t)
+	_, err := trading.NewOrder(client, BTCUSDT).
 		SetSide(enums.SideTypeSell).
 		SetType(enums.OrderTypeMarket).
 		SetQuantity("0.01").
@@ -483,20 +567,39 @@ func TestCreateOrderTest(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
+	if r := lastRequest(t, s); r.Path != consts.FApiTradingOrderTest || len(s.Orders()) != 0 {
+		t.Fatalf("path: %s orders: %v", r.Path, s.Orders())
+	}
 }
 func TestUpdateOrder(t *testing.T) {
+	_, client := newClient(t)
+	orderId := placeOrder(t, client, BTCUSDT)
 	res, err := trading.NewUpdateOrder(client, BTCUSDT).
-		SetOrderId(4067841292).
+		SetOrderId(orderId).
 		SetQuantity("0.005").
 		SetSide(enums.SideTypeSell).
 		SetPrice("97976.2").Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
+	if res.OrderId != orderId || res.Price != "97976.2" || res.OrigQty != "0.005" {
+		t.Fatalf("updateOrder: %+v", res)
+	}
 }
 func TestUpdateBatchOrder(t *testing.T) {
+	s, client := newClient(t)
+	s.Handle(http.MethodPut, consts.FApiBatchOrders, func(r *binancetest.Request) (any, error) {
+		var orders []map[string]any
+		err := json.Unmarshal([]byte(r.Params.Get("batchOrders")), &orders)
+		if err != nil {
+			return nil, binancetest.Error(binance.ErrInvalidParameter.Code, err.Error())
+		}
+		res := make([]map[string]any, len(orders))
+		for i, o := range orders {
+			res[i] = map[string]any{"orderId": o["orderId"], "symbol": o["symbol"], "price": o["price"], "origQty": o["quantity"], "status": "NEW"}
+		}
+		return res, nil
+	})
 	var quantity = "0.01"
 	var price = "96980"
 	var orderId = int64(4067841292)
@@ -522,67 +625,179 @@ func TestUpdateBatchOrder(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 2 || res[1].OrderId != orderId1 || res[1].Price != price {
+		t.Fatalf("batchOrders: %+v", res)
 	}
 }
 func TestOrderAmendment(t *testing.T) {
+	s, client := newClient(t)
 	res, err := account.NewOrderAmendment(client, enums.Limit20).
 		SetSymbol(BTCUSDT).
-		SetOrderId(4067841292).
+		SetOrderId(20072994037).
 		Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
+	if len(res) != 1 || res[0].Amendment.Price.After != "30003.2" || res[0].Amendment.Count != 3 {
+		t.Fatalf("orderAmendment: %+v", res)
+	}
+	if r := lastRequest(t, s); !r.Signed || r.Params.Get("orderId") != "20072994037" {
+		t.Fatalf("params: %v", r.Params)
+	}
 }
 func TestDeleteOrder(t *testing.T) {
-	res, err := trading.NewDeleteOrder(client, BTCUSDT).SetOrderId(4067841292).Call(context.Background())
+	_, client := newClient(t)
+	orderId := placeOrder(t, client, BTCUSDT)
+	res, err := trading.NewDeleteOrder(client, BTCUSDT).SetOrderId(orderId).Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
+	if int64(res.OrderId) != orderId || res.Status != "CANCELED" {
+		t.Fatalf("deleteOrder: %+v", res)
+	}
 }
 func TestBatchDeleteOrder(t *testing.T) {
+	s, client := newClient(t)
+	s.Handle(http.MethodDelete, consts.FApiBatchOrders, func(r *binancetest.Request) (any, error) {
+		if r.Params.Get("orderIdList") != "[4067841292]" {
+			t.Errorf("orderIdList: %v", r.Params)
+		}
+		return []byte(`[{"orderId":4067841292,"symbol":"BTCUSDT","status":"CANCELED"}]`), nil
+	})
 	res, err := trading.NewDeleteOrder(client, BTCUSDT).CallBatch(context.Background(), []int64{4067841292})
 	if err != nil {
 		t.Fatal(err)
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 1 || res[0].OrderId != 4067841292 {
+		t.Fatalf("batchOrders: %+v", res)
 	}
 }
 func TestCallAllOpenOrders(t *testing.T) {
+	_, client := newClient(t)
 	res, err := trading.NewDeleteOrder(client, BTCUSDT).SetSymbol(BTCUSDT).CallAllOpenOrders(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(res)
+	if res.Code != 200 {
+		t.Fatalf("allOpenOrders: %+v", res)
+	}
 }
 
 func TestCallCountdownCancelAll(t *testing.T) {
+	s, client := newClient(t)
 	res, err := trading.NewCancelOrder(client, BTCUSDT).CallCountdownCancelAll(context.Background(), 100)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(res)
+	if res.Symbol != BTCUSDT || res.CountdownTime != "100" {
+		t.Fatalf("countdownCancelAll: %+v", res)
+	}
+	if r := lastRequest(t, s); r.Params.Get("countdownTime") != "100" {
+		t.Fatalf("params: %v", r.Params)
+	}
 }
 func TestQueryOrder(t *testing.T) {
+	_, client := newClient(t)
+	orderId := placeOrder(t, client, BTCUSDT)
 	res, err := trading.NewQueryOrder(client, BTCUSDT).
-		SetOrderId(4067841292).
+		SetOrderId(orderId).
 		Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
+	if int64(res.OrderId) != orderId || res.Price != "97000" {
+		t.Fatalf("queryOrder: %+v", res)
+	}
 }
 
 func TestAllOrders(t *testing.T) {
+	_, client := newClient(t)
 	response, err := account.NewAllOrders(client, BTCUSDT, enums.Limit20).Call(context.Background())
 	if err != nil {
-		panic(err)
+		t.Fatal(err)
+	}
+	if len(response) != 1 || response[0].OrderId != 1917641 || response[0].PositionSide != enums.PositionSideTypeShort {
+		t.Fatalf("allOrders: %+v", response)
+	}
+}
+
+func TestAccount(t *testing.T) {
+	s, client := newClient(t)
+	res, err := account.NewAccount(client).Call(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.CanTrade || !res.TotalWalletBalance.Equal(decimal.NewFromStringOrZero("103.12345678")) || len(res.Assets) != 1 || len(res.Positions) != 1 {
+		t.Fatalf("account: %+v", res)
+	}
+	if p := res.Positions[0]; p.Symbol != BTCUSDT || !p.Leverage.Equal(decimal.NewFromInt(100)) || p.PositionSide != enums.PositionSideTypeBoth {
+		t.Fatalf("position: %+v", p)
+	}
+	if r := lastRequest(t, s); !r.Signed {
+		t.Fatalf("request: %+v", r)
+	}
+}
+
+func TestBalance(t *testing.T) {
+	_, client := newClient(t)
+	res, err := account.NewBalance(client).Call(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 1 || res[0].Asset != "USDT" || !res[0].AvailableBalance.Equal(decimal.NewFromStringOrZero("23.72469206")) {
+		t.Fatalf("balance: %+v", res)
+	}
+}
+
+func TestPositionRisk(t *testing.T) {
+	s, client := newClient(t)
+	res, err := account.NewPositionRisk(client).SetSymbol(BTCUSDT).Call(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 1 || res[0].MarginType != "isolated" || !res[0].MarkPrice.Equal(decimal.NewFromStringOrZero("6679.50671178")) {
+		t.Fatalf("positionRisk: %+v", res)
+	}
+	if r := lastRequest(t, s); r.Params.Get("symbol") != BTCUSDT {
+		t.Fatalf("params: %v", r.Params)
+	}
+}
+
+func TestLeverage(t *testing.T) {
+	s, client := newClient(t)
+	res, err := account.NewLeverage(client, BTCUSDT, 10).Call(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Leverage != 10 || res.Symbol != BTCUSDT || !res.MaxNotionalValue.Equal(decimal.NewFromInt(1000000)) {
+		t.Fatalf("leverage: %+v", res)
+	}
+	if r := lastRequest(t, s); r.Method != http.MethodPost || r.Params.Get("symbol") != BTCUSDT || r.Params.Get("leverage") != "10" {
+		t.Fatalf("request: %s %v", r.Method, r.Params)
+	}
+}
+
+func TestPositionMode(t *testing.T) {
+	_, client := newClient(t)
+	res, err := account.NewPositionMode(client).CallDualSidePosition(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.DualSidePosition {
+		t.Fatalf("positionSide/dual: %+v", res)
+	}
+}
+
+func TestCommissionRate(t *testing.T) {
+	s, client := newClient(t)
+	res, err := account.NewCommissionRate(client, BTCUSDT).Call(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Symbol != BTCUSDT || !res.MakerCommissionRate.Equal(decimal.NewFromStringOrZero("0.0002")) || !res.TakerCommissionRate.Equal(decimal.NewFromStringOrZero("0.0004")) {
+		t.Fatalf("commissionRate: %+v", res)
 	}
-	for _, v := range response {
-		fmt.Printf("%+v\n", v)
+	if r := lastRequest(t, s); r.Params.Get("symbol") != BTCUSDT {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
@@ -0,0 +1,153 @@
+package futures_account_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/account"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+const BTCUSDT = "BTCUSDT"
+
+func TestLeverageAndMargin(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	s.AddKey("key", "secret")
+	client := binance.NewClient("key", "secret", s.URL)
+	ctx := context.Background()
+
+	s.Handle(http.MethodPost, consts.FApiLeverage, func(r *binancetest.Request) (any, error) {
+		if !r.Signed || r.Params.Get("leverage") != "20" {
+			t.Errorf("leverage: %v", r.Params)
+		}
+		return []byte(`{"leverage":20,"maxNotionalValue":"1000000","symbol":"BTCUSDT"}`), nil
+	})
+	leverage, err := account.NewLeverage(client, BTCUSDT, 20).Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leverage.Leverage != 20 || leverage.Symbol != BTCUSDT {
+		t.Fatalf("leverage: %+v", leverage)
+	}
+
+	s.Handle(http.MethodPost, consts.FApiMarginType, func(r *binancetest.Request) (any, error) {
+		if r.Params.Get("marginType") != string(enums.MarginTypeIsolated) {
+			t.Errorf("marginType: %v", r.Params)
+		}
+		return nil, binancetest.Error(-4046, "No need to change margin type.")
+	})
+	_, err = account.NewMarginType(client, BTCUSDT, enums.MarginTypeIsolated).Call(ctx)
+	if !errors.Is(err, binance.ErrNoNeedToChangeMarginType) {
+		t.Fatalf("marginType: %v", err)
+	}
+
+	s.Handle(http.MethodPost, consts.FApiPositionMargin, func(r *binancetest.Request) (any, error) {
+		if r.Params.Get("positionSide") != "LONG" || r.Params.Get("type") != "2" || r.Params.Get("amount") != "10.5" {
+			t.Errorf("positionMargin: %v", r.Params)
+		}
+		return []byte(`{"amount":10.5,"code":200,"msg":"Successfully modify position margin.","type":2}`), nil
+	})
+	margin, err := account.NewPositionMargin(client, BTCUSDT, "10.5", enums.PositionMarginTypeReduce).
+		SetPositionSide(enums.PositionSideTypeLong).
+		Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if margin.Type != enums.PositionMarginTypeReduce || !margin.Amount.Equal(decimal.RequireFromString("10.5")) {
+		t.Fatalf("positionMargin: %+v", margin)
+	}
+}
+
+func TestPositionMode(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	client := binance.NewClient("key", "secret", s.URL)
+	ctx := context.Background()
+	dual := false
+	s.Handle(http.MethodGet, consts.FApiPositionSideDual, func(r *binancetest.Request) (any, error) {
+		return map[string]bool{"dualSidePosition": dual}, nil
+	})
+	s.Handle(http.MethodPost, consts.FApiPositionSideDual, func(r *binancetest.Request) (any, error) {
+		if r.Params.Get("dualSidePosition") == "true" == dual {
+			return nil, binancetest.Error(-4059, "No need to change position side.")
+		}
+		dual = !dual
+		return map[string]any{"code": 200, "msg": "success"}, nil
+	})
+	mode := account.NewPositionMode(client)
+	res, err := mode.CallChangeDualSidePosition(ctx, true)
+	if err != nil || res.Code != 200 {
+		t.Fatalf("change: %+v %v", res, err)
+	}
+	_, err = mode.CallChangeDualSidePosition(ctx, true)
+	if !errors.Is(err, binance.ErrNoNeedToChangePositionSide) {
+		t.Fatalf("change again: %v", err)
+	}
+	status, err := mode.CallDualSidePosition(ctx)
+	if err != nil || !status.DualSidePosition {
+		t.Fatalf("status: %+v %v", status, err)
+	}
+}
+
+func TestPositions(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	client := binance.NewClient("key", "secret", s.URL)
+	ctx := context.Background()
+	s.Handle(http.MethodGet, consts.FApiPositionRisk, func(r *binancetest.Request) (any, error) {
+		if r.Params.Get("symbol") != BTCUSDT {
+			t.Errorf("positionRisk: %v", r.Params)
+		}
+		return []byte(`[{"symbol":"BTCUSDT","positionAmt":"0.010","entryPrice":"60000.0","breakEvenPrice":"60024.0","markPrice":"61000.00000000",
+			"unRealizedProfit":"10.00000000","liquidationPrice":"0","leverage":"20","maxNotionalValue":"10000000","marginType":"cross",
+			"isolatedMargin":"0.00000000","isAutoAddMargin":"false","positionSide":"LONG","notional":"610.0","isolatedWallet":"0","updateTime":1720000000000}]`), nil
+	})
+	positions, err := account.NewPositionRisk(client).SetSymbol(BTCUSDT).Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(positions) != 1 || positions[0].PositionSide != enums.PositionSideTypeLong || !positions[0].UnRealizedProfit.Equal(decimal.NewFromInt(10)) {
+		t.Fatalf("positionRisk: %+v", positions)
+	}
+
+	s.Handle(http.MethodGet, consts.FApiAdlQuantile, func(r *binancetest.Request) (any, error) {
+		return []byte(`[{"symbol":"BTCUSDT","adlQuantile":{"LONG":1,"SHORT":2,"HEDGE":0}},{"symbol":"ETHUSDT","adlQuantile":{"BOTH":3}}]`), nil
+	})
+	adl, err := account.NewAdlQuantile(client).Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(adl) != 2 || adl[0].AdlQuantile[enums.PositionSideTypeShort] != 2 || adl[1].AdlQuantile[enums.PositionSideTypeBoth] != 3 {
+		t.Fatalf("adlQuantile: %+v", adl)
+	}
+
+	// 指定 symbol 时返回单个对象，否则返回数组
+	s.Handle(http.MethodGet, consts.FApiLeverageBracket, func(r *binancetest.Request) (any, error) {
+		bracket := `{"symbol":"BTCUSDT","notionalCoef":1.5,"brackets":[{"bracket":1,"initialLeverage":125,"notionalCap":50000,"notionalFloor":0,"maintMarginRatio":0.004,"cum":0}]}`
+		if r.Params.Get("symbol") != "" {
+			return []byte(bracket), nil
+		}
+		return []byte(`[` + bracket + `,{"symbol":"ETHUSDT","brackets":[{"bracket":1,"initialLeverage":100}]}]`), nil
+	})
+	brackets, err := account.NewLeverageBracket(client).SetSymbol(BTCUSDT).Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(brackets) != 1 || brackets[0].Symbol != BTCUSDT || brackets[0].Brackets[0].InitialLeverage != 125 {
+		t.Fatalf("leverageBracket: %+v", brackets)
+	}
+	brackets, err = account.NewLeverageBracket(client).Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(brackets) != 2 || brackets[1].Brackets[0].InitialLeverage != 100 {
+		t.Fatalf("leverageBracket: %+v", brackets)
+	}
+}
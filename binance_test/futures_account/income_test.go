@@ -0,0 +1,138 @@
+package futures_account_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/account"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// 2024-07-01 00:00:00 UTC
+const day1 = int64(1719792000000)
+
+func TestIncomeAndPnL(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	client := binance.NewClient("key", "secret", s.URL)
+	ctx := context.Background()
+	hour := int64(time.Hour / time.Millisecond)
+	incomes := []map[string]any{
+		{"symbol": "BTCUSDT", "incomeType": "REALIZED_PNL", "income": "10.5", "asset": "USDT", "time": day1 + hour, "tranId": 1, "tradeId": "100"},
+		{"symbol": "BTCUSDT", "incomeType": "COMMISSION", "income": "-0.5", "asset": "USDT", "time": day1 + hour, "tranId": 2, "tradeId": "100"},
+		{"symbol": "BTCUSDT", "incomeType": "FUNDING_FEE", "income": "-1.2", "asset": "USDT", "time": day1 + 8*hour, "tranId": 3, "tradeId": ""},
+		{"symbol": "BTCUSDT", "incomeType": "FUNDING_FEE", "income": "0.7", "asset": "USDT", "time": day1 + 16*hour, "tranId": 4, "tradeId": ""},
+		{"symbol": "", "incomeType": "TRANSFER", "income": "1000", "asset": "USDT", "time": day1 + 20*hour, "tranId": 5, "tradeId": ""},
+		{"symbol": "ETHUSDT", "incomeType": "REALIZED_PNL", "income": "-3", "asset": "USDT", "time": day1 + 25*hour, "tranId": 6, "tradeId": "200"},
+	}
+	s.Handle(http.MethodGet, consts.FApiIncome, func(r *binancetest.Request) (any, error) {
+		start, _ := strconv.ParseInt(r.Params.Get("startTime"), 10, 64)
+		end, _ := strconv.ParseInt(r.Params.Get("endTime"), 10, 64)
+		limit, _ := strconv.Atoi(r.Params.Get("limit"))
+		page := []map[string]any{}
+		for _, income := range incomes {
+			if tm := income["time"].(int64); tm >= start && tm <= end && len(page) < limit {
+				page = append(page, income)
+			}
+		}
+		return page, nil
+	})
+	all, err := account.NewIncome(client).SetStartTime(day1).SetEndTime(day1 + 48*hour).SetLimit(enums.Limit5).Iterate().Collect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != len(incomes) {
+		t.Fatalf("income: %d", len(all))
+	}
+
+	// 成交 100 已经在流水中出现，不重复计算；成交 101 只在成交历史中
+	s.Handle(http.MethodGet, consts.FApiUserTrades, func(r *binancetest.Request) (any, error) {
+		return []map[string]any{
+			{"symbol": "BTCUSDT", "id": 100, "orderId": 1, "side": "SELL", "price": "61000", "qty": "0.01", "quoteQty": "610",
+				"realizedPnl": "10.5", "commission": "0.5", "commissionAsset": "USDT", "time": day1 + hour, "positionSide": "BOTH"},
+			{"symbol": "BTCUSDT", "id": 101, "orderId": 2, "side": "SELL", "price": "61000", "qty": "0.01", "quoteQty": "610",
+				"realizedPnl": "2", "commission": "0.001", "commissionAsset": "BNB", "time": day1 + 2*hour, "positionSide": "BOTH"},
+		}, nil
+	})
+	trades, err := account.NewUserTrades(client, "BTCUSDT").SetStartTime(day1).SetEndTime(day1 + 24*hour).Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := account.NewPnLReport(time.UTC)
+	report.AddIncome(all...)
+	report.AddTrades(trades...)
+	report.AddIncome(all...)
+	days := report.Days()
+	expect := []struct {
+		date, symbol, asset string
+		pnl, commission     string
+		funding             string
+	}{
+		{"2024-07-01", "BTCUSDT", "BNB", "0", "-0.001", "0"},
+		{"2024-07-01", "BTCUSDT", "USDT", "12.5", "-0.5", "-0.5"},
+		{"2024-07-02", "ETHUSDT", "USDT", "-3", "0", "0"},
+	}
+	if len(days) != len(expect) {
+		t.Fatalf("days: %+v", days)
+	}
+	for i, e := range expect {
+		d := days[i]
+		if d.Date != e.date || d.Symbol != e.symbol || d.Asset != e.asset ||
+			!d.RealizedPnl.Equal(decimal.RequireFromString(e.pnl)) ||
+			!d.Commission.Equal(decimal.RequireFromString(e.commission)) ||
+			!d.FundingFee.Equal(decimal.RequireFromString(e.funding)) {
+			t.Fatalf("day %d: %+v", i, d)
+		}
+	}
+	if net := days[1].Net(); !net.Equal(decimal.RequireFromString("11.5")) {
+		t.Fatalf("net: %s", net)
+	}
+	fees := report.FundingFees()
+	if len(fees) != 1 || fees[0].Count != 2 ||
+		!fees[0].Paid.Equal(decimal.RequireFromString("-1.2")) ||
+		!fees[0].Received.Equal(decimal.RequireFromString("0.7")) {
+		t.Fatalf("funding: %+v", fees)
+	}
+}
+
+func TestDownload(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	client := binance.NewClient("key", "secret", s.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s.Handle(http.MethodGet, consts.FApiTradeAsyn, func(r *binancetest.Request) (any, error) {
+		return map[string]any{"avgCostTimestampOfLast30d": 7055, "downloadId": "546975389218332672"}, nil
+	})
+	polls := 0
+	s.Handle(http.MethodGet, consts.FApiTradeAsynId, func(r *binancetest.Request) (any, error) {
+		if r.Params.Get("downloadId") != "546975389218332672" {
+			t.Errorf("downloadId: %v", r.Params)
+		}
+		polls++
+		if polls < 3 {
+			return map[string]any{"downloadId": "546975389218332672", "status": "processing", "url": "", "notified": false, "expirationTimestamp": -1, "isExpired": nil}, nil
+		}
+		return map[string]any{"downloadId": "546975389218332672", "status": "completed", "url": "https://example.com/trade.csv", "notified": true, "expirationTimestamp": 1720000000000, "isExpired": false}, nil
+	})
+	download := account.NewTradeDownload(client)
+	id, err := download.CallCreate(ctx, day1, day1+int64(24*time.Hour/time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	link, err := download.Wait(ctx, id.DownloadId, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.Status != enums.DownloadStatusTypeCompleted || link.Url == "" || polls != 3 {
+		t.Fatalf("link: %+v %d", link, polls)
+	}
+}
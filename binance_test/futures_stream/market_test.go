@@ -0,0 +1,113 @@
+package futures_stream_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/binance/futures/market"
+)
+
+// receive 等待 Stream 连接订阅后推送 event，返回 handler 收到的数据
+func receive[T any](t *testing.T, s *binancetest.Server, stream string, event any, connect func(ctx context.Context, handler binance.Handler[T]) (*binance.WsStream, error)) T {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ch := make(chan T, 1)
+	ws, err := connect(ctx, func(event T) { ch <- event })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	err = s.WaitSubscribed(ctx, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Push(stream, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case v := <-ch:
+		return v
+	case <-ctx.Done():
+		t.Fatalf("%s: %v", stream, ctx.Err())
+	}
+	panic("unreachable")
+}
+
+func TestMarkPrice(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	exception := func(messageType int, err error) { t.Log(err) }
+	markPrice := map[string]any{"e": "markPriceUpdate", "E": 1, "s": "BTCUSDT", "p": "61000.1", "i": "61001.2", "P": "0", "r": "0.0001", "T": 1720000000000}
+
+	event := receive(t, s, "btcusdt@markPrice@1s", markPrice, func(ctx context.Context, handler binance.Handler[market.WsMarkPriceEvent]) (*binance.WsStream, error) {
+		return market.NewWsMarkPrice(ctx, binance.NewWsClient(false, true, s.WsURL()), []string{"BTCUSDT"}, handler, exception)
+	})
+	if event.Symbol != "BTCUSDT" || event.FundingRate.String() != "0.0001" || event.NextFundingTime != 1720000000000 {
+		t.Fatalf("markPrice: %+v", event)
+	}
+	all := receive(t, s, "!markPrice@arr", []any{markPrice, markPrice}, func(ctx context.Context, handler binance.Handler[market.StreamAllMarkPriceEvent]) (*binance.WsStream, error) {
+		return market.NewStreamAllMarkPrice(ctx, binance.NewWsClient(true, false, s.WsURL()), handler, exception)
+	})
+	if all.Stream != "!markPrice@arr" || len(all.Data) != 2 || all.Data[1].MarkPrice.String() != "61000.1" {
+		t.Fatalf("all markPrice: %+v", all)
+	}
+}
+
+func TestMarketStreams(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	exception := func(messageType int, err error) { t.Log(err) }
+	c := binance.NewWsClient(false, false, s.WsURL())
+
+	liquidation := receive(t, s, "!forceOrder@arr", map[string]any{"e": "forceOrder", "E": 1, "o": map[string]any{
+		"s": "BTCUSDT", "S": "SELL", "o": "LIMIT", "f": "IOC", "q": "0.014", "p": "9910", "ap": "9910", "X": "FILLED", "l": "0.014", "z": "0.014", "T": 1,
+	}}, func(ctx context.Context, handler binance.Handler[market.WsForceOrderEvent]) (*binance.WsStream, error) {
+		return market.NewWsAllForceOrder(ctx, c, handler, exception)
+	})
+	if liquidation.Order.Side != enums.SideTypeSell || liquidation.Order.Status != enums.StatusTypeFilled || liquidation.Order.OrigQty.String() != "0.014" {
+		t.Fatalf("forceOrder: %+v", liquidation)
+	}
+
+	kline := receive(t, s, "btcusdt_perpetual@continuousKline_1m", map[string]any{"e": "continuous_kline", "E": 1, "ps": "BTCUSDT", "ct": "PERPETUAL", "k": map[string]any{
+		"t": 1, "T": 60000, "i": "1m", "o": "1", "c": "2", "h": "3", "l": "0.5", "v": "10", "x": false,
+	}}, func(ctx context.Context, handler binance.Handler[market.WsContinuousKlineEvent]) (*binance.WsStream, error) {
+		return market.NewWsContinuousKline(ctx, c, []market.ContinuousKlineStream{
+			{Pair: "BTCUSDT", ContractType: enums.ContractTypePerpetual, Interval: enums.KlineIntervalType1m},
+		}, handler, exception)
+	})
+	if kline.ContractType != enums.ContractTypePerpetual || kline.Kline.High != "3" {
+		t.Fatalf("continuousKline: %+v", kline)
+	}
+
+	info := receive(t, s, "!contractInfo", map[string]any{"e": "contractInfo", "E": 1, "s": "BTCUSDT", "ps": "BTCUSDT", "ct": "PERPETUAL", "dt": 4133404800000, "ot": 1569398400000, "cs": "TRADING",
+		"bks": []map[string]any{{"bs": 1, "bnf": 0, "bnc": 5000, "mmr": 0.01, "cf": 0, "mi": 21, "ma": 50}},
+	}, func(ctx context.Context, handler binance.Handler[market.WsContractInfoEvent]) (*binance.WsStream, error) {
+		return market.NewWsContractInfo(ctx, c, handler, exception)
+	})
+	if info.ContractStatus != enums.ContractStatusTypeTrading || len(info.Brackets) != 1 || info.Brackets[0].MaxLeverage != 50 {
+		t.Fatalf("contractInfo: %+v", info)
+	}
+
+	index := receive(t, s, "!assetIndex@arr", []map[string]any{{"e": "assetIndexUpdate", "E": 1, "s": "ADXUSD", "i": "0.2", "b": "0.1", "a": "0.1", "B": "0.18", "A": "0.22"}},
+		func(ctx context.Context, handler binance.Handler[[]market.WsAssetIndexEvent]) (*binance.WsStream, error) {
+			return market.NewWsAllAssetIndex(ctx, c, handler, exception)
+		})
+	if len(index) != 1 || index[0].Symbol != "ADXUSD" || index[0].AskRate.String() != "0.22" {
+		t.Fatalf("assetIndex: %+v", index)
+	}
+
+	composite := receive(t, s, "defiusdt@compositeIndex", map[string]any{"e": "compositeIndex", "E": 1, "s": "DEFIUSDT", "p": "554.4", "C": "baseAsset",
+		"c": []map[string]any{{"b": "BAL", "q": "USDT", "w": "1.04", "W": "0.014", "i": "24.3"}},
+	}, func(ctx context.Context, handler binance.Handler[market.WsCompositeIndexEvent]) (*binance.WsStream, error) {
+		return market.NewWsCompositeIndex(ctx, c, []string{"DEFIUSDT"}, handler, exception)
+	})
+	if len(composite.Composition) != 1 || composite.Composition[0].BaseAsset != "BAL" {
+		t.Fatalf("compositeIndex: %+v", composite)
+	}
+}
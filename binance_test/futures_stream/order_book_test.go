@@ -0,0 +1,106 @@
+package futures_stream_test
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/binance/futures/market"
+)
+
+func depthEvent(first, last, prev int, bid string) *market.WsDepthEvent {
+	return &market.WsDepthEvent{Symbol: "BTCUSDT", FirstUpdateID: first, LastUpdateID: last, PrevLastUpdateID: prev, Bids: [][]string{{bid, "1"}}}
+}
+
+func TestOrderBookPrevGap(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	var snapshotId atomic.Int64
+	snapshotId.Store(100)
+	s.Handle(http.MethodGet, consts.FApiMarketDepth, func(r *binancetest.Request) (any, error) {
+		return map[string]any{"lastUpdateId": snapshotId.Load(), "bids": [][]string{{"100", "1"}}, "asks": [][]string{{"101", "1"}}}, nil
+	})
+	var (
+		mu      sync.Mutex
+		causes  []error
+		changes int
+	)
+	resyncs := func() []error {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]error(nil), causes...)
+	}
+	book := market.NewOrderBook(binance.NewClient("", "", s.URL), "BTCUSDT", enums.Limit5).
+		SetOnChange(func(*market.OrderBook) {
+			mu.Lock()
+			changes++
+			mu.Unlock()
+		}).
+		SetOnResync(func(err error) {
+			mu.Lock()
+			causes = append(causes, err)
+			mu.Unlock()
+		})
+	waitReady := func() {
+		deadline := time.Now().Add(5 * time.Second)
+		for !book.Ready() {
+			if time.Now().After(deadline) {
+				t.Fatal("not ready")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	// u < lastUpdateId 的缓存被丢弃，第一个 event 满足 U <= lastUpdateId <= u
+	book.Update(depthEvent(90, 95, 89, "98"))
+	book.Update(depthEvent(96, 102, 95, "99"))
+	waitReady()
+	if id := book.LastUpdateId(); id != 102 {
+		t.Fatalf("lastUpdateId: %d", id)
+	}
+	if bids := book.Bids(0); len(bids) != 2 || bids[0].Price.String() != "100" || bids[1].Price.String() != "99" {
+		t.Fatalf("bids: %v", bids)
+	}
+	book.Update(depthEvent(103, 105, 102, "97"))
+	if id := book.LastUpdateId(); id != 105 {
+		t.Fatalf("lastUpdateId: %d", id)
+	}
+	// 轮换时重复的 event 直接丢弃
+	book.Update(depthEvent(103, 105, 102, "97"))
+	if len(resyncs()) != 0 {
+		t.Fatalf("causes: %v", resyncs())
+	}
+	// pu 不等于上一个 u 时重新初始化
+	snapshotId.Store(110)
+	book.Update(depthEvent(108, 110, 107, "96"))
+	if causes := resyncs(); len(causes) != 1 || !strings.Contains(causes[0].Error(), "expected pu=105 got pu=107") {
+		t.Fatalf("causes: %v", causes)
+	}
+	waitReady()
+	if id := book.LastUpdateId(); id != 110 {
+		t.Fatalf("lastUpdateId: %d", id)
+	}
+	if bids := book.Bids(0); len(bids) != 2 || bids[1].Price.String() != "96" {
+		t.Fatalf("bids: %v", bids)
+	}
+	// 快照之后的回调在 Ready 之后执行
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		n := changes
+		mu.Unlock()
+		if n == 3 {
+			break
+		}
+		if n > 3 || time.Now().After(deadline) {
+			t.Fatalf("changes: %d", n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
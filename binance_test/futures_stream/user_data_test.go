@@ -0,0 +1,121 @@
+package futures_stream_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/binance/futures/account"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/binance/futures/stream"
+)
+
+func TestUserData(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	res, err := stream.NewUserDataStream(binance.NewClient("", "", s.URL)).CallCreate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orders := make(chan *account.WsOrderTradeUpdateEvent, 1)
+	accounts := make(chan *account.WsAccountUpdateEvent, 1)
+	configs := make(chan *account.WsAccountConfigUpdateEvent, 1)
+	expired := make(chan *account.WsListenKeyExpiredEvent, 1)
+	ws, err := account.NewWsUserData(ctx, binance.NewWsClient(false, false, s.WsURL()), res.ListenKey, account.UserDataHandlers{
+		OrderTradeUpdate:    func(event *account.WsOrderTradeUpdateEvent) { orders <- event },
+		AccountUpdate:       func(event *account.WsAccountUpdateEvent) { accounts <- event },
+		AccountConfigUpdate: func(event *account.WsAccountConfigUpdateEvent) { configs <- event },
+		ListenKeyExpired:    func(event *account.WsListenKeyExpiredEvent) { expired <- event },
+	}, func(messageType int, err error) {
+		t.Log(err)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	err = s.WaitSubscribed(ctx, res.ListenKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := []map[string]any{
+		{"e": "TRADE_LITE", "E": 1, "s": "BTCUSDT"}, // 没有设置回调，忽略
+		{"e": "ORDER_TRADE_UPDATE", "E": 2, "T": 2, "o": map[string]any{
+			"s": "BTCUSDT", "c": "abc", "S": "BUY", "o": "LIMIT", "q": "0.001", "p": "50000", "x": "TRADE", "X": "FILLED",
+			"i": 8886774, "l": "0.001", "L": "50000", "N": "USDT", "n": "0.02", "T": 2, "t": 1, "m": true, "ps": "BOTH", "rp": "0",
+		}},
+		{"e": "ACCOUNT_UPDATE", "E": 3, "T": 3, "a": map[string]any{
+			"m": "ORDER",
+			"B": []map[string]any{{"a": "USDT", "wb": "122624.12345678", "cw": "100.12345678", "bc": "50.12345678"}},
+			"P": []map[string]any{{"s": "BTCUSDT", "pa": "0.001", "ep": "50000", "bep": "50010", "cr": "0", "up": "0", "mt": "cross", "iw": "0", "ps": "BOTH"}},
+		}},
+		{"e": "ACCOUNT_CONFIG_UPDATE", "E": 4, "T": 4, "ac": map[string]any{"s": "BTCUSDT", "l": 25}},
+		{"e": "listenKeyExpired", "E": "5", "listenKey": res.ListenKey},
+	}
+	for _, event := range events {
+		_, err = s.Push(res.ListenKey, event)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	order := <-orders
+	if order.Order.OrderId != 8886774 || order.Order.Status != enums.StatusTypeFilled || !order.Order.IsMaker || order.Order.LastFilledPrice.String() != "50000" {
+		t.Fatalf("order: %+v", order)
+	}
+	update := <-accounts
+	if update.Account.Reason != "ORDER" || update.Account.Balances[0].Asset != "USDT" || update.Account.Positions[0].BreakEvenPrice.String() != "50010" {
+		t.Fatalf("account: %+v", update)
+	}
+	config := <-configs
+	if config.AccountConfig == nil || config.AccountConfig.Leverage != 25 || config.AccountInfo != nil {
+		t.Fatalf("config: %+v", config)
+	}
+	if e := <-expired; e.ListenKey != res.ListenKey || e.Time != "5" {
+		t.Fatalf("expired: %+v", e)
+	}
+}
+
+func TestKeepAlive(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client := binance.NewClient("", "", s.URL)
+	renewed := make(chan string, 1)
+	k := stream.NewKeepAlive(client).
+		SetInterval(20 * time.Millisecond).
+		SetOnRenew(func(listenKey string) { renewed <- listenKey }).
+		SetOnError(func(err error) { t.Error(err) })
+	listenKey, err := k.Start(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 合约账户只有一个 listenKey，重复生成返回同一个
+	again, err := stream.NewUserDataStream(client).CallCreate(ctx)
+	if err != nil || again.ListenKey != listenKey {
+		t.Fatalf("listenKey: %v %v", again, err)
+	}
+	// 关闭后延长返回 -1125，KeepAlive 重新生成
+	err = stream.NewUserDataStream(client).CallDelete(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-renewed:
+		if got == listenKey || got != k.ListenKey() {
+			t.Fatalf("renewed: %s", got)
+		}
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+	err = k.Close(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys := s.ListenKeys(); len(keys) != 0 {
+		t.Fatalf("listenKeys: %v", keys)
+	}
+}
@@ -0,0 +1,170 @@
+package futures_ws_api_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/futures/account"
+	"github.com/sleep-go/coin-go/binance/futures/enums"
+	"github.com/sleep-go/coin-go/binance/futures/market/ticker"
+	"github.com/sleep-go/coin-go/binance/futures/trading"
+)
+
+const BTCUSDT = "BTCUSDT"
+
+func newClient(t *testing.T) (*binancetest.Server, *binance.Client) {
+	s := binancetest.NewServer()
+	t.Cleanup(s.Close)
+	s.AddKey("key", "secret")
+	client := binance.NewFuturesWsApiHMACClient("key", "secret", s.WsFApiURL())
+	t.Cleanup(func() { _ = client.Close() })
+	return s, client
+}
+
+func TestOrder(t *testing.T) {
+	s, client := newClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	placed, err := trading.NewWsApiCreateOrder(client, BTCUSDT).
+		SetSide(enums.SideTypeBuy).
+		SetPositionSide(enums.PositionSideTypeLong).
+		SetType(enums.OrderTypeLimit).
+		SetQuantity("0.01").
+		SetPrice("60000").
+		Send(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if placed.Result.Status != enums.StatusTypeNew || placed.Result.PositionSide != enums.PositionSideTypeLong {
+		t.Fatalf("place: %+v", placed.Result)
+	}
+	orderId := int64(placed.Result.OrderId)
+	modified, err := trading.NewWsApiUpdateOrder(client, BTCUSDT).
+		SetOrderId(orderId).
+		SetSide(enums.SideTypeBuy).
+		SetQuantity("0.02").
+		SetPrice("59000").
+		Send(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if modified.Result.Price != "59000" || modified.Result.OrigQty != "0.02" {
+		t.Fatalf("modify: %+v", modified.Result)
+	}
+	status, err := trading.NewWsApiQueryOrder(client, BTCUSDT).SetOrderId(orderId).Send(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Result.Price != "59000" {
+		t.Fatalf("status: %+v", status.Result)
+	}
+	canceled, err := trading.NewWsApiDeleteOrder(client, BTCUSDT).SetOrderId(orderId).Send(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if canceled.Result.Status != "CANCELED" {
+		t.Fatalf("cancel: %+v", canceled.Result)
+	}
+	again, err := trading.NewWsApiDeleteOrder(client, BTCUSDT).SetOrderId(orderId).Send(ctx)
+	if !errors.Is(err, binance.ErrCancelRejected) {
+		t.Fatalf("cancel again: %v", err)
+	}
+	if again.Error == nil || !errors.Is(again.Error, binance.ErrUnknownOrder) {
+		t.Fatalf("cancel again response: %+v", again.Error)
+	}
+	methods := map[string]bool{}
+	for _, r := range s.Requests() {
+		if r.Method == "" && r.Signed {
+			methods[r.Path] = true
+		}
+	}
+	for _, m := range []string{"order.place", "order.modify", "order.status", "order.cancel"} {
+		if !methods[m] {
+			t.Fatalf("%s not signed: %v", m, methods)
+		}
+	}
+}
+
+func TestAccount(t *testing.T) {
+	s, client := newClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s.HandleWsApi("v2/account.position", func(r *binancetest.Request) (any, error) {
+		return []map[string]any{{"symbol": r.Params.Get("symbol"), "positionAmt": "0.01", "leverage": "20", "marginType": "cross", "positionSide": "BOTH"}}, nil
+	})
+	s.HandleWsApi("v2/account.balance", func(r *binancetest.Request) (any, error) {
+		return []map[string]any{{"asset": "USDT", "balance": "100.5", "availableBalance": "90"}}, nil
+	})
+	s.HandleWsApi("v2/account.status", func(r *binancetest.Request) (any, error) {
+		return map[string]any{"canTrade": true, "totalWalletBalance": "100.5", "assets": []map[string]any{{"asset": "USDT"}}}, nil
+	})
+	positions, err := account.NewWsApiPositionRisk(client).SetSymbol(BTCUSDT).Send(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(positions.Result) != 1 || positions.Result[0].Symbol != BTCUSDT || positions.Result[0].Leverage.String() != "20" {
+		t.Fatalf("position: %+v", positions.Result)
+	}
+	balances, err := account.NewWsApiBalance(client).Send(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(balances.Result) != 1 || balances.Result[0].Balance.String() != "100.5" {
+		t.Fatalf("balance: %+v", balances.Result)
+	}
+	status, err := account.NewWsApiAccount(client).Send(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Result.CanTrade || status.Result.TotalWalletBalance.String() != "100.5" || len(status.Result.Assets) != 1 {
+		t.Fatalf("status: %+v", status.Result)
+	}
+}
+
+func TestTicker(t *testing.T) {
+	s, client := newClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// 指定 symbol 时返回对象，不指定时返回数组
+	s.HandleWsApi("v2/ticker.price", func(r *binancetest.Request) (any, error) {
+		if symbol := r.Params.Get("symbol"); symbol != "" {
+			return map[string]any{"symbol": symbol, "price": "60000.1", "time": 1}, nil
+		}
+		return []map[string]any{{"symbol": BTCUSDT, "price": "60000.1"}, {"symbol": "ETHUSDT", "price": "3000"}}, nil
+	})
+	s.HandleWsApi("ticker.book", func(r *binancetest.Request) (any, error) {
+		return map[string]any{"symbol": r.Params.Get("symbol"), "bidPrice": "59999", "askPrice": "60001"}, nil
+	})
+	price, err := ticker.NewWsApiTickerPrice(client).SetSymbol(BTCUSDT).Send(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(price.Result) != 1 || price.Result[0].Price != "60000.1" {
+		t.Fatalf("price: %+v", price.Result)
+	}
+	all, err := ticker.NewWsApiTickerPrice(client).Send(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all.Result) != 2 || all.Result[1].Symbol != "ETHUSDT" {
+		t.Fatalf("all price: %+v", all.Result)
+	}
+	book, err := ticker.NewWsApiBookTicker(client).SetSymbol(BTCUSDT).Send(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(book.Result) != 1 || book.Result[0].AskPrice != "60001" {
+		t.Fatalf("book: %+v", book.Result)
+	}
+}
+
+func TestFuturesClientDefaultURL(t *testing.T) {
+	if url := binance.NewFuturesWsApiHMACClient("key", "secret").BaseURL; url != consts.WS_FAPI {
+		t.Fatalf("url: %s", url)
+	}
+}
@@ -0,0 +1,181 @@
+package margin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/margin/account"
+	"github.com/sleep-go/coin-go/binance/margin/stream"
+	"github.com/sleep-go/coin-go/binance/margin/trading"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+const BTCUSDT = "BTCUSDT"
+
+func TestOrder(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	s.AddKey("key", "secret")
+	client := binance.NewClient("key", "secret", s.URL)
+	ctx := context.Background()
+
+	order, err := trading.NewOrder(client, BTCUSDT).
+		SetIsolated(true).
+		SetSide(enums.SideTypeBuy).
+		SetType(enums.OrderTypeLimit).
+		SetTimeInForce(enums.TimeInForceTypeGTC).
+		SetQuantity("0.01").
+		SetPrice("50000").
+		SetSideEffectType(enums.SideEffectTypeMarginBuy).
+		Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs := s.Requests()
+	last := reqs[len(reqs)-1]
+	if last.Path != consts.SApiMarginOrder || !last.Signed ||
+		last.Params.Get("isIsolated") != "TRUE" || last.Params.Get("sideEffectType") != "MARGIN_BUY" {
+		t.Fatalf("request: %s %v", last.Path, last.Params)
+	}
+
+	_, err = trading.NewOrder(client, BTCUSDT).
+		SetSide(enums.SideTypeSell).
+		SetType(enums.OrderTypeLimit).
+		SetTimeInForce(enums.TimeInForceTypeGTC).
+		SetQuantity("0.01").
+		SetPrice("60000").
+		SetSideEffectType(enums.SideEffectTypeAutoRepay).
+		Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs = s.Requests()
+	last = reqs[len(reqs)-1]
+	if last.Params.Has("isIsolated") || last.Params.Get("sideEffectType") != "AUTO_REPAY" {
+		t.Fatalf("request: %v", last.Params)
+	}
+
+	query, err := trading.NewQueryOrder(client, BTCUSDT).SetIsolated(true).SetOrderId(order.OrderId).Call(ctx)
+	if err != nil || query.Status != enums.OrderStatusTypeNew {
+		t.Fatalf("query: %+v %v", query, err)
+	}
+	cancel, err := trading.NewDeleteOrder(client, BTCUSDT).SetIsolated(true).SetOrderId(order.OrderId).Call(ctx)
+	if err != nil || cancel.Status != enums.OrderStatusTypeCanceled {
+		t.Fatalf("cancel: %+v %v", cancel, err)
+	}
+
+	s.Handle(http.MethodPost, consts.SApiMarginOrderOco, func(r *binancetest.Request) (any, error) {
+		if !r.Signed || r.Params.Get("price") != "60000" || r.Params.Get("stopPrice") != "45000" ||
+			r.Params.Get("stopLimitPrice") != "44900" || r.Params.Get("sideEffectType") != "AUTO_REPAY" {
+			t.Errorf("oco: %v", r.Params)
+		}
+		return []byte(`{"orderListId":1,"contingencyType":"OCO","symbol":"BTCUSDT","orders":[{"orderId":1},{"orderId":2}]}`), nil
+	})
+	oco, err := trading.NewOCO(client, BTCUSDT, enums.SideTypeSell, "0.01", "60000", "45000").
+		SetStopLimitPrice("44900").
+		SetStopLimitTimeInForce(enums.TimeInForceTypeGTC).
+		SetSideEffectType(enums.SideEffectTypeAutoRepay).
+		Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oco.OrderListId != 1 || len(oco.Orders) != 2 {
+		t.Fatalf("oco: %+v", oco)
+	}
+}
+
+func TestBorrowRepay(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	s.AddKey("key", "secret")
+	client := binance.NewClient("key", "secret", s.URL)
+	ctx := context.Background()
+
+	s.Handle(http.MethodPost, consts.SApiMarginBorrowRepay, func(r *binancetest.Request) (any, error) {
+		if !r.Signed || r.Params.Get("asset") != "USDT" || r.Params.Get("amount") != "100" {
+			t.Errorf("borrowRepay: %v", r.Params)
+		}
+		return []byte(`{"tranId":100}`), nil
+	})
+	res, err := account.NewBorrow(client, "USDT", "100").SetIsolatedSymbol(BTCUSDT).Call(ctx)
+	if err != nil || res.TranId != 100 {
+		t.Fatalf("borrow: %+v %v", res, err)
+	}
+	reqs := s.Requests()
+	last := reqs[len(reqs)-1]
+	if last.Params.Get("type") != "BORROW" || last.Params.Get("isIsolated") != "TRUE" || last.Params.Get("symbol") != BTCUSDT {
+		t.Fatalf("borrow: %v", last.Params)
+	}
+	_, err = account.NewRepay(client, "USDT", "100").Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs = s.Requests()
+	last = reqs[len(reqs)-1]
+	if last.Params.Get("type") != "REPAY" || last.Params.Get("isIsolated") != "FALSE" || last.Params.Has("symbol") {
+		t.Fatalf("repay: %v", last.Params)
+	}
+
+	s.Handle(http.MethodGet, consts.SApiMarginMaxBorrowable, func(r *binancetest.Request) (any, error) {
+		if r.Params.Get("asset") != "USDT" || r.Params.Get("isolatedSymbol") != BTCUSDT {
+			t.Errorf("maxBorrowable: %v", r.Params)
+		}
+		return []byte(`{"amount":"1.5","borrowLimit":"60"}`), nil
+	})
+	borrowable, err := account.NewMaxBorrowable(client, "USDT").SetIsolatedSymbol(BTCUSDT).CallBorrowable(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !borrowable.Amount.Equal(decimal.RequireFromString("1.5")) || !borrowable.BorrowLimit.Equal(decimal.NewFromInt(60)) {
+		t.Fatalf("maxBorrowable: %+v", borrowable)
+	}
+
+	s.Handle(http.MethodGet, consts.SApiMarginInterestHistory, func(r *binancetest.Request) (any, error) {
+		if r.Params.Get("asset") != "USDT" || r.Params.Get("size") != "100" {
+			t.Errorf("interestHistory: %v", r.Params)
+		}
+		return []byte(`{"rows":[{"txId":1,"asset":"USDT","principal":"100","interest":"0.01","interestRate":"0.0001","type":"ON_BORROW"}],"total":1}`), nil
+	})
+	history, err := account.NewInterestHistory(client).SetAsset("USDT").SetSize(100).Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if history.Total != 1 || !history.Rows[0].Interest.Equal(decimal.RequireFromString("0.01")) {
+		t.Fatalf("interestHistory: %+v", history)
+	}
+}
+
+func TestUserDataStream(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	s.AddKey("key", "secret")
+	client := binance.NewClient("key", "secret", s.URL)
+	ctx := context.Background()
+
+	uds := stream.NewIsolatedUserDataStream(client, BTCUSDT)
+	res, err := uds.CallCreate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = uds.SetListenKey(res.ListenKey).CallUpdate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = uds.CallDelete(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys := s.ListenKeys(); len(keys) != 0 {
+		t.Fatalf("listenKeys: %v", keys)
+	}
+	for _, r := range s.Requests() {
+		if r.Path != consts.SApiUserDataStreamIsolated || r.Params.Get("symbol") != BTCUSDT {
+			t.Fatalf("request: %s %v", r.Path, r.Params)
+		}
+	}
+}
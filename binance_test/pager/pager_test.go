@@ -0,0 +1,163 @@
+package pager_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/spot/account"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/binance/spot/market"
+)
+
+const (
+	BTCUSDT = "BTCUSDT"
+	t0      = 1699999980000
+	minute  = 60000
+	day     = 24 * 60 * minute
+)
+
+func param(r *binancetest.Request, key string) int64 {
+	n, _ := strconv.ParseInt(r.Params.Get(key), 10, 64)
+	return n
+}
+
+func TestKlines(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	s.Handle(http.MethodGet, consts.ApiMarketKLines, func(r *binancetest.Request) (any, error) {
+		var rows [][12]any
+		for open := max(param(r, "startTime"), t0); open < t0+2500*minute && open <= param(r, "endTime") && len(rows) < int(param(r, "limit")); open += minute {
+			rows = append(rows, [12]any{open, "1", "1", "1", "1", "1", open + minute - 1, "1", 1, "0", "0", "0"})
+		}
+		return rows, nil
+	})
+	klines, err := market.NewKlines(binance.NewClient("", "", s.URL), BTCUSDT, enums.Limit1000).
+		SetInterval(enums.KlineIntervalType1m).
+		SetStartTime(t0).
+		SetEndTime(t0 + 3000*minute).
+		Iterate().
+		Collect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(klines) != 2500 || len(s.Requests()) != 3 {
+		t.Fatalf("klines: %d requests: %d", len(klines), len(s.Requests()))
+	}
+}
+
+func TestKlinesMalformed(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	s.Handle(http.MethodGet, consts.ApiMarketKLines, func(r *binancetest.Request) (any, error) {
+		return [][12]any{{"x", "1", "1", "1", "1", "1", t0 + minute - 1, "1", 1, "0", "0", "0"}}, nil
+	})
+	_, err := market.NewKlines(binance.NewClient("", "", s.URL), BTCUSDT, enums.Limit1000).
+		SetInterval(enums.KlineIntervalType1m).
+		SetStartTime(t0).
+		SetEndTime(t0 + 10*minute).
+		Iterate().
+		Collect(context.Background())
+	if err == nil {
+		t.Fatal("malformed open time accepted")
+	}
+}
+
+// 每 6 小时 3 个订单，同一时间的订单跨越页边界
+func allOrdersServer() *binancetest.Server {
+	s := binancetest.NewServer()
+	s.Handle(http.MethodGet, consts.ApiTradingAllOrders, func(r *binancetest.Request) (any, error) {
+		start, end := param(r, "startTime"), param(r, "endTime")
+		if end-start >= day {
+			return nil, binancetest.Error(binance.ErrInvalidParameter.Code, "More than 24 hours between startTime and endTime.")
+		}
+		var rows []map[string]any
+		for id := int64(1); id <= 12 && len(rows) < int(param(r, "limit")); id++ {
+			tm := t0 + (id-1)/3*6*60*minute
+			if r.Params.Has("orderId") && id < param(r, "orderId") || r.Params.Has("startTime") && (tm < start || tm > end) {
+				continue
+			}
+			rows = append(rows, map[string]any{"symbol": BTCUSDT, "orderId": id, "time": tm})
+		}
+		return rows, nil
+	})
+	return s
+}
+
+func TestAllOrdersByTime(t *testing.T) {
+	s := allOrdersServer()
+	defer s.Close()
+	p := account.NewAllOrders(binance.NewClient("", "", s.URL), BTCUSDT, enums.LimitType(4)).
+		SetStartTime(t0).
+		SetEndTime(t0 + 3*day).
+		Iterate()
+	orders, err := p.Collect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []int
+	for _, order := range orders {
+		ids = append(ids, order.OrderId)
+	}
+	if len(ids) != 12 || ids[0] != 1 || ids[11] != 12 {
+		t.Fatalf("ids: %v", ids)
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i] != ids[i-1]+1 {
+			t.Fatalf("ids: %v", ids)
+		}
+	}
+}
+
+func TestAllOrdersById(t *testing.T) {
+	s := allOrdersServer()
+	defer s.Close()
+	p := account.NewAllOrders(binance.NewClient("", "", s.URL), BTCUSDT, enums.LimitType(5)).
+		SetOrderId(3).
+		SetEndTime(uint64(t0 + 12*60*minute)).
+		Iterate()
+	var ids []int
+	for p.Next(context.Background()) {
+		ids = append(ids, p.Value().OrderId)
+	}
+	if p.Err() != nil {
+		t.Fatal(p.Err())
+	}
+	// 第 10 个订单的时间晚于 endTime
+	if len(ids) != 7 || ids[0] != 3 || ids[6] != 9 {
+		t.Fatalf("ids: %v", ids)
+	}
+	if got := s.Requests()[1].Params.Get("orderId"); got != "8" {
+		t.Fatalf("orderId: %s", got)
+	}
+}
+
+func TestRateLimited(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	var calls int
+	s.Handle(http.MethodGet, consts.ApiMarketHistoricalTrades, func(r *binancetest.Request) (any, error) {
+		calls++
+		if calls == 1 {
+			err := binancetest.Error(binance.ErrTooManyRequests.Code, "Too many requests.")
+			err.Header = http.Header{"Retry-After": {"0"}}
+			return nil, err
+		}
+		var rows []map[string]any
+		for id := param(r, "fromId"); id < 5 && len(rows) < int(param(r, "limit")); id++ {
+			rows = append(rows, map[string]any{"id": id, "price": "1", "qty": "1"})
+		}
+		return rows, nil
+	})
+	trades, err := market.NewHistoryTrades(binance.NewClient("", "", s.URL), BTCUSDT, enums.LimitType(2)).Iterate().Collect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 5 || calls != 4 {
+		t.Fatalf("trades: %d calls: %d", len(trades), calls)
+	}
+}
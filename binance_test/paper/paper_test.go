@@ -0,0 +1,337 @@
+package paper_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sleep-go/coin-go/binance"
+	fenums "github.com/sleep-go/coin-go/binance/futures/enums"
+	ftrading "github.com/sleep-go/coin-go/binance/futures/trading"
+	"github.com/sleep-go/coin-go/binance/paper"
+	"github.com/sleep-go/coin-go/binance/spot/account"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/binance/spot/stream"
+	"github.com/sleep-go/coin-go/binance/spot/trading"
+	"github.com/sleep-go/coin-go/pkg/decimal"
+	"github.com/sleep-go/coin-go/pkg/orderbook"
+)
+
+const BTCUSDT = "BTCUSDT"
+
+func d(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func level(price, qty string) orderbook.Level {
+	return orderbook.Level{Price: d(price), Quantity: d(qty)}
+}
+
+func balance(e *paper.Engine, asset string) paper.Balance {
+	for _, b := range e.Balances() {
+		if b.Asset == asset {
+			return b
+		}
+	}
+	return paper.Balance{Asset: asset}
+}
+
+func newSpot(t *testing.T) (*paper.Engine, *binance.Client) {
+	e := paper.NewEngine()
+	t.Cleanup(e.Close)
+	e.SetBalance("USDT", d("10000")).SetBalance("BTC", d("1"))
+	e.OnDepth(BTCUSDT, []orderbook.Level{level("59990", "1")}, []orderbook.Level{level("60010", "1")})
+	e.OnTrade(BTCUSDT, d("60000"), d("0.01"))
+	return e, binance.NewClient("key", "secret", e.Server.URL)
+}
+
+func TestLimitOrder(t *testing.T) {
+	e, client := newSpot(t)
+	ctx := context.Background()
+	order, err := trading.NewOrder(client, BTCUSDT).
+		SetSide(enums.SideTypeBuy).
+		SetType(enums.OrderTypeLimit).
+		SetTimeInForce(enums.TimeInForceTypeGTC).
+		SetQuantity("0.1").
+		SetPrice("60000").
+		Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != "NEW" {
+		t.Fatalf("order: %+v", order)
+	}
+	if usdt := balance(e, "USDT"); !usdt.Locked.Equal(d("6000")) {
+		t.Fatalf("usdt: %+v", usdt)
+	}
+	// 成交价穿过挂单价，按挂单价作为 maker 成交
+	e.OnTrade(BTCUSDT, d("59999"), d("1"))
+	res, err := trading.NewQueryOrder(client, BTCUSDT).SetOrderId(int64(order.OrderId)).Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Status != "FILLED" || !res.ExecutedQtyDecimal().Equal(d("0.1")) {
+		t.Fatalf("query order: %+v", res)
+	}
+	usdt, btc := balance(e, "USDT"), balance(e, "BTC")
+	if !usdt.Free.Equal(d("4000")) || !usdt.Locked.IsZero() || !btc.Free.Equal(d("1.0999")) {
+		t.Fatalf("balances: %+v %+v", usdt, btc)
+	}
+}
+
+func TestMarketOrder(t *testing.T) {
+	e, client := newSpot(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	listenKey, err := stream.NewUserDataStream(client).CallCreate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reports := make(chan *account.WsExecutionReportEvent, 4)
+	positions := make(chan *account.WsOutboundAccountPositionEvent, 4)
+	ws, err := account.NewWsUserData(ctx, binance.NewWsClient(false, false, e.Server.WsURL()), listenKey.ListenKey,
+		func(event *account.WsOutboundAccountPositionEvent) { positions <- event },
+		func(event *account.WsBalanceUpdateEvent) {},
+		func(event *account.WsExecutionReportEvent) { reports <- event },
+		func(event *account.WsListStatusEvent) {},
+		func(event *account.WsListenKeyExpiredEvent) {},
+		func(messageType int, err error) { fmt.Println(err) },
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	err = e.Server.WaitSubscribed(ctx, listenKey.ListenKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	order, err := trading.NewOrder(client, BTCUSDT).
+		SetSide(enums.SideTypeSell).
+		SetType(enums.OrderTypeMarket).
+		SetQuantity("0.5").
+		Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 买一只有 1 BTC，按 59990 全部成交，手续费从 USDT 中扣除
+	if order.Status != "FILLED" || len(order.Fills) != 1 || !order.Fills[0].CommissionDecimal().Equal(d("29.995")) {
+		t.Fatalf("order: %+v", order)
+	}
+	for _, want := range []string{"NEW", "TRADE"} {
+		select {
+		case event := <-reports:
+			if event.ExecutionType != want {
+				t.Fatalf("got %s, want %s", event.ExecutionType, want)
+			}
+		case <-ctx.Done():
+			t.Fatal(ctx.Err())
+		}
+	}
+	select {
+	case event := <-positions:
+		if len(event.Balances) != 2 || !event.Balances[1].FreeDecimal().Equal(d("39965.005")) {
+			t.Fatalf("account position: %+v", event)
+		}
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+}
+
+func TestOco(t *testing.T) {
+	e, client := newSpot(t)
+	ctx := context.Background()
+	oco, err := trading.NewOco(client, BTCUSDT).
+		SetSide(enums.SideTypeSell).
+		SetQuantity("1").
+		SetAboveType(enums.OrderTypeLimitMaker).
+		SetAbovePrice("61000").
+		SetBelowType(enums.OrderTypeStopLossLimit).
+		SetBelowStopPrice("59000").
+		SetBelowPrice("58900").
+		SetBelowTimeInForce(enums.TimeInForceTypeGTC).
+		Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(oco.Orders) != 2 || !balance(e, "BTC").Locked.Equal(d("1")) {
+		t.Fatalf("oco: %+v", oco)
+	}
+	e.OnTrade(BTCUSDT, d("61001"), d("2"))
+	list, err := trading.NewOrderList(client).SetOrderListId(int64(oco.OrderListId)).Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.ListOrderStatus != "ALL_DONE" {
+		t.Fatalf("list: %+v", list)
+	}
+	statuses := map[string]string{}
+	for _, o := range list.Orders {
+		res, err := trading.NewQueryOrder(client, BTCUSDT).SetOrderId(int64(o.OrderId)).Call(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		statuses[string(res.Type)] = string(res.Status)
+	}
+	if statuses["LIMIT_MAKER"] != "FILLED" || statuses["STOP_LOSS_LIMIT"] != "EXPIRED" {
+		t.Fatalf("orders: %v", statuses)
+	}
+	if btc := balance(e, "BTC"); !btc.Free.IsZero() || !btc.Locked.IsZero() {
+		t.Fatalf("btc: %+v", btc)
+	}
+}
+
+func TestOto(t *testing.T) {
+	e, client := newSpot(t)
+	ctx := context.Background()
+	oto, err := trading.NewOTO(client, BTCUSDT).
+		SetWorkingType(enums.OrderTypeLimit).
+		SetWorkingSide(enums.SideTypeBuy).
+		SetWorkingPrice("60000").
+		SetWorkingQuantity("0.1").
+		SetWorkingTimeInForce(enums.TimeInForceTypeGTC).
+		SetPendingType(enums.OrderTypeLimitMaker).
+		SetPendingSide(enums.SideTypeSell).
+		SetPendingPrice("62000").
+		SetPendingQuantity("0.1").
+		Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pendingId := int64(oto.Orders[1].OrderId)
+	pending, err := trading.NewQueryOrder(client, BTCUSDT).SetOrderId(pendingId).Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending.Status != "PENDING_NEW" {
+		t.Fatalf("pending: %+v", pending)
+	}
+	e.OnDepth(BTCUSDT, []orderbook.Level{level("59990", "1")}, []orderbook.Level{level("60000", "1")})
+	pending, err = trading.NewQueryOrder(client, BTCUSDT).SetOrderId(pendingId).Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending.Status != "NEW" {
+		t.Fatalf("pending: %+v", pending)
+	}
+	_, err = trading.NewCancelReplace(client, BTCUSDT).
+		SetCancelReplaceMode(enums.CancelReplaceModeTypeStopOnFailure).
+		SetCancelOrderId(pendingId).
+		SetSide(enums.SideTypeSell).
+		SetType(enums.OrderTypeLimit).
+		SetTimeInForce(enums.TimeInForceTypeGTC).
+		SetQuantity("0.1").
+		SetPrice("63000").
+		Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	open, err := trading.NewQueryOrder(client, BTCUSDT).CallOpenOrders(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(open) != 1 || !open[0].PriceDecimal().Equal(d("63000")) {
+		t.Fatalf("open orders: %+v", open)
+	}
+}
+
+func TestFutures(t *testing.T) {
+	e := paper.NewFuturesEngine()
+	defer e.Close()
+	e.SetBalance("USDT", d("1000")).SetLeverage(BTCUSDT, 10)
+	e.OnDepth(BTCUSDT, []orderbook.Level{level("59990", "1")}, []orderbook.Level{level("60010", "1")})
+	client := binance.NewClient("key", "secret", e.Server.URL)
+	ctx := context.Background()
+
+	_, err := ftrading.NewOrder(client, BTCUSDT).
+		SetSide(fenums.SideTypeBuy).
+		SetType(fenums.OrderTypeLimit).
+		SetQuantity("1").
+		SetPrice("60000").
+		Call(ctx)
+	if err == nil {
+		t.Fatal("want margin insufficient")
+	}
+	order, err := ftrading.NewOrder(client, BTCUSDT).
+		SetSide(fenums.SideTypeBuy).
+		SetType(fenums.OrderTypeLimit).
+		SetQuantity("0.1").
+		SetPrice("59000").
+		Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ftrading.NewUpdateOrder(client, BTCUSDT).
+		SetOrderId(int64(order.OrderId)).
+		SetSide(fenums.SideTypeBuy).
+		SetQuantity("0.1").
+		SetPrice("60010").
+		Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	positions := e.Positions()
+	if len(positions) != 1 || !positions[0].Amount.Equal(d("0.1")) || !positions[0].EntryPrice.Equal(d("60010")) {
+		t.Fatalf("positions: %+v", positions)
+	}
+	e.OnDepth(BTCUSDT, []orderbook.Level{level("61000", "1")}, []orderbook.Level{level("61010", "1")})
+	closed, err := ftrading.NewOrder(client, BTCUSDT).
+		SetSide(fenums.SideTypeSell).
+		SetType(fenums.OrderTypeMarket).
+		SetReduceOnly(true).
+		SetQuantity("0.2").
+		Call(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed.Status != "FILLED" || !closed.ExecutedQtyDecimal().Equal(d("0.1")) {
+		t.Fatalf("close: %+v", closed)
+	}
+	// 盈利 99，手续费 0.05% * (6001 + 6100)
+	usdt := balance(e, "USDT")
+	if len(e.Positions()) != 0 || !usdt.Free.Equal(d("1092.9495")) || !usdt.Locked.IsZero() {
+		t.Fatalf("usdt: %+v positions: %+v", usdt, e.Positions())
+	}
+}
+
+func TestFeedStopsStartedStreams(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	var accepted atomic.Int32
+	closed := make(chan struct{})
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 第一个交易对的深度推送可以连接，之后的连接失败
+		if accepted.Add(1) > 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				close(closed)
+				return
+			}
+		}
+	}))
+	defer s.Close()
+	e := paper.NewEngine()
+	defer e.Close()
+	err := e.Feed(context.Background(), binance.NewClient("", "", s.URL), []string{BTCUSDT, "ETHUSDT"}, "ws"+strings.TrimPrefix(s.URL, "http"))
+	if err == nil {
+		t.Fatal("feed succeeded")
+	}
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("started stream not stopped")
+	}
+}
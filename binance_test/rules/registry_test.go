@@ -0,0 +1,113 @@
+package rules_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance/rules"
+)
+
+const BTCUSDT = "BTCUSDT"
+
+// newRegistry 每次加载前等待 release，返回加载次数
+func newRegistry(release <-chan struct{}) (*rules.Registry, *atomic.Int32) {
+	var loads atomic.Int32
+	r := rules.NewRegistry(func(ctx context.Context) ([]*rules.Symbol, error) {
+		loads.Add(1)
+		<-release
+		return []*rules.Symbol{{Symbol: BTCUSDT}}, nil
+	})
+	return r, &loads
+}
+
+func TestSymbolConcurrent(t *testing.T) {
+	release := make(chan struct{})
+	r, loads := newRegistry(release)
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := r.Symbol(context.Background(), "UNKNOWN")
+			errs <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if !errors.Is(err, rules.ErrUnknownSymbol) {
+			t.Fatalf("err: %v", err)
+		}
+	}
+	if n := loads.Load(); n != 1 {
+		t.Fatalf("loads: %d", n)
+	}
+}
+
+func TestSymbolUnknownCached(t *testing.T) {
+	release := make(chan struct{})
+	close(release)
+	r, loads := newRegistry(release)
+	s, err := r.Symbol(context.Background(), BTCUSDT)
+	if err != nil || s.Symbol != BTCUSDT {
+		t.Fatalf("symbol: %v %v", s, err)
+	}
+	for _, symbol := range []string{"UNKNOWN", "UNKNOWN", "OTHER"} {
+		if _, err = r.Symbol(context.Background(), symbol); !errors.Is(err, rules.ErrUnknownSymbol) {
+			t.Fatalf("%s: %v", symbol, err)
+		}
+	}
+	if n := loads.Load(); n != 1 {
+		t.Fatalf("loads: %d", n)
+	}
+	// 超过 UnknownTTL 后重新加载
+	r.UnknownTTL = time.Millisecond
+	time.Sleep(5 * time.Millisecond)
+	if _, err = r.Symbol(context.Background(), "UNKNOWN"); !errors.Is(err, rules.ErrUnknownSymbol) {
+		t.Fatalf("err: %v", err)
+	}
+	if n := loads.Load(); n != 2 {
+		t.Fatalf("loads: %d", n)
+	}
+}
+
+func TestRefreshCallerCancel(t *testing.T) {
+	release := make(chan struct{})
+	var loads atomic.Int32
+	r := rules.NewRegistry(func(ctx context.Context) ([]*rules.Symbol, error) {
+		loads.Add(1)
+		<-release
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return []*rules.Symbol{{Symbol: BTCUSDT}}, nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() { first <- r.Refresh(ctx) }()
+	for loads.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	second := make(chan error, 1)
+	go func() { second <- r.Refresh(context.Background()) }()
+	// 第一个调用方取消只影响自己，加载继续
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Fatalf("first: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	if err := <-second; err != nil {
+		t.Fatalf("second: %v", err)
+	}
+	if s, err := r.Symbol(context.Background(), BTCUSDT); err != nil || s.Symbol != BTCUSDT || loads.Load() != 1 {
+		t.Fatalf("symbol: %v %v loads: %d", s, err, loads.Load())
+	}
+}
@@ -2,14 +2,16 @@ package spot_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
-	"strings"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
 	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/rules"
 	"github.com/sleep-go/coin-go/binance/spot/account"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
 	"github.com/sleep-go/coin-go/binance/spot/general"
@@ -20,219 +22,400 @@ import (
 	"github.com/spf13/cast"
 )
 
-var client *binance.Client
-
 const (
 	BTCUSDT = "BTCUSDT"
 	ETHUSDT = "ETHUSDT"
 )
 
-func init() {
-	// 设置身份验证
-	file, err := os.ReadFile("./.test.env")
+// newServer 启动模拟服务器，加载 testdata/fixtures.json 中录制的响应并注册 ED25519 API Key
+func newServer(t *testing.T) (*binancetest.Server, string) {
+	t.Helper()
+	s := binancetest.NewServer()
+	t.Cleanup(s.Close)
+	err := s.LoadFixtures("testdata/fixtures.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, public, err := binancetest.GenerateED25519Key(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.AddPublicKey("ed25519", public)
+	return s, path
+}
+
+// newClient 连接模拟服务器的 REST client
+func newClient(t *testing.T) (*binancetest.Server, *binance.Client) {
+	t.Helper()
+	s, path := newServer(t)
+	return s, binance.NewED25519Client("ed25519", path, s.URL)
+}
+
+// lastRequest 服务器收到的最后一个请求
+func lastRequest(t *testing.T, s *binancetest.Server) *binancetest.Request {
+	t.Helper()
+	requests := s.Requests()
+	if len(requests) == 0 {
+		t.Fatal("no request")
+	}
+	return requests[len(requests)-1]
+}
+
+// placeOrder 通过默认下单接口创建一个挂单
+func placeOrder(t *testing.T, client *binance.Client) int64 {
+	t.Helper()
+	res, err := trading.NewOrder(client, BTCUSDT).
+		SetQuantity("0.0001").
+		SetPrice("60000").
+		SetType(enums.OrderTypeLimit).
+		SetTimeInForce(enums.TimeInForceTypeGTC).
+		SetSide(enums.SideTypeBuy).
+		Call(context.Background())
 	if err != nil {
-		panic(err)
-		return
+		t.Fatal(err)
 	}
-	API_KEY := strings.TrimSpace(string(file))
-	PRIVATE_KEY_PATH := "./test-prv-key.pem"
-	fmt.Println(API_KEY)
-	client = binance.NewED25519Client(API_KEY, PRIVATE_KEY_PATH, consts.REST_API_TEST)
-	client.Debug = true
+	return int64(res.OrderId)
 }
+
 func TestPing(t *testing.T) {
+	s, client := newClient(t)
 	res, err := general.NewPing(client).Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
 	fmt.Println(res)
+	if r := lastRequest(t, s); r.Path != consts.ApiPing {
+		t.Fatalf("path: %s", r.Path)
+	}
 }
 func TestTime(t *testing.T) {
+	s, client := newClient(t)
+	s.TimeOffset = -time.Hour.Milliseconds()
+	before := s.Now()
 	res, err := general.NewTime(client).Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
 	fmt.Println(res)
+	if res.ServerTime < before || res.ServerTime > s.Now() {
+		t.Fatalf("serverTime: %d", res.ServerTime)
+	}
+}
+func TestTimeSync(t *testing.T) {
+	s, client := newClient(t)
+	s.TimeOffset = -time.Hour.Milliseconds()
+	// 本地时钟快 1 小时，timestamp 超出 recvWindow
+	_, err := account.NewGetAccount(client).Call(context.Background())
+	if !errors.Is(err, binance.ErrTimestampOutsideRecvWindow) {
+		t.Fatalf("err: %v", err)
+	}
+	ts := general.NewTimeSync(client)
+	err = ts.Sync(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offset := ts.Offset(); offset > -time.Hour+time.Second || offset < -time.Hour-time.Second {
+		t.Fatalf("offset: %v rtt: %v", offset, ts.RTT())
+	}
+	res, err := account.NewGetAccount(client).Call(binance.WithRecvWindow(context.Background(), 3000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Uid != 354937868 {
+		t.Fatalf("account: %+v", res)
+	}
+	if r := lastRequest(t, s); r.Params.Get("recvWindow") != "3000" {
+		t.Fatalf("params: %v", r.Params)
+	}
+}
+func TestRateLimiter(t *testing.T) {
+	s, client := newClient(t)
+	limiter, err := general.NewRateLimiter(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	limiter.Block = false
+	_, err = market.NewDepth(client, BTCUSDT, enums.Limit5000).Call(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// exchangeInfo 返回的 4 个限制，depth limit=5000 权重 250
+	limits := limiter.Limits()
+	if len(limits) != 4 || limits[0].RateLimitType != binance.RateLimitRequestWeight || limits[0].Limit != 6000 || limits[0].Count < 250 {
+		t.Fatalf("limits: %+v", limits)
+	}
+	// 超过上限时不发送请求，立即返回 ErrRateLimited
+	limiter.SetLimits(binance.RateLimits{RateLimitType: binance.RateLimitRequestWeight, Interval: "MINUTE", IntervalNum: 1, Limit: 100})
+	n := len(s.Requests())
+	_, err = market.NewDepth(client, BTCUSDT, enums.Limit5000).Call(context.Background())
+	if !errors.Is(err, binance.ErrRateLimited) {
+		t.Fatalf("err: %v", err)
+	}
+	if len(s.Requests()) != n {
+		t.Fatalf("requests: %d", len(s.Requests()))
+	}
+}
+func TestRetryPolicy(t *testing.T) {
+	s, _ := newClient(t)
+	down := binancetest.NewServer()
+	down.Close()
+	slow := binancetest.NewServer()
+	defer slow.Close()
+	slow.Handle(http.MethodGet, consts.ApiMarketDepth, func(r *binancetest.Request) (any, error) {
+		time.Sleep(500 * time.Millisecond)
+		return map[string]any{"lastUpdateId": 2, "bids": [][]string{}, "asks": [][]string{}}, nil
+	})
+
+	// 连接失败的 host 被跳过，切换到下一个 host
+	c := binance.NewClient("", "", down.URL)
+	c.Retry = binance.NewRetryPolicy(down.URL, s.URL)
+	c.Retry.InitialBackoff = 10 * time.Millisecond
+	res, err := market.NewDepth(c, BTCUSDT, enums.Limit5).Call(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if healthy := c.Retry.Healthy(); res.LastUpdateId != 1027024 || len(healthy) != 1 || healthy[0] != s.URL {
+		t.Fatalf("lastUpdateId: %d healthy: %v", res.LastUpdateId, healthy)
+	}
+
+	// 超过 Hedge 未返回时向下一个 host 再发一次，取先返回的结果
+	c = binance.NewClient("", "", slow.URL)
+	c.Retry = binance.NewRetryPolicy(slow.URL, s.URL)
+	c.Retry.Hedge = 50 * time.Millisecond
+	res, err = market.NewDepth(c, BTCUSDT, enums.Limit5).Call(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.LastUpdateId != 1027024 {
+		t.Fatalf("lastUpdateId: %d", res.LastUpdateId)
+	}
 }
 func TestNewExchangeInfo(t *testing.T) {
-	response, err := general.NewExchangeInfo(client, []string{"ETHUSDT", BTCUSDT}, nil).Call(context.Background())
+	s, client := newClient(t)
+	response, err := general.NewExchangeInfo(client, []string{ETHUSDT, BTCUSDT}, nil).Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
-		return
 	}
-	fmt.Println(response)
+	if len(response.Symbols) != 2 || response.Symbols[0].Symbol != BTCUSDT || len(response.Symbols[0].Filters) != 3 {
+		t.Fatalf("exchangeInfo: %+v", response)
+	}
+	if r := lastRequest(t, s); r.Params.Get("symbols") != `["ETHUSDT","BTCUSDT"]` {
+		t.Fatalf("params: %v", r.Params)
+	}
 }
 func TestDepth(t *testing.T) {
+	s, client := newClient(t)
 	response, err := market.NewDepth(client, "ETCUSDT", enums.Limit20).Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(response.Asks))
-	fmt.Println(len(response.Bids))
-	fmt.Println(response.LastUpdateId)
+	if response.LastUpdateId != 1027024 || len(response.Bids) != 1 || response.Asks[0][1] != "12.00000000" {
+		t.Fatalf("depth: %+v", response)
+	}
+	if r := lastRequest(t, s); r.Params.Get("symbol") != "ETCUSDT" || r.Params.Get("limit") != "20" {
+		t.Fatalf("params: %v", r.Params)
+	}
 }
 func TestTrades(t *testing.T) {
+	_, client := newClient(t)
 	res, err := market.NewTrades(client, BTCUSDT, enums.Limit20).Call(context.Background())
 	if err != nil {
-		fmt.Println(res)
 		t.Fatal(err)
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 1 || res[0].Id != 28457 || res[0].Qty != "12.00000000" {
+		t.Fatalf("trades: %+v", res)
 	}
 }
 func TestHistoryTrades(t *testing.T) {
+	s, client := newClient(t)
 	res, err := market.NewHistoryTrades(client, BTCUSDT, 1).
 		SetFromId(3049539).
 		Call(context.Background())
 	if err != nil {
-		t.Fatal(err.Error())
-		return
+		t.Fatal(err)
 	}
-	for _, r := range res {
-		fmt.Println(r)
+	if len(res) != 1 || res[0].Id != 3049539 {
+		t.Fatalf("historicalTrades: %+v", res)
+	}
+	if r := lastRequest(t, s); r.Params.Get("fromId") != "3049539" || r.Params.Get("limit") != "1" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestAggTrades(t *testing.T) {
+	s, client := newClient(t)
+	end := time.Now().UnixMilli()
 	res, err := market.NewAggTrades(client, BTCUSDT, 1).
-		SetStartTime(time.Now().UnixMilli() - 60*60*24*30*365*5).
-		SetEndTime(time.Now().UnixMilli()).
-		//SetFromId(3031206).
+		SetStartTime(end - time.Hour.Milliseconds()).
+		SetEndTime(end).
 		Call(context.Background())
 	if err != nil {
-		t.Fatal(err.Error())
-		return
+		t.Fatal(err)
 	}
-	for _, r := range res {
-		fmt.Println(r)
+	if len(res) != 1 || res[0].AggTradeID != 26129 || !res[0].IsBuyerMaker {
+		t.Fatalf("aggTrades: %+v", res)
+	}
+	if r := lastRequest(t, s); r.Params.Get("startTime") != cast.ToString(end-time.Hour.Milliseconds()) || r.Params.Get("endTime") != cast.ToString(end) {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestKlines(t *testing.T) {
+	s, client := newClient(t)
 	k := market.NewKlines(client, BTCUSDT, enums.Limit100).
 		SetInterval(enums.KlineIntervalType1M).
-		SetStartTime(time.Now().UnixMilli() - 60*60*24*30*365*5).
-		SetEndTime(time.Now().UnixMilli()).
 		SetTimeZone("0")
 	res, err := k.Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
-		return
 	}
 	res1, err := k.CallUI(context.Background())
 	if err != nil {
-		t.Fatal(err.Error())
-		return
+		t.Fatal(err)
+	}
+	if r := lastRequest(t, s); r.Path != consts.ApiMarketUIKLines || r.Params.Get("interval") != "1M" || r.Params.Get("timeZone") != "0" {
+		t.Fatalf("request: %s %v", r.Path, r.Params)
+	}
+	if len(res) != 1 || len(res1) != 1 {
+		t.Fatalf("klines: %v %v", res, res1)
 	}
 	for i, r := range res {
-		fmt.Print(time.UnixMilli(cast.ToInt64(r[0])).Format(time.DateTime), "开盘时间 ") // 开盘时间
-		fmt.Print(r[1], " ", res1[i][1], "开盘价 ")                                     // 开盘价
-		fmt.Print(r[2], " ", res1[i][2], "最高价 ")                                     // 最高价
-		fmt.Print(r[3], " ", res1[i][3], "最低价 ")                                     // 最低价
-		fmt.Print(r[4], " ", res1[i][4], "收盘价 ")                                     // 收盘价(当前K线未结束的即为最新价)
-		fmt.Print(r[5], "成交量 ")                                                      // 成交量
-		fmt.Print(time.UnixMilli(cast.ToInt64(r[6])), "收盘时间 ")                       // 收盘时间
-		fmt.Print(r[7], "成交额 ")                                                      // 成交额
-		fmt.Print(r[8], "成交笔数 ")                                                     // 成交笔数
-		fmt.Print(r[9], "主动买入成交量 ")                                                  // 主动买入成交量
-		fmt.Print(r[10], "主动买入成交额 ")                                                 // 主动买入成交额
-		fmt.Println(r[11])                                                           // 请忽略该参数
+		if cast.ToInt64(r[0]) != 1499040000000 || r[1] != res1[i][1] || r[4] != "0.01577100" || cast.ToInt(r[8]) != 308 {
+			t.Fatalf("kline: %v", r)
+		}
 	}
 }
 func TestAvgPrice(t *testing.T) {
+	_, client := newClient(t)
 	res, err := market.NewAvgPrice(client, BTCUSDT).Call(context.Background())
 	if err != nil {
-		t.Fatal(err.Error())
-		return
+		t.Fatal(err)
+	}
+	if res.Mins != 5 || res.Price != "9.35751834" {
+		t.Fatalf("avgPrice: %+v", res)
 	}
-	fmt.Println(res)
 }
 func TestHr24(t *testing.T) {
-	res, err := ticker.NewHr24(client, []string{"ETHUSDT", "BNBBTC"}, enums.TickerTypeFull).Call(context.Background())
+	s, client := newClient(t)
+	res, err := ticker.NewHr24(client, []string{ETHUSDT, "BNBBTC"}, enums.TickerTypeFull).Call(context.Background())
 	if err != nil {
-		t.Fatal(err.Error())
-		return
+		t.Fatal(err)
+	}
+	if len(res) != 2 || res[0].Symbol != ETHUSDT || res[1].Count != 51 {
+		t.Fatalf("hr24: %+v", res)
 	}
-	for _, v := range res {
-		fmt.Println(v)
+	if r := lastRequest(t, s); r.Params.Get("symbols") != `["ETHUSDT","BNBBTC"]` || r.Params.Get("type") != "FULL" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestTradingDay(t *testing.T) {
+	s, client := newClient(t)
 	res, err := ticker.NewTradingDay(client, []string{BTCUSDT}, "+8:00", enums.TickerTypeFull).Call(context.Background())
 	if err != nil {
-		t.Fatal(err.Error())
-		return
+		t.Fatal(err)
+	}
+	if len(res) != 1 || res[0].Count != 697727 {
+		t.Fatalf("tradingDay: %+v", res)
 	}
-	for _, v := range res {
-		fmt.Println(v)
+	if r := lastRequest(t, s); r.Params.Get("timeZone") != "+8:00" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestNewPrice(t *testing.T) {
-	res, err := ticker.NewPrice(client, []string{"ETHUSDT", "BNBBTC"}).Call(context.Background())
+	_, client := newClient(t)
+	res, err := ticker.NewPrice(client, []string{ETHUSDT, "BNBBTC"}).Call(context.Background())
 	if err != nil {
-		return
-	}
-	if err != nil {
-		t.Fatal(err.Error())
-		return
+		t.Fatal(err)
 	}
-	for _, v := range res {
-		fmt.Println(v)
+	if len(res) != 2 || res[0].Price != "2428.77000000" {
+		t.Fatalf("price: %+v", res)
 	}
 }
 func TestBookTicker(t *testing.T) {
-	res, err := ticker.NewBookTicker(client, []string{"ETHUSDT", "BNBBTC"}).Call(context.Background())
+	_, client := newClient(t)
+	res, err := ticker.NewBookTicker(client, []string{ETHUSDT, "BNBBTC"}).Call(context.Background())
 	if err != nil {
-		t.Fatal(err.Error())
-		return
+		t.Fatal(err)
 	}
-	for _, v := range res {
-		fmt.Println(v)
+	if len(res) != 2 || res[0].BidQty != "31.21000000" || res[1].Symbol != "BNBBTC" {
+		t.Fatalf("bookTicker: %+v", res)
 	}
 }
 func TestTicker(t *testing.T) {
-	var u uint8 = 255
-	fmt.Println(u)
-	res, err := ticker.NewTicker(client, []string{"ETHUSDT"}, enums.TickerTypeFull).SetMinute(1).Call(context.Background())
+	s, client := newClient(t)
+	res, err := ticker.NewTicker(client, []string{ETHUSDT}, enums.TickerTypeFull).SetMinute(1).Call(context.Background())
 	if err != nil {
-		t.Fatal(err.Error())
-		return
+		t.Fatal(err)
+	}
+	if len(res) != 1 || res[0].LastPrice != "1080.00000000" {
+		t.Fatalf("ticker: %+v", res)
 	}
-	for _, v := range res {
-		fmt.Println(v)
+	if r := lastRequest(t, s); r.Params.Get("windowSize") != "1m" {
+		t.Fatalf("params: %v", r.Params)
 	}
-	res, err = ticker.NewTicker(client, []string{"ETHUSDT", BTCUSDT}, enums.TickerTypeFull).SetDay(1).Call(context.Background())
+	_, err = ticker.NewTicker(client, []string{ETHUSDT, BTCUSDT}, enums.TickerTypeFull).SetDay(1).Call(context.Background())
 	if err != nil {
-		t.Fatal(err.Error())
-		return
+		t.Fatal(err)
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if r := lastRequest(t, s); r.Params.Get("windowSize") != "1d" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestQueryOrder(t *testing.T) {
+	_, client := newClient(t)
+	orderId := placeOrder(t, client)
 	res, err := trading.NewQueryOrder(client, BTCUSDT).
 		//SetFormId，SetOrigClientOrderId 二选一
-		SetOrderId(30102167318).
-		//SetFormId，SetOrigClientOrderId 二选一
-		//SetOrigClientOrderId("ios_e5556c10ddda4b4e8520c300cbab4c73").
+		SetOrderId(orderId).
 		Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
-		return
 	}
-	fmt.Printf("%+v\n", res)
+	if int64(res.OrderId) != orderId || res.Status != enums.OrderStatusTypeNew || res.Price != "60000" {
+		t.Fatalf("queryOrder: %+v", res)
+	}
+}
+func TestQueryUnknownOrder(t *testing.T) {
+	_, client := newClient(t)
+	_, err := trading.NewQueryOrder(client, BTCUSDT).
+		SetOrderId(1).
+		Call(context.Background())
+	var e *binance.APIError
+	if !errors.Is(err, binance.ErrUnknownOrder) || !errors.Is(err, binance.ErrNoSuchOrder) || !errors.As(err, &e) {
+		t.Fatalf("err: %v", err)
+	}
+	if e.StatusCode != http.StatusBadRequest || e.Code != -2013 || binance.IsRetryable(err) || binance.IsExecutionUnknown(err) {
+		t.Fatalf("err: %+v", e)
+	}
+	// 撤销不存在的订单返回 -2011 Unknown order sent.
+	_, err = trading.NewDeleteOrder(client, BTCUSDT).
+		SetOrderId(1).
+		Call(context.Background())
+	if !errors.Is(err, binance.ErrUnknownOrder) || !errors.Is(err, binance.ErrCancelRejected) {
+		t.Fatalf("err: %v", err)
+	}
 }
 func TestOpenOrders(t *testing.T) {
+	s, client := newClient(t)
+	s.Handle(http.MethodGet, consts.ApiOpenOrders, func(r *binancetest.Request) (any, error) {
+		var orders []map[string]any
+		for _, o := range s.Orders() {
+			if o["symbol"] == r.Params.Get("symbol") && o["status"] == "NEW" {
+				orders = append(orders, o)
+			}
+		}
+		return orders, nil
+	})
+	orderId := placeOrder(t, client)
 	res, err := trading.NewQueryOrder(client, BTCUSDT).
-		//SetTimestamp(time.Now().UnixMilli()).
 		CallOpenOrders(context.Background())
 	if err != nil {
 		t.Fatal(err)
-		return
 	}
-	fmt.Printf("%+v\n", res)
+	if len(res) != 1 || int64(res[0].OrderId) != orderId {
+		t.Fatalf("openOrders: %+v", res)
+	}
 }
 func TestCreateOrder(t *testing.T) {
+	s, client := newClient(t)
 	res, err := trading.NewOrder(client, BTCUSDT).
 		SetQuantity("1").
 		SetType(enums.OrderTypeMarket).
@@ -241,19 +424,65 @@ func TestCreateOrder(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
+	if res.Discount.DiscountAsset != "BNB" || res.StandardCommissionForOrder.Taker != "0.00000114" {
+		t.Fatalf("order/test: %+v", res)
+	}
+	if r := lastRequest(t, s); !r.Signed || r.Params.Get("computeCommissionRates") != "true" || len(s.Orders()) != 0 {
+		t.Fatalf("request: %+v", r)
+	}
+}
+func TestCreateOrderRules(t *testing.T) {
+	s, client := newClient(t)
+	registry := general.NewRules(client, BTCUSDT)
+	// 按 tickSize 0.01 和 stepSize 0.00001 舍入后发送
+	_, err := trading.NewOrder(client, BTCUSDT).
+		SetQuantity("0.000523456").
+		SetPrice("10000.123456").
+		SetType(enums.OrderTypeLimit).
+		SetTimeInForce(enums.TimeInForceTypeGTC).
+		SetSide(enums.SideTypeBuy).
+		SetRules(registry, true).
+		CallTest(context.Background(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := lastRequest(t, s); r.Path != consts.ApiTradingOrderTest || r.Params.Get("quantity") != "0.00052" || r.Params.Get("price") != "10000.12" {
+		t.Fatalf("request: %s %v", r.Path, r.Params)
+	}
+	// 名义价值低于 NOTIONAL 的 minNotional 5，不发送请求
+	n := len(s.Requests())
+	_, err = trading.NewOrder(client, BTCUSDT).
+		SetQuantity("0.000123456").
+		SetPrice("10000.123456").
+		SetType(enums.OrderTypeLimit).
+		SetTimeInForce(enums.TimeInForceTypeGTC).
+		SetSide(enums.SideTypeBuy).
+		SetRules(registry, true).
+		CallTest(context.Background(), true)
+	var ve *rules.ValidationError
+	if !errors.As(err, &ve) || len(ve.Violations) != 1 || ve.Violations[0].Filter != rules.FilterNotional {
+		t.Fatalf("err: %v", err)
+	}
+	if len(s.Requests()) != n {
+		t.Fatalf("requests: %d", len(s.Requests()))
+	}
 }
 func TestAllOrders(t *testing.T) {
+	s, client := newClient(t)
 	res, err := account.NewAllOrders(client, BTCUSDT, enums.Limit20).
 		Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 1 || res[0].ClientOrderId != "myOrder1" || res[0].Status != enums.OrderStatusTypeNew {
+		t.Fatalf("allOrders: %+v", res)
+	}
+	if r := lastRequest(t, s); !r.Signed || r.Params.Get("limit") != "20" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestCancelReplace(t *testing.T) {
+	s, client := newClient(t)
 	res, err := trading.NewCancelReplace(client, BTCUSDT).
 		SetSide(enums.SideTypeBuy).
 		SetQuantity("0.0001").
@@ -264,93 +493,121 @@ func TestCancelReplace(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
+	if res.CancelResult != "SUCCESS" || res.CancelResponse.OrderId != 123 || res.NewOrderResponse.Status != "FILLED" {
+		t.Fatalf("cancelReplace: %+v", res)
+	}
+	if r := lastRequest(t, s); r.Params.Get("cancelReplaceMode") != "STOP_ON_FAILURE" || r.Params.Get("cancelOrderId") != "123" {
+		t.Fatalf("params: %v", r.Params)
+	}
 }
 func TestDeleteOrder(t *testing.T) {
+	s, client := newClient(t)
+	orderId := placeOrder(t, client)
 	response, err := trading.NewDeleteOrder(client, BTCUSDT).
-		SetOrderId(394763750).
+		SetOrderId(orderId).
 		SetCancelRestrictions(enums.CancelRestrictionsTypeOnlyNew).
 		Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
-		return
 	}
-	fmt.Printf("%+v\n", response)
+	if int64(response.OrderId) != orderId || response.Status != enums.OrderStatusTypeCanceled {
+		t.Fatalf("deleteOrder: %+v", response)
+	}
+	if r := lastRequest(t, s); r.Params.Get("cancelRestrictions") != "ONLY_NEW" {
+		t.Fatalf("params: %v", r.Params)
+	}
 }
 func TestDeleteOpenOrders(t *testing.T) {
+	_, client := newClient(t)
 	response, err := trading.NewDeleteOpenOrders(client, BTCUSDT).Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
-		return
 	}
-	fmt.Printf("%+v\n", response)
+	if len(response) != 1 || response[0].OrderId != 11 || response[0].Status != "CANCELED" {
+		t.Fatalf("openOrders: %+v", response)
+	}
 }
 func TestGetAccount(t *testing.T) {
+	s, client := newClient(t)
 	response, err := account.NewGetAccount(client).
 		SetOmitZeroBalances(true).
 		Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	for _, balance := range response.Balances {
-		fmt.Printf("%+v\n", balance)
+	if len(response.Balances) != 2 || response.Balances[0].Asset != "BTC" || response.Permissions[0] != "SPOT" {
+		t.Fatalf("account: %+v", response)
+	}
+	if r := lastRequest(t, s); r.Params.Get("omitZeroBalances") != "true" {
+		t.Fatalf("params: %v", r.Params)
 	}
-	fmt.Printf("%+v\n", response.Permissions)
 }
 func TestMyTrades(t *testing.T) {
+	_, client := newClient(t)
 	res, err := account.NewMyTrades(client, BTCUSDT, 500).
-		//SetFormId(11750571916).
 		Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 1 || res[0].OrderId != 100234 || res[0].CommissionAsset != "BNB" {
+		t.Fatalf("myTrades: %+v", res)
 	}
 }
 func TestRateLimitOrder(t *testing.T) {
+	_, client := newClient(t)
 	res, err := account.NewRateLimitOrder(client).Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 2 || res[1].Interval != "DAY" || res[1].Limit != 20000 {
+		t.Fatalf("rateLimit/order: %+v", res)
 	}
 }
 func TestMyPreventedMatches(t *testing.T) {
+	s, client := newClient(t)
 	res, err := account.NewMyPreventedMatches(client, BTCUSDT, enums.Limit20).
 		SetOrderId(11750571916).
 		Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 1 || res[0].MakerOrderId != 11750571916 {
+		t.Fatalf("myPreventedMatches: %+v", res)
+	}
+	if r := lastRequest(t, s); r.Params.Get("orderId") != "11750571916" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestMyAllocations(t *testing.T) {
+	_, client := newClient(t)
 	res, err := account.NewMyAllocations(client, BTCUSDT, enums.Limit20).Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	for _, v := range res {
-		fmt.Printf("%+v\n", v)
+	if len(res) != 1 || res[0].AllocationType != "SOR" || res[0].Qty != "5.00000000" {
+		t.Fatalf("myAllocations: %+v", res)
 	}
 }
 func TestCommission(t *testing.T) {
+	_, client := newClient(t)
 	res, err := account.NewCommission(client, BTCUSDT).
 		Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
+	if res.Symbol != BTCUSDT || res.Discount.Discount != "0.75000000" {
+		t.Fatalf("commission: %+v", res)
+	}
 }
 func TestUserDataStream(t *testing.T) {
+	s, client := newClient(t)
 	res, err := stream.NewUserDataStream(client).CallCreate(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
+	if keys := s.ListenKeys(); len(keys) != 1 || keys[0] != res.ListenKey {
+		t.Fatalf("listenKeys: %v %+v", keys, res)
+	}
 	err = stream.NewUserDataStream(client).
 		SetListenKey(res.ListenKey).
 		CallUpdate(context.Background())
@@ -363,8 +620,12 @@ func TestUserDataStream(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	if keys := s.ListenKeys(); len(keys) != 0 {
+		t.Fatalf("listenKeys: %v", keys)
+	}
 }
 func TestOCO(t *testing.T) {
+	s, client := newClient(t)
 	res, err := trading.NewOco(client, BTCUSDT).
 		SetSide(enums.SideTypeSell).
 		SetQuantity("1").
@@ -381,9 +642,15 @@ func TestOCO(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
+	if res.ContingencyType != "OCO" || len(res.Orders) != 2 || len(res.OrderReports) != 2 {
+		t.Fatalf("oco: %+v", res)
+	}
+	if r := lastRequest(t, s); r.Params.Get("aboveType") != "STOP_LOSS_LIMIT" || r.Params.Get("aboveTimeInForce") != "IOC" || r.Params.Get("belowStopPrice") != "1" {
+		t.Fatalf("params: %v", r.Params)
+	}
 }
 func TestOTO(t *testing.T) {
+	s, client := newClient(t)
 	res, err := trading.NewOTO(client, BTCUSDT).
 		SetWorkingType(enums.OrderTypeLimit).
 		SetWorkingSide(enums.SideTypeSell).
@@ -400,9 +667,15 @@ func TestOTO(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
+	if res.ContingencyType != "OTO" || len(res.OrderReports) != 2 || res.OrderReports[1].Status != enums.OrderStatusTypePendingNew {
+		t.Fatalf("oto: %+v", res)
+	}
+	if r := lastRequest(t, s); r.Params.Get("workingType") != "LIMIT" || r.Params.Get("pendingType") != "STOP_LOSS_LIMIT" {
+		t.Fatalf("params: %v", r.Params)
+	}
 }
 func TestOTOCO(t *testing.T) {
+	s, client := newClient(t)
 	res, err := trading.NewOtoco(client, BTCUSDT).
 		SetWorkingType(enums.OrderTypeMarket).
 		SetWorkingSide(enums.SideTypeSell).
@@ -416,26 +689,41 @@ func TestOTOCO(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
+	if len(res.Orders) != 3 || len(res.OrderReports) != 3 {
+		t.Fatalf("otoco: %+v", res)
+	}
+	if r := lastRequest(t, s); r.Params.Get("pendingAboveType") != "STOP_LOSS_LIMIT" || r.Params.Get("pendingBelowType") != "STOP_LOSS_LIMIT" {
+		t.Fatalf("params: %v", r.Params)
+	}
 }
 func TestOrderList(t *testing.T) {
+	s, client := newClient(t)
 	res, err := trading.NewOrderList(client).
 		SetOrderListId(123456).
 		Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
+	if res.OrderListId != 123456 || len(res.Orders) != 2 {
+		t.Fatalf("orderList: %+v", res)
+	}
+	if r := lastRequest(t, s); r.Params.Get("orderListId") != "123456" {
+		t.Fatalf("params: %v", r.Params)
+	}
 }
 func TestOpenOrderList(t *testing.T) {
+	_, client := newClient(t)
 	res, err := account.NewOpenOrderList(client).
 		Call(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
+	if len(res) != 1 || res[0].OrderListId != 31 || res[0].ListStatusType != enums.ListStatusTypeExecStarted {
+		t.Fatalf("openOrderList: %+v", res)
+	}
 }
 func TestSor(t *testing.T) {
+	_, client := newClient(t)
 	res, err := trading.NewSor(client, BTCUSDT).
 		SetSide(enums.SideTypeBuy).
 		SetType(enums.OrderTypeMarket).
@@ -444,9 +732,12 @@ func TestSor(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
+	if !res.UsedSor || res.WorkingFloor != "SOR" || len(res.Fills) != 1 {
+		t.Fatalf("sor: %+v", res)
+	}
 }
 func TestSorTest(t *testing.T) {
+	s, client := newClient(t)
 	res, err := trading.NewSor(client, BTCUSDT).
 		SetSide(enums.SideTypeBuy).
 		SetType(enums.OrderTypeMarket).
@@ -455,5 +746,10 @@ func TestSorTest(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
+	if res.StandardCommissionForOrder.Maker != "0.00000112" {
+		t.Fatalf("sor/order/test: %+v", res)
+	}
+	if r := lastRequest(t, s); r.Path != consts.ApiTradingSorOrderTest || r.Params.Get("computeCommissionRates") != "true" {
+		t.Fatalf("request: %s %v", r.Path, r.Params)
+	}
 }
@@ -2,52 +2,64 @@ package spot_test
 
 import (
 	"context"
-	"fmt"
-	"os"
-	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sleep-go/coin-go/binance"
-	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/binancetest"
 	"github.com/sleep-go/coin-go/binance/spot/account"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
 	"github.com/sleep-go/coin-go/binance/spot/market"
 	"github.com/sleep-go/coin-go/binance/spot/market/ticker"
 	"github.com/sleep-go/coin-go/binance/spot/stream"
 	"github.com/sleep-go/coin-go/binance/spot/trading"
+	"github.com/spf13/cast"
 )
 
-var wsApiClient *binance.Client
+// newWsApiClient 连接模拟服务器的 WS API client
+func newWsApiClient(t *testing.T) (*binancetest.Server, *binance.Client) {
+	t.Helper()
+	s, path := newServer(t)
+	client := binance.NewWsApiED25519Client("ed25519", path, s.WsApiURL())
+	t.Cleanup(func() { _ = client.Close() })
+	return s, client
+}
 
-func init() {
-	// 设置身份验证
-	file, err := os.ReadFile("./.test.env")
+// wsApiOrder 通过默认 order.place 创建一个挂单
+func wsApiOrder(t *testing.T, client *binance.Client) int64 {
+	t.Helper()
+	res, err := trading.NewWsApiCreateOrder(client).
+		SetSymbol(BTCUSDT).
+		SetSide(enums.SideTypeBuy).
+		SetQuantity("0.01").
+		SetTimeInForce(enums.TimeInForceTypeGTC).
+		SetPrice("23416").
+		SetType(enums.OrderTypeLimit).
+		Send(context.Background())
 	if err != nil {
-		panic(err)
-		return
+		t.Fatal(err)
 	}
-	API_KEY := strings.TrimSpace(string(file))
-	PRIVATE_KEY_PATH := "./test-prv-key.pem"
-	wsApiClient = binance.NewWsApiED25519Client(API_KEY, PRIVATE_KEY_PATH, consts.WS_API_TEST)
-	wsApiClient.Debug = true
+	return int64(res.Result.OrderId)
 }
 
 func TestWsApiDepth(t *testing.T) {
+	s, wsApiClient := newWsApiClient(t)
 	res, err := market.NewWsApiDepth(wsApiClient).
 		SetSymbol(ETHUSDT).
 		SetLimit(enums.Limit5).Send(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Println(res.Result)
+	if res.Result.LastUpdateId != 1 {
+		t.Fatalf("depth: %+v", res.Result)
+	}
+	if r := lastRequest(t, s); r.Path != "depth" || r.Params.Get("symbol") != ETHUSDT || r.Params.Get("limit") != "5" {
+		t.Fatalf("request: %s %v", r.Path, r.Params)
 	}
 }
 func TestWsApiTrades(t *testing.T) {
+	_, wsApiClient := newWsApiClient(t)
 	res, err := market.NewWsApiTrades(wsApiClient).
 		SetSymbol(BTCUSDT).
 		SetLimit(enums.Limit5).
@@ -55,13 +67,12 @@ func TestWsApiTrades(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Println(res.Result)
+	if len(res.Result) != 1 || res.Result[0].Id != 194686783 {
+		t.Fatalf("trades.recent: %+v", res.Result)
 	}
 }
 func TestWsApiHistory(t *testing.T) {
+	s, wsApiClient := newWsApiClient(t)
 	res, err := market.NewWsApiHistoryTrades(wsApiClient).
 		SetSymbol(BTCUSDT).
 		SetLimit(enums.Limit5).
@@ -70,30 +81,35 @@ func TestWsApiHistory(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Println(res.Result)
+	if len(res.Result) != 1 || res.Result[0].Id != 1 {
+		t.Fatalf("trades.historical: %+v", res.Result)
+	}
+	if r := lastRequest(t, s); r.Params.Get("fromId") != "1" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestWsApiAggTrades(t *testing.T) {
+	s, wsApiClient := newWsApiClient(t)
+	end := time.Now().UnixMilli()
 	res, err := market.NewWsApiAggTrades(wsApiClient).
 		SetSymbol(BTCUSDT).
 		SetLimit(enums.Limit5).
 		//SetFromId(1).
-		SetStartTime(time.Now().UnixMilli() - 60*60*60).
-		SetEndTime(time.Now().UnixMilli()).
+		SetStartTime(end - 60*60*60).
+		SetEndTime(end).
 		Send(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Println(res.Result)
+	if len(res.Result) != 1 || res.Result[0].AggTradeID != 50000000 {
+		t.Fatalf("trades.aggregate: %+v", res.Result)
+	}
+	if r := lastRequest(t, s); r.Params.Get("endTime") != cast.ToString(end) {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestWsApiKline(t *testing.T) {
+	s, wsApiClient := newWsApiClient(t)
 	res, err := market.NewWsApiKlines(wsApiClient).
 		SetSymbol(BTCUSDT).
 		SetLimit(enums.Limit5).
@@ -104,25 +120,26 @@ func TestWsApiKline(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Println(res.Result)
+	if len(res.Result) != 1 || res.Result[0][1] != "0.01086000" || cast.ToInt(res.Result[0][8]) != 2283 {
+		t.Fatalf("klines: %+v", res.Result)
+	}
+	if r := lastRequest(t, s); r.Params.Get("interval") != "1d" || r.Params.Get("timeZone") != "+08:00" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestWsApiAvgPrice(t *testing.T) {
+	_, wsApiClient := newWsApiClient(t)
 	res, err := market.NewWsApiAvgPrice(wsApiClient).
 		SetSymbol(BTCUSDT).Send(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Println(res.Result)
+	if res.Result.Mins != 5 || res.Result.Price != "9.35751834" {
+		t.Fatalf("avgPrice: %+v", res.Result)
 	}
 }
 func TestWsApiHr24(t *testing.T) {
+	s, wsApiClient := newWsApiClient(t)
 	res, err := ticker.NewWsApiHr24(wsApiClient).
 		SetSymbols([]string{BTCUSDT, ETHUSDT}).
 		SetType(enums.TickerTypeFull).
@@ -130,28 +147,30 @@ func TestWsApiHr24(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		for _, v := range res.Result {
-			fmt.Printf("%+v\n", v)
-		}
+	if len(res.Result) != 2 || res.Result[0].Symbol != BTCUSDT || res.Result[1].Symbol != ETHUSDT {
+		t.Fatalf("ticker.24hr: %+v", res.Result)
+	}
+	if r := lastRequest(t, s); r.Params.Get("symbols") != `["BTCUSDT","ETHUSDT"]` {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestWsApiTradingDay(t *testing.T) {
+	s, wsApiClient := newWsApiClient(t)
 	res, err := ticker.NewWsApiTradingDay(wsApiClient).SetSymbols([]string{BTCUSDT, ETHUSDT}).
 		SetTimeZone("+08:00").
 		SetType(enums.TickerTypeFull).Send(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Println(res.Result)
+	if len(res.Result) != 1 || res.Result[0].LastPrice != "26221.67000000" {
+		t.Fatalf("ticker.tradingDay: %+v", res.Result)
+	}
+	if r := lastRequest(t, s); r.Params.Get("timeZone") != "+08:00" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestWsApiTicker(t *testing.T) {
+	s, wsApiClient := newWsApiClient(t)
 	res, err := ticker.NewWsApiTicker(wsApiClient).SetSymbols([]string{BTCUSDT, ETHUSDT}).
 		SetDay(3).
 		SetType(enums.TickerTypeFull).
@@ -159,40 +178,36 @@ func TestWsApiTicker(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Println(res.Result)
+	if len(res.Result) != 1 || res.Result[0].Symbol != BTCUSDT {
+		t.Fatalf("ticker: %+v", res.Result)
+	}
+	if r := lastRequest(t, s); r.Params.Get("windowSize") != "3d" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestWsApiTickerPrice(t *testing.T) {
+	_, wsApiClient := newWsApiClient(t)
 	res, err := ticker.NewWsApiTickerPrice(wsApiClient).Send(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Println(len(res.Result), res.Result)
-		for _, v := range res.Result {
-			fmt.Println(v.Symbol, v.Price)
-		}
+	if len(res.Result) != 2 || res.Result[1].Symbol != ETHUSDT || res.Result[1].Price != "1080.00000000" {
+		t.Fatalf("ticker.price: %+v", res.Result)
 	}
 }
 func TestWsApiBookTicker(t *testing.T) {
+	_, wsApiClient := newWsApiClient(t)
 	res, err := ticker.NewWsApiBookTicker(wsApiClient).
 		SetSymbols([]string{BTCUSDT, ETHUSDT}).Send(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Println(res.Result)
+	if len(res.Result) != 2 || res.Result[0].BidQty != "0.10000000" || res.Result[1].AskQty != "3.00000000" {
+		t.Fatalf("ticker.book: %+v", res.Result)
 	}
 }
 func TestWsApiCreateOrder(t *testing.T) {
-	//{"id":"08fbb7b7-741d-4d14-b1aa-d5f8800d369a","status":200,"result":{"symbol":"ETHUSDT","orderId":4241828,"orderListId":-1,"clientOrderId":"fvh0x6K2e2s0Gk4oqw7seI","transactTime":1728615836724,"price":"2000.00000000","origQty":"0.01000000","executedQty":"0.00000000","cummulativeQuoteQty":"0.00000000","status":"NEW","timeInForce":"GTC","type":"LIMIT","side":"BUY","workingTime":1728615836724,"fills":[],"selfTradePreventionMode":"EXPIRE_MAKER"},"rateLimits":[{"rateLimitType":"ORDERS","interval":"SECOND","intervalNum":10,"limit":50,"count":1},{"rateLimitType":"ORDERS","interval":"DAY","intervalNum":1,"limit":160000,"count":5},{"rateLimitType":"REQUEST_WEIGHT","interval":"MINUTE","intervalNum":1,"limit":6000,"count":3}]}
+	s, wsApiClient := newWsApiClient(t)
 	res, err := trading.NewWsApiCreateOrder(wsApiClient).
 		SetSymbol(ETHUSDT).
 		SetSide(enums.SideTypeBuy).
@@ -204,14 +219,15 @@ func TestWsApiCreateOrder(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Println(res.Result)
+	if res.Result.Symbol != ETHUSDT || res.Result.Status != "NEW" || res.Result.Price != "2000" {
+		t.Fatalf("order.place: %+v", res.Result)
+	}
+	if r := lastRequest(t, s); !r.Signed || r.APIKey != "ed25519" {
+		t.Fatalf("request: %+v", r)
 	}
 }
 func TestWsApiCreateOrderTest(t *testing.T) {
-	//{"id":"08fbb7b7-741d-4d14-b1aa-d5f8800d369a","status":200,"result":{"symbol":"ETHUSDT","orderId":4241828,"orderListId":-1,"clientOrderId":"fvh0x6K2e2s0Gk4oqw7seI","transactTime":1728615836724,"price":"2000.00000000","origQty":"0.01000000","executedQty":"0.00000000","cummulativeQuoteQty":"0.00000000","status":"NEW","timeInForce":"GTC","type":"LIMIT","side":"BUY","workingTime":1728615836724,"fills":[],"selfTradePreventionMode":"EXPIRE_MAKER"},"rateLimits":[{"rateLimitType":"ORDERS","interval":"SECOND","intervalNum":10,"limit":50,"count":1},{"rateLimitType":"ORDERS","interval":"DAY","intervalNum":1,"limit":160000,"count":5},{"rateLimitType":"REQUEST_WEIGHT","interval":"MINUTE","intervalNum":1,"limit":6000,"count":3}]}
+	s, wsApiClient := newWsApiClient(t)
 	res, err := trading.NewWsApiCreateOrder(wsApiClient).SetSymbol(ETHUSDT).
 		SetSide(enums.SideTypeBuy).
 		SetQuantity("0.01").
@@ -222,53 +238,66 @@ func TestWsApiCreateOrderTest(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Println(res.Result)
+	if res.Result.Discount.Discount != "0.25000000" || len(s.Orders()) != 0 {
+		t.Fatalf("order.test: %+v", res.Result)
+	}
+	if r := lastRequest(t, s); r.Params.Get("computeCommissionRates") != "true" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestWsApiQueryOrder(t *testing.T) {
+	_, wsApiClient := newWsApiClient(t)
+	orderId := wsApiOrder(t, wsApiClient)
 	res, err := trading.NewWsApiQueryOrder(wsApiClient).
-		SetOrderId(736954).
+		SetOrderId(orderId).
 		SetSymbol(BTCUSDT).Send(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Println(res.Result)
+	if int64(res.Result.OrderId) != orderId || res.Result.Price != "23416" {
+		t.Fatalf("order.status: %+v", res.Result)
 	}
 }
 func TestWsApiOpenOrders(t *testing.T) {
+	s, wsApiClient := newWsApiClient(t)
+	s.HandleWsApi("openOrders.status", func(r *binancetest.Request) (any, error) {
+		var orders []map[string]any
+		for _, o := range s.Orders() {
+			if o["symbol"] == r.Params.Get("symbol") && o["status"] == "NEW" {
+				orders = append(orders, o)
+			}
+		}
+		return orders, nil
+	})
+	orderId := wsApiOrder(t, wsApiClient)
 	res, err := trading.NewWsApiQueryOrder(wsApiClient).
-		SetOrderId(736954).
 		SetSymbol(BTCUSDT).SendOpenOrders(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Println(res.Result)
+	if len(res.Result) != 1 || int64(res.Result[0].OrderId) != orderId {
+		t.Fatalf("openOrders.status: %+v", res.Result)
 	}
 }
 func TestWsApiDeleteOrder(t *testing.T) {
+	s, wsApiClient := newWsApiClient(t)
+	orderId := wsApiOrder(t, wsApiClient)
 	res, err := trading.NewWsApiDeleteOrder(wsApiClient).
-		SetOrderId(736954).SetSymbol(BTCUSDT).
+		SetOrderId(orderId).SetSymbol(BTCUSDT).
 		SetCancelRestrictions(enums.CancelRestrictionsTypeOnlyNew).
 		Send(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Println(res.Result)
+	if int64(res.Result.OrderId) != orderId || res.Result.Status != enums.OrderStatusTypeCanceled {
+		t.Fatalf("order.cancel: %+v", res.Result)
+	}
+	if r := lastRequest(t, s); r.Params.Get("cancelRestrictions") != "ONLY_NEW" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestWsApiCancelReplace(t *testing.T) {
+	s, wsApiClient := newWsApiClient(t)
 	res, err := trading.NewWsApiCancelReplace(wsApiClient).
 		SetSymbol(BTCUSDT).
 		SetCancelReplaceMode(enums.CancelReplaceModeTypeAllowFailure).
@@ -281,32 +310,30 @@ func TestWsApiCancelReplace(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Println(res.Result)
+	if res.Result.CancelResult != "SUCCESS" || res.Result.CancelResponse.OrderId != 736954 || res.Result.NewOrderResponse.OrderId != 736955 {
+		t.Fatalf("order.cancelReplace: %+v", res.Result)
+	}
+	if r := lastRequest(t, s); r.Params.Get("cancelReplaceMode") != "ALLOW_FAILURE" || r.Params.Get("cancelOrderId") != "736954" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestWsApiDeleteOpenOrders(t *testing.T) {
+	_, wsApiClient := newWsApiClient(t)
 	res, err := trading.NewWsApiDeleteOpenOrders(wsApiClient).SetSymbol(BTCUSDT).Send(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		for _, v := range res.Result {
-			fmt.Printf("%+v\n", v)
-		}
+	if len(res.Result) != 1 || res.Result[0].OrderId != 12345 {
+		t.Fatalf("openOrders.cancelAll: %+v", res.Result)
 	}
 }
 func TestWsApiOCO(t *testing.T) {
-	//OrderListId:2604
-	//&{OrderListId:2605 ContingencyType:OCO ListStatusType:EXEC_STARTED ListOrderStatus:EXECUTING ListClientOrderId:8d931268-307c-4bb4-97fb-0a978f974f14 TransactionTime:1728658223336 Symbol:ETHUSDT Orders:[{Symbol:ETHUSDT OrderId:4564037 ClientOrderId:qta1Pd9f1C1SHMAJj3zhyR} {Symbol:ETHUSDT OrderId:4564038 ClientOrderId:OTffGJW6HD7Qvweq1ujCth}] OrderReports:[{Symbol:ETHUSDT OrderId:4564037 OrderListId:2605 ClientOrderId:qta1Pd9f1C1SHMAJj3zhyR TransactTime:1728658223336 Price:2428.77000000 OrigQty:0.01000000 ExecutedQty:0.00000000 CummulativeQuoteQty:0.00000000 Status:NEW TimeInForce:GTC Type:STOP_LOSS_LIMIT Side:BUY StopPrice:2428.87000000 WorkingTime:-1 IcebergQty: SelfTradePreventionMode:EXPIRE_MAKER} {Symbol:ETHUSDT OrderId:4564038 OrderListId:2605 ClientOrderId:OTffGJW6HD7Qvweq1ujCth TransactTime:1728658223336 Price:1500.00000000 OrigQty:0.01000000 ExecutedQty:0.00000000 CummulativeQuoteQty:0.00000000 Status:NEW TimeInForce:GTC Type:LIMIT_MAKER Side:BUY StopPrice: WorkingTime:1728658223336 IcebergQty: SelfTradePreventionMode:EXPIRE_MAKER}]}
+	s, wsApiClient := newWsApiClient(t)
+	listClientOrderId := uuid.New().String()
 	res, err := trading.NewWsApiOCO(wsApiClient).
 		SetSymbol(ETHUSDT).
 		SetSide(enums.SideTypeBuy).
-		SetListClientOrderId(uuid.New().String()).
+		SetListClientOrderId(listClientOrderId).
 		SetAboveType(enums.OrderTypeStopLossLimit).
 		SetAbovePrice("2428.77000000").
 		SetAboveStopPrice("2428.87000000").
@@ -318,14 +345,15 @@ func TestWsApiOCO(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Printf("%+v\n", res.Result)
+	if res.Result.OrderListId != 2605 || res.Result.ContingencyType != "OCO" || len(res.Result.OrderReports) != 2 {
+		t.Fatalf("orderList.place.oco: %+v", res.Result)
+	}
+	if r := lastRequest(t, s); r.Params.Get("listClientOrderId") != listClientOrderId || r.Params.Get("belowType") != "LIMIT_MAKER" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestWsApiOTO(t *testing.T) {
-	//&{OrderListId:2609 ContingencyType:OTO ListStatusType:EXEC_STARTED ListOrderStatus:EXECUTING ListClientOrderId:267f613e-116a-44ed-b353-e87e4f2fe412 TransactionTime:1728659162507 Symbol:ETHUSDT Orders:[{Symbol:ETHUSDT OrderId:4571011 ClientOrderId:hto0WXQ2Msjr3PwDgSX224} {Symbol:ETHUSDT OrderId:4571012 ClientOrderId:fV1kDH2dTlMVxM9FL4qiJI}] OrderReports:[{Symbol:ETHUSDT OrderId:4571011 OrderListId:2609 ClientOrderId:hto0WXQ2Msjr3PwDgSX224 TransactTime:1728659162507 Price:2428.77000000 OrigQty:0.01000000 ExecutedQty:0.01000000 CummulativeQuoteQty:24.36850000 Status:FILLED TimeInForce:GTC Type:LIMIT Side:SELL StopPrice: WorkingTime:1728659162507 IcebergQty: SelfTradePreventionMode:EXPIRE_MAKER} {Symbol:ETHUSDT OrderId:4571012 OrderListId:2609 ClientOrderId:fV1kDH2dTlMVxM9FL4qiJI TransactTime:1728659162507 Price:0.00000000 OrigQty:0.01000000 ExecutedQty:0.00000000 CummulativeQuoteQty:0.00000000 Status:PENDING_NEW TimeInForce:GTC Type:MARKET Side:BUY StopPrice: WorkingTime:-1 IcebergQty: SelfTradePreventionMode:EXPIRE_MAKER}]}
+	s, wsApiClient := newWsApiClient(t)
 	res, err := trading.NewWsApiOTO(wsApiClient).
 		SetSymbol(ETHUSDT).
 		SetPendingSide(enums.SideTypeBuy).
@@ -341,13 +369,15 @@ func TestWsApiOTO(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Printf("%+v\n", res.Result)
+	if res.Result.OrderListId != 2609 || len(res.Result.OrderReports) != 2 || res.Result.OrderReports[1].Status != enums.OrderStatusTypePendingNew {
+		t.Fatalf("orderList.place.oto: %+v", res.Result)
+	}
+	if r := lastRequest(t, s); r.Params.Get("pendingType") != "MARKET" || r.Params.Get("workingType") != "LIMIT" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestWsApiOTOCO(t *testing.T) {
+	s, wsApiClient := newWsApiClient(t)
 	res, err := trading.NewWsApiOTOCO(wsApiClient).
 		SetSymbol("LTCBNB").
 		SetPendingSide(enums.SideTypeSell).
@@ -365,40 +395,40 @@ func TestWsApiOTOCO(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Printf("%+v\n", res.Result)
+	if res.Result.OrderListId != 629 || len(res.Result.Orders) != 3 || len(res.Result.OrderReports) != 3 {
+		t.Fatalf("orderList.place.otoco: %+v", res.Result)
+	}
+	if r := lastRequest(t, s); r.Params.Get("pendingAboveType") != "STOP_LOSS" || r.Params.Get("pendingAboveStopPrice") != "0.5" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestWsOrderListCancel(t *testing.T) {
+	s, wsApiClient := newWsApiClient(t)
 	res, err := trading.NewWsApiOrderList(wsApiClient).
 		SetOrigClientOrderId("fvh0x6K2e2s0Gk4oqw7seI").
 		Send(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Printf("%+v\n", res.Result)
+	if res.Result.ListClientOrderId != "fvh0x6K2e2s0Gk4oqw7seI" {
+		t.Fatalf("orderList.status: %+v", res.Result)
+	}
+	if r := lastRequest(t, s); r.Params.Get("origClientOrderId") != "fvh0x6K2e2s0Gk4oqw7seI" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestOpenOrderLists(t *testing.T) {
+	_, wsApiClient := newWsApiClient(t)
 	res, err := account.NewWsApiOpenOrderList(wsApiClient).Send(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		for _, v := range res.Result {
-			fmt.Printf("%+v\n", v)
-		}
+	if len(res.Result) != 1 || res.Result[0].ContingencyType != "OCO" {
+		t.Fatalf("openOrderLists.status: %+v", res.Result)
 	}
 }
 func TestWsApiSor(t *testing.T) {
+	_, wsApiClient := newWsApiClient(t)
 	res, err := trading.NewWsApiSOR(wsApiClient).
 		SetSymbol(ETHUSDT).
 		SetSide(enums.SideTypeBuy).
@@ -408,14 +438,12 @@ func TestWsApiSor(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Printf("%+v\n", res.Result)
+	if res.Result.Symbol != ETHUSDT || !res.Result.UsedSor {
+		t.Fatalf("sor.order.place: %+v", res.Result)
 	}
 }
 func TestWsApiSorTest(t *testing.T) {
+	s, wsApiClient := newWsApiClient(t)
 	res, err := trading.NewWsApiSOR(wsApiClient).
 		SetSymbol(ETHUSDT).
 		SetSide(enums.SideTypeBuy).
@@ -425,57 +453,56 @@ func TestWsApiSorTest(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Printf("%+v\n", res.Result)
+	if res.Result.StandardCommissionForOrder.Taker != "0.00000114" {
+		t.Fatalf("sor.order.test: %+v", res.Result)
+	}
+	if r := lastRequest(t, s); r.Path != "sor.order.test" || r.Params.Get("computeCommissionRates") != "true" {
+		t.Fatalf("request: %s %v", r.Path, r.Params)
 	}
 }
 func TestWsApiAccount(t *testing.T) {
+	s, wsApiClient := newWsApiClient(t)
 	res, err := account.NewWsApiAccount(wsApiClient).
 		SetOmitZeroBalances(false).
 		Send(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Printf("%+v\n", res.Result)
+	if res.Result.MakerCommission != 15 || len(res.Result.Balances) != 2 || res.Result.Balances[0].Asset != "BNB" {
+		t.Fatalf("account.status: %+v", res.Result)
+	}
+	if r := lastRequest(t, s); r.Params.Get("omitZeroBalances") != "false" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestWsApiRateLimitOrder(t *testing.T) {
+	_, wsApiClient := newWsApiClient(t)
 	res, err := account.NewWsApiWsApiRateLimitOrder(wsApiClient).
 		Send(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		for _, v := range res.Result {
-			fmt.Printf("%+v\n", v)
-		}
+	if len(res.Result) != 2 || res.Result[1].Limit != 160000 {
+		t.Fatalf("account.rateLimits.orders: %+v", res.Result)
 	}
 }
 func TestWsApiNewWsApiAllOrderList(t *testing.T) {
+	s, wsApiClient := newWsApiClient(t)
 	res, err := account.NewWsApiAllOrderList(wsApiClient).
 		SetLimit(enums.Limit20).
 		Send(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		for _, v := range res.Result {
-			fmt.Printf("%+v\n", v)
-		}
-		fmt.Printf("%+v\n", res.RateLimits)
+	if len(res.Result) != 1 || res.Result[0].OrderListId != 1274512 {
+		t.Fatalf("allOrderLists: %+v", res.Result)
+	}
+	if r := lastRequest(t, s); r.Params.Get("limit") != "20" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestWsApiAllOrders(t *testing.T) {
+	_, wsApiClient := newWsApiClient(t)
 	res, err := account.NewWsApiAllOrders(wsApiClient).
 		SetSymbol(ETHUSDT).
 		SetLimit(enums.Limit100).
@@ -483,16 +510,12 @@ func TestWsApiAllOrders(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		for _, v := range res.Result {
-			fmt.Printf("%+v\n", v)
-		}
-		fmt.Printf("%+v\n", res.RateLimits)
+	if len(res.Result) != 1 || res.Result[0].OrderId != 4241828 || res.Result[0].ClientOrderId != "fvh0x6K2e2s0Gk4oqw7seI" {
+		t.Fatalf("allOrders: %+v", res.Result)
 	}
 }
 func TestWsApiMyTrades(t *testing.T) {
+	s, wsApiClient := newWsApiClient(t)
 	res, err := account.NewWsApiMyTrades(wsApiClient).
 		SetSymbol(ETHUSDT).
 		SetFromId(834230).
@@ -501,16 +524,15 @@ func TestWsApiMyTrades(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		for _, v := range res.Result {
-			fmt.Printf("%+v\n", v)
-		}
-		fmt.Printf("%+v\n", res.RateLimits)
+	if len(res.Result) != 1 || res.Result[0].Id != 834230 || res.Result[0].OrderId != 4241828 {
+		t.Fatalf("myTrades: %+v", res.Result)
+	}
+	if r := lastRequest(t, s); r.Params.Get("fromId") != "834230" {
+		t.Fatalf("params: %v", r.Params)
 	}
 }
 func TestNewWsApiMyPreventedMatches(t *testing.T) {
+	_, wsApiClient := newWsApiClient(t)
 	res, err := account.NewWsApiMyPreventedMatches(wsApiClient).
 		SetSymbol(ETHUSDT).
 		SetLimit(enums.Limit5).
@@ -519,16 +541,12 @@ func TestNewWsApiMyPreventedMatches(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		for _, v := range res.Result {
-			fmt.Printf("%+v\n", v)
-		}
-		fmt.Printf("%+v\n", res.RateLimits)
+	if len(res.Result) != 1 || res.Result[0].MakerOrderId != 11750571916 {
+		t.Fatalf("myPreventedMatches: %+v", res.Result)
 	}
 }
 func TestWsApiMyAllocations(t *testing.T) {
+	_, wsApiClient := newWsApiClient(t)
 	res, err := account.NewWsApiMyAllocations(wsApiClient).
 		SetSymbol(ETHUSDT).
 		SetLimit(enums.Limit5).
@@ -536,58 +554,42 @@ func TestWsApiMyAllocations(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		for _, v := range res.Result {
-			fmt.Printf("%+v\n", v)
-		}
-		fmt.Printf("%+v\n", res.RateLimits)
+	if len(res.Result) != 1 || res.Result[0].OrderId != 500 || res.Result[0].AllocationType != "SOR" {
+		t.Fatalf("myAllocations: %+v", res.Result)
 	}
 }
 func TestNewWsApiWsApiCommission(t *testing.T) {
+	_, wsApiClient := newWsApiClient(t)
 	res, err := account.NewWsApiCommission(wsApiClient).
 		SetSymbol(ETHUSDT).
 		Send(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-	} else {
-		fmt.Printf("%+v\n", res.Result)
-		fmt.Printf("%+v\n", res.RateLimits)
+	if res.Result.Symbol != ETHUSDT || res.Result.StandardCommission.Taker != "0.00000020" {
+		t.Fatalf("account.commission: %+v", res.Result)
 	}
 }
 func TestNewWsApiUserDataStream(t *testing.T) {
+	s, wsApiClient := newWsApiClient(t)
 	ds := stream.NewWsApiUserDataStream(wsApiClient)
 	res, err := ds.SendStart(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-		return
-	}
-	fmt.Printf("%+v\n", res.Result)
-	fmt.Printf("%+v\n", res.RateLimits)
 	listenKey := res.Result.ListenKey
-	res, err = ds.SetListenKey(listenKey).SendPing(context.Background())
+	if keys := s.ListenKeys(); len(keys) != 1 || keys[0] != listenKey {
+		t.Fatalf("listenKeys: %v %+v", keys, res.Result)
+	}
+	_, err = ds.SetListenKey(listenKey).SendPing(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-		return
-	}
-	fmt.Printf("%+v\n", res.RateLimits)
-	res, err = ds.SetListenKey(listenKey).SendStop(context.Background())
+	_, err = ds.SetListenKey(listenKey).SendStop(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	if res.Error != nil {
-		fmt.Println(res.Error)
-		return
+	if keys := s.ListenKeys(); len(keys) != 0 {
+		t.Fatalf("listenKeys: %v", keys)
 	}
-	fmt.Printf("%+v\n", res.RateLimits)
 }
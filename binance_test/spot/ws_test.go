@@ -3,262 +3,242 @@ package spot_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
 	"github.com/sleep-go/coin-go/binance/consts"
 	"github.com/sleep-go/coin-go/binance/spot/account"
 	"github.com/sleep-go/coin-go/binance/spot/enums"
 	"github.com/sleep-go/coin-go/binance/spot/market"
 	"github.com/sleep-go/coin-go/binance/spot/market/ticker"
 	"github.com/sleep-go/coin-go/binance/spot/stream"
+	"github.com/sleep-go/coin-go/pkg/decimal"
 	"github.com/tidwall/gjson"
 )
 
-var err error
-var wsClient *binance.Client
+// receive 连接 Stream，返回 handler 收到的第一条推送，订阅后服务器会先回放 testdata 中录制的推送
+func receive[T any](t *testing.T, connect func(ctx context.Context, handler binance.Handler[T]) (*binance.WsStream, error)) T {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ch := make(chan T, 1)
+	s, err := connect(ctx, func(event T) {
+		select {
+		case ch <- event:
+		default:
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	select {
+	case v := <-ch:
+		return v
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+	panic("unreachable")
+}
+
+// newWsClient 连接模拟服务器的组合流和单一流 client
+func newWsClient(t *testing.T) (s *binancetest.Server, combined, raw *binance.Client) {
+	t.Helper()
+	s, _ = newServer(t)
+	return s, binance.NewWsClient(true, true, s.WsURL()), binance.NewWsClient(false, true, s.WsURL())
+}
 
-func init() {
-	wsClient = binance.NewWsClient(true, true, consts.WS_STREAM_TEST)
+func exception(messageType int, err error) {
+	fmt.Println(messageType, err)
 }
 
 func TestDepthWs(t *testing.T) {
-	// {"e":"depthUpdate","E":1728284450004,"s":"BTCUSDT","U":3999521,"u":3999550,"b":[["63698.69000000","0.00691000"],["63698.27000000","0.00409000"],["63698.26000000","0.00589000"],["63689.07000000","0.00000000"],["63688.87000000","0.00000000"],["63686.36000000","0.00000000"],["63686.06000000","0.00000000"]],"a":[["63699.99000000","0.00785000"],["63701.67000000","0.00000000"],["63701.79000000","0.00000000"],["63701.99000000","0.00479000"],["63702.17000000","0.00000000"],["63702.32000000","0.00000000"],["63702.82000000","0.00409000"],["63702.83000000","0.00000000"],["63703.48000000","0.00000000"],["63703.51000000","0.00000000"],["63705.30000000","0.00424000"],["63705.37000000","0.00542000"],["63706.07000000","0.00668000"],["63707.17000000","0.00699000"],["63708.00000000","0.00000000"],["63708.02000000","0.00605000"],["63709.39000000","0.00636000"],["63709.40000000","0.00691000"],["63709.94000000","0.00746000"],["63711.34000000","0.00424000"],["63731.16000000","0.00000000"],["63733.34000000","0.00000000"],["63733.67000000","0.00000000"]]}
-	if wsClient.IsCombined {
-		err := market.NewStreamDepth(wsClient, []string{BTCUSDT, "ETHUSDT"}, func(event *market.StreamDepthEvent) {
-			//fmt.Println(event.Stream, event.Data)
-		}, func(messageType int, err error) {
-			fmt.Println(messageType, err)
-		})
-		if err != nil {
-			t.Fatal(err)
-		}
-	} else {
-		err := market.NewWsDepth(wsClient, []string{BTCUSDT, "ETHUSDT"}, func(event *market.WsDepthEvent) {
-			fmt.Println(event)
-		}, func(messageType int, err error) {
-			fmt.Println(messageType, err)
-		})
-		if err != nil {
-			t.Fatal(err)
-		}
+	_, wsClient, rawClient := newWsClient(t)
+	event := receive(t, func(ctx context.Context, handler binance.Handler[*market.StreamDepthEvent]) (*binance.WsStream, error) {
+		return market.NewStreamDepth(ctx, wsClient, []string{BTCUSDT, ETHUSDT}, handler, exception)
+	})
+	if event.Stream != "btcusdt@depth@100ms" || event.Data.FirstUpdateID != 3999521 || event.Data.LastUpdateID != 3999550 {
+		t.Fatalf("depth: %+v", event)
+	}
+	raw := receive(t, func(ctx context.Context, handler binance.Handler[*market.WsDepthEvent]) (*binance.WsStream, error) {
+		return market.NewWsDepth(ctx, rawClient, []string{BTCUSDT, ETHUSDT}, handler, exception)
+	})
+	if bids, err := raw.BidLevels(); err != nil || len(bids) != 1 || bids[0].Price.String() != "63698.69000000" {
+		t.Fatalf("depth: %+v %v", raw, err)
 	}
 }
 func TestWsDepthLevel(t *testing.T) {
-	if wsClient.IsCombined {
-		err := market.NewStreamDepthLevels(wsClient, map[string]enums.LimitType{
+	_, wsClient, rawClient := newWsClient(t)
+	event := receive(t, func(ctx context.Context, handler binance.Handler[market.StreamDepthLevelsEvent]) (*binance.WsStream, error) {
+		return market.NewStreamDepthLevels(ctx, wsClient, map[string]enums.LimitType{
 			BTCUSDT: enums.Limit5,
-		}, func(event market.StreamDepthLevelsEvent) {
-			//fmt.Println(event.Stream, event.Data)
-		}, func(messageType int, err error) {
-			fmt.Println(messageType, err)
-		})
-		if err != nil {
-			t.Fatal(err)
-		}
-	} else {
-		err := market.NewWsDepthLevels(wsClient, map[string]enums.LimitType{
+		}, handler, exception)
+	})
+	if event.Stream != "btcusdt@depth5@100ms" || event.Data.LastUpdateId != 160 {
+		t.Fatalf("depth5: %+v", event)
+	}
+	raw := receive(t, func(ctx context.Context, handler binance.Handler[market.WsDepthLevelsEvent]) (*binance.WsStream, error) {
+		return market.NewWsDepthLevels(ctx, rawClient, map[string]enums.LimitType{
 			BTCUSDT: enums.Limit5,
-		}, func(event market.WsDepthLevelsEvent) {
-			fmt.Println(event)
-		}, func(messageType int, err error) {
-			fmt.Println(messageType, err)
-		})
-		if err != nil {
-			t.Fatal(err)
-		}
+		}, handler, exception)
+	})
+	if len(raw.Asks) != 1 || raw.Asks[0][1] != "100" {
+		t.Fatalf("depth5: %+v", raw)
 	}
 }
 func TestWsAggTrade(t *testing.T) {
-	//{"e":"aggTrade","E":1728319526169,"s":"BTCUSDT","a":549858,"p":"63778.14000000","q":"0.00024000","f":566460,"l":566460,"T":1728319526169,"m":false,"M":true}
-	if wsClient.IsCombined {
-		err = market.NewStreamAggTrade(wsClient, []string{BTCUSDT}, func(event market.StreamAggTradeEvent) {
-			fmt.Println(event.Stream, event.Data)
-		}, func(messageType int, err error) {
-			fmt.Println(messageType, err)
-		})
-	} else {
-		err = market.NewWsAggTrade(wsClient, []string{BTCUSDT}, func(event market.WsAggTradeEvent) {
-			fmt.Println(event)
-		}, func(messageType int, err error) {
-			fmt.Println(messageType, err)
-		})
+	_, wsClient, rawClient := newWsClient(t)
+	event := receive(t, func(ctx context.Context, handler binance.Handler[market.StreamAggTradeEvent]) (*binance.WsStream, error) {
+		return market.NewStreamAggTrade(ctx, wsClient, []string{BTCUSDT}, handler, exception)
+	})
+	if event.Stream != "btcusdt@aggTrade" || event.Data.AggTradeID != 549858 {
+		t.Fatalf("aggTrade: %+v", event)
 	}
-	if err != nil {
-		t.Fatal(err)
+	raw := receive(t, func(ctx context.Context, handler binance.Handler[market.WsAggTradeEvent]) (*binance.WsStream, error) {
+		return market.NewWsAggTrade(ctx, rawClient, []string{BTCUSDT}, handler, exception)
+	})
+	if raw.Symbol != BTCUSDT || raw.Price != "63778.14000000" {
+		t.Fatalf("aggTrade: %+v", raw)
 	}
 }
 func TestWsTrade(t *testing.T) {
-	if wsClient.IsCombined {
-		err = market.NewStreamTrade(wsClient, []string{BTCUSDT}, func(event market.StreamTradeEvent) {
-			fmt.Println(event.Stream, event.Data)
-		}, func(messageType int, err error) {})
-	} else {
-		err = market.NewWsTrade(wsClient, []string{BTCUSDT}, func(event market.WsTradeEvent) {
-			fmt.Printf("%+v\n", event)
-		}, func(messageType int, err error) {})
+	_, wsClient, rawClient := newWsClient(t)
+	event := receive(t, func(ctx context.Context, handler binance.Handler[market.StreamTradeEvent]) (*binance.WsStream, error) {
+		return market.NewStreamTrade(ctx, wsClient, []string{BTCUSDT}, handler, exception)
+	})
+	if event.Stream != "btcusdt@trade" || event.Data.TradeID != 12345 {
+		t.Fatalf("trade: %+v", event)
 	}
-	if err != nil {
-		t.Fatal(err)
+	raw := receive(t, func(ctx context.Context, handler binance.Handler[market.WsTradeEvent]) (*binance.WsStream, error) {
+		return market.NewWsTrade(ctx, rawClient, []string{BTCUSDT}, handler, exception)
+	})
+	if raw.Quantity != "100" || !raw.IsBuyerMaker {
+		t.Fatalf("trade: %+v", raw)
 	}
 }
 func TestWsKline(t *testing.T) {
+	_, wsClient, rawClient := newWsClient(t)
 	//设置带有时区偏移量的K线
 	wsClient.Timezone = "+08:00"
-	if wsClient.IsCombined {
-		err = market.NewStreamKline(wsClient, map[string]enums.KlineIntervalType{
+	rawClient.Timezone = "+08:00"
+	event := receive(t, func(ctx context.Context, handler binance.Handler[market.StreamKlineEvent]) (*binance.WsStream, error) {
+		return market.NewStreamKline(ctx, wsClient, map[string]enums.KlineIntervalType{
 			BTCUSDT: enums.KlineIntervalType1d,
-		}, func(event market.StreamKlineEvent) {
-			fmt.Println(event.Stream, event.Data)
-		}, func(messageType int, err error) {
-			fmt.Println(messageType, err)
-		})
-	} else {
-		err = market.NewWsKline(wsClient, map[string]enums.KlineIntervalType{
-			BTCUSDT: enums.KlineIntervalType1d,
-		}, func(event market.WsKlineEvent) {
-			fmt.Println(event)
-		}, func(messageType int, err error) {
-			fmt.Println(messageType, err)
-		})
+		}, handler, exception)
+	})
+	if event.Stream != "btcusdt@kline_1d@+08:00" || event.Data.Kline.Interval != "1d" {
+		t.Fatalf("kline: %+v", event)
 	}
-	if err != nil {
-		t.Fatal(err)
+	raw := receive(t, func(ctx context.Context, handler binance.Handler[market.WsKlineEvent]) (*binance.WsStream, error) {
+		return market.NewWsKline(ctx, rawClient, map[string]enums.KlineIntervalType{
+			BTCUSDT: enums.KlineIntervalType1d,
+		}, handler, exception)
+	})
+	if raw.Kline.Volume != "1000" || raw.Kline.IsFinal {
+		t.Fatalf("kline: %+v", raw)
 	}
 }
 func TestWsMiniTicker(t *testing.T) {
-	if wsClient.IsCombined {
-		err = ticker.NewStreamMiniTicker(wsClient, []string{BTCUSDT, ETHUSDT}, func(event ticker.StreamMiniTickerEvent) {
-			fmt.Println(event)
-		}, func(messageType int, err error) {
-			fmt.Println(messageType, err)
-		})
-	} else {
-		err = ticker.NewWsMiniTicker(wsClient, []string{BTCUSDT, ETHUSDT}, func(event ticker.WsMiniTickerEvent) {
-			fmt.Println(event)
-		}, func(messageType int, err error) {
-			fmt.Println(messageType, err)
-		})
+	_, wsClient, rawClient := newWsClient(t)
+	event := receive(t, func(ctx context.Context, handler binance.Handler[ticker.StreamMiniTickerEvent]) (*binance.WsStream, error) {
+		return ticker.NewStreamMiniTicker(ctx, wsClient, []string{BTCUSDT, ETHUSDT}, handler, exception)
+	})
+	if event.Stream != "btcusdt@miniTicker" || event.Data.LastPrice != "0.0025" {
+		t.Fatalf("miniTicker: %+v", event)
+	}
+	raw := receive(t, func(ctx context.Context, handler binance.Handler[ticker.WsMiniTickerEvent]) (*binance.WsStream, error) {
+		return ticker.NewWsMiniTicker(ctx, rawClient, []string{BTCUSDT, ETHUSDT}, handler, exception)
+	})
+	if raw.Symbol != BTCUSDT || raw.QuoteVolume != "18" {
+		t.Fatalf("miniTicker: %+v", raw)
 	}
 }
 func TestWsAllMiniTicker(t *testing.T) {
-	if wsClient.IsCombined {
-		err = ticker.NewStreamAllMiniTicker(wsClient, func(event ticker.StreamAllMiniTickerEvent) {
-			for _, e := range event.Data {
-				if e.Symbol == BTCUSDT {
-					fmt.Println(event.Stream, e)
-				}
-			}
-		}, func(messageType int, err error) {
-			fmt.Println(messageType, err)
-		})
-	} else {
-		err = ticker.NewWsAllMiniTicker(wsClient, func(event []ticker.WsMiniTickerEvent) {
-			for _, e := range event {
-				fmt.Println(e)
-			}
-		}, func(messageType int, err error) {
-			fmt.Println(messageType, err)
-		})
+	_, wsClient, rawClient := newWsClient(t)
+	event := receive(t, func(ctx context.Context, handler binance.Handler[ticker.StreamAllMiniTickerEvent]) (*binance.WsStream, error) {
+		return ticker.NewStreamAllMiniTicker(ctx, wsClient, handler, exception)
+	})
+	if event.Stream != "!miniTicker@arr" || len(event.Data) != 2 || event.Data[0].Symbol != BTCUSDT {
+		t.Fatalf("miniTicker@arr: %+v", event)
+	}
+	raw := receive(t, func(ctx context.Context, handler binance.Handler[[]ticker.WsMiniTickerEvent]) (*binance.WsStream, error) {
+		return ticker.NewWsAllMiniTicker(ctx, rawClient, handler, exception)
+	})
+	if len(raw) != 2 || raw[1].Symbol != ETHUSDT || raw[1].LastPrice != "1080" {
+		t.Fatalf("miniTicker@arr: %+v", raw)
 	}
 }
 func TestAllTicker(t *testing.T) {
-	if wsClient.IsCombined {
-		err = ticker.NewStreamAllTicker(wsClient, func(event ticker.StreamAllTickerEvent) {
-			for _, e := range event.Data {
-				fmt.Println(e)
-			}
-		}, func(messageType int, err error) {
-			fmt.Println(messageType, err)
-		})
-	} else {
-		err = ticker.NewWsTicker(wsClient, []string{BTCUSDT, ETHUSDT}, func(event ticker.WsTickerEvent) {
-			fmt.Println(event)
-		}, func(messageType int, err error) {
-			fmt.Println(messageType, err)
-		})
-	}
-	if err != nil {
-		t.Fatal(err)
+	_, wsClient, _ := newWsClient(t)
+	event := receive(t, func(ctx context.Context, handler binance.Handler[ticker.StreamAllTickerEvent]) (*binance.WsStream, error) {
+		return ticker.NewStreamAllTicker(ctx, wsClient, handler, exception)
+	})
+	if event.Stream != "!ticker@arr" || len(event.Data) == 0 || event.Data[0].Symbol != BTCUSDT || event.Data[0].Count != 18151 {
+		t.Fatalf("ticker@arr: %+v", event)
 	}
 }
 func TestWsBookTicker(t *testing.T) {
-	if wsClient.IsCombined {
-		err = ticker.NewStreamBookTicker(wsClient, []string{BTCUSDT, ETHUSDT}, func(event ticker.StreamBookTickerEvent) {
-			fmt.Println(event.Stream, event.Data)
-		}, func(messageType int, err error) {
-			fmt.Println(messageType, err)
-		})
-	} else {
-		err = ticker.NewWsBookTicker(wsClient, []string{BTCUSDT, ETHUSDT}, func(event ticker.WsBookTickerEvent) {
-			fmt.Println(event)
-		}, func(messageType int, err error) {
-			fmt.Println(messageType, err)
-		})
+	_, wsClient, rawClient := newWsClient(t)
+	event := receive(t, func(ctx context.Context, handler binance.Handler[ticker.StreamBookTickerEvent]) (*binance.WsStream, error) {
+		return ticker.NewStreamBookTicker(ctx, wsClient, []string{BTCUSDT, ETHUSDT}, handler, exception)
+	})
+	if event.Stream != "btcusdt@bookTicker" || event.Data.UpdateID != 400900217 {
+		t.Fatalf("bookTicker: %+v", event)
 	}
-	if err != nil {
-		t.Fatal(err)
+	raw := receive(t, func(ctx context.Context, handler binance.Handler[ticker.WsBookTickerEvent]) (*binance.WsStream, error) {
+		return ticker.NewWsBookTicker(ctx, rawClient, []string{BTCUSDT, ETHUSDT}, handler, exception)
+	})
+	if raw.BestBidQty != "31.21000000" || raw.BestAskQty != "40.66000000" {
+		t.Fatalf("bookTicker: %+v", raw)
 	}
 }
 func TestWsAvgTicker(t *testing.T) {
-	if wsClient.IsCombined {
-		err = market.NewStreamAvgPrice(wsClient, []string{BTCUSDT, ETHUSDT}, func(event market.StreamAvgPriceEvent) {
-			fmt.Println(event.Stream, event.Data)
-		}, func(messageType int, err error) {
-			fmt.Println(messageType, err)
-		})
-	} else {
-		err = market.NewWsAvgPrice(wsClient, []string{BTCUSDT, ETHUSDT}, func(event market.WsAvgPriceEvent) {
-			fmt.Println(event)
-		}, func(messageType int, err error) {
-			fmt.Println(messageType, err)
-		})
+	_, wsClient, rawClient := newWsClient(t)
+	event := receive(t, func(ctx context.Context, handler binance.Handler[market.StreamAvgPriceEvent]) (*binance.WsStream, error) {
+		return market.NewStreamAvgPrice(ctx, wsClient, []string{BTCUSDT, ETHUSDT}, handler, exception)
+	})
+	if event.Stream != "btcusdt@avgPrice" || event.Data.Interval != "5m" {
+		t.Fatalf("avgPrice: %+v", event)
 	}
-	if err != nil {
-		t.Fatal(err)
+	raw := receive(t, func(ctx context.Context, handler binance.Handler[market.WsAvgPriceEvent]) (*binance.WsStream, error) {
+		return market.NewWsAvgPrice(ctx, rawClient, []string{BTCUSDT, ETHUSDT}, handler, exception)
+	})
+	if raw.AvgPrice != "25776.86000000" {
+		t.Fatalf("avgPrice: %+v", raw)
 	}
 }
 func TestWsUserData(t *testing.T) {
+	s, path := newServer(t)
+	client := binance.NewED25519Client("ed25519", path, s.URL)
+	wsClient := binance.NewWsClient(true, true, s.WsURL())
 	res, err := stream.NewUserDataStream(client).CallCreate(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", res)
-	if wsClient.IsCombined {
-		err = account.NewStreamUserData(wsClient, res.ListenKey,
-			func(event *account.WsOutboundAccountPositionEvent) {
-				fmt.Println(event)
-			}, func(event *account.WsBalanceUpdateEvent) {
-				fmt.Println(event)
-			}, func(event *account.WsExecutionReportEvent) {
-				//{"stream":"re1kcvyiLnbcX8D7xqHK4dKfdWlSzrvLYHvpYCdP9bKH6JPlJkSc36mp8ezY","data":{"e":"listStatus","E":1728660487437,"s":"ETHUSDT","g":2617,"c":"OTO","l":"EXEC_STARTED","L":"EXECUTING","r":"NONE","C":"7780ba25-a448-4d97-ac27-156bab1bea54","T":1728660487437,"O":[{"s":"ETHUSDT","i":4580466,"c":"MQucRQKc3SWeKPFVoP45Me"},{"s":"ETHUSDT","i":4580467,"c":"CefgUNxEhQq2RPhyti21Oi"}]}}
-				fmt.Println(event)
-			}, func(event *account.WsListStatusEvent) {
-				fmt.Println(event)
-			}, func(event *account.WsListenKeyExpiredEvent) {
-				fmt.Println(event)
-			}, func(messageType int, err error) {
-				fmt.Println(messageType, err)
-			})
-	} else {
-		err = account.NewWsUserData(wsClient, res.ListenKey,
+	//{"stream":"re1kcvyiLnbcX8D7xqHK4dKfdWlSzrvLYHvpYCdP9bKH6JPlJkSc36mp8ezY","data":{"e":"listStatus","E":1728660487437,"s":"ETHUSDT","g":2617,"c":"OTO","l":"EXEC_STARTED","L":"EXECUTING","r":"NONE","C":"7780ba25-a448-4d97-ac27-156bab1bea54","T":1728660487437,"O":[{"s":"ETHUSDT","i":4580466,"c":"MQucRQKc3SWeKPFVoP45Me"},{"s":"ETHUSDT","i":4580467,"c":"CefgUNxEhQq2RPhyti21Oi"}]}}
+	err = s.StreamFixture(res.ListenKey, map[string]any{"e": "executionReport", "E": 1728662068985, "s": ETHUSDT, "c": "xyJifh0PaRFoXdrNN5ZoXN", "S": "SELL", "o": "LIMIT", "x": "NEW", "X": "NEW", "i": 4592157})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := receive(t, func(ctx context.Context, handler binance.Handler[*account.WsExecutionReportEvent]) (*binance.WsStream, error) {
+		return account.NewStreamUserData(ctx, wsClient, res.ListenKey,
 			func(event *account.WsOutboundAccountPositionEvent) {
 				fmt.Println(event)
 			}, func(event *account.WsBalanceUpdateEvent) {
 				fmt.Println(event)
-			}, func(event *account.WsExecutionReportEvent) {
-				fmt.Println(event)
-			}, func(event *account.WsListStatusEvent) {
+			}, handler, func(event *account.WsListStatusEvent) {
 				fmt.Println(event)
 			}, func(event *account.WsListenKeyExpiredEvent) {
 				fmt.Println(event)
-			}, func(messageType int, err error) {
-				fmt.Println(messageType, err)
-			})
-	}
-	if err != nil {
-		t.Fatal(err)
+			}, exception)
+	})
+	if event.Id != 4592157 || event.Status != enums.OrderStatusTypeNew || event.Side != enums.SideTypeSell {
+		t.Fatalf("executionReport: %+v", event)
 	}
 }
 func TestUnmarshal(t *testing.T) {
@@ -283,3 +263,229 @@ func TestUnmarshal(t *testing.T) {
 	}
 
 }
+func TestWsKlineSupervisor(t *testing.T) {
+	s, _ := newServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c := binance.NewWsClient(false, false, s.WsURL())
+	events := make(chan binance.StreamEvent, 16)
+	c.Supervisor = binance.NewSupervisor(func(event binance.StreamEvent) {
+		events <- event
+	})
+	c.Supervisor.InitialBackoff = 10 * time.Millisecond
+	c.Supervisor.MaxRetries = 5
+	klines := make(chan market.WsKlineEvent, 1)
+	ws, err := market.NewWsKline(ctx, c, map[string]enums.KlineIntervalType{
+		BTCUSDT: enums.KlineIntervalType1m,
+	}, func(event market.WsKlineEvent) {
+		klines <- event
+	}, exception)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	stream := "btcusdt@kline_1m"
+	err = s.WaitSubscribed(ctx, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := s.Disconnect(stream); n != 1 {
+		t.Fatalf("disconnect: %d", n)
+	}
+	// 断开后依次收到 DISCONNECTED、RECONNECTING、CONNECTED
+	var got []binance.StreamEventType
+	for len(got) < 4 {
+		select {
+		case event := <-events:
+			got = append(got, event.Type)
+		case <-ctx.Done():
+			t.Fatalf("events: %v", got)
+		}
+	}
+	if got[0] != binance.StreamEventConnected || got[1] != binance.StreamEventDisconnected || got[2] != binance.StreamEventReconnecting || got[3] != binance.StreamEventConnected {
+		t.Fatalf("events: %v", got)
+	}
+	err = s.WaitSubscribed(ctx, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Push(stream, map[string]any{"e": "kline", "E": 1, "s": BTCUSDT, "k": map[string]any{"t": 1, "T": 60000, "s": BTCUSDT, "i": "1m", "v": "10"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case kline := <-klines:
+		if kline.Kline.Interval != "1m" || kline.Kline.Volume != "10" {
+			t.Fatalf("kline: %+v", kline)
+		}
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+}
+
+// waitStream 取消 ctx 后等待推送结束，Err 返回 ctx 取消的原因
+func waitStream(t *testing.T, s *binance.WsStream, cancel context.CancelFunc) {
+	t.Helper()
+	cancel()
+	select {
+	case <-s.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream not closed after ctx canceled")
+	}
+	if !errors.Is(s.Err(), context.Canceled) {
+		t.Fatalf("err: %v", s.Err())
+	}
+}
+func TestWsStreamLifecycle(t *testing.T) {
+	s, _ := newServer(t)
+	c := binance.NewWsClient(false, false, s.WsURL())
+	connect := func(ctx context.Context) *binance.WsStream {
+		ws, err := market.NewWsTrade(ctx, c, []string{BTCUSDT}, func(event market.WsTradeEvent) {}, exception)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = s.WaitSubscribed(ctx, "btcusdt@trade")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ws
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 取消 ctx
+	streamCtx, streamCancel := context.WithCancel(ctx)
+	waitStream(t, connect(streamCtx), streamCancel)
+
+	// Close 结束时 Err 为 nil
+	ws := connect(ctx)
+	if ws.Err() != nil {
+		t.Fatalf("err before done: %v", ws.Err())
+	}
+	_ = ws.Close()
+	if ws.Err() != nil {
+		t.Fatalf("err after close: %v", ws.Err())
+	}
+
+	// 服务器断开连接时 Err 返回连接错误
+	ws = connect(ctx)
+	s.Disconnect("btcusdt@trade")
+	select {
+	case <-ws.Done():
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+	if ws.Err() == nil || errors.Is(ws.Err(), context.Canceled) {
+		t.Fatalf("err after disconnect: %v", ws.Err())
+	}
+}
+func TestWsSubscriptions(t *testing.T) {
+	s, _ := newServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c := binance.NewWsClient(true, false, s.WsURL())
+	subs, err := binance.NewWsSubscriptions(ctx, c, exception)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trades := make(chan market.WsTradeEvent, 1)
+	err = market.SubscribeTrade(ctx, subs, []string{BTCUSDT}, func(event market.WsTradeEvent) {
+		trades <- event
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	klines := make(chan market.WsKlineEvent, 1)
+	err = market.SubscribeKline(ctx, subs, map[string]enums.KlineIntervalType{
+		ETHUSDT: enums.KlineIntervalType1m,
+	}, func(event market.WsKlineEvent) {
+		klines <- event
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	streams, err := subs.ListSubscriptions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(streams) != 2 || streams[0] != "btcusdt@trade" || streams[1] != "ethusdt@kline_1m" {
+		t.Fatalf("streams: %v", streams)
+	}
+	// 订阅后回放 fixture 中的 btcusdt@trade
+	select {
+	case trade := <-trades:
+		if trade.TradeID != 12345 {
+			t.Fatalf("trade: %+v", trade)
+		}
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+	_, err = s.Push("ethusdt@kline_1m", map[string]any{"e": "kline", "E": 1, "s": ETHUSDT, "k": map[string]any{"s": ETHUSDT, "i": "1m", "c": "1080"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case kline := <-klines:
+		if kline.Symbol != ETHUSDT || kline.Kline.Close != "1080" {
+			t.Fatalf("kline: %+v", kline)
+		}
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+	err = subs.Unsubscribe(ctx, "btcusdt@trade")
+	if err != nil {
+		t.Fatal(err)
+	}
+	streams, err = subs.ListSubscriptions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(streams) != 1 || streams[0] != "ethusdt@kline_1m" {
+		t.Fatalf("streams after unsubscribe: %v", streams)
+	}
+	waitStream(t, subs.WsStream, cancel)
+}
+func TestOrderBook(t *testing.T) {
+	s, client := newClient(t)
+	// 快照介于 fixture 中录制的 btcusdt@depth@100ms 推送 U 和 u 之间
+	s.Handle(http.MethodGet, consts.ApiMarketDepth, func(r *binancetest.Request) (any, error) {
+		return map[string]any{"lastUpdateId": 3999530, "bids": [][]string{{"63690.00000000", "1"}}, "asks": [][]string{{"63700.00000000", "1"}}}, nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	changed := make(chan struct{}, 10)
+	book := market.NewOrderBook(client, BTCUSDT, enums.Limit1000).
+		SetOnChange(func(o *market.OrderBook) {
+			changed <- struct{}{}
+		}).
+		SetOnResync(func(err error) {
+			t.Errorf("resync: %v", err)
+		})
+	ws, err := book.Run(ctx, binance.NewWsClient(true, true, s.WsURL()), exception)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream := "btcusdt@depth@100ms"
+	err = s.WaitSubscribed(ctx, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Push(stream, map[string]any{"e": "depthUpdate", "s": BTCUSDT, "U": 3999551, "u": 3999552, "b": [][]string{}, "a": [][]string{{"63699.99000000", "0"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for book.LastUpdateId() != 3999552 {
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			t.Fatalf("lastUpdateId: %d", book.LastUpdateId())
+		}
+	}
+	if bids := book.Bids(0); len(bids) != 2 || !bids[0].Price.Equal(decimal.NewFromStringOrZero("63698.69")) {
+		t.Fatalf("bids: %v", bids)
+	}
+	if ask, ok := book.BestAsk(); !ok || !ask.Price.Equal(decimal.NewFromInt(63700)) {
+		t.Fatalf("best ask: %v", ask)
+	}
+	waitStream(t, ws, cancel)
+}
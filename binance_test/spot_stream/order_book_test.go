@@ -0,0 +1,81 @@
+package spot_stream_test
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/binance/consts"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/binance/spot/market"
+)
+
+func depthEvent(first, last int) *market.WsDepthEvent {
+	return &market.WsDepthEvent{Symbol: "BTCUSDT", FirstUpdateID: first, LastUpdateID: last}
+}
+
+func TestOrderBookResync(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	release := make(chan struct{})
+	var snapshotId atomic.Int64
+	snapshotId.Store(1000)
+	s.Handle(http.MethodGet, consts.ApiMarketDepth, func(r *binancetest.Request) (any, error) {
+		<-release
+		return map[string]any{"lastUpdateId": snapshotId.Load(), "bids": [][]string{{"100", "1"}}, "asks": [][]string{{"101", "1"}}}, nil
+	})
+	var (
+		mu     sync.Mutex
+		causes []error
+		book   *market.OrderBook
+	)
+	resyncs := func() []error {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]error(nil), causes...)
+	}
+	waitReady := func() {
+		deadline := time.Now().Add(5 * time.Second)
+		for !book.Ready() {
+			if time.Now().After(deadline) {
+				t.Fatal("not ready")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	book = market.NewOrderBook(binance.NewClient("", "", s.URL), "BTCUSDT", enums.Limit5).
+		SetOnResync(func(err error) {
+			// 回调时不持有锁
+			_ = book.Ready()
+			mu.Lock()
+			causes = append(causes, err)
+			mu.Unlock()
+		})
+	// 等待快照期间缓存溢出，丢弃缓存重新初始化
+	for i := 1; i <= 1001; i++ {
+		book.Update(depthEvent(i, i))
+	}
+	if causes := resyncs(); len(causes) != 1 || !strings.Contains(causes[0].Error(), "buffered") {
+		t.Fatalf("causes: %v", causes)
+	}
+	close(release)
+	waitReady()
+	if id := book.LastUpdateId(); id != 1001 {
+		t.Fatalf("lastUpdateId: %d", id)
+	}
+	// 丢包时在 Update 返回前回调
+	snapshotId.Store(1003)
+	book.Update(depthEvent(1003, 1003))
+	if causes := resyncs(); len(causes) != 2 || !strings.Contains(causes[1].Error(), "sequence gap") {
+		t.Fatalf("causes: %v", causes)
+	}
+	waitReady()
+	if id := book.LastUpdateId(); id != 1003 || len(resyncs()) != 2 {
+		t.Fatalf("lastUpdateId: %d causes: %v", id, resyncs())
+	}
+}
@@ -0,0 +1,132 @@
+package spot_stream_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/binance/spot/account"
+	"github.com/sleep-go/coin-go/binance/spot/stream"
+)
+
+func TestUserDataSession(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client := binance.NewClient("", "", s.URL)
+	session := stream.NewUserDataSession(client, binance.NewWsClient(false, false, s.WsURL())).SetInterval(50 * time.Millisecond)
+	reports := make(chan *account.WsExecutionReportEvent, 1)
+	gaps := make(chan stream.UserDataGap, 1)
+	session.OnExecutionReport = func(event *account.WsExecutionReportEvent) { reports <- event }
+	session.OnGap = func(gap stream.UserDataGap) { gaps <- gap }
+	session.OnError = func(err error) { t.Error(err) }
+	err := session.Start(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listenKey := session.ListenKey()
+	err = s.WaitSubscribed(ctx, listenKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Push(listenKey, map[string]any{"e": "executionReport", "s": "BTCUSDT", "i": 1, "X": "NEW"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report := <-reports; report.Id != 1 {
+		t.Fatalf("report: %+v", report)
+	}
+
+	// listenKey 过期后重新生成并重连
+	_, err = s.Push(listenKey, map[string]any{"e": "listenKeyExpired", "E": 1, "listenKey": listenKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gap := <-gaps
+	if !errors.Is(gap.Reason, stream.ErrListenKeyExpired) || !gap.Renewed || gap.ListenKey == listenKey || gap.ListenKey != session.ListenKey() || gap.To.Before(gap.From) {
+		t.Fatalf("gap: %+v", gap)
+	}
+	listenKey = gap.ListenKey
+
+	// 连接断开后使用原来的 listenKey 重连
+	err = s.WaitSubscribed(ctx, listenKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := s.Disconnect(listenKey); n != 1 {
+		t.Fatalf("disconnect: %d", n)
+	}
+	gap = <-gaps
+	if gap.Reason == nil || gap.Renewed || gap.ListenKey != listenKey {
+		t.Fatalf("gap: %+v", gap)
+	}
+
+	// 延长时返回 -1125 后重新生成
+	err = stream.NewUserDataStream(client).SetListenKey(listenKey).CallDelete(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gap = <-gaps
+	if !errors.Is(gap.Reason, binance.ErrInvalidListenKey) || !gap.Renewed || gap.ListenKey == listenKey {
+		t.Fatalf("gap: %+v", gap)
+	}
+	err = s.WaitSubscribed(ctx, gap.ListenKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Push(gap.ListenKey, map[string]any{"e": "executionReport", "s": "BTCUSDT", "i": 2, "X": "FILLED"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report := <-reports; report.Id != 2 {
+		t.Fatalf("report: %+v", report)
+	}
+
+	err = session.Close(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 只推送了过期事件的第一个 listenKey 在模拟服务端仍然有效
+	if keys := s.ListenKeys(); len(keys) != 1 || keys[0] == gap.ListenKey {
+		t.Fatalf("listenKeys: %v", keys)
+	}
+}
+
+func TestUserDataSessionRestart(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	client := binance.NewClient("", "", s.URL)
+	session := stream.NewUserDataSession(client, binance.NewWsClient(false, false, s.WsURL()))
+	ctx, cancel := context.WithCancel(context.Background())
+	err := session.Start(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := session.ListenKey()
+	cancel()
+	// ctx 结束后再次 Start 重新生成 listenKey 并连接
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for session.ListenKey() == first {
+		if ctx.Err() != nil {
+			t.Fatal("session not restarted")
+		}
+		err = session.Start(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	err = s.WaitSubscribed(ctx, session.ListenKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = session.Close(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
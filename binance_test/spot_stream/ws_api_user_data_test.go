@@ -0,0 +1,104 @@
+package spot_stream_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/binance/spot/account"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/binance/spot/trading"
+)
+
+const BTCUSDT = "BTCUSDT"
+
+func TestWsApiUserData(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	path, public, err := binancetest.GenerateED25519Key(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.AddPublicKey("ed25519", public)
+	client := binance.NewWsApiED25519Client("ed25519", path, s.WsApiURL())
+	defer client.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 未登录时不能订阅
+	_, err = account.SubscribeWsApiUserData(ctx, client, nil, nil, nil, nil, nil, nil)
+	if !errors.Is(err, binance.ErrUnauthorized) {
+		t.Fatalf("subscribe: %v", err)
+	}
+	logon, err := client.SessionLogon(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logon.Result.ApiKey == nil || *logon.Result.ApiKey != "ed25519" || logon.Result.UserDataStream {
+		t.Fatalf("logon: %+v", logon.Result)
+	}
+	// 登录后的签名请求不带 apiKey 和 signature
+	_, err = trading.NewWsApiCreateOrder(client).
+		SetSymbol(BTCUSDT).
+		SetSide(enums.SideTypeBuy).
+		SetType(enums.OrderTypeLimit).
+		SetTimeInForce(enums.TimeInForceTypeGTC).
+		SetQuantity("0.01").
+		SetPrice("60000").
+		Send(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests := s.Requests()
+	if r := requests[len(requests)-1]; r.Signed || r.Params.Has("apiKey") || !r.Params.Has("timestamp") || r.APIKey != "ed25519" {
+		t.Fatalf("order.place: %+v", r.Params)
+	}
+
+	reports := make(chan *account.WsExecutionReportEvent, 1)
+	terminated := make(chan *account.WsEventStreamTerminatedEvent, 1)
+	_, err = account.SubscribeWsApiUserData(ctx, client, nil, nil,
+		func(event *account.WsExecutionReportEvent) { reports <- event },
+		nil,
+		func(event *account.WsEventStreamTerminatedEvent) { terminated <- event },
+		func(messageType int, err error) { t.Error(err) },
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err := client.SessionStatus(ctx)
+	if err != nil || !status.Result.UserDataStream {
+		t.Fatalf("status: %+v %v", status, err)
+	}
+	n, err := s.PushUserData(map[string]any{"e": "executionReport", "s": BTCUSDT, "i": 1, "X": "NEW"})
+	if err != nil || n != 1 {
+		t.Fatalf("push: %d %v", n, err)
+	}
+	if report := <-reports; report.Id != 1 || report.Status != enums.OrderStatusTypeNew {
+		t.Fatalf("report: %+v", report)
+	}
+
+	logout, err := client.SessionLogout(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logout.Result.ApiKey != nil || logout.Result.UserDataStream {
+		t.Fatalf("logout: %+v", logout.Result)
+	}
+	select {
+	case <-terminated:
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+	// 登出后重新使用 apiKey 和签名
+	_, err = client.SessionStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests = s.Requests()
+	if r := requests[len(requests)-1]; r.Params.Get("apiKey") != "ed25519" {
+		t.Fatalf("session.status: %+v", r.Params)
+	}
+}
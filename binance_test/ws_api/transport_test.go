@@ -0,0 +1,169 @@
+package ws_api_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sleep-go/coin-go/binance"
+	"github.com/sleep-go/coin-go/binance/binancetest"
+	"github.com/sleep-go/coin-go/binance/spot/enums"
+	"github.com/sleep-go/coin-go/binance/spot/market"
+	"github.com/sleep-go/coin-go/binance/spot/trading"
+)
+
+const BTCUSDT = "BTCUSDT"
+
+func placeOrder(ctx context.Context, client *binance.Client) (*trading.WsApiCreateOrderResponse, error) {
+	return trading.NewWsApiCreateOrder(client).
+		SetSymbol(BTCUSDT).
+		SetSide(enums.SideTypeBuy).
+		SetType(enums.OrderTypeLimit).
+		SetTimeInForce(enums.TimeInForceTypeGTC).
+		SetQuantity("0.01").
+		SetPrice("60000").
+		Send(ctx)
+}
+
+// blockDepth depth 请求在 release 关闭之前不返回，返回收到请求的通知
+func blockDepth(s *binancetest.Server, release chan struct{}) chan struct{} {
+	received := make(chan struct{}, 16)
+	s.HandleWsApi("depth", func(r *binancetest.Request) (any, error) {
+		received <- struct{}{}
+		<-release
+		return map[string]any{"lastUpdateId": 1, "bids": [][]string{}, "asks": [][]string{}}, nil
+	})
+	return received
+}
+
+func TestConcurrent(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	dir := t.TempDir()
+	rsaPath, rsaKey, err := binancetest.GenerateRSAKey(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPath, edKey, err := binancetest.GenerateED25519Key(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.AddKey("hmac", "secret")
+	s.AddPublicKey("rsa", rsaKey)
+	s.AddPublicKey("ed25519", edKey)
+	clients := map[string]*binance.Client{
+		"hmac":    binance.NewWsApiHMACClient("hmac", "secret", s.WsApiURL()),
+		"rsa":     binance.NewWsApiRSAClient("rsa", rsaPath, s.WsApiURL()),
+		"ed25519": binance.NewWsApiED25519Client("ed25519", edPath, s.WsApiURL()),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for name, client := range clients {
+		defer client.Close()
+		var wg sync.WaitGroup
+		ids := make(chan int64, 20)
+		for i := 0; i < cap(ids); i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				order, err := placeOrder(ctx, client)
+				if err != nil {
+					t.Errorf("%s: %v", name, err)
+					return
+				}
+				ids <- int64(order.Result.OrderId)
+			}()
+		}
+		wg.Wait()
+		close(ids)
+		seen := make(map[int64]bool)
+		for id := range ids {
+			if seen[id] {
+				t.Fatalf("%s: duplicate order %d", name, id)
+			}
+			seen[id] = true
+		}
+		if len(seen) != cap(ids) {
+			t.Fatalf("%s: %d orders", name, len(seen))
+		}
+	}
+}
+
+func TestDisconnect(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	path, key, err := binancetest.GenerateED25519Key(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.AddPublicKey("ed25519", key)
+	release := make(chan struct{})
+	defer close(release)
+	received := blockDepth(s, release)
+	client := binance.NewWsApiED25519Client("ed25519", path, s.WsApiURL())
+	defer client.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = client.SessionLogon(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 连接断开时等待中的请求返回 ErrWsApiDisconnected
+	errs := make(chan error, 1)
+	go func() {
+		_, err := market.NewWsApiDepth(client).SetSymbol(BTCUSDT).Send(ctx)
+		errs <- err
+	}()
+	<-received
+	if n := s.DisconnectWsApi(); n != 1 {
+		t.Fatalf("disconnect: %d", n)
+	}
+	if err = <-errs; !errors.Is(err, binance.ErrWsApiDisconnected) {
+		t.Fatalf("depth: %v", err)
+	}
+
+	// 重连后自动重新登录
+	status, err := client.SessionStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Result.ApiKey == nil || *status.Result.ApiKey != "ed25519" {
+		t.Fatalf("status: %+v", status.Result)
+	}
+	requests := s.Requests()
+	if r := requests[len(requests)-1]; r.Params.Has("apiKey") {
+		t.Fatalf("session.status: %+v", r.Params)
+	}
+	if r := requests[len(requests)-2]; r.Path != "session.logon" || !r.Signed {
+		t.Fatalf("relogon: %s %+v", r.Path, r.Params)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	s := binancetest.NewServer()
+	defer s.Close()
+	release := make(chan struct{})
+	blockDepth(s, release)
+	client := binance.NewWsApiHMACClient("hmac", "secret", s.WsApiURL())
+	client.WsApiTimeout = 100 * time.Millisecond
+	_, err := market.NewWsApiDepth(client).SetSymbol(BTCUSDT).Send(context.Background())
+	close(release)
+	if !errors.Is(err, binance.ErrWsApiTimeout) {
+		t.Fatalf("depth: %v", err)
+	}
+	// 超时不影响之后的请求
+	client.WsApiTimeout = 0
+	_, err = placeOrder(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = client.Close()
+	_, err = placeOrder(context.Background(), client)
+	if !errors.Is(err, binance.ErrWsApiClosed) {
+		t.Fatalf("closed: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package ws_stream_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sleep-go/coin-go/binance"
+)
+
+// stallServer 第一个连接推送一条消息后不再读写也不关闭，模拟半开连接；之后的连接正常回复 pong
+func stallServer(t *testing.T) (*httptest.Server, func()) {
+	var (
+		mu    sync.Mutex
+		n     int
+		conns []*websocket.Conn
+	)
+	upgrader := websocket.Upgrader{}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		mu.Lock()
+		n++
+		id := n
+		conns = append(conns, conn)
+		mu.Unlock()
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"id":`+strconv.Itoa(id)+`}`))
+		if id == 1 {
+			return
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	return s, func() {
+		mu.Lock()
+		for _, c := range conns {
+			_ = c.Close()
+		}
+		mu.Unlock()
+		s.Close()
+	}
+}
+
+func TestServeStalled(t *testing.T) {
+	s, stop := stallServer(t)
+	defer stop()
+	c := binance.NewWsClient(false, false, "ws"+strings.TrimPrefix(s.URL, "http"))
+	c.Supervisor = &binance.Supervisor{InitialBackoff: 10 * time.Millisecond, PingInterval: 50 * time.Millisecond, RotateAfter: -1}
+	events := make(chan binance.StreamEvent, 16)
+	c.Supervisor.OnEvent = func(event binance.StreamEvent) { events <- event }
+	messages := make(chan string, 16)
+	stream, err := c.Serve(context.Background(), c.BaseURL, func(mt int, msg []byte) {
+		messages <- string(msg)
+	}, func(mt int, err error) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	var disconnected bool
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event := <-events:
+			if event.Type == binance.StreamEventDisconnected {
+				disconnected = true
+			}
+		case msg := <-messages:
+			if msg == `{"id":2}` {
+				if !disconnected {
+					t.Fatal("reconnected without disconnect event")
+				}
+				return
+			}
+		case <-timeout:
+			t.Fatal("stalled connection was not detected")
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package ws_stream_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sleep-go/coin-go/binance"
+)
+
+// subscribeServer 组合 Stream 服务端，订阅名称以 invalid@ 开头的 Stream 时返回错误
+func subscribeServer(t *testing.T) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		for {
+			var msg struct {
+				Id     uint64   `json:"id"`
+				Method string   `json:"method"`
+				Params []string `json:"params"`
+			}
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			resp := map[string]any{"id": msg.Id, "result": nil}
+			if slices.ContainsFunc(msg.Params, func(name string) bool { return strings.HasPrefix(name, "invalid@") }) {
+				resp = map[string]any{"id": msg.Id, "error": map[string]any{"code": 2, "msg": "Invalid request: invalid stream name"}}
+			}
+			if err := conn.WriteJSON(resp); err != nil {
+				return
+			}
+		}
+	}))
+}
+
+func newSubscriptions(t *testing.T, s *httptest.Server) *binance.WsSubscriptions {
+	c := binance.NewWsClient(true, false, "ws"+strings.TrimPrefix(s.URL, "http"))
+	subs, err := binance.NewWsSubscriptions(context.Background(), c, func(mt int, err error) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	subs.Timeout = 5 * time.Second
+	return subs
+}
+
+func TestSubscribeError(t *testing.T) {
+	s := subscribeServer(t)
+	defer s.Close()
+	subs := newSubscriptions(t, s)
+	defer subs.Close()
+
+	err := binance.Subscribe(context.Background(), subs, []string{"invalid@trade"}, func(event map[string]any) {})
+	var e *binance.APIError
+	if !errors.As(err, &e) || e.Code != 2 {
+		t.Fatalf("subscribe: %v", err)
+	}
+}
+
+func TestSubscribeErrorKeepsStreams(t *testing.T) {
+	s := subscribeServer(t)
+	defer s.Close()
+	subs := newSubscriptions(t, s)
+	defer subs.Close()
+	ctx := context.Background()
+
+	err := binance.Subscribe(ctx, subs, []string{"btcusdt@trade"}, func(event map[string]any) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = binance.Subscribe(ctx, subs, []string{"btcusdt@trade", "invalid@trade"}, func(event map[string]any) {})
+	if err == nil {
+		t.Fatal("subscribe invalid stream succeeded")
+	}
+	if streams := subs.Streams(); len(streams) != 1 || streams[0] != "btcusdt@trade" {
+		t.Fatalf("streams: %v", streams)
+	}
+}
+
+// rotationServer 第一个连接正常回复，之后的连接只记录收到的订阅请求，不回复，使轮换停在切换之前
+func rotationServer(t *testing.T) (*httptest.Server, func(conn int) [][]string) {
+	var (
+		mu       sync.Mutex
+		received [][][]string
+	)
+	upgrader := websocket.Upgrader{}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		mu.Lock()
+		id := len(received)
+		received = append(received, nil)
+		mu.Unlock()
+		for {
+			var msg struct {
+				Id     uint64   `json:"id"`
+				Method string   `json:"method"`
+				Params []string `json:"params"`
+			}
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			mu.Lock()
+			received[id] = append(received[id], msg.Params)
+			mu.Unlock()
+			if id > 0 {
+				continue
+			}
+			if err := conn.WriteJSON(map[string]any{"id": msg.Id, "result": nil}); err != nil {
+				return
+			}
+		}
+	}))
+	return s, func(conn int) [][]string {
+		mu.Lock()
+		defer mu.Unlock()
+		if conn >= len(received) {
+			return nil
+		}
+		return slices.Clone(received[conn])
+	}
+}
+
+func TestSubscribeDuringRotation(t *testing.T) {
+	s, received := rotationServer(t)
+	defer s.Close()
+	c := binance.NewWsClient(true, false, "ws"+strings.TrimPrefix(s.URL, "http"))
+	c.Supervisor = &binance.Supervisor{InitialBackoff: 10 * time.Millisecond, RotateAfter: 200 * time.Millisecond, RotateGrace: time.Minute}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	subs, err := binance.NewWsSubscriptions(ctx, c, func(mt int, err error) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	subs.Timeout = 5 * time.Second
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		// Supervisor 在后台拨号，连接建立前订阅返回 ErrNotConnected
+		err = binance.Subscribe(ctx, subs, []string{"btcusdt@trade"}, func(event map[string]any) {})
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal(err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// 等待新连接建立并重新订阅
+	for len(received(1)) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("no rotation")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// 切换之前的订阅同样发给新连接
+	err = binance.Subscribe(ctx, subs, []string{"ethusdt@trade"}, func(event map[string]any) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for !slices.ContainsFunc(received(1), func(params []string) bool { return slices.Equal(params, []string{"ethusdt@trade"}) }) {
+		if time.Now().After(deadline) {
+			t.Fatalf("new connection: %v", received(1))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestPongRateLimited(t *testing.T) {
+	pongs := make(chan time.Time, 20)
+	upgrader := websocket.Upgrader{}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		conn.SetPongHandler(func(string) error {
+			pongs <- time.Now()
+			return nil
+		})
+		for i := 0; i < 10; i++ {
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second)); err != nil {
+				return
+			}
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer s.Close()
+	subs := newSubscriptions(t, s)
+	defer subs.Close()
+	// 服务端连续 ping 10 次，回复的 pong 与订阅请求共用每秒 5 个消息的限制
+	start := time.Now()
+	var n int
+	timeout := time.After(5 * time.Second)
+	for n < 10 {
+		select {
+		case at := <-pongs:
+			n++
+			if n > binance.MaxMessagesPerSecond && at.Sub(start) < 900*time.Millisecond {
+				t.Fatalf("pong %d after %v", n, at.Sub(start))
+			}
+		case <-timeout:
+			t.Fatalf("pongs: %d", n)
+		}
+	}
+}
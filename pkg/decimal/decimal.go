@@ -0,0 +1,467 @@
+package decimal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode 舍入方式
+type RoundingMode int
+
+const (
+	RoundDown     RoundingMode = iota // 向零舍入(截断)
+	RoundUp                           // 远离零舍入
+	RoundHalfUp                       // 四舍五入，0.5 远离零
+	RoundHalfDown                     // 五舍六入，0.5 向零
+	RoundHalfEven                     // 银行家舍入，0.5 舍入到偶数
+	RoundFloor                        // 向负无穷舍入
+	RoundCeiling                      // 向正无穷舍入
+)
+
+// DivisionPrecision Div 结果保留的小数位数
+var DivisionPrecision int32 = 16
+
+// MaxExponent NewFromString 允许的最大指数和小数位数，防止 "1e2147483647" 这类输入分配超大整数
+const MaxExponent = 1024
+
+var (
+	ErrSyntax       = errors.New("decimal: invalid syntax")
+	ErrDivideByZero = errors.New("decimal: division by zero")
+)
+
+var (
+	bigOne = big.NewInt(1)
+	bigTen = big.NewInt(10)
+)
+
+// Zero 0
+var Zero = Decimal{}
+
+// Decimal 定点小数，值为 coef * 10^-scale
+// 零值即为 0，可以直接使用，所有运算都返回新值
+type Decimal struct {
+	coef  *big.Int
+	scale int32 // 小数位数，>= 0
+}
+
+// New 创建 value * 10^-scale
+func New(value int64, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{coef: new(big.Int).Mul(big.NewInt(value), pow10(-scale))}
+	}
+	return Decimal{coef: big.NewInt(value), scale: scale}
+}
+
+// NewFromInt 整数
+func NewFromInt(value int64) Decimal {
+	return New(value, 0)
+}
+
+// NewFromString 解析十进制字符串，支持 "-1.23"、"0.00100000"、"1e-8"
+// 指数和小数位数超过 MaxExponent 时返回 ErrSyntax
+func NewFromString(s string) (Decimal, error) {
+	orig := s
+	if s == "" {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrSyntax, orig)
+	}
+	var exp int64
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil || e > MaxExponent || e < -MaxExponent {
+			return Decimal{}, fmt.Errorf("%w: %q", ErrSyntax, orig)
+		}
+		exp = e
+		s = s[:i]
+	}
+	if s == "" {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrSyntax, orig)
+	}
+	neg := false
+	switch s[0] {
+	case '-':
+		neg = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	if intPart == "" && fracPart == "" {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrSyntax, orig)
+	}
+	digits := intPart + fracPart
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return Decimal{}, fmt.Errorf("%w: %q", ErrSyntax, orig)
+		}
+	}
+	coef, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrSyntax, orig)
+	}
+	if neg {
+		coef.Neg(coef)
+	}
+	scale := int64(len(fracPart)) - exp
+	if scale > MaxExponent || scale < -MaxExponent {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrSyntax, orig)
+	}
+	if scale < 0 {
+		coef.Mul(coef, pow10(int32(-scale)))
+		scale = 0
+	}
+	return Decimal{coef: coef, scale: int32(scale)}, nil
+}
+
+// RequireFromString 同 NewFromString，解析失败时 panic
+func RequireFromString(s string) Decimal {
+	d, err := NewFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// NewFromStringOrZero 同 NewFromString，空字符串或解析失败时返回 0
+// 用于响应中字符串字段的 decimal 视图
+func NewFromStringOrZero(s string) Decimal {
+	d, err := NewFromString(s)
+	if err != nil {
+		return Zero
+	}
+	return d
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) value() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// rescale 扩大到 scale 位小数，scale 必须 >= d.scale
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale == d.scale {
+		return d.value()
+	}
+	return new(big.Int).Mul(d.value(), pow10(scale-d.scale))
+}
+
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	scale := max(a.scale, b.scale)
+	return a.rescale(scale), b.rescale(scale), scale
+}
+
+// Scale 小数位数
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Add d + d2
+func (d Decimal) Add(d2 Decimal) Decimal {
+	a, b, scale := align(d, d2)
+	return Decimal{coef: new(big.Int).Add(a, b), scale: scale}
+}
+
+// Sub d - d2
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	a, b, scale := align(d, d2)
+	return Decimal{coef: new(big.Int).Sub(a, b), scale: scale}
+}
+
+// Mul d * d2
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.value(), d2.value()), scale: d.scale + d2.scale}
+}
+
+// Div d / d2，保留 DivisionPrecision 位小数，四舍五入
+// d2 为 0 时 panic
+func (d Decimal) Div(d2 Decimal) Decimal {
+	return d.DivRound(d2, DivisionPrecision, RoundHalfUp)
+}
+
+// DivRound d / d2，按 mode 保留 scale 位小数
+// d2 为 0 时 panic
+func (d Decimal) DivRound(d2 Decimal, scale int32, mode RoundingMode) Decimal {
+	if d2.IsZero() {
+		panic(ErrDivideByZero)
+	}
+	if scale < 0 {
+		scale = 0
+	}
+	// d/d2 = (a*10^-sa) / (b*10^-sb)，结果放大 10^scale 后为 a*10^(scale-sa+sb) / b
+	num := new(big.Int).Set(d.value())
+	den := new(big.Int).Set(d2.value())
+	k := scale - d.scale + d2.scale
+	if k >= 0 {
+		num.Mul(num, pow10(k))
+	} else {
+		den.Mul(den, pow10(-k))
+	}
+	return Decimal{coef: quo(num, den, mode), scale: scale}
+}
+
+// QuoRem 整数商和余数，d = q*d2 + r，q 向零截断
+func (d Decimal) QuoRem(d2 Decimal) (Decimal, Decimal) {
+	q := d.DivRound(d2, 0, RoundDown)
+	return q, d.Sub(q.Mul(d2))
+}
+
+// Mod d % d2，符号与 d 相同
+func (d Decimal) Mod(d2 Decimal) Decimal {
+	_, r := d.QuoRem(d2)
+	return r
+}
+
+// quo 按舍入方式计算 num / den
+func quo(num, den *big.Int, mode RoundingMode) *big.Int {
+	if den.Sign() < 0 {
+		num = new(big.Int).Neg(num)
+		den = new(big.Int).Neg(den)
+	}
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	// 此时 q 向零截断，r 与 num 同号
+	neg := num.Sign() < 0
+	away := false
+	switch mode {
+	case RoundDown:
+	case RoundUp:
+		away = true
+	case RoundFloor:
+		away = neg
+	case RoundCeiling:
+		away = !neg
+	case RoundHalfUp, RoundHalfDown, RoundHalfEven:
+		half := new(big.Int).Abs(r)
+		half.Lsh(half, 1)
+		switch half.Cmp(den) {
+		case 1:
+			away = true
+		case 0:
+			away = mode == RoundHalfUp || (mode == RoundHalfEven && q.Bit(0) == 1)
+		}
+	}
+	if away {
+		if neg {
+			q.Sub(q, bigOne)
+		} else {
+			q.Add(q, bigOne)
+		}
+	}
+	return q
+}
+
+// Round 按 mode 保留 scale 位小数，小数位数本来就不超过 scale 时原样返回
+func (d Decimal) Round(scale int32, mode RoundingMode) Decimal {
+	if scale < 0 {
+		scale = 0
+	}
+	if scale >= d.scale {
+		return d
+	}
+	return Decimal{coef: quo(d.value(), pow10(d.scale-scale), mode), scale: scale}
+}
+
+// Truncate 截断到 scale 位小数
+func (d Decimal) Truncate(scale int32) Decimal {
+	return d.Round(scale, RoundDown)
+}
+
+// RoundStep 按 mode 舍入到 step 的整数倍，如 tickSize、stepSize
+// step <= 0 时原样返回
+func (d Decimal) RoundStep(step Decimal, mode RoundingMode) Decimal {
+	if step.Sign() <= 0 {
+		return d
+	}
+	return d.DivRound(step, 0, mode).Mul(step)
+}
+
+// Normalize 去掉末尾的 0
+func (d Decimal) Normalize() Decimal {
+	if d.scale == 0 || d.coef == nil {
+		return Decimal{coef: d.coef}
+	}
+	coef := new(big.Int).Set(d.coef)
+	scale := d.scale
+	r := new(big.Int)
+	q := new(big.Int)
+	for scale > 0 {
+		q.QuoRem(coef, bigTen, r)
+		if r.Sign() != 0 {
+			break
+		}
+		coef, q = q, coef
+		scale--
+	}
+	return Decimal{coef: coef, scale: scale}
+}
+
+// Neg -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.value()), scale: d.scale}
+}
+
+// Abs |d|
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.value()), scale: d.scale}
+}
+
+// Sign -1、0、1
+func (d Decimal) Sign() int {
+	if d.coef == nil {
+		return 0
+	}
+	return d.coef.Sign()
+}
+
+func (d Decimal) IsZero() bool     { return d.Sign() == 0 }
+func (d Decimal) IsPositive() bool { return d.Sign() > 0 }
+func (d Decimal) IsNegative() bool { return d.Sign() < 0 }
+
+// Cmp 比较大小，d < d2 返回 -1，相等返回 0，d > d2 返回 1
+func (d Decimal) Cmp(d2 Decimal) int {
+	if d.scale == d2.scale {
+		return d.value().Cmp(d2.value())
+	}
+	a, b, _ := align(d, d2)
+	return a.Cmp(b)
+}
+
+func (d Decimal) Equal(d2 Decimal) bool              { return d.Cmp(d2) == 0 }
+func (d Decimal) LessThan(d2 Decimal) bool           { return d.Cmp(d2) < 0 }
+func (d Decimal) LessThanOrEqual(d2 Decimal) bool    { return d.Cmp(d2) <= 0 }
+func (d Decimal) GreaterThan(d2 Decimal) bool        { return d.Cmp(d2) > 0 }
+func (d Decimal) GreaterThanOrEqual(d2 Decimal) bool { return d.Cmp(d2) >= 0 }
+
+// Min 最小值
+func Min(first Decimal, rest ...Decimal) Decimal {
+	for _, d := range rest {
+		if d.LessThan(first) {
+			first = d
+		}
+	}
+	return first
+}
+
+// Max 最大值
+func Max(first Decimal, rest ...Decimal) Decimal {
+	for _, d := range rest {
+		if d.GreaterThan(first) {
+			first = d
+		}
+	}
+	return first
+}
+
+// Sum 求和
+func Sum(values ...Decimal) Decimal {
+	var total Decimal
+	for _, d := range values {
+		total = total.Add(d)
+	}
+	return total
+}
+
+// IntPart 整数部分，向零截断
+func (d Decimal) IntPart() int64 {
+	return d.Truncate(0).value().Int64()
+}
+
+// Float64 转为 float64，仅用于展示或绘图，可能丢失精度
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String 保留原有小数位数，"0.01000000" 解析后仍输出 "0.01000000"
+func (d Decimal) String() string {
+	return d.string(d.scale)
+}
+
+// StringFixed 输出 scale 位小数，多余的位数四舍五入
+func (d Decimal) StringFixed(scale int32) string {
+	if scale < 0 {
+		scale = 0
+	}
+	return d.Round(scale, RoundHalfUp).string(scale)
+}
+
+func (d Decimal) string(scale int32) string {
+	coef := d.value()
+	if scale > d.scale {
+		coef = d.rescale(scale)
+	}
+	digits := new(big.Int).Abs(coef).String()
+	var sb strings.Builder
+	if coef.Sign() < 0 {
+		sb.WriteByte('-')
+	}
+	if scale == 0 {
+		sb.WriteString(digits)
+		return sb.String()
+	}
+	if n := int(scale) - len(digits) + 1; n > 0 {
+		digits = strings.Repeat("0", n) + digits
+	}
+	sb.WriteString(digits[:len(digits)-int(scale)])
+	sb.WriteByte('.')
+	sb.WriteString(digits[len(digits)-int(scale):])
+	return sb.String()
+}
+
+// MarshalJSON 输出为字符串，与币安接口保持一致
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON 支持字符串和数字，null 和 "" 解析为 0
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = Decimal{}
+		return nil
+	}
+	s := string(data)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	if s == "" {
+		*d = Decimal{}
+		return nil
+	}
+	v, err := NewFromString(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*d = Decimal{}
+		return nil
+	}
+	v, err := NewFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
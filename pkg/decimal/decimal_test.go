@@ -0,0 +1,135 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+func TestNewFromString(t *testing.T) {
+	tests := []struct {
+		in, string, normalize string
+	}{
+		{"0", "0", "0"},
+		{"0.000", "0.000", "0"},
+		{"100", "100", "100"},
+		{"0.01000000", "0.01000000", "0.01"},
+		{"-0.50", "-0.50", "-0.5"},
+		{"+1.5", "1.5", "1.5"},
+		{".5", "0.5", "0.5"},
+		{"5.", "5", "5"},
+		{"1e-8", "0.00000001", "0.00000001"},
+		{"1.5e3", "1500", "1500"},
+		{"1.20E1", "12.0", "12"},
+		{"123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789"},
+	}
+	for _, tt := range tests {
+		d, err := decimal.NewFromString(tt.in)
+		if err != nil {
+			t.Errorf("%q: %v", tt.in, err)
+			continue
+		}
+		if s := d.String(); s != tt.string {
+			t.Errorf("%q: String %q, want %q", tt.in, s, tt.string)
+		}
+		if s := d.Normalize().String(); s != tt.normalize {
+			t.Errorf("%q: Normalize %q, want %q", tt.in, s, tt.normalize)
+		}
+	}
+}
+
+func TestNewFromStringInvalid(t *testing.T) {
+	for _, in := range []string{
+		"", "e5", "-e5", "-", "+", ".", "-.", "1e", "1e5x", "1.2.3", "abc", "--1", " 1",
+		"1e2147483647", "1e-2000", "0." + strings.Repeat("1", 2000),
+	} {
+		_, err := decimal.NewFromString(in)
+		if !errors.Is(err, decimal.ErrSyntax) {
+			t.Errorf("%q: err %v", in, err)
+		}
+	}
+}
+
+func TestRound(t *testing.T) {
+	in := []string{"2.5", "-2.5", "1.5", "2.4", "-2.6"}
+	tests := []struct {
+		mode decimal.RoundingMode
+		want []string
+	}{
+		{decimal.RoundDown, []string{"2", "-2", "1", "2", "-2"}},
+		{decimal.RoundUp, []string{"3", "-3", "2", "3", "-3"}},
+		{decimal.RoundHalfUp, []string{"3", "-3", "2", "2", "-3"}},
+		{decimal.RoundHalfDown, []string{"2", "-2", "1", "2", "-3"}},
+		{decimal.RoundHalfEven, []string{"2", "-2", "2", "2", "-3"}},
+		{decimal.RoundFloor, []string{"2", "-3", "1", "2", "-3"}},
+		{decimal.RoundCeiling, []string{"3", "-2", "2", "3", "-2"}},
+	}
+	for _, tt := range tests {
+		for i, s := range in {
+			got := decimal.RequireFromString(s).Round(0, tt.mode).String()
+			if got != tt.want[i] {
+				t.Errorf("mode %d: Round(%s) = %s, want %s", tt.mode, s, got, tt.want[i])
+			}
+		}
+	}
+	if got := decimal.RequireFromString("1.2").Round(4, decimal.RoundUp).String(); got != "1.2" {
+		t.Errorf("Round keeps scale: %s", got)
+	}
+}
+
+func TestRoundStep(t *testing.T) {
+	tests := []struct {
+		in, step string
+		mode     decimal.RoundingMode
+		want     string
+	}{
+		{"1.23456", "0.01", decimal.RoundDown, "1.23"},
+		{"1.235", "0.01", decimal.RoundHalfUp, "1.24"},
+		{"-1.237", "0.01", decimal.RoundFloor, "-1.24"},
+		{"0.0123", "0.005", decimal.RoundDown, "0.010"},
+		{"17", "5", decimal.RoundCeiling, "20"},
+		{"1.5", "0", decimal.RoundDown, "1.5"},
+	}
+	for _, tt := range tests {
+		got := decimal.RequireFromString(tt.in).RoundStep(decimal.RequireFromString(tt.step), tt.mode).String()
+		if got != tt.want {
+			t.Errorf("RoundStep(%s, %s) = %s, want %s", tt.in, tt.step, got, tt.want)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var v struct {
+		A, B, C, D decimal.Decimal
+	}
+	err := json.Unmarshal([]byte(`{"A":"0.010","B":1.5,"C":null,"D":""}`), &v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.A.String() != "0.010" || v.B.String() != "1.5" || !v.C.IsZero() || !v.D.IsZero() {
+		t.Fatalf("unmarshal: %v %v %v %v", v.A, v.B, v.C, v.D)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"A":"0.010","B":"1.5","C":"0","D":"0"}` {
+		t.Fatalf("marshal: %s", b)
+	}
+	for _, in := range []string{`{"A":"e5"}`, `{"A":"-"}`, `{"A":"1e2147483647"}`} {
+		if err := json.Unmarshal([]byte(in), &v); !errors.Is(err, decimal.ErrSyntax) {
+			t.Errorf("%s: err %v", in, err)
+		}
+	}
+}
+
+func TestNewFromStringOrZero(t *testing.T) {
+	for in, want := range map[string]string{"0.01000000": "0.01000000", "": "0", "abc": "0", "-1.5": "-1.5"} {
+		if got := decimal.NewFromStringOrZero(in).String(); got != want {
+			t.Errorf("%q: %s, want %s", in, got, want)
+		}
+	}
+}
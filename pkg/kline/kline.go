@@ -0,0 +1,147 @@
+package kline
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// Kline K线
+// 现货、合约、连续合约、指数价格、标记价格、溢价指数K线都可以转换为 Kline
+type Kline struct {
+	OpenTime            int64           // 开盘时间
+	CloseTime           int64           // 收盘时间
+	Open                decimal.Decimal // 开盘价
+	High                decimal.Decimal // 最高价
+	Low                 decimal.Decimal // 最低价
+	Close               decimal.Decimal // 收盘价(当前K线未结束的即为最新价)
+	Volume              decimal.Decimal // 成交量
+	QuoteVolume         decimal.Decimal // 成交额
+	TradeCount          int64           // 成交笔数
+	TakerBuyVolume      decimal.Decimal // 主动买入成交量
+	TakerBuyQuoteVolume decimal.Decimal // 主动买入成交额
+	IsFinal             bool            // 这根K线是否完结
+}
+
+// Source 可以转换为 Kline 的数据
+// REST 接口返回的 KlinesResponse 和 Websocket 推送的 WsKline 都实现了该接口
+type Source interface {
+	Kline() (Kline, error)
+}
+
+// Kline 实现 Source
+func (k Kline) Kline() (Kline, error) {
+	return k, nil
+}
+
+// UnmarshalArray 解析 REST 接口返回的K线数组，数字保留为 json.Number 以免经过 float64
+func UnmarshalArray(data []byte, a *[12]any) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	return d.Decode(a)
+}
+
+// FromArray 从 REST 接口返回的K线数组转换，结果只取决于输入
+// 数组中没有是否完结的标记，IsFinal 为 false，需要时由调用方按参考时间调用 SetFinal
+// 时间和成交笔数可以是 json.Number、字符串、整数或没有小数部分的 float64，
+// 价格和数量必须是字符串、json.Number 或整数，float64 会丢失精度，返回错误
+func FromArray(a [12]any) (Kline, error) {
+	var k Kline
+	var err error
+	ints := []struct {
+		dst *int64
+		i   int
+	}{{&k.OpenTime, 0}, {&k.CloseTime, 6}, {&k.TradeCount, 8}}
+	for _, v := range ints {
+		*v.dst, err = toInt64(a[v.i])
+		if err != nil {
+			return Kline{}, fmt.Errorf("kline: index %d: %w", v.i, err)
+		}
+	}
+	decimals := []struct {
+		dst *decimal.Decimal
+		i   int
+	}{
+		{&k.Open, 1}, {&k.High, 2}, {&k.Low, 3}, {&k.Close, 4}, {&k.Volume, 5},
+		{&k.QuoteVolume, 7}, {&k.TakerBuyVolume, 9}, {&k.TakerBuyQuoteVolume, 10},
+	}
+	for _, v := range decimals {
+		*v.dst, err = toDecimal(a[v.i])
+		if err != nil {
+			return Kline{}, fmt.Errorf("kline: index %d: %w", v.i, err)
+		}
+	}
+	return k, nil
+}
+
+// SetFinal 按参考时间 now(毫秒) 设置是否完结，收盘时间早于 now 即视为完结
+func (k *Kline) SetFinal(now int64) {
+	k.IsFinal = k.CloseTime < now
+}
+
+// FromArrays 批量转换 REST 接口返回的K线
+func FromArrays[T ~[12]any](rows []*T) ([]Kline, error) {
+	klines := make([]Kline, 0, len(rows))
+	for _, row := range rows {
+		if row == nil {
+			continue
+		}
+		k, err := FromArray(*row)
+		if err != nil {
+			return nil, err
+		}
+		klines = append(klines, k)
+	}
+	return klines, nil
+}
+
+var errFloat64 = errors.New("float64 loses precision, decode with UnmarshalArray")
+
+// maxExactFloat64 float64 可以精确表示的最大整数
+const maxExactFloat64 = 1 << 53
+
+func toInt64(v any) (int64, error) {
+	switch v := v.(type) {
+	case json.Number:
+		return v.Int64()
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case nil:
+		return 0, nil
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > maxExactFloat64 {
+			return 0, errFloat64
+		}
+		return int64(v), nil
+	default:
+		return 0, fmt.Errorf("unexpected type %T", v)
+	}
+}
+
+func toDecimal(v any) (decimal.Decimal, error) {
+	switch v := v.(type) {
+	case string:
+		return decimal.NewFromString(v)
+	case json.Number:
+		return decimal.NewFromString(v.String())
+	case int64:
+		return decimal.NewFromInt(v), nil
+	case int:
+		return decimal.NewFromInt(int64(v)), nil
+	case nil:
+		return decimal.Zero, nil
+	case float64:
+		return decimal.Zero, errFloat64
+	default:
+		return decimal.Zero, fmt.Errorf("unexpected type %T", v)
+	}
+}
@@ -0,0 +1,64 @@
+package kline_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sleep-go/coin-go/pkg/kline"
+)
+
+const row = `[1499040000000,"0.01634790","0.80000000","0.01575800","0.01577100","148976.11427815",1499644799999,"2434.19055334",308,"1756.87402397","28.46694368","0"]`
+
+func TestFromArray(t *testing.T) {
+	var a [12]any
+	err := kline.UnmarshalArray([]byte(row), &a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err := kline.FromArray(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.OpenTime != 1499040000000 || k.TradeCount != 308 || k.Open.String() != "0.01634790" || k.TakerBuyQuoteVolume.String() != "28.46694368" {
+		t.Fatalf("kline: %+v", k)
+	}
+	if k.IsFinal {
+		t.Fatal("IsFinal set without reference time")
+	}
+	k.SetFinal(1499644799999)
+	if k.IsFinal {
+		t.Fatal("final before close time")
+	}
+	k.SetFinal(1499644800000)
+	if !k.IsFinal {
+		t.Fatal("not final after close time")
+	}
+
+	a[1] = 0.0163479
+	if _, err = kline.FromArray(a); err == nil {
+		t.Fatal("float64 price accepted")
+	}
+}
+
+func TestFromArrayPlainJSON(t *testing.T) {
+	var a [12]any
+	err := json.Unmarshal([]byte(row), &a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := a[0].(float64); !ok {
+		t.Fatalf("open time decoded as %T", a[0])
+	}
+	k, err := kline.FromArray(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.OpenTime != 1499040000000 || k.CloseTime != 1499644799999 || k.TradeCount != 308 {
+		t.Fatalf("kline: %+v", k)
+	}
+
+	a[0] = 1499040000000.5
+	if _, err = kline.FromArray(a); err == nil {
+		t.Fatal("fractional open time accepted")
+	}
+}
@@ -0,0 +1,205 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// Side 买卖方向
+type Side int
+
+const (
+	Bid Side = iota // 买单
+	Ask             // 卖单
+)
+
+// Level 一个价格档位，JSON 格式为 ["价格", "数量"]
+type Level struct {
+	Price    decimal.Decimal
+	Quantity decimal.Decimal
+}
+
+func (l Level) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]decimal.Decimal{l.Price, l.Quantity})
+}
+
+func (l *Level) UnmarshalJSON(data []byte) error {
+	var v []decimal.Decimal
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+	if len(v) < 2 {
+		return fmt.Errorf("orderbook: invalid level %s", data)
+	}
+	l.Price, l.Quantity = v[0], v[1]
+	return nil
+}
+
+// ParseLevels 解析 [["价格", "数量"], ...] 格式的档位
+func ParseLevels(levels [][]string) ([]Level, error) {
+	out := make([]Level, len(levels))
+	for i, l := range levels {
+		if len(l) < 2 {
+			return nil, fmt.Errorf("orderbook: invalid level %v", l)
+		}
+		price, err := decimal.NewFromString(l[0])
+		if err != nil {
+			return nil, err
+		}
+		quantity, err := decimal.NewFromString(l[1])
+		if err != nil {
+			return nil, err
+		}
+		out[i] = Level{Price: price, Quantity: quantity}
+	}
+	return out, nil
+}
+
+type side struct {
+	desc   bool              // 买单按价格从高到低排列
+	prices []decimal.Decimal // 有序价格
+	qty    map[string]Level  // key 为去掉末尾 0 的价格
+}
+
+func newSide(desc bool) *side {
+	return &side{desc: desc, qty: make(map[string]Level)}
+}
+
+func key(price decimal.Decimal) string {
+	return price.Normalize().String()
+}
+
+// search 价格在有序数组中的位置
+func (s *side) search(price decimal.Decimal) int {
+	if s.desc {
+		return sort.Search(len(s.prices), func(i int) bool { return s.prices[i].LessThanOrEqual(price) })
+	}
+	return sort.Search(len(s.prices), func(i int) bool { return s.prices[i].GreaterThanOrEqual(price) })
+}
+
+// set 挂单量为绝对值，为 0 时移除该价位
+func (s *side) set(l Level) {
+	k := key(l.Price)
+	_, exists := s.qty[k]
+	if l.Quantity.IsZero() {
+		if !exists {
+			return
+		}
+		delete(s.qty, k)
+		i := s.search(l.Price)
+		s.prices = append(s.prices[:i], s.prices[i+1:]...)
+		return
+	}
+	s.qty[k] = l
+	if exists {
+		return
+	}
+	i := s.search(l.Price)
+	s.prices = append(s.prices, decimal.Zero)
+	copy(s.prices[i+1:], s.prices[i:])
+	s.prices[i] = l.Price
+}
+
+func (s *side) level(i int) Level {
+	return s.qty[key(s.prices[i])]
+}
+
+func (s *side) levels(n int) []Level {
+	if n <= 0 || n > len(s.prices) {
+		n = len(s.prices)
+	}
+	levels := make([]Level, n)
+	for i := 0; i < n; i++ {
+		levels[i] = s.level(i)
+	}
+	return levels
+}
+
+// cumulative 从最优价到 price (含) 的累计挂单量
+func (s *side) cumulative(price decimal.Decimal) decimal.Decimal {
+	var total decimal.Decimal
+	for i, p := range s.prices {
+		if (s.desc && p.LessThan(price)) || (!s.desc && p.GreaterThan(price)) {
+			break
+		}
+		total = total.Add(s.level(i).Quantity)
+	}
+	return total
+}
+
+// Book 本地订单簿
+// 只负责存储价格档位，不做并发保护，同步逻辑由调用方实现
+type Book struct {
+	bids *side
+	asks *side
+}
+
+func NewBook() *Book {
+	return &Book{bids: newSide(true), asks: newSide(false)}
+}
+
+// Reset 用深度快照替换全部档位
+func (b *Book) Reset(bids, asks []Level) {
+	b.bids = newSide(true)
+	b.asks = newSide(false)
+	b.Update(bids, asks)
+}
+
+// Update 应用增量深度，数量为价位当前挂单量的绝对值
+func (b *Book) Update(bids, asks []Level) {
+	for _, l := range bids {
+		b.bids.set(l)
+	}
+	for _, l := range asks {
+		b.asks.set(l)
+	}
+}
+
+func (b *Book) sideOf(sd Side) *side {
+	if sd == Bid {
+		return b.bids
+	}
+	return b.asks
+}
+
+// BestBid 买一
+func (b *Book) BestBid() (Level, bool) {
+	return b.best(b.bids)
+}
+
+// BestAsk 卖一
+func (b *Book) BestAsk() (Level, bool) {
+	return b.best(b.asks)
+}
+
+func (b *Book) best(s *side) (Level, bool) {
+	if len(s.prices) == 0 {
+		return Level{}, false
+	}
+	return s.level(0), true
+}
+
+// Bids 前 n 档买单，n <= 0 返回全部
+func (b *Book) Bids(n int) []Level {
+	return b.bids.levels(n)
+}
+
+// Asks 前 n 档卖单，n <= 0 返回全部
+func (b *Book) Asks(n int) []Level {
+	return b.asks.levels(n)
+}
+
+// CumulativeVolume 从最优价到 price (含) 的累计挂单量
+// 买单累计价格 >= price 的档位，卖单累计价格 <= price 的档位
+func (b *Book) CumulativeVolume(sd Side, price decimal.Decimal) decimal.Decimal {
+	return b.sideOf(sd).cumulative(price)
+}
+
+// Len 买卖档位数量
+func (b *Book) Len() (bids, asks int) {
+	return len(b.bids.prices), len(b.asks.prices)
+}
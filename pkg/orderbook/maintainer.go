@@ -0,0 +1,269 @@
+package orderbook
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/sleep-go/coin-go/pkg/decimal"
+)
+
+// Diff 增量深度
+type Diff struct {
+	FirstUpdateId    int // U
+	LastUpdateId     int // u
+	PrevLastUpdateId int // pu，只有合约有
+	Bids             [][]string
+	Asks             [][]string
+}
+
+// Snapshot 深度快照
+type Snapshot struct {
+	LastUpdateId int
+	Bids         [][]string
+	Asks         [][]string
+}
+
+// Sequencer 按各市场的规则检查增量深度能否应用在 lastUpdateId 之后
+// first 为应用快照之后的第一个 diff，返回 false 表示丢弃过期的 diff，返回错误时重新初始化
+type Sequencer func(d *Diff, lastUpdateId int, first bool) (bool, error)
+
+// MaxBufferedDiffs 等待快照期间最多缓存的 diff 数，超过后丢弃缓存重新初始化
+const MaxBufferedDiffs = 1000
+
+// Maintainer 按文档 "如何正确在本地维护一个orderbook副本" 维护本地副本:
+// 缓存快照之前收到的 diff，获取快照后按 Sequencer 依次应用，
+// 检测到丢包、缓存溢出或获取快照失败时丢弃副本重新初始化
+//
+// 回调串行执行且不持有锁，可以读取 orderbook，但不能在回调中调用 Update
+type Maintainer struct {
+	name     string
+	snapshot func(ctx context.Context) (*Snapshot, error)
+	sequence Sequencer
+	onChange func()
+	onResync func(error)
+	debugf   func(format string, v ...any)
+
+	notify       sync.Mutex // 串行执行回调
+	ctx          context.Context
+	mu           sync.RWMutex
+	book         *Book
+	buffer       []*Diff
+	ready        bool // 已应用快照
+	first        bool // 等待快照之后的第一个 diff
+	syncing      bool
+	lastUpdateId int
+}
+
+// NewMaintainer name 用于错误信息，snapshot 获取深度快照
+func NewMaintainer(name string, snapshot func(ctx context.Context) (*Snapshot, error), sequence Sequencer) *Maintainer {
+	return &Maintainer{
+		name:     name,
+		snapshot: snapshot,
+		sequence: sequence,
+		debugf:   func(string, ...any) {},
+		ctx:      context.Background(),
+		book:     NewBook(),
+	}
+}
+
+// SetOnChange 每次 orderbook 更新后回调
+func (m *Maintainer) SetOnChange(onChange func()) *Maintainer {
+	m.onChange = onChange
+	return m
+}
+
+// SetOnResync 需要重新初始化时回调
+func (m *Maintainer) SetOnResync(onResync func(error)) *Maintainer {
+	m.onResync = onResync
+	return m
+}
+
+// SetDebugf 调试日志
+func (m *Maintainer) SetDebugf(debugf func(format string, v ...any)) *Maintainer {
+	m.debugf = debugf
+	return m
+}
+
+// SetContext 获取快照使用的 ctx
+func (m *Maintainer) SetContext(ctx context.Context) {
+	m.mu.Lock()
+	m.ctx = ctx
+	m.mu.Unlock()
+}
+
+// Update 处理一个增量深度
+func (m *Maintainer) Update(d *Diff) {
+	if d == nil {
+		return
+	}
+	m.mu.Lock()
+	if !m.ready {
+		if len(m.buffer) >= MaxBufferedDiffs {
+			err := fmt.Errorf("orderbook %s: %d events buffered while waiting for snapshot", m.name, len(m.buffer))
+			m.resync([]*Diff{d}, err)
+			m.mu.Unlock()
+			m.notifyResync(err)
+			return
+		}
+		m.buffer = append(m.buffer, d)
+		m.startSync()
+		m.mu.Unlock()
+		return
+	}
+	changed, err := m.apply(d)
+	if err != nil {
+		m.resync([]*Diff{d}, err)
+		m.mu.Unlock()
+		m.notifyResync(err)
+		return
+	}
+	m.mu.Unlock()
+	if changed {
+		m.notifyChange()
+	}
+}
+
+// apply 应用一个 diff，调用方需持有锁
+func (m *Maintainer) apply(d *Diff) (bool, error) {
+	ok, err := m.sequence(d, m.lastUpdateId, m.first)
+	if err != nil {
+		return false, fmt.Errorf("orderbook %s: %w", m.name, err)
+	}
+	if !ok {
+		return false, nil
+	}
+	m.first = false
+	bids, err := ParseLevels(d.Bids)
+	if err != nil {
+		return false, err
+	}
+	asks, err := ParseLevels(d.Asks)
+	if err != nil {
+		return false, err
+	}
+	m.book.Update(bids, asks)
+	m.lastUpdateId = d.LastUpdateId
+	return true, nil
+}
+
+// resync 丢弃本地副本，从 diffs 开始重新缓存并获取快照，调用方需持有锁，释放锁后调用 notifyResync
+func (m *Maintainer) resync(diffs []*Diff, cause error) {
+	m.debugf("%v, resync", cause)
+	m.ready = false
+	m.buffer = diffs
+	m.book = NewBook()
+	m.startSync()
+}
+
+func (m *Maintainer) notifyChange() {
+	m.notify.Lock()
+	defer m.notify.Unlock()
+	if m.onChange != nil {
+		m.onChange()
+	}
+}
+
+func (m *Maintainer) notifyResync(cause error) {
+	m.notify.Lock()
+	defer m.notify.Unlock()
+	if m.onResync != nil {
+		m.onResync(cause)
+	}
+}
+
+// startSync 后台获取深度快照，调用方需持有锁
+func (m *Maintainer) startSync() {
+	if m.syncing {
+		return
+	}
+	m.syncing = true
+	go m.sync(m.ctx)
+}
+
+func (m *Maintainer) sync(ctx context.Context) {
+	snapshot, err := m.snapshot(ctx)
+	var bids, asks []Level
+	if err == nil {
+		bids, err = ParseLevels(snapshot.Bids)
+	}
+	if err == nil {
+		asks, err = ParseLevels(snapshot.Asks)
+	}
+	m.mu.Lock()
+	m.syncing = false
+	if err != nil {
+		m.mu.Unlock()
+		m.debugf("orderbook %s snapshot err:%v", m.name, err)
+		m.notifyResync(err)
+		// 下一个 diff 到达时重试
+		return
+	}
+	m.book.Reset(bids, asks)
+	m.lastUpdateId = snapshot.LastUpdateId
+	m.ready = true
+	m.first = true
+	buffer := m.buffer
+	m.buffer = nil
+	for i, d := range buffer {
+		_, err = m.apply(d)
+		if err != nil {
+			// 快照早于缓存的 diff，需要重新获取
+			m.resync(buffer[i:], err)
+			m.mu.Unlock()
+			m.notifyResync(err)
+			return
+		}
+	}
+	m.mu.Unlock()
+	m.notifyChange()
+}
+
+// Ready 是否已完成初始化
+func (m *Maintainer) Ready() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.ready
+}
+
+// LastUpdateId 最后一次更新的ID
+func (m *Maintainer) LastUpdateId() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.lastUpdateId
+}
+
+// BestBid 买一
+func (m *Maintainer) BestBid() (Level, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.book.BestBid()
+}
+
+// BestAsk 卖一
+func (m *Maintainer) BestAsk() (Level, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.book.BestAsk()
+}
+
+// Bids 前 n 档买单，n <= 0 返回全部
+func (m *Maintainer) Bids(n int) []Level {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.book.Bids(n)
+}
+
+// Asks 前 n 档卖单，n <= 0 返回全部
+func (m *Maintainer) Asks(n int) []Level {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.book.Asks(n)
+}
+
+// CumulativeVolume 从最优价到 price (含) 的累计挂单量
+func (m *Maintainer) CumulativeVolume(side Side, price decimal.Decimal) decimal.Decimal {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.book.CumulativeVolume(side, price)
+}
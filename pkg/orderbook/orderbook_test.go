@@ -0,0 +1,156 @@
+package orderbook_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sleep-go/coin-go/pkg/decimal"
+	"github.com/sleep-go/coin-go/pkg/orderbook"
+)
+
+func levels(t *testing.T, l [][]string) []orderbook.Level {
+	t.Helper()
+	out, err := orderbook.ParseLevels(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestBook(t *testing.T) {
+	b := orderbook.NewBook()
+	b.Reset(levels(t, [][]string{{"100", "1"}, {"99.50", "2"}}), levels(t, [][]string{{"101", "1"}, {"102", "3"}}))
+	// 数量为 0 删除档位，价格末尾的 0 不影响匹配
+	b.Update(levels(t, [][]string{{"99.5", "0"}, {"98", "4"}}), levels(t, [][]string{{"100.5", "2"}}))
+	if bid, ok := b.BestBid(); !ok || bid.Price.String() != "100" {
+		t.Fatalf("best bid: %v", bid)
+	}
+	if ask, ok := b.BestAsk(); !ok || ask.Price.String() != "100.5" {
+		t.Fatalf("best ask: %v", ask)
+	}
+	if bids, asks := b.Len(); bids != 2 || asks != 3 {
+		t.Fatalf("len: %d %d", bids, asks)
+	}
+	if v := b.CumulativeVolume(orderbook.Ask, decimal.NewFromInt(101)); v.String() != "3" {
+		t.Fatalf("cumulative: %v", v)
+	}
+	if _, err := orderbook.ParseLevels([][]string{{"1"}}); err == nil {
+		t.Fatal("invalid level accepted")
+	}
+}
+
+// sequence 现货规则
+func sequence(d *orderbook.Diff, lastUpdateId int, first bool) (bool, error) {
+	if d.LastUpdateId <= lastUpdateId {
+		return false, nil
+	}
+	if !first && d.FirstUpdateId != lastUpdateId+1 {
+		return false, fmt.Errorf("sequence gap")
+	}
+	return true, nil
+}
+
+func diff(first, last int, bid string) *orderbook.Diff {
+	return &orderbook.Diff{FirstUpdateId: first, LastUpdateId: last, Bids: [][]string{{bid, "1"}}}
+}
+
+func waitReady(t *testing.T, m *orderbook.Maintainer) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !m.Ready() {
+		if time.Now().After(deadline) {
+			t.Fatal("not ready")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestMaintainer(t *testing.T) {
+	var snapshotId atomic.Int64
+	snapshotId.Store(10)
+	var fail atomic.Bool
+	fail.Store(true)
+	var (
+		mu     sync.Mutex
+		causes []error
+	)
+	m := orderbook.NewMaintainer("TEST", func(ctx context.Context) (*orderbook.Snapshot, error) {
+		if fail.Swap(false) {
+			return nil, errors.New("snapshot failed")
+		}
+		return &orderbook.Snapshot{LastUpdateId: int(snapshotId.Load()), Bids: [][]string{{"100", "1"}}}, nil
+	}, sequence).SetOnResync(func(err error) {
+		mu.Lock()
+		causes = append(causes, err)
+		mu.Unlock()
+	})
+	// 获取快照失败，下一个 diff 到达时重试
+	m.Update(diff(5, 8, "90"))
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		n := len(causes)
+		mu.Unlock()
+		if n == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("snapshot error not reported")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if m.Ready() {
+		t.Fatal("ready without snapshot")
+	}
+	m.Update(diff(9, 12, "99"))
+	waitReady(t, m)
+	// 过期的 diff 被丢弃
+	if id := m.LastUpdateId(); id != 12 || len(m.Bids(0)) != 2 {
+		t.Fatalf("lastUpdateId: %d bids: %v", id, m.Bids(0))
+	}
+	// 丢包时丢弃副本重新初始化
+	snapshotId.Store(20)
+	m.Update(diff(15, 20, "98"))
+	mu.Lock()
+	if len(causes) != 2 || causes[1].Error() != "orderbook TEST: sequence gap" {
+		t.Fatalf("causes: %v", causes)
+	}
+	mu.Unlock()
+	waitReady(t, m)
+	if bids := m.Bids(0); m.LastUpdateId() != 20 || len(bids) != 1 {
+		t.Fatalf("lastUpdateId: %d bids: %v", m.LastUpdateId(), bids)
+	}
+}
+
+func TestMaintainerSerialCallbacks(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var running, overlaps, calls atomic.Int32
+	m := orderbook.NewMaintainer("TEST", func(ctx context.Context) (*orderbook.Snapshot, error) {
+		<-release
+		return &orderbook.Snapshot{LastUpdateId: 1}, nil
+	}, sequence)
+	m.SetOnChange(func() {
+		if running.Add(1) > 1 {
+			overlaps.Add(1)
+		}
+		if calls.Add(1) == 1 {
+			close(started)
+			time.Sleep(50 * time.Millisecond)
+		}
+		running.Add(-1)
+	})
+	m.Update(diff(2, 2, "1"))
+	close(release)
+	// 快照之后的回调还在执行时 Update 触发回调
+	<-started
+	m.Update(diff(3, 3, "1"))
+	if calls.Load() != 2 || overlaps.Load() != 0 {
+		t.Fatalf("calls: %d concurrent callbacks: %d", calls.Load(), overlaps.Load())
+	}
+}
@@ -3,22 +3,12 @@ package utils
 import (
 	"net/http"
 
-	"github.com/duke-git/lancet/v2/netutil"
-	"github.com/sleep-go/coin-go/pkg/errors"
+	"github.com/sleep-go/coin-go/binance"
 )
 
+// ParseHttpResponse 解析响应，非 200 或者返回内容为 {"code":<负数>,"msg":...} 时返回 *binance.APIError
+//
+// Deprecated: 使用 binance.ParseHttpResponse
 func ParseHttpResponse[T any](resp *http.Response) (body T, err error) {
-	if resp.StatusCode != http.StatusOK {
-		var e *errors.Error
-		err = netutil.ParseHttpResponse(resp, &e)
-		if err != nil {
-			return body, errors.New(resp.StatusCode, err.Error(), resp.Status)
-		}
-		return body, e
-	}
-	err = netutil.ParseHttpResponse(resp, &body)
-	if err != nil {
-		return body, err
-	}
-	return body, nil
+	return binance.ParseHttpResponse[T](resp)
 }